@@ -49,6 +49,7 @@ func (b BBL) Up(additionalArgs ...string) *gexec.Session {
 		"--state-dir", b.stateDirectory,
 		"--debug",
 		"up",
+		"--no-confirm",
 	}
 
 	args = append(args, additionalArgs...)
@@ -88,6 +89,7 @@ func (b BBL) CreateLB(loadBalancerType string, cert string, key string, chain st
 		"--debug",
 		"create-lbs",
 		"--type", loadBalancerType,
+		"--no-confirm",
 	}
 
 	if loadBalancerType == "cf" || b.configuration.IAAS == "aws" {
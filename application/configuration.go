@@ -3,8 +3,10 @@ package application
 import "github.com/cloudfoundry/bosh-bootloader/storage"
 
 type GlobalConfiguration struct {
-	StateDir string
-	Debug    bool
+	StateDir             string
+	Debug                bool
+	BOSHDeploymentDir    string
+	JumpboxDeploymentDir string
 }
 
 type StringSlice []string
@@ -10,15 +10,26 @@ import (
 	awslib "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	awselb "github.com/aws/aws-sdk-go/service/elb"
 )
 
 type EC2Client interface {
 	ImportKeyPair(*awsec2.ImportKeyPairInput) (*awsec2.ImportKeyPairOutput, error)
 	DescribeKeyPairs(*awsec2.DescribeKeyPairsInput) (*awsec2.DescribeKeyPairsOutput, error)
 	DescribeAvailabilityZones(*awsec2.DescribeAvailabilityZonesInput) (*awsec2.DescribeAvailabilityZonesOutput, error)
+	DescribeRegions(*awsec2.DescribeRegionsInput) (*awsec2.DescribeRegionsOutput, error)
 	DescribeInstances(*awsec2.DescribeInstancesInput) (*awsec2.DescribeInstancesOutput, error)
 	DescribeVpcs(*awsec2.DescribeVpcsInput) (*awsec2.DescribeVpcsOutput, error)
 	DeleteKeyPair(*awsec2.DeleteKeyPairInput) (*awsec2.DeleteKeyPairOutput, error)
+	DescribeAccountAttributes(*awsec2.DescribeAccountAttributesInput) (*awsec2.DescribeAccountAttributesOutput, error)
+	DescribeAddresses(*awsec2.DescribeAddressesInput) (*awsec2.DescribeAddressesOutput, error)
+	DescribeNatGateways(*awsec2.DescribeNatGatewaysInput) (*awsec2.DescribeNatGatewaysOutput, error)
+	DescribeSubnets(*awsec2.DescribeSubnetsInput) (*awsec2.DescribeSubnetsOutput, error)
+}
+
+type ELBClient interface {
+	DescribeAccountLimits(*awselb.DescribeAccountLimitsInput) (*awselb.DescribeAccountLimitsOutput, error)
+	DescribeLoadBalancers(*awselb.DescribeLoadBalancersInput) (*awselb.DescribeLoadBalancersOutput, error)
 }
 
 type logger interface {
@@ -31,12 +42,16 @@ type AvailabilityZoneRetriever interface {
 
 type Client struct {
 	ec2Client EC2Client
+	elbClient ELBClient
 	logger    logger
 }
 
 func NewClient(config aws.Config, logger logger) Client {
+	awsSession := session.New(config.ClientConfig())
+
 	return Client{
-		ec2Client: awsec2.New(session.New(config.ClientConfig())),
+		ec2Client: awsec2.New(awsSession),
+		elbClient: awselb.New(awsSession),
 		logger:    logger,
 	}
 }
@@ -76,6 +91,24 @@ func (c Client) RetrieveAvailabilityZones(region string) ([]string, error) {
 	return azList, nil
 }
 
+func (c Client) RetrieveRegions() ([]string, error) {
+	output, err := c.ec2Client.DescribeRegions(&awsec2.DescribeRegionsInput{})
+	if err != nil {
+		return []string{}, err
+	}
+
+	regions := []string{}
+	for _, region := range output.Regions {
+		if region == nil || region.RegionName == nil {
+			return []string{}, errors.New("aws returned region with nil region name")
+		}
+
+		regions = append(regions, *region.RegionName)
+	}
+
+	return regions, nil
+}
+
 func (c Client) CheckExists(networkName string) (bool, error) {
 	vpcs, err := c.ec2Client.DescribeVpcs(&awsec2.DescribeVpcsInput{
 		Filters: []*awsec2.Filter{
@@ -108,6 +108,58 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("RetrieveRegions", func() {
+		var (
+			client    ec2.Client
+			ec2Client *fakes.AWSEC2Client
+		)
+
+		BeforeEach(func() {
+			ec2Client = &fakes.AWSEC2Client{}
+			client = ec2.NewClientWithInjectedEC2Client(ec2Client, &fakes.Logger{})
+		})
+
+		It("fetches the regions available to the account", func() {
+			ec2Client.DescribeRegionsCall.Returns.Output = &awsec2.DescribeRegionsOutput{
+				Regions: []*awsec2.Region{
+					{RegionName: awslib.String("us-east-1")},
+					{RegionName: awslib.String("eu-west-1")},
+				},
+			}
+
+			regions, err := client.RetrieveRegions()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(regions).To(ConsistOf("us-east-1", "eu-west-1"))
+		})
+
+		Describe("failure cases", func() {
+			Context("when a region has a nil RegionName", func() {
+				BeforeEach(func() {
+					ec2Client.DescribeRegionsCall.Returns.Output = &awsec2.DescribeRegionsOutput{
+						Regions: []*awsec2.Region{{RegionName: nil}},
+					}
+				})
+
+				It("returns an error", func() {
+					_, err := client.RetrieveRegions()
+					Expect(err).To(MatchError("aws returned region with nil region name"))
+				})
+			})
+
+			Context("when describe regions fails", func() {
+				BeforeEach(func() {
+					ec2Client.DescribeRegionsCall.Returns.Error = errors.New("describe regions failed")
+				})
+
+				It("returns an error", func() {
+					_, err := client.RetrieveRegions()
+					Expect(err).To(MatchError("describe regions failed"))
+				})
+			})
+		})
+	})
+
 	Describe("DeleteKeyPair", func() {
 		var (
 			client    ec2.Client
@@ -7,6 +7,14 @@ func NewClientWithInjectedEC2Client(ec2Client EC2Client, logger logger) Client {
 	}
 }
 
+func NewClientWithInjectedClients(ec2Client EC2Client, elbClient ELBClient, logger logger) Client {
+	return Client{
+		ec2Client: ec2Client,
+		elbClient: elbClient,
+		logger:    logger,
+	}
+}
+
 func (c Client) GetEC2Client() EC2Client {
 	return c.ec2Client
 }
@@ -0,0 +1,71 @@
+package ec2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// instanceTypeSizes lists the sizes of the current x86 instance families.
+// The vendored EC2 API cannot list the instance types of a region, so
+// instance types are checked against this list rather than the region. It
+// falls behind as new families are released, so callers should only warn
+// about the instance types it does not know.
+var instanceTypeSizes = map[string][]string{
+	"t2":   {"nano", "micro", "small", "medium", "large", "xlarge", "2xlarge"},
+	"t3":   {"nano", "micro", "small", "medium", "large", "xlarge", "2xlarge"},
+	"t3a":  {"nano", "micro", "small", "medium", "large", "xlarge", "2xlarge"},
+	"m4":   {"large", "xlarge", "2xlarge", "4xlarge", "10xlarge", "16xlarge"},
+	"m5":   {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge", "metal"},
+	"m5a":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge"},
+	"m5d":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge", "metal"},
+	"m5n":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge", "metal"},
+	"m6i":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge", "32xlarge", "metal"},
+	"c4":   {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge"},
+	"c5":   {"large", "xlarge", "2xlarge", "4xlarge", "9xlarge", "12xlarge", "18xlarge", "24xlarge", "metal"},
+	"c5a":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge"},
+	"c5d":  {"large", "xlarge", "2xlarge", "4xlarge", "9xlarge", "12xlarge", "18xlarge", "24xlarge", "metal"},
+	"c5n":  {"large", "xlarge", "2xlarge", "4xlarge", "9xlarge", "18xlarge", "metal"},
+	"c6i":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge", "32xlarge", "metal"},
+	"r4":   {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "16xlarge"},
+	"r5":   {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge", "metal"},
+	"r5a":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge"},
+	"r5d":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge", "metal"},
+	"r6i":  {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge", "32xlarge", "metal"},
+	"i3":   {"large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "16xlarge", "metal"},
+	"x1":   {"16xlarge", "32xlarge"},
+	"x1e":  {"xlarge", "2xlarge", "4xlarge", "8xlarge", "16xlarge", "32xlarge"},
+	"z1d":  {"large", "xlarge", "2xlarge", "3xlarge", "6xlarge", "12xlarge", "metal"},
+	"p3":   {"2xlarge", "8xlarge", "16xlarge"},
+	"g4dn": {"xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "metal"},
+}
+
+// CheckInstanceTypes returns an error naming every instance type that is not
+// a known EC2 instance type.
+func (c Client) CheckInstanceTypes(instanceTypes []string) error {
+	var unknown []string
+	for _, instanceType := range instanceTypes {
+		if !isInstanceType(instanceType) {
+			unknown = append(unknown, instanceType)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("Unknown instance types: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+func isInstanceType(instanceType string) bool {
+	parts := strings.SplitN(instanceType, ".", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	for _, size := range instanceTypeSizes[parts[0]] {
+		if size == parts[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package ec2_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckInstanceTypes", func() {
+	var (
+		client    ec2.Client
+		ec2Client *fakes.AWSEC2Client
+	)
+
+	BeforeEach(func() {
+		ec2Client = &fakes.AWSEC2Client{}
+		client = ec2.NewClientWithInjectedEC2Client(ec2Client, &fakes.Logger{})
+	})
+
+	It("accepts known instance types", func() {
+		err := client.CheckInstanceTypes([]string{"m5.large", "c5.metal", "t3.micro"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns an error naming the instance types that are not known", func() {
+		err := client.CheckInstanceTypes([]string{"m5.large", "m5.huge", "m9.large", "m5"})
+		Expect(err).To(MatchError("Unknown instance types: m5.huge, m9.large, m5"))
+	})
+})
@@ -0,0 +1,292 @@
+package ec2
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	awslib "github.com/aws/aws-sdk-go/aws"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+)
+
+// The VPC and NAT gateway limits are not reported by the EC2 API, so they are
+// checked against the AWS defaults unless Limits says otherwise.
+const (
+	DefaultVPCLimit        = 5
+	DefaultNATGatewayLimit = 5
+)
+
+// Resources are the limited resources an environment will create. NAT
+// gateways are limited per availability zone: NATGateways are created in a
+// single zone and NATGatewaysPerAZ in every zone of the region, each with its
+// own Elastic IP. LoadBalancers are classic load balancers.
+type Resources struct {
+	VPCs             int
+	ElasticIPs       int
+	NATGateways      int
+	NATGatewaysPerAZ int
+	LoadBalancers    int
+}
+
+// Limits are the VPC limit of the region and the NAT gateway limit of each
+// availability zone, for accounts that have had them raised. Zero means the
+// AWS default.
+type Limits struct {
+	VPCs        int
+	NATGateways int
+}
+
+// CheckQuotas returns an error naming every resource that the account does not
+// have enough of to create resources in region.
+func (c Client) CheckQuotas(region string, resources Resources, limits Limits) error {
+	var problems []string
+
+	vpcLimit := DefaultVPCLimit
+	if limits.VPCs > 0 {
+		vpcLimit = limits.VPCs
+	}
+
+	natGatewayLimit := DefaultNATGatewayLimit
+	if limits.NATGateways > 0 {
+		natGatewayLimit = limits.NATGateways
+	}
+
+	if resources.VPCs > 0 {
+		// DescribeVpcs returns every vpc in the region in one response.
+		vpcs, err := c.ec2Client.DescribeVpcs(&awsec2.DescribeVpcsInput{})
+		if err != nil {
+			return fmt.Errorf("Describe vpcs: %s", err)
+		}
+
+		if len(vpcs.Vpcs)+resources.VPCs > vpcLimit {
+			problems = append(problems, fmt.Sprintf("VPCs: %d needed, %d of %d in use in %s", resources.VPCs, len(vpcs.Vpcs), vpcLimit, region))
+		}
+	}
+
+	var zones []string
+	if resources.NATGateways > 0 || resources.NATGatewaysPerAZ > 0 {
+		var err error
+		zones, err = c.RetrieveAvailabilityZones(region)
+		if err != nil {
+			return fmt.Errorf("Retrieve availability zones: %s", err)
+		}
+	}
+
+	elasticIPs := resources.ElasticIPs + resources.NATGatewaysPerAZ*len(zones)
+	if elasticIPs > 0 {
+		limit, err := c.elasticIPLimit()
+		if err != nil {
+			return err
+		}
+
+		addresses, err := c.ec2Client.DescribeAddresses(&awsec2.DescribeAddressesInput{
+			Filters: []*awsec2.Filter{{
+				Name:   awslib.String("domain"),
+				Values: []*string{awslib.String("vpc")},
+			}},
+		})
+		if err != nil {
+			return fmt.Errorf("Describe addresses: %s", err)
+		}
+
+		if len(addresses.Addresses)+elasticIPs > limit {
+			problems = append(problems, fmt.Sprintf("Elastic IPs: %d needed, %d of %d in use in %s", elasticIPs, len(addresses.Addresses), limit, region))
+		}
+	}
+
+	if len(zones) > 0 {
+		usage, err := c.natGatewaysPerAZ()
+		if err != nil {
+			return err
+		}
+
+		if resources.NATGatewaysPerAZ > 0 {
+			for _, zone := range zones {
+				if usage[zone]+resources.NATGatewaysPerAZ > natGatewayLimit {
+					problems = append(problems, fmt.Sprintf("NAT gateways: %d needed, %d of %d in use in %s", resources.NATGatewaysPerAZ, usage[zone], natGatewayLimit, zone))
+				}
+			}
+		}
+
+		if resources.NATGateways > 0 {
+			leastUsed := zones[0]
+			for _, zone := range zones {
+				if usage[zone] < usage[leastUsed] {
+					leastUsed = zone
+				}
+			}
+
+			if usage[leastUsed]+resources.NATGateways > natGatewayLimit {
+				problems = append(problems, fmt.Sprintf("NAT gateways: %d needed, %d of %d in use in %s", resources.NATGateways, usage[leastUsed], natGatewayLimit, leastUsed))
+			}
+		}
+	}
+
+	if resources.LoadBalancers > 0 {
+		limit, err := c.loadBalancerLimit()
+		if err != nil {
+			return err
+		}
+
+		inUse, err := c.loadBalancers()
+		if err != nil {
+			return err
+		}
+
+		if inUse+resources.LoadBalancers > limit {
+			problems = append(problems, fmt.Sprintf("Classic load balancers: %d needed, %d of %d in use in %s", resources.LoadBalancers, inUse, limit, region))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Not enough quota to create this environment, free up resources or request a limit increase:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+func (c Client) elasticIPLimit() (int, error) {
+	output, err := c.ec2Client.DescribeAccountAttributes(&awsec2.DescribeAccountAttributesInput{
+		AttributeNames: []*string{awslib.String("vpc-max-elastic-ips")},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Describe account attributes: %s", err)
+	}
+
+	for _, attribute := range output.AccountAttributes {
+		if awslib.StringValue(attribute.AttributeName) != "vpc-max-elastic-ips" || len(attribute.AttributeValues) == 0 {
+			continue
+		}
+
+		limit, err := strconv.Atoi(awslib.StringValue(attribute.AttributeValues[0].AttributeValue))
+		if err != nil {
+			return 0, fmt.Errorf("Parse Elastic IP limit: %s", err)
+		}
+
+		return limit, nil
+	}
+
+	return 0, errors.New("Describe account attributes: vpc-max-elastic-ips was not returned")
+}
+
+func (c Client) loadBalancerLimit() (int, error) {
+	var marker *string
+	for {
+		output, err := c.elbClient.DescribeAccountLimits(&awselb.DescribeAccountLimitsInput{Marker: marker})
+		if err != nil {
+			return 0, fmt.Errorf("Describe account limits: %s", err)
+		}
+
+		for _, limit := range output.Limits {
+			if awslib.StringValue(limit.Name) != "classic-load-balancers" {
+				continue
+			}
+
+			max, err := strconv.Atoi(awslib.StringValue(limit.Max))
+			if err != nil {
+				return 0, fmt.Errorf("Parse classic load balancer limit: %s", err)
+			}
+
+			return max, nil
+		}
+
+		if output.NextMarker == nil {
+			return 0, errors.New("Describe account limits: classic-load-balancers was not returned")
+		}
+		marker = output.NextMarker
+	}
+}
+
+func (c Client) loadBalancers() (int, error) {
+	var count int
+	var marker *string
+	for {
+		output, err := c.elbClient.DescribeLoadBalancers(&awselb.DescribeLoadBalancersInput{Marker: marker})
+		if err != nil {
+			return 0, fmt.Errorf("Describe load balancers: %s", err)
+		}
+
+		count += len(output.LoadBalancerDescriptions)
+
+		if output.NextMarker == nil {
+			return count, nil
+		}
+		marker = output.NextMarker
+	}
+}
+
+func (c Client) natGatewaysPerAZ() (map[string]int, error) {
+	var gateways []*awsec2.NatGateway
+	var nextToken *string
+	for {
+		output, err := c.ec2Client.DescribeNatGateways(&awsec2.DescribeNatGatewaysInput{
+			Filter: []*awsec2.Filter{{
+				Name:   awslib.String("state"),
+				Values: []*string{awslib.String("pending"), awslib.String("available")},
+			}},
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Describe nat gateways: %s", err)
+		}
+
+		gateways = append(gateways, output.NatGateways...)
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+
+	usage := map[string]int{}
+	if len(gateways) == 0 {
+		return usage, nil
+	}
+
+	var subnetIDs []*string
+	seen := map[string]bool{}
+	for _, gateway := range gateways {
+		if !seen[awslib.StringValue(gateway.SubnetId)] {
+			seen[awslib.StringValue(gateway.SubnetId)] = true
+			subnetIDs = append(subnetIDs, gateway.SubnetId)
+		}
+	}
+
+	subnets, err := c.ec2Client.DescribeSubnets(&awsec2.DescribeSubnetsInput{
+		SubnetIds: subnetIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Describe subnets: %s", err)
+	}
+
+	zones := map[string]string{}
+	for _, subnet := range subnets.Subnets {
+		zones[awslib.StringValue(subnet.SubnetId)] = awslib.StringValue(subnet.AvailabilityZone)
+	}
+
+	for _, gateway := range gateways {
+		usage[zones[awslib.StringValue(gateway.SubnetId)]]++
+	}
+
+	return usage, nil
+}
+
+// Minus returns the resources in r that are not already in existing.
+func (r Resources) Minus(existing Resources) Resources {
+	return Resources{
+		VPCs:             remaining(r.VPCs, existing.VPCs),
+		ElasticIPs:       remaining(r.ElasticIPs, existing.ElasticIPs),
+		NATGateways:      remaining(r.NATGateways, existing.NATGateways),
+		NATGatewaysPerAZ: remaining(r.NATGatewaysPerAZ, existing.NATGatewaysPerAZ),
+		LoadBalancers:    remaining(r.LoadBalancers, existing.LoadBalancers),
+	}
+}
+
+func remaining(needed, existing int) int {
+	if needed < existing {
+		return 0
+	}
+	return needed - existing
+}
@@ -0,0 +1,260 @@
+package ec2_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+
+	awslib "github.com/aws/aws-sdk-go/aws"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckQuotas", func() {
+	var (
+		client    ec2.Client
+		ec2Client *fakes.AWSEC2Client
+		elbClient *fakes.AWSELBClient
+		resources ec2.Resources
+	)
+
+	BeforeEach(func() {
+		ec2Client = &fakes.AWSEC2Client{}
+		elbClient = &fakes.AWSELBClient{}
+		client = ec2.NewClientWithInjectedClients(ec2Client, elbClient, &fakes.Logger{})
+
+		ec2Client.DescribeVpcsCall.Returns.Output = &awsec2.DescribeVpcsOutput{
+			Vpcs: []*awsec2.Vpc{{}, {}},
+		}
+		ec2Client.DescribeAvailabilityZonesCall.Returns.Output = &awsec2.DescribeAvailabilityZonesOutput{
+			AvailabilityZones: []*awsec2.AvailabilityZone{
+				{ZoneName: awslib.String("us-east-1a")},
+				{ZoneName: awslib.String("us-east-1b")},
+			},
+		}
+		ec2Client.DescribeAccountAttributesCall.Returns.Output = &awsec2.DescribeAccountAttributesOutput{
+			AccountAttributes: []*awsec2.AccountAttribute{{
+				AttributeName:   awslib.String("vpc-max-elastic-ips"),
+				AttributeValues: []*awsec2.AccountAttributeValue{{AttributeValue: awslib.String("5")}},
+			}},
+		}
+		ec2Client.DescribeAddressesCall.Returns.Output = &awsec2.DescribeAddressesOutput{
+			Addresses: []*awsec2.Address{{}, {}},
+		}
+		ec2Client.DescribeNatGatewaysCall.Returns.Output = &awsec2.DescribeNatGatewaysOutput{
+			NatGateways: []*awsec2.NatGateway{
+				{SubnetId: awslib.String("subnet-a")},
+				{SubnetId: awslib.String("subnet-a")},
+				{SubnetId: awslib.String("subnet-b")},
+			},
+		}
+		ec2Client.DescribeSubnetsCall.Returns.Output = &awsec2.DescribeSubnetsOutput{
+			Subnets: []*awsec2.Subnet{
+				{SubnetId: awslib.String("subnet-a"), AvailabilityZone: awslib.String("us-east-1a")},
+				{SubnetId: awslib.String("subnet-b"), AvailabilityZone: awslib.String("us-east-1b")},
+			},
+		}
+
+		elbClient.DescribeAccountLimitsCall.Returns.Output = &awselb.DescribeAccountLimitsOutput{
+			Limits: []*awselb.Limit{
+				{Name: awslib.String("classic-listeners"), Max: awslib.String("100")},
+				{Name: awslib.String("classic-load-balancers"), Max: awslib.String("20")},
+			},
+		}
+		elbClient.DescribeLoadBalancersCall.Returns.Output = &awselb.DescribeLoadBalancersOutput{
+			LoadBalancerDescriptions: make([]*awselb.LoadBalancerDescription, 16),
+		}
+
+		resources = ec2.Resources{VPCs: 1, ElasticIPs: 2, NATGateways: 1}
+	})
+
+	It("checks the resources fit in the account limits", func() {
+		err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ec2Client.DescribeAccountAttributesCall.Receives.Input).To(Equal(&awsec2.DescribeAccountAttributesInput{
+			AttributeNames: []*string{awslib.String("vpc-max-elastic-ips")},
+		}))
+		Expect(ec2Client.DescribeAddressesCall.Receives.Input.Filters[0].Name).To(Equal(awslib.String("domain")))
+		Expect(ec2Client.DescribeSubnetsCall.Receives.Input.SubnetIds).To(Equal([]*string{
+			awslib.String("subnet-a"),
+			awslib.String("subnet-b"),
+		}))
+	})
+
+	Context("when there are not enough vpcs", func() {
+		It("returns an error", func() {
+			ec2Client.DescribeVpcsCall.Returns.Output.Vpcs = make([]*awsec2.Vpc, 5)
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError("Not enough quota to create this environment, free up resources or request a limit increase:\n  VPCs: 1 needed, 5 of 5 in use in us-east-1"))
+		})
+	})
+
+	Context("when the vpc limit has been raised", func() {
+		It("checks against the given limit", func() {
+			ec2Client.DescribeVpcsCall.Returns.Output.Vpcs = make([]*awsec2.Vpc, 5)
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{VPCs: 10})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when there are not enough elastic ips", func() {
+		It("counts an elastic ip for each nat gateway per availability zone", func() {
+			ec2Client.DescribeAddressesCall.Returns.Output.Addresses = make([]*awsec2.Address, 3)
+			resources = ec2.Resources{VPCs: 1, ElasticIPs: 1, NATGatewaysPerAZ: 1}
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError("Not enough quota to create this environment, free up resources or request a limit increase:\n  Elastic IPs: 3 needed, 3 of 5 in use in us-east-1"))
+		})
+	})
+
+	Context("when an availability zone has no room for a nat gateway", func() {
+		BeforeEach(func() {
+			ec2Client.DescribeNatGatewaysCall.Returns.Output.NatGateways = []*awsec2.NatGateway{
+				{SubnetId: awslib.String("subnet-a")},
+				{SubnetId: awslib.String("subnet-a")},
+				{SubnetId: awslib.String("subnet-a")},
+				{SubnetId: awslib.String("subnet-a")},
+				{SubnetId: awslib.String("subnet-a")},
+			}
+		})
+
+		It("uses another availability zone for a single nat gateway", func() {
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error for a nat gateway in every availability zone", func() {
+			resources = ec2.Resources{NATGatewaysPerAZ: 1}
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError(ContainSubstring("NAT gateways: 1 needed, 5 of 5 in use in us-east-1a")))
+		})
+
+		It("checks against the given nat gateway limit", func() {
+			resources = ec2.Resources{NATGatewaysPerAZ: 1}
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{NATGateways: 6})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when the nat gateways span several pages", func() {
+		It("counts the nat gateways on every page", func() {
+			ec2Client.DescribeNatGatewaysCall.Stub = func(input *awsec2.DescribeNatGatewaysInput) (*awsec2.DescribeNatGatewaysOutput, error) {
+				if input.NextToken == nil {
+					return &awsec2.DescribeNatGatewaysOutput{
+						NatGateways: []*awsec2.NatGateway{
+							{SubnetId: awslib.String("subnet-a")},
+							{SubnetId: awslib.String("subnet-a")},
+							{SubnetId: awslib.String("subnet-a")},
+						},
+						NextToken: awslib.String("some-token"),
+					}, nil
+				}
+
+				return &awsec2.DescribeNatGatewaysOutput{
+					NatGateways: []*awsec2.NatGateway{
+						{SubnetId: awslib.String("subnet-a")},
+						{SubnetId: awslib.String("subnet-a")},
+					},
+				}, nil
+			}
+			resources = ec2.Resources{NATGatewaysPerAZ: 1}
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError(ContainSubstring("NAT gateways: 1 needed, 5 of 5 in use in us-east-1a")))
+		})
+	})
+
+	Context("when load balancers are needed", func() {
+		BeforeEach(func() {
+			resources = ec2.Resources{LoadBalancers: 3}
+		})
+
+		It("checks them against the classic load balancer limit", func() {
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts the load balancers on every page", func() {
+			elbClient.DescribeLoadBalancersCall.Stub = func(input *awselb.DescribeLoadBalancersInput) (*awselb.DescribeLoadBalancersOutput, error) {
+				if input.Marker == nil {
+					return &awselb.DescribeLoadBalancersOutput{
+						LoadBalancerDescriptions: make([]*awselb.LoadBalancerDescription, 16),
+						NextMarker:               awslib.String("some-marker"),
+					}, nil
+				}
+
+				return &awselb.DescribeLoadBalancersOutput{
+					LoadBalancerDescriptions: make([]*awselb.LoadBalancerDescription, 2),
+				}, nil
+			}
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError("Not enough quota to create this environment, free up resources or request a limit increase:\n  Classic load balancers: 3 needed, 18 of 20 in use in us-east-1"))
+		})
+
+		It("returns an error when describe account limits fails", func() {
+			elbClient.DescribeAccountLimitsCall.Returns.Error = errors.New("forbidden")
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError("Describe account limits: forbidden"))
+		})
+
+		It("returns an error when describe load balancers fails", func() {
+			elbClient.DescribeLoadBalancersCall.Returns.Error = errors.New("throttled")
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError("Describe load balancers: throttled"))
+		})
+	})
+
+	Context("when nothing is needed", func() {
+		It("does not call aws", func() {
+			err := client.CheckQuotas("us-east-1", ec2.Resources{}, ec2.Limits{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ec2Client.DescribeAccountAttributesCall.Receives.Input).To(BeNil())
+			Expect(ec2Client.DescribeVpcsCall.Receives.Input).To(BeNil())
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when describe account attributes fails", func() {
+			ec2Client.DescribeAccountAttributesCall.Returns.Error = errors.New("forbidden")
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError("Describe account attributes: forbidden"))
+		})
+
+		It("returns an error when the elastic ip limit is missing", func() {
+			ec2Client.DescribeAccountAttributesCall.Returns.Output.AccountAttributes = nil
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError("Describe account attributes: vpc-max-elastic-ips was not returned"))
+		})
+
+		It("returns an error when describe nat gateways fails", func() {
+			ec2Client.DescribeNatGatewaysCall.Returns.Error = errors.New("throttled")
+
+			err := client.CheckQuotas("us-east-1", resources, ec2.Limits{})
+			Expect(err).To(MatchError("Describe nat gateways: throttled"))
+		})
+	})
+})
+
+var _ = Describe("Resources", func() {
+	Describe("Minus", func() {
+		It("returns the resources that do not exist yet", func() {
+			needed := ec2.Resources{VPCs: 1, ElasticIPs: 1, NATGatewaysPerAZ: 1, LoadBalancers: 3}.Minus(ec2.Resources{VPCs: 1, ElasticIPs: 2, NATGateways: 1, LoadBalancers: 1})
+			Expect(needed).To(Equal(ec2.Resources{NATGatewaysPerAZ: 1, LoadBalancers: 2}))
+		})
+	})
+})
@@ -0,0 +1,161 @@
+package iam
+
+// Features are the parts of an environment that need their own IAM actions,
+// in the order missing actions are reported.
+const (
+	FeatureBase        = "base"
+	FeatureKMS         = "kms"
+	FeatureCFLB        = "cf load balancers"
+	FeatureConcourseLB = "concourse load balancer"
+	FeatureDNS         = "dns"
+)
+
+var Features = []string{FeatureBase, FeatureKMS, FeatureCFLB, FeatureConcourseLB, FeatureDNS}
+
+var loadBalancerActions = []string{
+	"elasticloadbalancing:AddTags",
+	"elasticloadbalancing:ApplySecurityGroupsToLoadBalancer",
+	"elasticloadbalancing:AttachLoadBalancerToSubnets",
+	"elasticloadbalancing:ConfigureHealthCheck",
+	"elasticloadbalancing:CreateLoadBalancer",
+	"elasticloadbalancing:CreateLoadBalancerListeners",
+	"elasticloadbalancing:DeleteLoadBalancer",
+	"elasticloadbalancing:DeleteLoadBalancerListeners",
+	"elasticloadbalancing:DeregisterInstancesFromLoadBalancer",
+	"elasticloadbalancing:DescribeAccountLimits",
+	"elasticloadbalancing:DescribeLoadBalancerAttributes",
+	"elasticloadbalancing:DescribeLoadBalancers",
+	"elasticloadbalancing:DescribeTags",
+	"elasticloadbalancing:DetachLoadBalancerFromSubnets",
+	"elasticloadbalancing:ModifyLoadBalancerAttributes",
+	"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+	"elasticloadbalancing:RemoveTags",
+	"iam:DeleteServerCertificate",
+	"iam:GetServerCertificate",
+	"iam:UploadServerCertificate",
+}
+
+// Actions are the IAM actions the terraform templates, the ec2 client and the
+// AWS CPI of the director need for each feature. The load balancer actions
+// include registering instances for the lb vm_extensions, and the KMS actions
+// include encrypting disks with the bbl key.
+var Actions = map[string][]string{
+	FeatureBase: {
+		"ec2:AllocateAddress",
+		"ec2:AssociateAddress",
+		"ec2:AssociateRouteTable",
+		"ec2:AttachInternetGateway",
+		"ec2:AttachVolume",
+		"ec2:AuthorizeSecurityGroupEgress",
+		"ec2:AuthorizeSecurityGroupIngress",
+		"ec2:CancelSpotInstanceRequests",
+		"ec2:CopyImage",
+		"ec2:CreateFlowLogs",
+		"ec2:CreateInternetGateway",
+		"ec2:CreateNatGateway",
+		"ec2:CreateRoute",
+		"ec2:CreateRouteTable",
+		"ec2:CreateSecurityGroup",
+		"ec2:CreateSnapshot",
+		"ec2:CreateSubnet",
+		"ec2:CreateTags",
+		"ec2:CreateVolume",
+		"ec2:CreateVpc",
+		"ec2:DeleteFlowLogs",
+		"ec2:DeleteInternetGateway",
+		"ec2:DeleteKeyPair",
+		"ec2:DeleteNatGateway",
+		"ec2:DeleteRoute",
+		"ec2:DeleteRouteTable",
+		"ec2:DeleteSecurityGroup",
+		"ec2:DeleteSnapshot",
+		"ec2:DeleteSubnet",
+		"ec2:DeleteVolume",
+		"ec2:DeleteVpc",
+		"ec2:DeregisterImage",
+		"ec2:DescribeAccountAttributes",
+		"ec2:DescribeAddresses",
+		"ec2:DescribeAvailabilityZones",
+		"ec2:DescribeFlowLogs",
+		"ec2:DescribeImages",
+		"ec2:DescribeInstances",
+		"ec2:DescribeInternetGateways",
+		"ec2:DescribeKeyPairs",
+		"ec2:DescribeNatGateways",
+		"ec2:DescribeRegions",
+		"ec2:DescribeRouteTables",
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeSnapshots",
+		"ec2:DescribeSpotInstanceRequests",
+		"ec2:DescribeSpotPriceHistory",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeVolumes",
+		"ec2:DescribeVpcAttribute",
+		"ec2:DescribeVpcs",
+		"ec2:DetachInternetGateway",
+		"ec2:DetachVolume",
+		"ec2:DisassociateAddress",
+		"ec2:DisassociateRouteTable",
+		"ec2:ImportKeyPair",
+		"ec2:ModifyInstanceAttribute",
+		"ec2:ModifyVpcAttribute",
+		"ec2:RebootInstances",
+		"ec2:RegisterImage",
+		"ec2:ReleaseAddress",
+		"ec2:RequestSpotInstances",
+		"ec2:RevokeSecurityGroupEgress",
+		"ec2:RevokeSecurityGroupIngress",
+		"ec2:RunInstances",
+		"ec2:TerminateInstances",
+		"iam:AddRoleToInstanceProfile",
+		"iam:AttachRolePolicy",
+		"iam:CreateInstanceProfile",
+		"iam:CreatePolicy",
+		"iam:CreateRole",
+		"iam:DeleteInstanceProfile",
+		"iam:DeletePolicy",
+		"iam:DeleteRole",
+		"iam:DeleteRolePolicy",
+		"iam:DetachRolePolicy",
+		"iam:GetInstanceProfile",
+		"iam:GetPolicy",
+		"iam:GetPolicyVersion",
+		"iam:GetRole",
+		"iam:GetRolePolicy",
+		"iam:ListAttachedRolePolicies",
+		"iam:ListInstanceProfilesForRole",
+		"iam:ListPolicyVersions",
+		"iam:PassRole",
+		"iam:PutRolePolicy",
+		"iam:RemoveRoleFromInstanceProfile",
+		"logs:CreateLogGroup",
+		"logs:DeleteLogGroup",
+		"logs:DescribeLogGroups",
+		"logs:ListTagsLogGroup",
+	},
+	FeatureKMS: {
+		"kms:CreateGrant",
+		"kms:CreateKey",
+		"kms:Decrypt",
+		"kms:DescribeKey",
+		"kms:GenerateDataKeyWithoutPlaintext",
+		"kms:GetKeyPolicy",
+		"kms:GetKeyRotationStatus",
+		"kms:ListResourceTags",
+		"kms:ReEncryptFrom",
+		"kms:ReEncryptTo",
+		"kms:ScheduleKeyDeletion",
+	},
+	FeatureCFLB:        loadBalancerActions,
+	FeatureConcourseLB: loadBalancerActions,
+	FeatureDNS: {
+		"route53:ChangeResourceRecordSets",
+		"route53:ChangeTagsForResource",
+		"route53:CreateHostedZone",
+		"route53:DeleteHostedZone",
+		"route53:GetChange",
+		"route53:GetHostedZone",
+		"route53:ListResourceRecordSets",
+		"route53:ListTagsForResource",
+	},
+}
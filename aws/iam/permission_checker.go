@@ -0,0 +1,128 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+type PermissionChecker struct {
+	policySimulator policySimulator
+	identityClient  identityClient
+	logger          warningLogger
+}
+
+type policySimulator interface {
+	SimulatePrincipalPolicyPages(*awsiam.SimulatePrincipalPolicyInput, func(*awsiam.SimulatePolicyResponse, bool) bool) error
+}
+
+type identityClient interface {
+	GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
+}
+
+type warningLogger interface {
+	Println(string)
+}
+
+func NewPermissionChecker(policySimulator policySimulator, identityClient identityClient, logger warningLogger) PermissionChecker {
+	return PermissionChecker{
+		policySimulator: policySimulator,
+		identityClient:  identityClient,
+		logger:          logger,
+	}
+}
+
+// CheckPermissions simulates the policies of the caller against the actions
+// of each feature and returns an error listing the actions that are denied.
+// Root accounts are not checked, and credentials that may not simulate
+// policies are only warned about.
+func (p PermissionChecker) CheckPermissions(features []string) error {
+	identity, err := p.identityClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return fmt.Errorf("Get caller identity: %s", err)
+	}
+
+	principal, ok := principalARN(aws.StringValue(identity.Arn))
+	if !ok {
+		return nil
+	}
+
+	var actions []*string
+	seen := map[string]bool{}
+	for _, feature := range features {
+		for _, action := range Actions[feature] {
+			if !seen[action] {
+				seen[action] = true
+				actions = append(actions, aws.String(action))
+			}
+		}
+	}
+
+	if len(actions) == 0 {
+		return nil
+	}
+
+	denied := map[string]bool{}
+	err = p.policySimulator.SimulatePrincipalPolicyPages(&awsiam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal),
+		ActionNames:     actions,
+	}, func(page *awsiam.SimulatePolicyResponse, lastPage bool) bool {
+		for _, result := range page.EvaluationResults {
+			if aws.StringValue(result.EvalDecision) != awsiam.PolicyEvaluationDecisionTypeAllowed {
+				denied[aws.StringValue(result.EvalActionName)] = true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "AccessDenied" {
+			p.logger.Println(fmt.Sprintf("warning: %s may not call iam:SimulatePrincipalPolicy, so its permissions were not checked", principal))
+			return nil
+		}
+		return fmt.Errorf("Simulate principal policy: %s", err)
+	}
+
+	var problems []string
+	for _, feature := range features {
+		var missing []string
+		for _, action := range Actions[feature] {
+			if denied[action] {
+				missing = append(missing, action)
+			}
+		}
+
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s", feature, strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s is missing permissions:\n  %s", principal, strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+// principalARN returns the IAM user or role that policies can be simulated
+// for. Sessions of an assumed role are simulated as the role itself.
+func principalARN(callerARN string) (string, bool) {
+	parts := strings.SplitN(callerARN, ":", 6)
+	if len(parts) != 6 {
+		return "", false
+	}
+
+	resource := parts[5]
+	switch {
+	case parts[2] == "iam" && (strings.HasPrefix(resource, "user/") || strings.HasPrefix(resource, "role/")):
+		return callerARN, true
+	case parts[2] == "sts" && strings.HasPrefix(resource, "assumed-role/"):
+		role := strings.Split(resource, "/")[1]
+		return fmt.Sprintf("%s:%s:iam::%s:role/%s", parts[0], parts[1], parts[4], role), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,185 @@
+package iam_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/cloudfoundry/bosh-bootloader/aws/iam"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PermissionChecker", func() {
+	var (
+		server *httptest.Server
+		denied map[string]bool
+
+		callerARN       string
+		simulateStatus  int
+		simulatedARN    string
+		simulatedAction []string
+
+		checker iam.PermissionChecker
+		logger  *fakes.Logger
+	)
+
+	BeforeEach(func() {
+		denied = map[string]bool{}
+		callerARN = "arn:aws:iam::123456789012:user/bbl-user"
+		simulateStatus = http.StatusOK
+		simulatedARN = ""
+		simulatedAction = []string{}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+
+			switch r.Form.Get("Action") {
+			case "GetCallerIdentity":
+				fmt.Fprintf(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>%s</Arn>
+    <UserId>AIDAEXAMPLE</UserId>
+    <Account>123456789012</Account>
+  </GetCallerIdentityResult>
+  <ResponseMetadata><RequestId>some-request-id</RequestId></ResponseMetadata>
+</GetCallerIdentityResponse>`, callerARN)
+			case "SimulatePrincipalPolicy":
+				if simulateStatus != http.StatusOK {
+					w.WriteHeader(simulateStatus)
+					code := "InvalidInput"
+					if simulateStatus == http.StatusForbidden {
+						code = "AccessDenied"
+					}
+					fmt.Fprintf(w, `<ErrorResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <Error><Type>Sender</Type><Code>%s</Code><Message>failed to simulate</Message></Error>
+  <RequestId>some-request-id</RequestId>
+</ErrorResponse>`, code)
+					return
+				}
+
+				simulatedARN = r.Form.Get("PolicySourceArn")
+
+				var results []string
+				for i := 1; r.Form.Get(fmt.Sprintf("ActionNames.member.%d", i)) != ""; i++ {
+					action := r.Form.Get(fmt.Sprintf("ActionNames.member.%d", i))
+					simulatedAction = append(simulatedAction, action)
+
+					decision := "allowed"
+					if denied[action] {
+						decision = "implicitDeny"
+					}
+					results = append(results, fmt.Sprintf("<member><EvalActionName>%s</EvalActionName><EvalResourceName>*</EvalResourceName><EvalDecision>%s</EvalDecision></member>", action, decision))
+				}
+
+				fmt.Fprintf(w, `<SimulatePrincipalPolicyResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">
+  <SimulatePrincipalPolicyResult>
+    <IsTruncated>false</IsTruncated>
+    <EvaluationResults>%s</EvaluationResults>
+  </SimulatePrincipalPolicyResult>
+  <ResponseMetadata><RequestId>some-request-id</RequestId></ResponseMetadata>
+</SimulatePrincipalPolicyResponse>`, strings.Join(results, ""))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}))
+
+		awsSession := session.New(&aws.Config{
+			Credentials: credentials.NewStaticCredentials("some-access-key-id", "some-secret-access-key", ""),
+			Region:      aws.String("some-region"),
+			Endpoint:    aws.String(server.URL),
+			MaxRetries:  aws.Int(0),
+		})
+
+		logger = &fakes.Logger{}
+		checker = iam.NewPermissionChecker(awsiam.New(awsSession), sts.New(awsSession), logger)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("CheckPermissions", func() {
+		It("simulates the actions of every feature for the caller", func() {
+			err := checker.CheckPermissions([]string{iam.FeatureBase, iam.FeatureKMS})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(simulatedARN).To(Equal("arn:aws:iam::123456789012:user/bbl-user"))
+			Expect(simulatedAction).To(Equal(append(iam.Actions[iam.FeatureBase], iam.Actions[iam.FeatureKMS]...)))
+		})
+
+		It("simulates actions shared by features once", func() {
+			err := checker.CheckPermissions([]string{iam.FeatureCFLB, iam.FeatureConcourseLB})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(simulatedAction).To(Equal(iam.Actions[iam.FeatureCFLB]))
+		})
+
+		Context("when actions are denied", func() {
+			BeforeEach(func() {
+				denied["kms:CreateKey"] = true
+				denied["route53:CreateHostedZone"] = true
+				denied["route53:GetHostedZone"] = true
+			})
+
+			It("returns an error listing the missing actions of each feature", func() {
+				err := checker.CheckPermissions([]string{iam.FeatureBase, iam.FeatureKMS, iam.FeatureDNS})
+				Expect(err).To(MatchError("arn:aws:iam::123456789012:user/bbl-user is missing permissions:\n" +
+					"  kms: kms:CreateKey\n" +
+					"  dns: route53:CreateHostedZone, route53:GetHostedZone"))
+			})
+		})
+
+		Context("when the caller has assumed a role", func() {
+			It("simulates the policies of the role", func() {
+				callerARN = "arn:aws:sts::123456789012:assumed-role/bbl-role/some-session"
+
+				err := checker.CheckPermissions([]string{iam.FeatureKMS})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(simulatedARN).To(Equal("arn:aws:iam::123456789012:role/bbl-role"))
+			})
+		})
+
+		Context("when the caller is the root account", func() {
+			It("does not simulate policies", func() {
+				callerARN = "arn:aws:iam::123456789012:root"
+
+				err := checker.CheckPermissions([]string{iam.FeatureKMS})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(simulatedAction).To(BeEmpty())
+			})
+		})
+
+		Context("when the caller may not simulate policies", func() {
+			It("warns that the permissions were not checked instead of returning an error", func() {
+				simulateStatus = http.StatusForbidden
+
+				err := checker.CheckPermissions([]string{iam.FeatureKMS})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnMessages()).To(Equal([]string{
+					"warning: arn:aws:iam::123456789012:user/bbl-user may not call iam:SimulatePrincipalPolicy, so its permissions were not checked",
+				}))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when simulating fails", func() {
+				simulateStatus = http.StatusBadRequest
+
+				err := checker.CheckPermissions([]string{iam.FeatureKMS})
+				Expect(err).To(MatchError(ContainSubstring("Simulate principal policy: InvalidInput: failed to simulate")))
+			})
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package azure
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
 	"github.com/Azure/azure-sdk-for-go/arm/storage"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
@@ -14,17 +18,13 @@ func NewClient() AzureClient {
 }
 
 func (a AzureClient) ValidateCredentials(subscriptionID, tenantID, clientID, clientSecret string) error {
-	oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, tenantID)
-	if err != nil {
-		return err
-	}
-	servicePrincipalToken, err := adal.NewServicePrincipalToken(*oauthConfig, clientID, clientSecret, azure.PublicCloud.ResourceManagerEndpoint)
+	authorizer, err := newAuthorizer(tenantID, clientID, clientSecret)
 	if err != nil {
 		return err
 	}
 
 	ac := storage.NewAccountsClient(subscriptionID)
-	ac.Authorizer = autorest.NewBearerAuthorizer(servicePrincipalToken)
+	ac.Authorizer = authorizer
 	ac.Sender = autorest.CreateSender(autorest.AsIs())
 
 	_, err = ac.List()
@@ -34,3 +34,54 @@ func (a AzureClient) ValidateCredentials(subscriptionID, tenantID, clientID, cli
 
 	return nil
 }
+
+// ValidateLocation checks that virtual machines can be created in location.
+// Azure reports locations by display name, so "West US" matches "westus".
+func (a AzureClient) ValidateLocation(subscriptionID, tenantID, clientID, clientSecret, location string) error {
+	authorizer, err := newAuthorizer(tenantID, clientID, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	pc := resources.NewProvidersClient(subscriptionID)
+	pc.Authorizer = authorizer
+	pc.Sender = autorest.CreateSender(autorest.AsIs())
+
+	provider, err := pc.Get("Microsoft.Compute", "")
+	if err != nil {
+		return err
+	}
+
+	if provider.ResourceTypes != nil {
+		for _, resourceType := range *provider.ResourceTypes {
+			if resourceType.ResourceType == nil || *resourceType.ResourceType != "virtualMachines" || resourceType.Locations == nil {
+				continue
+			}
+
+			for _, l := range *resourceType.Locations {
+				if normalizeLocation(l) == normalizeLocation(location) {
+					return nil
+				}
+			}
+		}
+	}
+
+	return fmt.Errorf("location %q does not support virtual machines", location)
+}
+
+func newAuthorizer(tenantID, clientID, clientSecret string) (autorest.Authorizer, error) {
+	oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	servicePrincipalToken, err := adal.NewServicePrincipalToken(*oauthConfig, clientID, clientSecret, azure.PublicCloud.ResourceManagerEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return autorest.NewBearerAuthorizer(servicePrincipalToken), nil
+}
+
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.Replace(location, " ", "", -1))
+}
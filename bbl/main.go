@@ -12,6 +12,7 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/aws"
 	"github.com/cloudfoundry/bosh-bootloader/aws/clientmanager"
 	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+	"github.com/cloudfoundry/bosh-bootloader/aws/iam"
 	"github.com/cloudfoundry/bosh-bootloader/azure"
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/certs"
@@ -24,6 +25,9 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform"
 
+	"github.com/aws/aws-sdk-go/aws/session"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
 	awsapplication "github.com/cloudfoundry/bosh-bootloader/application/aws"
 	gcpapplication "github.com/cloudfoundry/bosh-bootloader/application/gcp"
 	awscloudconfig "github.com/cloudfoundry/bosh-bootloader/cloudconfig/aws"
@@ -39,6 +43,11 @@ var (
 	gcpBasePath string
 )
 
+// exitCoder is implemented by errors that need bbl to exit with a specific code.
+type exitCoder interface {
+	ExitCode() int
+}
+
 func main() {
 	newConfig := config.NewConfig(storage.GetState)
 	appConfig, err := newConfig.Bootstrap(os.Args)
@@ -73,6 +82,7 @@ func main() {
 		certificateValidator      certs.Validator
 		networkClient             helpers.NetworkClient
 		networkDeletionValidator  commands.NetworkDeletionValidator
+		iaasChecker               commands.IAASChecker
 
 		// this should be replaced by an IAAS agnostic variable, but that needs a common interface. We don't have time right now. AWS clients should also be combined into one struct.
 		gcpClient gcp.Client
@@ -90,6 +100,9 @@ func main() {
 		availabilityZoneRetriever = awsClient
 		certificateValidator = certs.NewValidator()
 		networkDeletionValidator = awsClient
+		awsSession := session.New(awsConfiguration.ClientConfig())
+		permissionChecker := iam.NewPermissionChecker(awsiam.New(awsSession), sts.New(awsSession), logger)
+		iaasChecker = commands.NewAWSDoctor(awsClient, awsterraform.NewTemplateGenerator(), permissionChecker)
 
 		networkClient = awsClient
 	}
@@ -103,6 +116,7 @@ func main() {
 		gcpClient = gcpClientProvider.Client()
 		networkClient = gcpClient
 		networkDeletionValidator = gcpClient
+		iaasChecker = commands.NewGCPDoctor(gcpClient, gcpterraform.NewTemplateGenerator())
 	}
 
 	var envIDManager helpers.EnvIDManager
@@ -145,7 +159,10 @@ func main() {
 	boshCommand := bosh.NewCmd(os.Stderr)
 	boshExecutor := bosh.NewExecutor(boshCommand, ioutil.TempDir, ioutil.ReadFile, json.Unmarshal,
 		json.Marshal, ioutil.WriteFile)
-	boshManager := bosh.NewManager(boshExecutor, logger, socks5Proxy)
+	boshManager := bosh.NewManager(boshExecutor, logger, socks5Proxy, bosh.DeploymentDirs{
+		BOSH:    appConfig.Global.BOSHDeploymentDir,
+		Jumpbox: appConfig.Global.JumpboxDeploymentDir,
+	})
 	boshClientProvider := bosh.NewClientProvider(socks5Proxy)
 	sshKeyGetter := bosh.NewSSHKeyGetter()
 
@@ -158,7 +175,7 @@ func main() {
 	case "azure":
 		cloudConfigOpsGenerator = azurecloudconfig.NewOpsGenerator(terraformManager)
 	}
-	cloudConfigManager := cloudconfig.NewManager(logger, boshCommand, cloudConfigOpsGenerator, boshClientProvider, socks5Proxy, terraformManager, sshKeyGetter)
+	cloudConfigManager := cloudconfig.NewManager(logger, cloudConfigOpsGenerator, boshClientProvider, socks5Proxy, terraformManager, sshKeyGetter, os.Stdin)
 
 	// Subcommands
 	var (
@@ -182,12 +199,16 @@ func main() {
 		deleteLBsCmd = commands.NewGCPDeleteLBs(stateStore, environmentValidator, terraformManager, cloudConfigManager)
 	case "azure":
 		azureClient := azure.NewClient()
+		iaasChecker = commands.NewAzureDoctor(azureClient)
 		upCmd = commands.NewAzureUp(azureClient)
 		deleteLBsCmd = commands.NewAzureDeleteLBs(cloudConfigManager, stateStore, terraformManager)
 	}
 
 	// Commands
-	up := commands.NewUp(upCmd, boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager)
+	doctor := commands.NewDoctor(logger, terraformManager, boshManager, iaasChecker, socks5Proxy, appConfig.Global.StateDir)
+	directorUploader := bosh.NewUploader(boshClientProvider, logger)
+	deploymentDeleter := bosh.NewDeploymentDeleter(boshClientProvider, logger)
+	up := commands.NewUp(upCmd, boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager, doctor, directorUploader, logger)
 	usage := commands.NewUsage(logger)
 
 	commandSet := application.CommandSet{}
@@ -196,9 +217,10 @@ func main() {
 	commandSet["up"] = up
 	sshKeyDeleter := bosh.NewSSHKeyDeleter()
 	commandSet["rotate"] = commands.NewRotate(stateValidator, sshKeyDeleter, up)
-	commandSet["destroy"] = commands.NewDestroy(logger, os.Stdin, boshManager, stateStore, stateValidator, terraformManager, networkDeletionValidator)
+	commandSet["destroy"] = commands.NewDestroy(logger, os.Stdin, boshManager, stateStore, stateValidator, terraformManager, networkDeletionValidator, deploymentDeleter)
 	commandSet["down"] = commandSet["destroy"]
-	commandSet["create-lbs"] = commands.NewCreateLBs(createLBsCmd, logger, stateValidator, certificateValidator, boshManager)
+	commandSet["upgrade-director"] = commands.NewUpgradeDirector(logger, os.Stdin, boshManager, stateStore, stateValidator, terraformManager)
+	commandSet["create-lbs"] = commands.NewCreateLBs(createLBsCmd, logger, stateValidator, certificateValidator, boshManager, iaasChecker)
 	commandSet["update-lbs"] = commandSet["create-lbs"]
 	commandSet["delete-lbs"] = commands.NewDeleteLBs(deleteLBsCmd, logger, stateValidator, boshManager)
 	commandSet["lbs"] = commands.NewLBs(lbsCmd, stateValidator)
@@ -211,6 +233,8 @@ func main() {
 	commandSet["env-id"] = commands.NewStateQuery(logger, stateValidator, terraformManager, commands.EnvIDPropertyName)
 	commandSet["latest-error"] = commands.NewLatestError(logger, stateValidator)
 	commandSet["print-env"] = commands.NewPrintEnv(logger, stateValidator, terraformManager)
+	commandSet["doctor"] = doctor
+	commandSet["status"] = commands.NewStatus(logger, stateValidator, bosh.NewStatusChecker(boshClientProvider))
 	commandSet["cloud-config"] = commands.NewCloudConfig(logger, stateValidator, cloudConfigManager)
 	commandSet["jumpbox-deployment-vars"] = commands.NewJumpboxDeploymentVars(logger, boshManager, stateValidator, terraformManager)
 	commandSet["bosh-deployment-vars"] = commands.NewBOSHDeploymentVars(logger, boshManager, stateValidator, terraformManager)
@@ -219,6 +243,10 @@ func main() {
 
 	err = app.Run()
 	if err != nil {
+		if exitErr, ok := err.(exitCoder); ok {
+			log.Printf("\n\n%s\n", err)
+			os.Exit(exitErr.ExitCode())
+		}
 		log.Fatalf("\n\n%s\n", err)
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 type CIDRBlock struct {
 	CIDRSize int
+	maskBits int
 	firstIP  IP
 }
 
@@ -38,6 +39,7 @@ func ParseCIDRBlock(cidrBlock string) (CIDRBlock, error) {
 	cidrSize := 1 << (HIGHEST_BITMASK - uint(maskBits))
 	return CIDRBlock{
 		CIDRSize: cidrSize,
+		maskBits: maskBits,
 		firstIP:  ip,
 	}, nil
 }
@@ -49,3 +51,37 @@ func (c CIDRBlock) GetFirstIP() IP {
 func (c CIDRBlock) GetLastIP() IP {
 	return c.firstIP.Add(c.CIDRSize - 1)
 }
+
+func (c CIDRBlock) Subnet(newBits, netNum int) (CIDRBlock, error) {
+	const HIGHEST_BITMASK = 32
+
+	maskBits := c.maskBits + newBits
+	if newBits < 0 || maskBits > HIGHEST_BITMASK {
+		return CIDRBlock{}, fmt.Errorf("cannot extend %s by %d bits", c, newBits)
+	}
+
+	if netNum < 0 || netNum >= 1<<uint(newBits) {
+		return CIDRBlock{}, fmt.Errorf("subnet %d does not fit in %s with %d new bits", netNum, c, newBits)
+	}
+
+	cidrSize := 1 << (HIGHEST_BITMASK - uint(maskBits))
+	return CIDRBlock{
+		CIDRSize: cidrSize,
+		maskBits: maskBits,
+		firstIP:  c.firstIP.Add(netNum * cidrSize),
+	}, nil
+}
+
+// Contains reports whether every address of other is in the block.
+func (c CIDRBlock) Contains(other CIDRBlock) bool {
+	return c.firstIP.ip <= other.firstIP.ip && other.GetLastIP().ip <= c.GetLastIP().ip
+}
+
+// Overlaps reports whether the blocks share any address.
+func (c CIDRBlock) Overlaps(other CIDRBlock) bool {
+	return c.firstIP.ip <= other.GetLastIP().ip && other.firstIP.ip <= c.GetLastIP().ip
+}
+
+func (c CIDRBlock) String() string {
+	return fmt.Sprintf("%s/%d", c.firstIP, c.maskBits)
+}
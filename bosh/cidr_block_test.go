@@ -32,6 +32,68 @@ var _ = Describe("CIDRBlock", func() {
 		})
 	})
 
+	Describe("Subnet", func() {
+		It("returns the requested subnet of the cidr block", func() {
+			subnet, err := cidrBlock.Subnet(4, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.String()).To(Equal("10.0.18.0/24"))
+			Expect(subnet.CIDRSize).To(Equal(256))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the new bits exceed the address space", func() {
+				_, err := cidrBlock.Subnet(13, 0)
+				Expect(err).To(MatchError("cannot extend 10.0.16.0/20 by 13 bits"))
+			})
+
+			It("returns an error when the subnet number does not fit in the new bits", func() {
+				_, err := cidrBlock.Subnet(4, 16)
+				Expect(err).To(MatchError("subnet 16 does not fit in 10.0.16.0/20 with 4 new bits"))
+			})
+		})
+	})
+
+	Describe("Contains", func() {
+		It("returns true when the other block is inside the cidr block", func() {
+			other, err := bosh.ParseCIDRBlock("10.0.24.0/24")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Contains(other)).To(BeTrue())
+			Expect(cidrBlock.Contains(cidrBlock)).To(BeTrue())
+		})
+
+		It("returns false when the other block is partly or entirely outside", func() {
+			other, err := bosh.ParseCIDRBlock("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Contains(other)).To(BeFalse())
+
+			other, err = bosh.ParseCIDRBlock("10.0.32.0/24")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Contains(other)).To(BeFalse())
+		})
+	})
+
+	Describe("Overlaps", func() {
+		It("returns true when the blocks share addresses", func() {
+			other, err := bosh.ParseCIDRBlock("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Overlaps(other)).To(BeTrue())
+			Expect(other.Overlaps(cidrBlock)).To(BeTrue())
+		})
+
+		It("returns false when the blocks are apart", func() {
+			other, err := bosh.ParseCIDRBlock("10.0.32.0/20")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrBlock.Overlaps(other)).To(BeFalse())
+			Expect(other.Overlaps(cidrBlock)).To(BeFalse())
+		})
+	})
+
+	Describe("String", func() {
+		It("returns the cidr notation of the block", func() {
+			Expect(cidrBlock.String()).To(Equal("10.0.16.0/20"))
+		})
+	})
+
 	Describe("ParseCIDRBlock", func() {
 		Context("failure cases", func() {
 			Context("when input string is not a valid CIDR block", func() {
@@ -5,9 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,7 +20,16 @@ import (
 )
 
 type Client interface {
-	UpdateCloudConfig(yaml []byte) error
+	UpdateConfig(configType, name string, content []byte) error
+	LatestConfigs(configType string) ([]Config, error)
+	DeleteConfig(configType, name string) error
+	UploadStemcell(stemcell io.Reader, size int64) (int, error)
+	UploadRemoteStemcell(url string) (int, error)
+	Releases() ([]Release, error)
+	UploadRemoteRelease(url, sha1 string) (int, error)
+	Deployments() ([]Deployment, error)
+	DeleteDeployment(name string, force bool) (int, error)
+	WaitForTask(id int, events func(TaskEvent)) (Task, error)
 	Info() (Info, error)
 }
 
@@ -26,9 +39,83 @@ type Info struct {
 	Version string `json:"version"`
 }
 
+type Config struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+type Deployment struct {
+	Name string `json:"name"`
+}
+
+type Release struct {
+	Name            string            `json:"name"`
+	ReleaseVersions []UploadedVersion `json:"release_versions"`
+}
+
+type UploadedVersion struct {
+	Version string `json:"version"`
+}
+
+// Task is a director task, which runs until its state is no longer queued
+// or processing.
+type Task struct {
+	ID          int    `json:"id"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	Result      string `json:"result"`
+}
+
+func (t Task) running() bool {
+	return t.State == "queued" || t.State == "processing"
+}
+
+// TaskError is returned for a task that finished in a state other than done,
+// such as error, cancelled or timeout.
+type TaskError struct {
+	Task Task
+}
+
+func (e TaskError) Error() string {
+	return fmt.Sprintf("task %d %s: %s", e.Task.ID, e.Task.State, e.Task.Result)
+}
+
+// TaskEvent is a line of the event output of a task. Events either report
+// the progress of a stage of the task or an error.
+type TaskEvent struct {
+	Time     int64           `json:"time"`
+	Stage    string          `json:"stage"`
+	Task     string          `json:"task"`
+	Index    int             `json:"index"`
+	Total    int             `json:"total"`
+	State    string          `json:"state"`
+	Progress int             `json:"progress"`
+	Error    *TaskEventError `json:"error,omitempty"`
+}
+
+type TaskEventError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e TaskEvent) String() string {
+	timestamp := time.Unix(e.Time, 0).UTC().Format("15:04:05")
+	if e.Error != nil {
+		return fmt.Sprintf("%s | Error: %s", timestamp, e.Error.Message)
+	}
+
+	return fmt.Sprintf("%s | %s: %s (%d/%d) %s", timestamp, e.Stage, e.Task, e.Index, e.Total, e.State)
+}
+
 var (
-	MAX_RETRIES = 5
-	RETRY_DELAY = 10 * time.Second
+	MAX_RETRIES         = 5
+	RETRY_DELAY         = 10 * time.Second
+	TASK_POLL_DELAY     = 1 * time.Second
+	MAX_TASK_POLL_DELAY = 10 * time.Second
+
+	taskLocation = regexp.MustCompile(`/tasks/(\d+)$`)
 )
 
 type client struct {
@@ -37,16 +124,31 @@ type client struct {
 	password        string
 	caCert          string
 	httpClient      *http.Client
+	tokenSource     oauth2.TokenSource
 }
 
+// NewClient returns a client that authenticates with UAA on the director. The
+// UAA token is shared by every request and only fetched again once it expires.
 func NewClient(httpClient *http.Client, directorAddress, username, password, caCert string) Client {
-	return client{
+	c := client{
 		directorAddress: directorAddress,
 		username:        username,
 		password:        password,
 		caCert:          caCert,
 		httpClient:      httpClient,
 	}
+
+	tokenURL, err := c.uaaTokenURL()
+	if err == nil {
+		conf := &clientcredentials.Config{
+			ClientID:     username,
+			ClientSecret: password,
+			TokenURL:     tokenURL,
+		}
+		c.tokenSource = conf.TokenSource(c.httpClientContext())
+	}
+
+	return c
 }
 
 func (c client) Info() (Info, error) {
@@ -59,6 +161,7 @@ func (c client) Info() (Info, error) {
 	if err != nil {
 		return Info{}, err
 	}
+	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
 		return Info{}, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
@@ -72,60 +175,418 @@ func (c client) Info() (Info, error) {
 	return info, nil
 }
 
-func (c client) UpdateCloudConfig(yaml []byte) error {
-	request, err := http.NewRequest("POST", fmt.Sprintf("%s/cloud_configs", c.directorAddress), bytes.NewBuffer(yaml))
+// UpdateConfig uploads content as the latest version of the named config of
+// configType. The director merges named cloud configs, so configs uploaded by
+// other names are left alone.
+func (c client) UpdateConfig(configType, name string, content []byte) error {
+	body, err := json.Marshal(map[string]string{
+		"type":    configType,
+		"name":    name,
+		"content": string(content),
+	})
+	if err != nil {
+		return err //not tested
+	}
+
+	request, err := http.NewRequest("POST", fmt.Sprintf("%s/configs", c.directorAddress), bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
-	request.Header.Set("Content-Type", "text/yaml")
+	request.Header.Set("Content-Type", "application/json")
 
-	urlParts, err := url.Parse(c.directorAddress)
+	response, err := c.authenticatedRequest(request)
 	if err != nil {
-		return err //not tested
+		return err
 	}
+	defer response.Body.Close()
 
-	boshHost, _, err := net.SplitHostPort(urlParts.Host)
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	return nil
+}
+
+// LatestConfigs returns the latest version of every named config of
+// configType.
+func (c client) LatestConfigs(configType string) ([]Config, error) {
+	query := url.Values{"type": {configType}, "latest": {"true"}}
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/configs?%s", c.directorAddress, query.Encode()), nil)
 	if err != nil {
-		return err //not tested
+		return nil, err
 	}
 
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	response, err := c.authenticatedRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
 
-	conf := &clientcredentials.Config{
-		ClientID:     c.username,
-		ClientSecret: c.password,
-		TokenURL:     fmt.Sprintf("https://%s:8443/oauth/token", boshHost),
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
 	}
 
-	httpClient := conf.Client(ctx)
-	response, err := makeRequests(httpClient, request)
+	var configs []Config
+	if err := json.NewDecoder(response.Body).Decode(&configs); err != nil {
+		return nil, err
+	}
+
+	return configs, nil
+}
+
+func (c client) DeleteConfig(configType, name string) error {
+	query := url.Values{"type": {configType}, "name": {name}}
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/configs?%s", c.directorAddress, query.Encode()), nil)
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode != http.StatusCreated {
+	response, err := c.authenticatedRequest(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
 	}
 
 	return nil
 }
 
+// UploadStemcell uploads a stemcell tarball of size bytes and returns the id
+// of the task that imports it.
+func (c client) UploadStemcell(stemcell io.Reader, size int64) (int, error) {
+	request, err := http.NewRequest("POST", fmt.Sprintf("%s/stemcells", c.directorAddress), stemcell)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/x-compressed")
+	request.ContentLength = size
+
+	return c.startTask(request)
+}
+
+// UploadRemoteStemcell has the director download the stemcell at url and
+// returns the id of the task that imports it.
+func (c client) UploadRemoteStemcell(stemcellURL string) (int, error) {
+	body, err := json.Marshal(map[string]string{"location": stemcellURL})
+	if err != nil {
+		return 0, err //not tested
+	}
+
+	request, err := http.NewRequest("POST", fmt.Sprintf("%s/stemcells", c.directorAddress), bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	return c.startTask(request)
+}
+
+// Releases returns the releases on the director with all of their versions.
+func (c client) Releases() ([]Release, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/releases", c.directorAddress), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.authenticatedRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(response.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+// UploadRemoteRelease has the director download the release at url, checking
+// it against sha1 when it is not empty, and returns the id of the task that
+// imports it.
+func (c client) UploadRemoteRelease(releaseURL, sha1 string) (int, error) {
+	body, err := json.Marshal(map[string]string{"location": releaseURL, "sha1": sha1})
+	if err != nil {
+		return 0, err //not tested
+	}
+
+	request, err := http.NewRequest("POST", fmt.Sprintf("%s/releases", c.directorAddress), bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	return c.startTask(request)
+}
+
+func (c client) Deployments() ([]Deployment, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/deployments", c.directorAddress), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.authenticatedRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var deployments []Deployment
+	if err := json.NewDecoder(response.Body).Decode(&deployments); err != nil {
+		return nil, err
+	}
+
+	return deployments, nil
+}
+
+// DeleteDeployment deletes the named deployment and returns the id of the
+// task that deletes it. With force, the director carries on past errors
+// deleting VMs and disks.
+func (c client) DeleteDeployment(name string, force bool) (int, error) {
+	query := url.Values{}
+	if force {
+		query.Set("force", "true")
+	}
+
+	request, err := http.NewRequest("DELETE", fmt.Sprintf("%s/deployments/%s?%s", c.directorAddress, url.PathEscape(name), query.Encode()), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return c.startTask(request)
+}
+
+// WaitForTask polls the task until it is no longer queued or processing,
+// backing off up to MAX_TASK_POLL_DELAY between polls. New events of the
+// task are passed to events as they come in, unless events is nil. A task
+// that does not finish as done is returned as a TaskError.
+func (c client) WaitForTask(id int, events func(TaskEvent)) (Task, error) {
+	var offset int64
+	delay := TASK_POLL_DELAY
+
+	for {
+		task, err := c.task(id)
+		if err != nil {
+			return Task{}, err
+		}
+
+		if events != nil {
+			var newEvents []TaskEvent
+			newEvents, offset, err = c.taskEvents(id, offset)
+			if err != nil {
+				return Task{}, err
+			}
+
+			for _, event := range newEvents {
+				events(event)
+			}
+		}
+
+		if !task.running() {
+			if task.State != "done" {
+				return task, TaskError{Task: task}
+			}
+
+			return task, nil
+		}
+
+		time.Sleep(delay)
+
+		delay *= 2
+		if delay > MAX_TASK_POLL_DELAY {
+			delay = MAX_TASK_POLL_DELAY
+		}
+	}
+}
+
+// startTask makes a request that starts a director task. The director
+// redirects to the task, the id of which is read from the Location header
+// rather than following it.
+func (c client) startTask(request *http.Request) (int, error) {
+	httpClient, err := c.authenticatedClient()
+	if err != nil {
+		return 0, err
+	}
+	httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	response, err := makeRequests(httpClient, request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusFound {
+		return 0, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	location := response.Header.Get("Location")
+	matches := taskLocation.FindStringSubmatch(location)
+	if matches == nil {
+		return 0, fmt.Errorf("unexpected task location %q", location)
+	}
+
+	return strconv.Atoi(matches[1])
+}
+
+func (c client) task(id int) (Task, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/tasks/%d", c.directorAddress, id), nil)
+	if err != nil {
+		return Task{}, err //not tested
+	}
+
+	response, err := c.authenticatedRequest(request)
+	if err != nil {
+		return Task{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return Task{}, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	var task Task
+	if err := json.NewDecoder(response.Body).Decode(&task); err != nil {
+		return Task{}, err
+	}
+
+	return task, nil
+}
+
+// taskEvents returns the events of the task past offset, and the offset to
+// ask for next time. Only complete lines are read, so an event that is still
+// being written is returned by the next call.
+func (c client) taskEvents(id int, offset int64) ([]TaskEvent, int64, error) {
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s/tasks/%d/output?type=event", c.directorAddress, id), nil)
+	if err != nil {
+		return nil, offset, err //not tested
+	}
+	request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+
+	response, err := c.authenticatedRequest(request)
+	if err != nil {
+		return nil, offset, err
+	}
+	defer response.Body.Close()
+
+	var output []byte
+	switch response.StatusCode {
+	case http.StatusRequestedRangeNotSatisfiable:
+		return nil, offset, nil
+	case http.StatusPartialContent, http.StatusOK:
+		output, err = ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, offset, err //not tested
+		}
+	default:
+		return nil, offset, fmt.Errorf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))
+	}
+
+	if response.StatusCode == http.StatusOK {
+		if int64(len(output)) < offset {
+			return nil, offset, nil
+		}
+		output = output[offset:]
+	}
+
+	complete := bytes.LastIndexByte(output, '\n') + 1
+	output = output[:complete]
+
+	var events []TaskEvent
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var event TaskEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, offset, fmt.Errorf("parse task event: %s", err)
+		}
+		events = append(events, event)
+	}
+
+	return events, offset + int64(complete), nil
+}
+
+func (c client) authenticatedRequest(request *http.Request) (*http.Response, error) {
+	httpClient, err := c.authenticatedClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return makeRequests(httpClient, request)
+}
+
+// authenticatedClient returns a client that adds the cached UAA token to
+// requests. A new client is returned every time, as startTask changes how it
+// follows redirects.
+func (c client) authenticatedClient() (*http.Client, error) {
+	if c.tokenSource == nil {
+		_, err := c.uaaTokenURL()
+		return nil, err //not tested
+	}
+
+	return oauth2.NewClient(c.httpClientContext(), c.tokenSource), nil
+}
+
+func (c client) uaaTokenURL() (string, error) {
+	urlParts, err := url.Parse(c.directorAddress)
+	if err != nil {
+		return "", err //not tested
+	}
+
+	boshHost, _, err := net.SplitHostPort(urlParts.Host)
+	if err != nil {
+		return "", err //not tested
+	}
+
+	return fmt.Sprintf("https://%s:8443/oauth/token", boshHost), nil
+}
+
+func (c client) httpClientContext() context.Context {
+	return context.WithValue(context.Background(), oauth2.HTTPClient, c.httpClient)
+}
+
+// makeRequests retries GET and HEAD requests on errors. Other requests, such
+// as uploads and deletes that start director tasks, are only tried once, as
+// the director may have acted on a request whose response was lost.
 func makeRequests(httpClient *http.Client, request *http.Request) (*http.Response, error) {
 	var (
 		response *http.Response
 		err      error
+		attempts int
 	)
 
-	for i := 0; i < MAX_RETRIES; i++ {
+	retries := MAX_RETRIES
+	if request.Method != "GET" && request.Method != "HEAD" {
+		retries = 1
+	}
+
+	for attempts < retries {
+		attempts++
 		response, err = httpClient.Do(request)
 		if err == nil {
 			break
 		}
-		time.Sleep(RETRY_DELAY)
+		if attempts < retries {
+			time.Sleep(RETRY_DELAY)
+		}
 	}
 	if err != nil {
-		return &http.Response{}, fmt.Errorf("made %d attempts, last error: %s", MAX_RETRIES, err)
+		return &http.Response{}, fmt.Errorf("made %d attempts, last error: %s", attempts, err)
 	}
 
 	return response, nil
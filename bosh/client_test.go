@@ -3,6 +3,7 @@ package bosh_test
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -10,6 +11,7 @@ import (
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
@@ -26,9 +28,19 @@ var _ = Describe("Client", func() {
 		ca                     []byte
 		cloudConfig            []byte
 		token                  string
+		tokenRequests          int
 		username               string
 		password               string
 		cloudConfigContentType string
+		configsRequest         *http.Request
+		stemcellsRequest       *http.Request
+		stemcellsBody          []byte
+		releasesRequest        *http.Request
+		releasesBody           []byte
+		taskRequests           int
+		taskState              string
+		taskOutputRanges       []string
+		deploymentsRequest     *http.Request
 		httpClient             *http.Client
 		failStatus             int
 	)
@@ -36,6 +48,12 @@ var _ = Describe("Client", func() {
 	BeforeEach(func() {
 		bosh.MAX_RETRIES = 1
 		bosh.RETRY_DELAY = 1 * time.Millisecond
+		bosh.TASK_POLL_DELAY = 1 * time.Millisecond
+
+		taskRequests = 0
+		tokenRequests = 0
+		taskState = "done"
+		taskOutputRanges = nil
 
 		var err error
 		ca, err = ioutil.ReadFile("fixtures/some-fake-ca.crt")
@@ -57,6 +75,7 @@ var _ = Describe("Client", func() {
 		fakeBOSH = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			switch req.URL.Path {
 			case "/oauth/token":
+				tokenRequests++
 				username, password, _ = req.BasicAuth()
 
 				w.Header().Set("Content-Type", "application/json")
@@ -78,22 +97,117 @@ var _ = Describe("Client", func() {
 				          "uuid": "some-uuid",
 				          "version": "some-version"
 		                }`))
-			case "/cloud_configs":
+			case "/configs":
 				if failStatus != 0 {
 					w.WriteHeader(failStatus)
 					return
 				}
 
-				username, password, _ = req.BasicAuth()
-
 				token = req.Header.Get("Authorization")
-				cloudConfigContentType = req.Header.Get("Content-Type")
+				configsRequest = req
+
+				switch req.Method {
+				case "POST":
+					cloudConfigContentType = req.Header.Get("Content-Type")
+
+					var err error
+					cloudConfig, err = ioutil.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					w.WriteHeader(http.StatusCreated)
+				case "GET":
+					w.Write([]byte(`[
+					  {"id": "2", "type": "cloud", "name": "bbl", "content": "some: config"},
+					  {"id": "1", "type": "cloud", "name": "default", "content": "some-other: config"}
+					]`))
+				case "DELETE":
+					w.WriteHeader(http.StatusNoContent)
+				}
+			case "/stemcells":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
+
+				stemcellsRequest = req
+
+				var err error
+				stemcellsBody, err = ioutil.ReadAll(req.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				w.Header().Set("Location", "/tasks/1")
+				w.WriteHeader(http.StatusFound)
+			case "/releases":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
 
-				w.WriteHeader(http.StatusCreated)
+				releasesRequest = req
+
+				if req.Method == "GET" {
+					w.Write([]byte(`[
+					  {"name": "bosh-dns", "release_versions": [{"version": "0.0.10"}, {"version": "0.0.11"}]}
+					]`))
+					return
+				}
 
 				var err error
-				cloudConfig, err = ioutil.ReadAll(req.Body)
+				releasesBody, err = ioutil.ReadAll(req.Body)
 				Expect(err).NotTo(HaveOccurred())
+
+				w.Header().Set("Location", "/tasks/1")
+				w.WriteHeader(http.StatusFound)
+			case "/deployments":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
+
+				w.Write([]byte(`[
+				  {"name": "cf", "releases": [], "stemcells": [], "cloud_config": "latest"},
+				  {"name": "concourse", "releases": [], "stemcells": [], "cloud_config": "latest"}
+				]`))
+			case "/deployments/cf":
+				if failStatus != 0 {
+					w.WriteHeader(failStatus)
+					return
+				}
+
+				deploymentsRequest = req
+
+				w.Header().Set("Location", "/tasks/1")
+				w.WriteHeader(http.StatusFound)
+			case "/tasks/1":
+				taskRequests++
+
+				state := "processing"
+				if taskRequests > 1 {
+					state = taskState
+				}
+
+				w.Write([]byte(fmt.Sprintf(`{"id": 1, "state": %q, "description": "create stemcell", "result": "some-result"}`, state)))
+			case "/tasks/1/output":
+				Expect(req.URL.Query().Get("type")).To(Equal("event"))
+
+				rangeHeader := req.Header.Get("Range")
+				taskOutputRanges = append(taskOutputRanges, rangeHeader)
+
+				output := `{"time": 1500000000, "stage": "Update stemcell", "task": "Extracting stemcell archive", "index": 1, "total": 2, "state": "started", "progress": 0}` + "\n" + `{"time": 1500000001, "stage": "Upd`
+				if taskRequests > 1 {
+					output = `{"time": 1500000000, "stage": "Update stemcell", "task": "Extracting stemcell archive", "index": 1, "total": 2, "state": "started", "progress": 0}` + "\n" +
+						`{"time": 1500000001, "stage": "Update stemcell", "task": "Extracting stemcell archive", "index": 1, "total": 2, "state": "finished", "progress": 100}` + "\n"
+				}
+
+				var offset int
+				fmt.Sscanf(rangeHeader, "bytes=%d-", &offset)
+				if offset >= len(output) {
+					w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+					return
+				}
+
+				w.WriteHeader(http.StatusPartialContent)
+				w.Write([]byte(output[offset:]))
 			default:
 				dump, err := httputil.DumpRequest(req, true)
 				Expect(err).NotTo(HaveOccurred())
@@ -181,9 +295,9 @@ var _ = Describe("Client", func() {
 		})
 	})
 
-	Describe("UpdateCloudConfig", func() {
+	Describe("UpdateConfig", func() {
 		Context("when a jumpbox is enabled", func() {
-			It("uses UAA to get a token in order to upload the cloud-config", func() {
+			It("uses UAA to get a token in order to upload the config", func() {
 				dialer := &fakes.Socks5Client{}
 				dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
 					u, _ := url.Parse(fakeBOSH.URL)
@@ -201,11 +315,13 @@ var _ = Describe("Client", func() {
 
 				client := bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
 
-				err := client.UpdateCloudConfig([]byte("cloud: config"))
+				err := client.UpdateConfig("cloud", "bbl", []byte("cloud: config"))
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(token).To(Equal("Bearer some-uaa-token"))
-				Expect(cloudConfig).To(Equal([]byte("cloud: config")))
+				Expect(configsRequest.Method).To(Equal("POST"))
+				Expect(cloudConfigContentType).To(Equal("application/json"))
+				Expect(cloudConfig).To(MatchJSON(`{"type": "cloud", "name": "bbl", "content": "cloud: config"}`))
 			})
 
 			Context("when an error occurs", func() {
@@ -215,7 +331,7 @@ var _ = Describe("Client", func() {
 
 						client := bosh.NewClient(httpClient, fakeBOSH.URL, "", "", string(ca))
 
-						err := client.UpdateCloudConfig([]byte("cloud: config"))
+						err := client.UpdateConfig("cloud", "bbl", []byte("cloud: config"))
 						Expect(err).To(MatchError(ContainSubstring("made 1 attempts, last error: Post")))
 						Expect(err).To(MatchError(ContainSubstring("connection refused")))
 					})
@@ -223,4 +339,277 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Context("when the director is reachable", func() {
+		var client bosh.Client
+
+		BeforeEach(func() {
+			dialer := &fakes.Socks5Client{}
+			dialer.DialCall.Stub = func(network, addr string) (net.Conn, error) {
+				u, _ := url.Parse(fakeBOSH.URL)
+				return net.Dial(network, u.Host)
+			}
+
+			httpClient = &http.Client{
+				Transport: &http.Transport{
+					Dial:            dialer.Dial,
+					TLSClientConfig: tlsConfig,
+				},
+			}
+
+			fakeBOSH.StartTLS()
+
+			client = bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+		})
+
+		It("fetches the UAA token once and uses it for every request", func() {
+			_, err := client.LatestConfigs("cloud")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.Deployments()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tokenRequests).To(Equal(1))
+		})
+
+		Context("when the director cannot be reached", func() {
+			var dials int
+
+			BeforeEach(func() {
+				bosh.MAX_RETRIES = 3
+
+				dials = 0
+				httpClient = &http.Client{
+					Transport: &http.Transport{
+						Dial: func(network, addr string) (net.Conn, error) {
+							dials++
+							return nil, errors.New("connection refused")
+						},
+					},
+				}
+				client = bosh.NewClient(httpClient, fakeBOSH.URL, "some-username", "some-password", string(ca))
+			})
+
+			It("retries GET requests", func() {
+				_, err := client.Deployments()
+				Expect(err).To(MatchError(ContainSubstring("made 3 attempts")))
+				Expect(dials).To(Equal(3))
+			})
+
+			It("does not retry POST requests", func() {
+				_, err := client.UploadRemoteStemcell("https://example.com/some-stemcell.tgz")
+				Expect(err).To(MatchError(ContainSubstring("made 1 attempts")))
+				Expect(dials).To(Equal(1))
+			})
+
+			It("does not retry DELETE requests", func() {
+				_, err := client.DeleteDeployment("cf", false)
+				Expect(err).To(MatchError(ContainSubstring("made 1 attempts")))
+				Expect(dials).To(Equal(1))
+			})
+		})
+
+		Describe("LatestConfigs", func() {
+			It("returns the latest version of each config of the type", func() {
+				configs, err := client.LatestConfigs("cloud")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(configsRequest.Method).To(Equal("GET"))
+				Expect(configsRequest.URL.Query().Get("type")).To(Equal("cloud"))
+				Expect(configsRequest.URL.Query().Get("latest")).To(Equal("true"))
+				Expect(token).To(Equal("Bearer some-uaa-token"))
+
+				Expect(configs).To(Equal([]bosh.Config{
+					{ID: "2", Type: "cloud", Name: "bbl", Content: "some: config"},
+					{ID: "1", Type: "cloud", Name: "default", Content: "some-other: config"},
+				}))
+			})
+
+			Context("when the response is not StatusOK", func() {
+				It("returns an error", func() {
+					failStatus = http.StatusInternalServerError
+
+					_, err := client.LatestConfigs("cloud")
+					Expect(err).To(MatchError("unexpected http response 500 Internal Server Error"))
+				})
+			})
+		})
+
+		Describe("DeleteConfig", func() {
+			It("deletes the named config", func() {
+				err := client.DeleteConfig("cloud", "default")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(configsRequest.Method).To(Equal("DELETE"))
+				Expect(configsRequest.URL.Query().Get("type")).To(Equal("cloud"))
+				Expect(configsRequest.URL.Query().Get("name")).To(Equal("default"))
+			})
+
+			Context("when the response is not StatusNoContent", func() {
+				It("returns an error", func() {
+					failStatus = http.StatusNotFound
+
+					err := client.DeleteConfig("cloud", "default")
+					Expect(err).To(MatchError("unexpected http response 404 Not Found"))
+				})
+			})
+		})
+
+		Describe("UploadRemoteStemcell", func() {
+			It("has the director download the stemcell and returns the task id from the redirect", func() {
+				taskID, err := client.UploadRemoteStemcell("https://example.com/some-stemcell.tgz")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(taskID).To(Equal(1))
+
+				Expect(stemcellsRequest.Method).To(Equal("POST"))
+				Expect(stemcellsRequest.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(stemcellsBody).To(MatchJSON(`{"location": "https://example.com/some-stemcell.tgz"}`))
+				Expect(stemcellsRequest.Header.Get("Authorization")).To(Equal("Bearer some-uaa-token"))
+				Expect(taskRequests).To(Equal(0))
+			})
+
+			Context("when the director does not start a task", func() {
+				It("returns an error", func() {
+					failStatus = http.StatusBadRequest
+
+					_, err := client.UploadRemoteStemcell("https://example.com/some-stemcell.tgz")
+					Expect(err).To(MatchError("unexpected http response 400 Bad Request"))
+				})
+			})
+		})
+
+		Describe("UploadStemcell", func() {
+			It("uploads the stemcell tarball and returns the task id from the redirect", func() {
+				taskID, err := client.UploadStemcell(strings.NewReader("some-stemcell"), int64(len("some-stemcell")))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(taskID).To(Equal(1))
+
+				Expect(stemcellsRequest.Method).To(Equal("POST"))
+				Expect(stemcellsRequest.Header.Get("Content-Type")).To(Equal("application/x-compressed"))
+				Expect(string(stemcellsBody)).To(Equal("some-stemcell"))
+			})
+		})
+
+		Describe("Releases", func() {
+			It("returns the releases on the director", func() {
+				releases, err := client.Releases()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(releasesRequest.Method).To(Equal("GET"))
+				Expect(releases).To(Equal([]bosh.Release{
+					{Name: "bosh-dns", ReleaseVersions: []bosh.UploadedVersion{{Version: "0.0.10"}, {Version: "0.0.11"}}},
+				}))
+			})
+
+			Context("when the response is not StatusOK", func() {
+				It("returns an error", func() {
+					failStatus = http.StatusInternalServerError
+
+					_, err := client.Releases()
+					Expect(err).To(MatchError("unexpected http response 500 Internal Server Error"))
+				})
+			})
+		})
+
+		Describe("UploadRemoteRelease", func() {
+			It("has the director download the release and returns the task id from the redirect", func() {
+				taskID, err := client.UploadRemoteRelease("https://example.com/some-release.tgz", "some-sha1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(taskID).To(Equal(1))
+
+				Expect(releasesRequest.Method).To(Equal("POST"))
+				Expect(releasesRequest.Header.Get("Content-Type")).To(Equal("application/json"))
+				Expect(releasesBody).To(MatchJSON(`{"location": "https://example.com/some-release.tgz", "sha1": "some-sha1"}`))
+			})
+
+			Context("when the director does not start a task", func() {
+				It("returns an error", func() {
+					failStatus = http.StatusBadRequest
+
+					_, err := client.UploadRemoteRelease("https://example.com/some-release.tgz", "some-sha1")
+					Expect(err).To(MatchError("unexpected http response 400 Bad Request"))
+				})
+			})
+		})
+
+		Describe("Deployments", func() {
+			It("returns the deployments on the director", func() {
+				deployments, err := client.Deployments()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(deployments).To(Equal([]bosh.Deployment{{Name: "cf"}, {Name: "concourse"}}))
+			})
+
+			Context("when the response is not StatusOK", func() {
+				It("returns an error", func() {
+					failStatus = http.StatusInternalServerError
+
+					_, err := client.Deployments()
+					Expect(err).To(MatchError("unexpected http response 500 Internal Server Error"))
+				})
+			})
+		})
+
+		Describe("DeleteDeployment", func() {
+			It("deletes the deployment and returns the task id from the redirect", func() {
+				taskID, err := client.DeleteDeployment("cf", false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(taskID).To(Equal(1))
+
+				Expect(deploymentsRequest.Method).To(Equal("DELETE"))
+				Expect(deploymentsRequest.URL.Query().Get("force")).To(BeEmpty())
+			})
+
+			It("forces the delete", func() {
+				_, err := client.DeleteDeployment("cf", true)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(deploymentsRequest.URL.Query().Get("force")).To(Equal("true"))
+			})
+
+			Context("when the director does not start a task", func() {
+				It("returns an error", func() {
+					failStatus = http.StatusNotFound
+
+					_, err := client.DeleteDeployment("cf", false)
+					Expect(err).To(MatchError("unexpected http response 404 Not Found"))
+				})
+			})
+		})
+
+		Describe("WaitForTask", func() {
+			It("polls the task until it is done", func() {
+				task, err := client.WaitForTask(1, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(task).To(Equal(bosh.Task{ID: 1, State: "done", Description: "create stemcell", Result: "some-result"}))
+				Expect(taskRequests).To(Equal(2))
+				Expect(taskOutputRanges).To(BeEmpty())
+			})
+
+			It("streams each complete event of the task once", func() {
+				var events []bosh.TaskEvent
+				_, err := client.WaitForTask(1, func(event bosh.TaskEvent) {
+					events = append(events, event)
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(events).To(Equal([]bosh.TaskEvent{
+					{Time: 1500000000, Stage: "Update stemcell", Task: "Extracting stemcell archive", Index: 1, Total: 2, State: "started"},
+					{Time: 1500000001, Stage: "Update stemcell", Task: "Extracting stemcell archive", Index: 1, Total: 2, State: "finished", Progress: 100},
+				}))
+				Expect(taskOutputRanges).To(Equal([]string{"bytes=0-", "bytes=147-"}))
+			})
+
+			Context("when the task fails", func() {
+				It("returns a task error with the result of the task", func() {
+					taskState = "error"
+
+					task, err := client.WaitForTask(1, nil)
+					Expect(err).To(MatchError("task 1 error: some-result"))
+					Expect(err).To(Equal(bosh.TaskError{Task: task}))
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,53 @@
+package bosh
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// DeploymentDeleter deletes every deployment on the director, so that the
+// director and its network can be torn down.
+type DeploymentDeleter struct {
+	clientProvider clientProvider
+	logger         logger
+}
+
+func NewDeploymentDeleter(clientProvider clientProvider, logger logger) DeploymentDeleter {
+	return DeploymentDeleter{
+		clientProvider: clientProvider,
+		logger:         logger,
+	}
+}
+
+// DeleteDeployments deletes the deployments one at a time, waiting for each
+// task to finish before starting the next.
+func (d DeploymentDeleter) DeleteDeployments(state storage.State, force bool) error {
+	boshClient, err := d.clientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	if err != nil {
+		return err
+	}
+
+	deployments, err := boshClient.Deployments()
+	if err != nil {
+		return fmt.Errorf("list deployments: %s", err)
+	}
+
+	for _, deployment := range deployments {
+		d.logger.Step("deleting deployment %s", deployment.Name)
+
+		taskID, err := boshClient.DeleteDeployment(deployment.Name, force)
+		if err != nil {
+			return fmt.Errorf("delete deployment %s: %s", deployment.Name, err)
+		}
+
+		_, err = boshClient.WaitForTask(taskID, func(event TaskEvent) {
+			d.logger.Println(event.String())
+		})
+		if err != nil {
+			return fmt.Errorf("delete deployment %s: %s", deployment.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package bosh_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeploymentDeleter", func() {
+	var (
+		boshClient         *fakes.BOSHClient
+		boshClientProvider *fakes.BOSHClientProvider
+		logger             *fakes.Logger
+		deleter            bosh.DeploymentDeleter
+		state              storage.State
+	)
+
+	BeforeEach(func() {
+		boshClient = &fakes.BOSHClient{}
+		boshClient.DeploymentsCall.Returns.Deployments = []bosh.Deployment{
+			{Name: "cf"},
+			{Name: "concourse"},
+		}
+		boshClient.DeleteDeploymentCall.Returns.TaskID = 7
+		boshClient.WaitForTaskCall.Returns.Events = []bosh.TaskEvent{
+			{Time: 1500000000, Stage: "Deleting instances", Task: "router/0", Index: 1, Total: 1, State: "finished"},
+		}
+
+		boshClientProvider = &fakes.BOSHClientProvider{}
+		boshClientProvider.ClientCall.Returns.Client = boshClient
+		logger = &fakes.Logger{}
+
+		deleter = bosh.NewDeploymentDeleter(boshClientProvider, logger)
+
+		state = storage.State{
+			Jumpbox: storage.Jumpbox{
+				URL: "some-jumpbox-url",
+			},
+			BOSH: storage.BOSH{
+				DirectorAddress:  "some-director-address",
+				DirectorUsername: "some-director-username",
+				DirectorPassword: "some-director-password",
+				DirectorSSLCA:    "some-director-ca",
+			},
+		}
+	})
+
+	It("deletes every deployment and waits for each task", func() {
+		err := deleter.DeleteDeployments(state, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(boshClientProvider.ClientCall.Receives.Jumpbox.URL).To(Equal("some-jumpbox-url"))
+		Expect(boshClientProvider.ClientCall.Receives.DirectorAddress).To(Equal("some-director-address"))
+
+		Expect(boshClient.DeleteDeploymentCall.Receives).To(Equal([]fakes.DeleteDeploymentCallReceive{
+			{Name: "cf", Force: false},
+			{Name: "concourse", Force: false},
+		}))
+		Expect(boshClient.WaitForTaskCall.CallCount).To(Equal(2))
+		Expect(boshClient.WaitForTaskCall.Receives.ID).To(Equal(7))
+
+		Expect(logger.StepCall.Messages).To(Equal([]string{"deleting deployment cf", "deleting deployment concourse"}))
+		Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+			"02:40:00 | Deleting instances: router/0 (1/1) finished",
+			"02:40:00 | Deleting instances: router/0 (1/1) finished",
+		}))
+	})
+
+	It("passes force to the director", func() {
+		err := deleter.DeleteDeployments(state, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(boshClient.DeleteDeploymentCall.Receives[0].Force).To(BeTrue())
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the client cannot be created", func() {
+			boshClientProvider.ClientCall.Returns.Error = errors.New("failed to start proxy")
+
+			err := deleter.DeleteDeployments(state, false)
+			Expect(err).To(MatchError("failed to start proxy"))
+		})
+
+		It("returns an error when the deployments cannot be listed", func() {
+			boshClient.DeploymentsCall.Returns.Error = errors.New("unexpected http response 500 Internal Server Error")
+
+			err := deleter.DeleteDeployments(state, false)
+			Expect(err).To(MatchError("list deployments: unexpected http response 500 Internal Server Error"))
+		})
+
+		It("returns an error when a deployment cannot be deleted", func() {
+			boshClient.DeleteDeploymentCall.Returns.Error = errors.New("unexpected http response 404 Not Found")
+
+			err := deleter.DeleteDeployments(state, false)
+			Expect(err).To(MatchError("delete deployment cf: unexpected http response 404 Not Found"))
+		})
+
+		It("stops at the first task that fails", func() {
+			boshClient.WaitForTaskCall.Returns.Error = bosh.TaskError{Task: bosh.Task{ID: 7, State: "error", Result: "some-result"}}
+
+			err := deleter.DeleteDeployments(state, false)
+			Expect(err).To(MatchError("delete deployment cf: task 7 error: some-result"))
+			Expect(boshClient.DeleteDeploymentCall.CallCount).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package bosh
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+//go:generate go run gen_deployment_versions.go
+
+// The SHAs of the vendored deployments are the source of
+// deployment-versions.txt at the root of the repository. Run go generate in
+// this package after changing them.
+const (
+	VendoredSource = "vendored"
+
+	VendoredBOSHDeploymentSHA    = "096b55060b77d438af385c010841cf8608c63dfb"
+	VendoredJumpboxDeploymentSHA = "8c7c9495c5f8f81f1a48ba662900a98e6c9a2453"
+
+	boshDeploymentVendorDir    = "vendor/github.com/cloudfoundry/bosh-deployment"
+	jumpboxDeploymentVendorDir = "vendor/github.com/cppforlife/jumpbox-deployment"
+)
+
+// DeploymentDirs are local checkouts of bosh-deployment and jumpbox-deployment
+// to use instead of the vendored copies. An empty dir uses the vendored copy.
+type DeploymentDirs struct {
+	BOSH    string
+	Jumpbox string
+}
+
+// deploymentFiles reads each path, relative to the root of the deployment
+// repository, from dir or from the vendored copy when dir is empty.
+func (e Executor) deploymentFiles(dir, vendorDir string, paths map[string]string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for name, path := range paths {
+		if dir == "" {
+			files[name] = MustAsset(filepath.Join(vendorDir, path))
+			continue
+		}
+
+		contents, err := e.readFile(filepath.Join(dir, path))
+		if err != nil {
+			return nil, err
+		}
+		files[name] = contents
+	}
+
+	return files, nil
+}
+
+func (e Executor) deploymentSource(dir, vendoredSHA string) storage.DeploymentSource {
+	if dir == "" {
+		return storage.DeploymentSource{
+			Source: VendoredSource,
+			SHA:    vendoredSHA,
+		}
+	}
+
+	return storage.DeploymentSource{
+		Source: dir,
+		SHA:    e.gitHeadSHA(dir),
+	}
+}
+
+// gitHeadSHA returns the commit checked out in dir, or an empty string when
+// dir is not the root of a git repository.
+func (e Executor) gitHeadSHA(dir string) string {
+	head, err := e.readFile(filepath.Join(dir, ".git", "HEAD"))
+	if err != nil {
+		return ""
+	}
+
+	ref := strings.TrimSpace(string(head))
+	if !strings.HasPrefix(ref, "ref: ") {
+		return ref
+	}
+	ref = strings.TrimPrefix(ref, "ref: ")
+
+	sha, err := e.readFile(filepath.Join(dir, ".git", filepath.FromSlash(ref)))
+	if err == nil {
+		return strings.TrimSpace(string(sha))
+	}
+
+	packedRefs, err := e.readFile(filepath.Join(dir, ".git", "packed-refs"))
+	if err != nil {
+		return ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(packedRefs))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == ref {
+			return fields[0]
+		}
+	}
+
+	return ""
+}
+
+// DeploymentVersions returns the contents of deployment-versions.txt, which
+// names the vendored jumpbox-deployment and bosh-deployment commits.
+func DeploymentVersions() string {
+	return fmt.Sprintf("- *Current jumpbox-deployment: cppforlife/jumpbox-deployment@%s*\n"+
+		"- *Current bosh-deployment: cloudfoundry/bosh-deployment@%s*\n",
+		VendoredJumpboxDeploymentSHA, VendoredBOSHDeploymentSHA)
+}
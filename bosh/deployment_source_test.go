@@ -0,0 +1,19 @@
+package bosh_test
+
+import (
+	"io/ioutil"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeploymentVersions", func() {
+	It("matches deployment-versions.txt, run go generate in the bosh package if it does not", func() {
+		contents, err := ioutil.ReadFile("../deployment-versions.txt")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(contents)).To(Equal(bosh.DeploymentVersions()))
+	})
+})
@@ -0,0 +1,185 @@
+package bosh
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// DirectorVersions are the releases and stemcell of a director manifest. A
+// versions file pins them in the same form.
+type DirectorVersions struct {
+	Releases []ReleaseVersion `yaml:"releases,omitempty"`
+	Stemcell StemcellVersion  `yaml:"stemcell,omitempty"`
+}
+
+type ReleaseVersion struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	URL     string `yaml:"url"`
+	SHA1    string `yaml:"sha1"`
+}
+
+type StemcellVersion struct {
+	URL  string `yaml:"url,omitempty"`
+	SHA1 string `yaml:"sha1,omitempty"`
+}
+
+// ComponentVersion is a line of the summary of a director's versions.
+type ComponentVersion struct {
+	Name    string
+	Version string
+}
+
+// ManifestVersions reads the releases and the stemcell of the vms resource
+// pool from a director manifest.
+func ManifestVersions(manifest string) (DirectorVersions, error) {
+	var parsed struct {
+		Releases      []ReleaseVersion `yaml:"releases"`
+		ResourcePools []struct {
+			Name     string          `yaml:"name"`
+			Stemcell StemcellVersion `yaml:"stemcell"`
+		} `yaml:"resource_pools"`
+	}
+
+	err := yaml.Unmarshal([]byte(manifest), &parsed)
+	if err != nil {
+		return DirectorVersions{}, fmt.Errorf("parse director manifest: %s", err)
+	}
+
+	versions := DirectorVersions{Releases: parsed.Releases}
+	for _, pool := range parsed.ResourcePools {
+		if pool.Name == "vms" {
+			versions.Stemcell = pool.Stemcell
+		}
+	}
+
+	sort.Slice(versions.Releases, func(i, j int) bool {
+		return versions.Releases[i].Name < versions.Releases[j].Name
+	})
+
+	return versions, nil
+}
+
+// ParseVersionsFile reads a versions file. Every release needs a version, url
+// and sha1 for create-env to fetch it, and so does a pinned stemcell.
+func ParseVersionsFile(contents string) (DirectorVersions, error) {
+	var versions DirectorVersions
+	err := yaml.UnmarshalStrict([]byte(contents), &versions)
+	if err != nil {
+		return DirectorVersions{}, fmt.Errorf("parse versions file: %s", err)
+	}
+
+	if len(versions.Releases) == 0 && versions.Stemcell == (StemcellVersion{}) {
+		return DirectorVersions{}, errors.New("versions file does not pin any releases or a stemcell")
+	}
+
+	for _, release := range versions.Releases {
+		if release.Name == "" || release.Version == "" || release.URL == "" || release.SHA1 == "" {
+			return DirectorVersions{}, fmt.Errorf("release %q in versions file needs a name, version, url and sha1", release.Name)
+		}
+	}
+
+	if versions.Stemcell != (StemcellVersion{}) && (versions.Stemcell.URL == "" || versions.Stemcell.SHA1 == "") {
+		return DirectorVersions{}, errors.New("stemcell in versions file needs a url and sha1")
+	}
+
+	return versions, nil
+}
+
+// OpsFile replaces the releases and stemcell of the director manifest with
+// the pinned ones. Releases the manifest does not have yet are added.
+func (v DirectorVersions) OpsFile() []byte {
+	type op struct {
+		Type  string      `yaml:"type"`
+		Path  string      `yaml:"path"`
+		Value interface{} `yaml:"value"`
+	}
+
+	var ops []op
+	for _, release := range v.Releases {
+		ops = append(ops, op{
+			Type:  "replace",
+			Path:  fmt.Sprintf("/releases/name=%s?", release.Name),
+			Value: release,
+		})
+	}
+
+	if v.Stemcell != (StemcellVersion{}) {
+		ops = append(ops, op{
+			Type:  "replace",
+			Path:  "/resource_pools/name=vms/stemcell?",
+			Value: v.Stemcell,
+		})
+	}
+
+	return mustMarshal(ops)
+}
+
+// VersionsFile returns the versions as a versions file that pins them.
+func (v DirectorVersions) VersionsFile() string {
+	return string(mustMarshal(v))
+}
+
+// Summary returns the versions of the director, UAA, CredHub and CPI
+// releases and of the stemcell, with "-" for those the manifest lacks.
+func (v DirectorVersions) Summary() []ComponentVersion {
+	summary := []ComponentVersion{
+		{Name: "director", Version: v.release("bosh").Version},
+		{Name: "uaa", Version: v.release("uaa").Version},
+		{Name: "credhub", Version: v.release("credhub").Version},
+		{Name: "cpi", Version: v.cpi()},
+		{Name: "stemcell", Version: v.Stemcell.String()},
+	}
+
+	for i := range summary {
+		if summary[i].Version == "" {
+			summary[i].Version = "-"
+		}
+	}
+
+	return summary
+}
+
+func (v DirectorVersions) release(name string) ReleaseVersion {
+	for _, release := range v.Releases {
+		if release.Name == name {
+			return release
+		}
+	}
+	return ReleaseVersion{}
+}
+
+func (v DirectorVersions) cpi() string {
+	for _, release := range v.Releases {
+		if strings.HasSuffix(release.Name, "-cpi") {
+			return fmt.Sprintf("%s/%s", release.Name, release.Version)
+		}
+	}
+	return ""
+}
+
+// String returns name/version for bosh.io stemcell urls, and the name of the
+// tarball for any other url.
+func (s StemcellVersion) String() string {
+	if s.URL == "" {
+		return ""
+	}
+
+	stemcellURL, err := url.Parse(s.URL)
+	if err != nil {
+		return s.URL
+	}
+
+	name := path.Base(stemcellURL.Path)
+	if version := stemcellURL.Query().Get("v"); version != "" {
+		return fmt.Sprintf("%s/%s", name, version)
+	}
+
+	return name
+}
@@ -0,0 +1,167 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/interpolate"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DirectorVersions", func() {
+	const manifest = `---
+name: bosh
+releases:
+- name: bosh
+  version: "263.2.0"
+  url: https://bosh.io/d/github.com/cloudfoundry/bosh?v=263.2.0
+  sha1: some-bosh-sha1
+- name: uaa
+  version: "45"
+  url: https://bosh.io/d/github.com/cloudfoundry/uaa-release?v=45
+  sha1: some-uaa-sha1
+- name: bosh-google-cpi
+  version: 25.10.0
+  url: https://bosh.io/d/github.com/cloudfoundry-incubator/bosh-google-cpi-release?v=25.10.0
+  sha1: some-cpi-sha1
+resource_pools:
+- name: vms
+  stemcell:
+    url: https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-trusty-go_agent?v=3445.7
+    sha1: some-stemcell-sha1
+`
+
+	Describe("ManifestVersions", func() {
+		It("reads the releases and stemcell of the manifest", func() {
+			versions, err := bosh.ManifestVersions(manifest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(versions.Releases).To(Equal([]bosh.ReleaseVersion{
+				{Name: "bosh", Version: "263.2.0", URL: "https://bosh.io/d/github.com/cloudfoundry/bosh?v=263.2.0", SHA1: "some-bosh-sha1"},
+				{Name: "bosh-google-cpi", Version: "25.10.0", URL: "https://bosh.io/d/github.com/cloudfoundry-incubator/bosh-google-cpi-release?v=25.10.0", SHA1: "some-cpi-sha1"},
+				{Name: "uaa", Version: "45", URL: "https://bosh.io/d/github.com/cloudfoundry/uaa-release?v=45", SHA1: "some-uaa-sha1"},
+			}))
+			Expect(versions.Stemcell).To(Equal(bosh.StemcellVersion{
+				URL:  "https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-trusty-go_agent?v=3445.7",
+				SHA1: "some-stemcell-sha1",
+			}))
+		})
+
+		It("returns an error when the manifest is not yaml", func() {
+			_, err := bosh.ManifestVersions("%%%")
+			Expect(err).To(MatchError(ContainSubstring("parse director manifest: ")))
+		})
+	})
+
+	Describe("Summary", func() {
+		It("returns the version of each component of the director", func() {
+			versions, err := bosh.ManifestVersions(manifest)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(versions.Summary()).To(Equal([]bosh.ComponentVersion{
+				{Name: "director", Version: "263.2.0"},
+				{Name: "uaa", Version: "45"},
+				{Name: "credhub", Version: "-"},
+				{Name: "cpi", Version: "bosh-google-cpi/25.10.0"},
+				{Name: "stemcell", Version: "bosh-google-kvm-ubuntu-trusty-go_agent/3445.7"},
+			}))
+		})
+
+		It("names stemcells from other urls after the tarball", func() {
+			stemcell := bosh.StemcellVersion{URL: "https://example.com/stemcells/light-bosh-stemcell-3445.7-aws-xen-hvm-ubuntu-trusty-go_agent.tgz"}
+			Expect(stemcell.String()).To(Equal("light-bosh-stemcell-3445.7-aws-xen-hvm-ubuntu-trusty-go_agent.tgz"))
+		})
+	})
+
+	Describe("ParseVersionsFile", func() {
+		It("parses pinned releases and a stemcell", func() {
+			versions, err := bosh.ParseVersionsFile(`
+releases:
+- name: bosh
+  version: 264.1.0
+  url: https://bosh.io/d/github.com/cloudfoundry/bosh?v=264.1.0
+  sha1: some-new-bosh-sha1
+stemcell:
+  url: https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-trusty-go_agent?v=3445.11
+  sha1: some-new-stemcell-sha1
+`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(versions.Releases).To(Equal([]bosh.ReleaseVersion{
+				{Name: "bosh", Version: "264.1.0", URL: "https://bosh.io/d/github.com/cloudfoundry/bosh?v=264.1.0", SHA1: "some-new-bosh-sha1"},
+			}))
+			Expect(versions.Stemcell.SHA1).To(Equal("some-new-stemcell-sha1"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error for unknown keys", func() {
+				_, err := bosh.ParseVersionsFile("release: []")
+				Expect(err).To(MatchError(ContainSubstring("parse versions file: ")))
+			})
+
+			It("returns an error when nothing is pinned", func() {
+				_, err := bosh.ParseVersionsFile("releases: []")
+				Expect(err).To(MatchError("versions file does not pin any releases or a stemcell"))
+			})
+
+			It("returns an error when a release has no sha1", func() {
+				_, err := bosh.ParseVersionsFile(`releases: [{name: bosh, version: 264.1.0, url: "https://example.com/bosh.tgz"}]`)
+				Expect(err).To(MatchError(`release "bosh" in versions file needs a name, version, url and sha1`))
+			})
+
+			It("returns an error when the stemcell has no sha1", func() {
+				_, err := bosh.ParseVersionsFile(`stemcell: {url: "https://example.com/stemcell.tgz"}`)
+				Expect(err).To(MatchError("stemcell in versions file needs a url and sha1"))
+			})
+		})
+	})
+
+	Describe("VersionsFile", func() {
+		It("pins the versions of the manifest", func() {
+			versions, err := bosh.ManifestVersions(manifest)
+			Expect(err).NotTo(HaveOccurred())
+
+			pinned, err := bosh.ParseVersionsFile(versions.VersionsFile())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pinned).To(Equal(versions))
+		})
+	})
+
+	Describe("OpsFile", func() {
+		It("replaces the releases and stemcell of the manifest and adds missing releases", func() {
+			versions, err := bosh.ParseVersionsFile(`
+releases:
+- name: bosh
+  version: 264.1.0
+  url: https://bosh.io/d/github.com/cloudfoundry/bosh?v=264.1.0
+  sha1: some-new-bosh-sha1
+- name: credhub
+  version: 1.5.0
+  url: https://bosh.io/d/github.com/pivotal-cf/credhub-release?v=1.5.0
+  sha1: some-credhub-sha1
+stemcell:
+  url: https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-trusty-go_agent?v=3445.11
+  sha1: some-new-stemcell-sha1
+`)
+			Expect(err).NotTo(HaveOccurred())
+
+			output, err := interpolate.Interpolate(interpolate.Input{
+				Manifest: []byte(manifest),
+				OpsFiles: [][]byte{versions.OpsFile()},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			pinned, err := bosh.ManifestVersions(string(output.Manifest))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pinned.Summary()).To(Equal([]bosh.ComponentVersion{
+				{Name: "director", Version: "264.1.0"},
+				{Name: "uaa", Version: "45"},
+				{Name: "credhub", Version: "1.5.0"},
+				{Name: "cpi", Version: "bosh-google-cpi/25.10.0"},
+				{Name: "stemcell", Version: "bosh-google-kvm-ubuntu-trusty-go_agent/3445.11"},
+			}))
+			Expect(pinned.Stemcell.SHA1).To(Equal("some-new-stemcell-sha1"))
+		})
+	})
+})
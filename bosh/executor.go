@@ -10,6 +10,9 @@ import (
 	"regexp"
 
 	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	"github.com/cloudfoundry/bosh-bootloader/interpolate"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	yaml "gopkg.in/yaml.v2"
 )
 
 const gcpBoshDirectorEphemeralIPOps = `
@@ -18,6 +21,20 @@ const gcpBoshDirectorEphemeralIPOps = `
   value: true
 `
 
+// The Shared VPC ops files have the google cpi look up the network and
+// subnetwork in the host project rather than the project of the vms.
+const gcpBoshDirectorSharedVPCOps = `
+- type: replace
+  path: /networks/name=default/subnets/0/cloud_properties/xpn_host_project_id?
+  value: ((xpn_host_project_id))
+`
+
+const gcpJumpboxSharedVPCOps = `
+- type: replace
+  path: /networks/name=private/subnets/0/cloud_properties/xpn_host_project_id?
+  value: ((xpn_host_project_id))
+`
+
 const awsBoshDirectorEphemeralIPOps = `
 - type: replace
   path: /resource_pools/name=vms/cloud_properties/auto_assign_public_ip?
@@ -39,6 +56,14 @@ const azureSSHStaticIP = `
   value: ((external_ip))
 `
 
+const privateJumpboxOps = `
+- type: remove
+  path: /instance_groups/name=jumpbox/networks/name=public
+
+- type: remove
+  path: /networks/name=public
+`
+
 type Executor struct {
 	command       command
 	tempDir       func(string, string) (string, error)
@@ -54,17 +79,23 @@ type InterpolateInput struct {
 	JumpboxDeploymentVars  string
 	BOSHState              map[string]interface{}
 	Variables              string
-	OpsFile                string
+	OpsFiles               []storage.OpsFile
+	Tags                   map[string]string
+	Private                bool
+	SharedVPC              bool
+	DeploymentDirs         DeploymentDirs
 }
 
 type InterpolateOutput struct {
-	Variables string
-	Manifest  string
+	Variables        string
+	Manifest         string
+	DeploymentSource storage.DeploymentSource
 }
 
 type JumpboxInterpolateOutput struct {
-	Variables string
-	Manifest  string
+	Variables        string
+	Manifest         string
+	DeploymentSource storage.DeploymentSource
 }
 
 type CreateEnvInput struct {
@@ -103,162 +134,155 @@ func NewExecutor(cmd command, tempDir func(string, string) (string, error), read
 }
 
 func (e Executor) JumpboxInterpolate(interpolateInput InterpolateInput) (JumpboxInterpolateOutput, error) {
-	tempDir, err := e.tempDir("", "")
+	jumpboxSetupFiles, err := e.deploymentFiles(interpolateInput.DeploymentDirs.Jumpbox, jumpboxDeploymentVendorDir, map[string]string{
+		"jumpbox.yml": "jumpbox.yml",
+		"cpi.yml":     filepath.Join(interpolateInput.IAAS, "cpi.yml"),
+	})
 	if err != nil {
-		return JumpboxInterpolateOutput{}, fmt.Errorf("create temp dir: %s", err)
+		return JumpboxInterpolateOutput{}, fmt.Errorf("read jumpbox-deployment: %s", err)
 	}
 
-	var jumpboxSetupFiles = map[string][]byte{
-		"jumpbox-deployment-vars.yml": []byte(interpolateInput.JumpboxDeploymentVars),
-		"jumpbox.yml":                 MustAsset("vendor/github.com/cppforlife/jumpbox-deployment/jumpbox.yml"),
-		"cpi.yml":                     MustAsset(filepath.Join("vendor/github.com/cppforlife/jumpbox-deployment", interpolateInput.IAAS, "cpi.yml")),
+	opsFiles := [][]byte{jumpboxSetupFiles["cpi.yml"]}
+
+	if interpolateInput.Private {
+		opsFiles = append(opsFiles, []byte(privateJumpboxOps))
 	}
 
-	if interpolateInput.Variables != "" {
-		jumpboxSetupFiles["variables.yml"] = []byte(interpolateInput.Variables)
+	if interpolateInput.SharedVPC {
+		opsFiles = append(opsFiles, []byte(gcpJumpboxSharedVPCOps))
 	}
 
-	for path, contents := range jumpboxSetupFiles {
-		err = e.writeFile(filepath.Join(tempDir, path), contents, os.ModePerm)
+	if len(interpolateInput.Tags) > 0 {
+		tagsOps, err := tagsOpsFile(interpolateInput.Tags)
 		if err != nil {
 			//not tested
-			return JumpboxInterpolateOutput{}, fmt.Errorf("write file: %s", err)
+			return JumpboxInterpolateOutput{}, fmt.Errorf("tags ops file: %s", err)
 		}
+		opsFiles = append(opsFiles, tagsOps)
 	}
 
-	args := []string{
-		"interpolate", filepath.Join(tempDir, "jumpbox.yml"),
-		"--var-errs",
-		"--vars-store", filepath.Join(tempDir, "variables.yml"),
-		"--vars-file", filepath.Join(tempDir, "jumpbox-deployment-vars.yml"),
-		"-o", filepath.Join(tempDir, "cpi.yml"),
-	}
-
-	buffer := bytes.NewBuffer([]byte{})
-	err = e.command.Run(buffer, tempDir, args)
-	if err != nil {
-		return JumpboxInterpolateOutput{}, fmt.Errorf("bosh interpolate: %s: %s", err, buffer)
+	for _, opsFile := range interpolateInput.OpsFiles {
+		opsFiles = append(opsFiles, []byte(opsFile.Contents))
 	}
 
-	varsStore, err := e.readFile(filepath.Join(tempDir, "variables.yml"))
+	output, err := interpolate.Interpolate(interpolate.Input{
+		Manifest:  jumpboxSetupFiles["jumpbox.yml"],
+		OpsFiles:  opsFiles,
+		VarsFile:  []byte(interpolateInput.JumpboxDeploymentVars),
+		VarsStore: []byte(interpolateInput.Variables),
+		VarErrs:   true,
+	})
 	if err != nil {
-		return JumpboxInterpolateOutput{}, fmt.Errorf("read file: %s", err)
+		return JumpboxInterpolateOutput{}, fmt.Errorf("interpolate jumpbox manifest: %s", err)
 	}
 
 	return JumpboxInterpolateOutput{
-		Variables: string(varsStore),
-		Manifest:  buffer.String(),
+		Variables:        string(output.VarsStore),
+		Manifest:         string(output.Manifest),
+		DeploymentSource: e.deploymentSource(interpolateInput.DeploymentDirs.Jumpbox, VendoredJumpboxDeploymentSHA),
 	}, nil
 }
 
 func (e Executor) DirectorInterpolate(interpolateInput InterpolateInput) (InterpolateOutput, error) {
-	tempDir, err := e.tempDir("", "")
+	directorSetupFiles, err := e.deploymentFiles(interpolateInput.DeploymentDirs.BOSH, boshDeploymentVendorDir, map[string]string{
+		"bosh.yml":                              "bosh.yml",
+		"cpi.yml":                               filepath.Join(interpolateInput.IAAS, "cpi.yml"),
+		"iam-instance-profile.yml":              "aws/iam-instance-profile.yml",
+		"jumpbox-user.yml":                      "jumpbox-user.yml",
+		"gcp-external-ip-not-recommended.yml":   "external-ip-not-recommended.yml",
+		"azure-external-ip-not-recommended.yml": "external-ip-not-recommended.yml",
+		"aws-external-ip-not-recommended.yml":   "external-ip-with-registry-not-recommended.yml",
+		"uaa.yml":                               "uaa.yml",
+		"credhub.yml":                           "credhub.yml",
+	})
 	if err != nil {
-		//not tested
-		return InterpolateOutput{}, err
-	}
-
-	var directorSetupFiles = map[string][]byte{
-		"deployment-vars.yml":                    []byte(interpolateInput.DirectorDeploymentVars),
-		"user-ops-file.yml":                      []byte(interpolateInput.OpsFile),
-		"bosh.yml":                               MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/bosh.yml"),
-		"cpi.yml":                                MustAsset(filepath.Join("vendor/github.com/cloudfoundry/bosh-deployment", interpolateInput.IAAS, "cpi.yml")),
-		"iam-instance-profile.yml":               MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/aws/iam-instance-profile.yml"),
-		"gcp-bosh-director-ephemeral-ip-ops.yml": []byte(gcpBoshDirectorEphemeralIPOps),
-		"aws-bosh-director-ephemeral-ip-ops.yml": []byte(awsBoshDirectorEphemeralIPOps),
-		"aws-bosh-director-encrypt-disk-ops.yml": []byte(awsEncryptDiskOps),
-		"azure-ssh-static-ip.yml":                []byte(azureSSHStaticIP),
-		"jumpbox-user.yml":                       MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/jumpbox-user.yml"),
-		"gcp-external-ip-not-recommended.yml":    MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/external-ip-not-recommended.yml"),
-		"azure-external-ip-not-recommended.yml":  MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/external-ip-not-recommended.yml"),
-		"aws-external-ip-not-recommended.yml":    MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/external-ip-with-registry-not-recommended.yml"),
-		"uaa.yml":     MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/uaa.yml"),
-		"credhub.yml": MustAsset("vendor/github.com/cloudfoundry/bosh-deployment/credhub.yml"),
-	}
-
-	if interpolateInput.Variables != "" {
-		directorSetupFiles["variables.yml"] = []byte(interpolateInput.Variables)
-	}
-
-	for path, contents := range directorSetupFiles {
-		err = e.writeFile(filepath.Join(tempDir, path), contents, os.ModePerm)
-		if err != nil {
-			//not tested
-			return InterpolateOutput{}, err
-		}
+		return InterpolateOutput{}, fmt.Errorf("read bosh-deployment: %s", err)
 	}
 
-	var args = []string{
-		"interpolate", filepath.Join(tempDir, "bosh.yml"),
-		"--var-errs",
-		"--var-errs-unused",
-		"--vars-store", filepath.Join(tempDir, "variables.yml"),
-		"--vars-file", filepath.Join(tempDir, "deployment-vars.yml"),
-		"-o", filepath.Join(tempDir, "cpi.yml"),
-	}
+	opsFiles := [][]byte{directorSetupFiles["cpi.yml"]}
 
 	switch interpolateInput.IAAS {
 	case "gcp":
-		args = append(args,
-			"-o", filepath.Join(tempDir, "jumpbox-user.yml"),
-			"-o", filepath.Join(tempDir, "uaa.yml"),
-			"-o", filepath.Join(tempDir, "credhub.yml"),
-			"-o", filepath.Join(tempDir, "gcp-bosh-director-ephemeral-ip-ops.yml"),
+		opsFiles = append(opsFiles,
+			directorSetupFiles["jumpbox-user.yml"],
+			directorSetupFiles["uaa.yml"],
+			directorSetupFiles["credhub.yml"],
 		)
+		if !interpolateInput.Private {
+			opsFiles = append(opsFiles, []byte(gcpBoshDirectorEphemeralIPOps))
+		}
+		if interpolateInput.SharedVPC {
+			opsFiles = append(opsFiles, []byte(gcpBoshDirectorSharedVPCOps))
+		}
 	case "aws":
-		args = append(args,
-			"-o", filepath.Join(tempDir, "jumpbox-user.yml"),
-			"-o", filepath.Join(tempDir, "uaa.yml"),
-			"-o", filepath.Join(tempDir, "credhub.yml"),
-			"-o", filepath.Join(tempDir, "aws-bosh-director-ephemeral-ip-ops.yml"),
-			"-o", filepath.Join(tempDir, "iam-instance-profile.yml"),
-			"-o", filepath.Join(tempDir, "aws-bosh-director-encrypt-disk-ops.yml"),
+		opsFiles = append(opsFiles,
+			directorSetupFiles["jumpbox-user.yml"],
+			directorSetupFiles["uaa.yml"],
+			directorSetupFiles["credhub.yml"],
+		)
+		if !interpolateInput.Private {
+			opsFiles = append(opsFiles, []byte(awsBoshDirectorEphemeralIPOps))
+		}
+		opsFiles = append(opsFiles,
+			directorSetupFiles["iam-instance-profile.yml"],
+			[]byte(awsEncryptDiskOps),
 		)
 	case "azure":
 		// NOTE: azure does not yet support jumpbox
-		args = append(args,
-			"-o", filepath.Join(tempDir, "jumpbox-user.yml"),
-			"-o", filepath.Join(tempDir, "azure-external-ip-not-recommended.yml"),
-			"-o", filepath.Join(tempDir, "azure-ssh-static-ip.yml"),
-		)
-	}
-
-	buffer := bytes.NewBuffer([]byte{})
-	err = e.command.Run(buffer, tempDir, args)
-	if err != nil {
-		return InterpolateOutput{}, err
+		opsFiles = append(opsFiles, directorSetupFiles["jumpbox-user.yml"])
+		if !interpolateInput.Private {
+			opsFiles = append(opsFiles,
+				directorSetupFiles["azure-external-ip-not-recommended.yml"],
+				[]byte(azureSSHStaticIP),
+			)
+		}
 	}
 
-	if interpolateInput.OpsFile != "" {
-		err = e.writeFile(filepath.Join(tempDir, "bosh.yml"), buffer.Bytes(), os.ModePerm)
+	if len(interpolateInput.Tags) > 0 {
+		tagsOps, err := tagsOpsFile(interpolateInput.Tags)
 		if err != nil {
 			//not tested
 			return InterpolateOutput{}, err
 		}
+		opsFiles = append(opsFiles, tagsOps)
+	}
+
+	output, err := interpolate.Interpolate(interpolate.Input{
+		Manifest:      directorSetupFiles["bosh.yml"],
+		OpsFiles:      opsFiles,
+		VarsFile:      []byte(interpolateInput.DirectorDeploymentVars),
+		VarsStore:     []byte(interpolateInput.Variables),
+		VarErrs:       true,
+		VarErrsUnused: true,
+	})
+	if err != nil {
+		return InterpolateOutput{}, fmt.Errorf("interpolate director manifest: %s", err)
+	}
 
-		args = []string{
-			"interpolate", filepath.Join(tempDir, "bosh.yml"),
-			"--var-errs",
-			"--vars-store", filepath.Join(tempDir, "variables.yml"),
-			"--vars-file", filepath.Join(tempDir, "deployment-vars.yml"),
-			"-o", filepath.Join(tempDir, "user-ops-file.yml"),
+	// User ops files are applied in a second pass so that they may leave
+	// deployment vars unused.
+	if len(interpolateInput.OpsFiles) > 0 {
+		var userOpsFiles [][]byte
+		for _, opsFile := range interpolateInput.OpsFiles {
+			userOpsFiles = append(userOpsFiles, []byte(opsFile.Contents))
 		}
 
-		buffer = bytes.NewBuffer([]byte{})
-		err = e.command.Run(buffer, tempDir, args)
+		output, err = interpolate.Interpolate(interpolate.Input{
+			Manifest:  output.Manifest,
+			OpsFiles:  userOpsFiles,
+			VarsFile:  []byte(interpolateInput.DirectorDeploymentVars),
+			VarsStore: output.VarsStore,
+			VarErrs:   true,
+		})
 		if err != nil {
-			return InterpolateOutput{}, err
+			return InterpolateOutput{}, fmt.Errorf("interpolate director manifest with ops files: %s", err)
 		}
 	}
 
-	varsStore, err := e.readFile(filepath.Join(tempDir, "variables.yml"))
-	if err != nil {
-		return InterpolateOutput{}, err
-	}
-
 	return InterpolateOutput{
-		Variables: string(varsStore),
-		Manifest:  buffer.String(),
+		Variables:        string(output.VarsStore),
+		Manifest:         string(output.Manifest),
+		DeploymentSource: e.deploymentSource(interpolateInput.DeploymentDirs.BOSH, VendoredBOSHDeploymentSHA),
 	}, nil
 }
 
@@ -407,3 +431,15 @@ func (e Executor) writePreviousFiles(state map[string]interface{}, variables, ma
 
 	return tempDir, nil
 }
+
+// tagsOpsFile builds an ops file that sets the manifest's top level tags,
+// which bosh applies to every vm and disk it creates.
+func tagsOpsFile(tags map[string]string) ([]byte, error) {
+	return yaml.Marshal([]map[string]interface{}{
+		{
+			"type":  "replace",
+			"path":  "/tags?",
+			"value": tags,
+		},
+	})
+}
@@ -7,10 +7,13 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/pivotal-cf-experimental/gomegamatchers"
+	yaml "gopkg.in/yaml.v2"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,93 +22,105 @@ import (
 var _ = Describe("Executor", func() {
 	Describe("DirectorInterpolate", func() {
 		var (
-			cmd *fakes.BOSHCommand
-
-			tempDir          string
-			tempDirFunc      func(string, string) (string, error)
-			tempDirCallCount int
-
+			cmd      *fakes.BOSHCommand
 			executor bosh.Executor
-
-			interpolateInput bosh.InterpolateInput
 		)
 
 		BeforeEach(func() {
 			cmd = &fakes.BOSHCommand{}
-
-			var err error
-			tempDir, err = ioutil.TempDir("", "")
-			Expect(err).NotTo(HaveOccurred())
-
-			tempDirFunc = func(prefix, dir string) (string, error) {
-				tempDirCallCount++
-				return tempDir, nil
-			}
-
-			interpolateInput = bosh.InterpolateInput{
-				DirectorDeploymentVars: "internal_cidr: 10.0.0.0/24",
-				BOSHState: map[string]interface{}{
-					"key": "value",
-				},
-				Variables: "key: value",
-				OpsFile:   "some-ops-file",
-			}
-
-			executor = bosh.NewExecutor(cmd, tempDirFunc, ioutil.ReadFile, json.Unmarshal, json.Marshal, ioutil.WriteFile)
-		})
-
-		AfterEach(func() {
-			tempDirCallCount = 0
+			executor = bosh.NewExecutor(cmd, ioutil.TempDir, ioutil.ReadFile, json.Unmarshal, json.Marshal, ioutil.WriteFile)
 		})
 
 		Context("azure", func() {
 			var azureInterpolateInput bosh.InterpolateInput
 
 			BeforeEach(func() {
-				azureInterpolateInput = interpolateInput
-				azureInterpolateInput.IAAS = "azure"
+				azureInterpolateInput = bosh.InterpolateInput{
+					IAAS: "azure",
+					DirectorDeploymentVars: `
+internal_cidr: 10.0.0.0/24
+internal_gw: 10.0.0.1
+internal_ip: 10.0.0.6
+director_name: bosh-some-env
+external_ip: 1.2.3.4
+vnet_name: some-vnet
+subnet_name: some-subnet
+subscription_id: some-subscription-id
+tenant_id: some-tenant-id
+client_id: some-client-id
+client_secret: some-client-secret
+resource_group_name: some-resource-group
+storage_account_name: some-storage-account
+default_security_group: some-security-group
+`,
+				}
 			})
 
 			It("generates a bosh manifest", func() {
-				cmd.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
-					stdout.Write([]byte("some-manifest"))
-					return nil
-				}
-
 				interpolateOutput, err := executor.DirectorInterpolate(azureInterpolateInput)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(cmd.RunCallCount()).To(Equal(2))
-				Expect(tempDirCallCount).To(Equal(1))
-
-				expectedArgs := append([]string{
-					"interpolate", fmt.Sprintf("%s/bosh.yml", tempDir),
-					"--var-errs",
-					"--var-errs-unused",
-					"--vars-store", fmt.Sprintf("%s/variables.yml", tempDir),
-					"--vars-file", fmt.Sprintf("%s/deployment-vars.yml", tempDir),
-					"-o", fmt.Sprintf("%s/cpi.yml", tempDir),
-					"-o", fmt.Sprintf("%s/jumpbox-user.yml", tempDir),
-					"-o", fmt.Sprintf("%s/azure-external-ip-not-recommended.yml", tempDir),
-					"-o", fmt.Sprintf("%s/azure-ssh-static-ip.yml", tempDir),
-				})
+				Expect(cmd.RunCallCount()).To(Equal(0))
 
-				_, _, args := cmd.RunArgsForCall(0)
-				Expect(args).To(Equal(expectedArgs))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("name: bosh-azure-cpi"))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("host: 1.2.3.4"))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("name: jumpbox"))
+				Expect(interpolateOutput.Manifest).NotTo(ContainSubstring("(("))
 
-				expectedArgs = append([]string{
-					"interpolate", fmt.Sprintf("%s/bosh.yml", tempDir),
-					"--var-errs",
-					"--vars-store", fmt.Sprintf("%s/variables.yml", tempDir),
-					"--vars-file", fmt.Sprintf("%s/deployment-vars.yml", tempDir),
-					"-o", fmt.Sprintf("%s/user-ops-file.yml", tempDir),
-				})
+				variables := map[string]interface{}{}
+				Expect(yaml.Unmarshal([]byte(interpolateOutput.Variables), &variables)).To(Succeed())
+				Expect(variables).To(HaveKey("admin_password"))
+				Expect(variables).To(HaveKey("director_ssl"))
+				Expect(variables).To(HaveKey("jumpbox_ssh"))
+			})
+		})
+
+		Context("gcp", func() {
+			var gcpInterpolateInput bosh.InterpolateInput
+
+			BeforeEach(func() {
+				gcpInterpolateInput = bosh.InterpolateInput{
+					IAAS: "gcp",
+					JumpboxDeploymentVars: `
+internal_cidr: 10.0.0.0/24
+internal_gw: 10.0.0.1
+internal_ip: 10.0.0.5
+director_name: bosh-some-env
+external_ip: 1.2.3.4
+zone: some-zone
+network: some-network
+subnetwork: some-subnetwork
+tags: [some-jumpbox-tag]
+project_id: some-project-id
+xpn_host_project_id: some-host-project
+gcp_credentials_json: some-credential-json
+`,
+					DirectorDeploymentVars: `
+internal_cidr: 10.0.0.0/24
+internal_gw: 10.0.0.1
+internal_ip: 10.0.0.6
+director_name: bosh-some-env
+zone: some-zone
+network: some-network
+subnetwork: some-subnetwork
+tags: [some-director-tag]
+project_id: some-project-id
+xpn_host_project_id: some-host-project
+gcp_credentials_json: some-credential-json
+`,
+					SharedVPC: true,
+				}
+			})
 
-				_, _, args = cmd.RunArgsForCall(1)
-				Expect(args).To(Equal(expectedArgs))
+			It("looks up the network of the jumpbox and director in the Shared VPC host project", func() {
+				jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(gcpInterpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(jumpboxInterpolateOutput.Manifest).To(ContainSubstring("xpn_host_project_id: some-host-project"))
 
-				Expect(interpolateOutput.Manifest).To(Equal("some-manifest"))
-				Expect(interpolateOutput.Variables).To(Equal("key: value"))
+				interpolateOutput, err := executor.DirectorInterpolate(gcpInterpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("xpn_host_project_id: some-host-project"))
+				Expect(interpolateOutput.Manifest).NotTo(ContainSubstring("(("))
 			})
 		})
 
@@ -113,65 +128,209 @@ var _ = Describe("Executor", func() {
 			var awsInterpolateInput bosh.InterpolateInput
 
 			BeforeEach(func() {
-				awsInterpolateInput = interpolateInput
-				awsInterpolateInput.IAAS = "aws"
-
-				cmd.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
-					stdout.Write([]byte("some-manifest"))
-					return nil
+				awsInterpolateInput = bosh.InterpolateInput{
+					IAAS: "aws",
+					JumpboxDeploymentVars: `
+internal_cidr: 10.0.0.0/24
+internal_gw: 10.0.0.1
+internal_ip: 10.0.0.5
+director_name: bosh-some-env
+external_ip: 1.2.3.4
+az: some-az
+subnet_id: some-subnet-id
+access_key_id: some-access-key-id
+secret_access_key: some-secret-access-key
+iam_instance_profile: some-iam-instance-profile
+default_key_name: some-key-name
+default_security_groups: [some-jumpbox-security-group]
+region: some-region
+private_key: some-private-key
+`,
+					DirectorDeploymentVars: `
+internal_cidr: 10.0.0.0/24
+internal_gw: 10.0.0.1
+internal_ip: 10.0.0.6
+director_name: bosh-some-env
+az: some-az
+subnet_id: some-subnet-id
+access_key_id: some-access-key-id
+secret_access_key: some-secret-access-key
+iam_instance_profile: some-iam-instance-profile
+default_key_name: some-key-name
+default_security_groups: [some-bosh-security-group]
+region: some-region
+private_key: some-private-key
+kms_key_arn: some-kms-key-arn
+`,
 				}
 			})
 
 			It("interpolates the jumpbox and bosh manifests", func() {
-				awsInterpolateInput.JumpboxDeploymentVars = "internal_cidr: 10.0.0.0/24"
-				awsInterpolateInput.OpsFile = ""
-
 				jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(awsInterpolateInput)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(cmd.RunCallCount()).To(Equal(1))
-				Expect(tempDirCallCount).To(Equal(1))
+				Expect(jumpboxInterpolateOutput.Manifest).To(ContainSubstring("name: bosh-aws-cpi"))
+				Expect(jumpboxInterpolateOutput.Manifest).To(ContainSubstring("- some-jumpbox-security-group"))
+				Expect(jumpboxInterpolateOutput.Manifest).To(ContainSubstring("name: public"))
+				Expect(jumpboxInterpolateOutput.Manifest).NotTo(ContainSubstring("(("))
 
-				expectedArgs := append([]string{
-					"interpolate", fmt.Sprintf("%s/jumpbox.yml", tempDir),
-					"--var-errs",
-					"--vars-store", fmt.Sprintf("%s/variables.yml", tempDir),
-					"--vars-file", fmt.Sprintf("%s/jumpbox-deployment-vars.yml", tempDir),
-					"-o", fmt.Sprintf("%s/cpi.yml", tempDir),
-				})
+				jumpboxVariables := map[string]interface{}{}
+				Expect(yaml.Unmarshal([]byte(jumpboxInterpolateOutput.Variables), &jumpboxVariables)).To(Succeed())
+				Expect(jumpboxVariables).To(HaveKey("jumpbox_ssh"))
+				Expect(jumpboxVariables).To(HaveKey("mbus_bootstrap_password"))
 
-				_, _, args := cmd.RunArgsForCall(0)
-				Expect(args).To(Equal(expectedArgs))
+				interpolateOutput, err := executor.DirectorInterpolate(awsInterpolateInput)
+				Expect(err).NotTo(HaveOccurred())
 
-				Expect(jumpboxInterpolateOutput.Manifest).To(Equal("some-manifest"))
-				Expect(jumpboxInterpolateOutput.Variables).To(gomegamatchers.MatchYAML("key: value"))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("auto_assign_public_ip: true"))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("iam_instance_profile: some-iam-instance-profile"))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("kms_key_arn: some-kms-key-arn"))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("name: uaa"))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("name: credhub"))
+				Expect(interpolateOutput.Manifest).NotTo(ContainSubstring("(("))
+
+				variables := map[string]interface{}{}
+				Expect(yaml.Unmarshal([]byte(interpolateOutput.Variables), &variables)).To(Succeed())
+				Expect(variables).To(HaveKey("admin_password"))
+				Expect(variables).To(HaveKey("director_ssl"))
+				Expect(variables).To(HaveKey("uaa_jwt_signing_key"))
+				Expect(variables).To(HaveKey("credhub_tls"))
+
+				Expect(cmd.RunCallCount()).To(Equal(0))
+			})
 
+			It("reuses the variables in the vars store", func() {
 				interpolateOutput, err := executor.DirectorInterpolate(awsInterpolateInput)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(cmd.RunCallCount()).To(Equal(2))
-				Expect(tempDirCallCount).To(Equal(2))
-
-				expectedArgs = append([]string{
-					"interpolate", fmt.Sprintf("%s/bosh.yml", tempDir),
-					"--var-errs",
-					"--var-errs-unused",
-					"--vars-store", fmt.Sprintf("%s/variables.yml", tempDir),
-					"--vars-file", fmt.Sprintf("%s/deployment-vars.yml", tempDir),
-					"-o", fmt.Sprintf("%s/cpi.yml", tempDir),
-					"-o", fmt.Sprintf("%s/jumpbox-user.yml", tempDir),
-					"-o", fmt.Sprintf("%s/uaa.yml", tempDir),
-					"-o", fmt.Sprintf("%s/credhub.yml", tempDir),
-					"-o", fmt.Sprintf("%s/aws-bosh-director-ephemeral-ip-ops.yml", tempDir),
-					"-o", fmt.Sprintf("%s/iam-instance-profile.yml", tempDir),
-					"-o", fmt.Sprintf("%s/aws-bosh-director-encrypt-disk-ops.yml", tempDir),
+				awsInterpolateInput.Variables = interpolateOutput.Variables
+
+				reinterpolateOutput, err := executor.DirectorInterpolate(awsInterpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(reinterpolateOutput.Variables).To(Equal(interpolateOutput.Variables))
+				Expect(reinterpolateOutput.Manifest).To(Equal(interpolateOutput.Manifest))
+			})
+
+			Context("when the network is private", func() {
+				BeforeEach(func() {
+					awsInterpolateInput.Private = true
+				})
+
+				It("removes the public network from the jumpbox and does not give the director a public ip", func() {
+					jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(awsInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(jumpboxInterpolateOutput.Manifest).NotTo(ContainSubstring("name: public"))
+
+					interpolateOutput, err := executor.DirectorInterpolate(awsInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(interpolateOutput.Manifest).NotTo(ContainSubstring("auto_assign_public_ip"))
+				})
+			})
+
+			Context("when there are jumpbox ops files", func() {
+				BeforeEach(func() {
+					awsInterpolateInput.OpsFiles = []storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: `
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/instance_type
+  value: m4.large
+`},
+						{Name: "some-other-ops-file.yml", Contents: `
+- type: replace
+  path: /resource_pools/name=vms/cloud_properties/instance_type
+  value: m4.xlarge
+`},
+					}
+				})
+
+				It("applies them in order after the bbl ops files", func() {
+					jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(awsInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(jumpboxInterpolateOutput.Manifest).To(ContainSubstring("instance_type: m4.xlarge"))
+				})
+			})
+
+			Context("when deployment dirs are provided", func() {
+				var (
+					boshDeploymentDir    string
+					jumpboxDeploymentDir string
+				)
+
+				BeforeEach(func() {
+					var err error
+					boshDeploymentDir, err = ioutil.TempDir("", "bosh-deployment")
+					Expect(err).NotTo(HaveOccurred())
+
+					jumpboxDeploymentDir, err = ioutil.TempDir("", "jumpbox-deployment")
+					Expect(err).NotTo(HaveOccurred())
+
+					for _, path := range []string{"aws/iam-instance-profile.yml", "jumpbox-user.yml", "external-ip-not-recommended.yml",
+						"external-ip-with-registry-not-recommended.yml", "uaa.yml", "credhub.yml"} {
+						writeDeploymentFile(boshDeploymentDir, path, "[]")
+					}
+					writeDeploymentFile(boshDeploymentDir, "bosh.yml", "name: ((director_name))\ndisk_pools: [{name: disks}]\nresource_pools: [{name: vms, cloud_properties: {}}]\n")
+					writeDeploymentFile(boshDeploymentDir, "aws/cpi.yml", "- type: replace\n  path: /cpi?\n  value: some-local-aws-cpi\n")
+					writeDeploymentFile(boshDeploymentDir, ".git/HEAD", "ref: refs/heads/master\n")
+					writeDeploymentFile(boshDeploymentDir, ".git/refs/heads/master", "some-bosh-deployment-sha\n")
+
+					writeDeploymentFile(jumpboxDeploymentDir, "jumpbox.yml", "name: jumpbox\n")
+					writeDeploymentFile(jumpboxDeploymentDir, "aws/cpi.yml", "- type: replace\n  path: /cpi?\n  value: some-local-jumpbox-aws-cpi\n")
+					writeDeploymentFile(jumpboxDeploymentDir, ".git/HEAD", "ref: refs/heads/master\n")
+					writeDeploymentFile(jumpboxDeploymentDir, ".git/packed-refs", "# pack-refs with: peeled fully-peeled\nsome-jumpbox-deployment-sha refs/heads/master\n")
+
+					awsInterpolateInput.DirectorDeploymentVars = "director_name: bosh-some-env\nkms_key_arn: some-kms-key-arn\n"
+					awsInterpolateInput.DeploymentDirs = bosh.DeploymentDirs{
+						BOSH:    boshDeploymentDir,
+						Jumpbox: jumpboxDeploymentDir,
+					}
 				})
 
-				_, _, args = cmd.RunArgsForCall(1)
-				Expect(args).To(Equal(expectedArgs))
+				It("reads the manifests from the deployment dirs and records their git sha", func() {
+					jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(awsInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
 
-				Expect(interpolateOutput.Manifest).To(Equal("some-manifest"))
-				Expect(jumpboxInterpolateOutput.Variables).To(gomegamatchers.MatchYAML("key: value"))
+					Expect(jumpboxInterpolateOutput.Manifest).To(gomegamatchers.MatchYAML("name: jumpbox\ncpi: some-local-jumpbox-aws-cpi\n"))
+					Expect(jumpboxInterpolateOutput.DeploymentSource).To(Equal(storage.DeploymentSource{
+						Source: jumpboxDeploymentDir,
+						SHA:    "some-jumpbox-deployment-sha",
+					}))
+
+					interpolateOutput, err := executor.DirectorInterpolate(awsInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(interpolateOutput.Manifest).To(ContainSubstring("name: bosh-some-env"))
+					Expect(interpolateOutput.Manifest).To(ContainSubstring("cpi: some-local-aws-cpi"))
+					Expect(interpolateOutput.DeploymentSource).To(Equal(storage.DeploymentSource{
+						Source: boshDeploymentDir,
+						SHA:    "some-bosh-deployment-sha",
+					}))
+				})
+
+				It("returns an error when a manifest is missing from a deployment dir", func() {
+					err := os.Remove(filepath.Join(boshDeploymentDir, "uaa.yml"))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = executor.DirectorInterpolate(awsInterpolateInput)
+					Expect(err).To(MatchError(fmt.Sprintf("read bosh-deployment: open %s/uaa.yml: no such file or directory", boshDeploymentDir)))
+				})
+			})
+
+			It("records the vendored deployments as the source", func() {
+				jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(awsInterpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(jumpboxInterpolateOutput.DeploymentSource).To(Equal(storage.DeploymentSource{
+					Source: "vendored",
+					SHA:    bosh.VendoredJumpboxDeploymentSHA,
+				}))
+
+				interpolateOutput, err := executor.DirectorInterpolate(awsInterpolateInput)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(interpolateOutput.DeploymentSource).To(Equal(storage.DeploymentSource{
+					Source: "vendored",
+					SHA:    bosh.VendoredBOSHDeploymentSHA,
+				}))
 			})
 		})
 
@@ -179,195 +338,163 @@ var _ = Describe("Executor", func() {
 			var gcpInterpolateInput bosh.InterpolateInput
 
 			BeforeEach(func() {
-				gcpInterpolateInput = interpolateInput
-				gcpInterpolateInput.IAAS = "gcp"
-
-				cmd.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
-					stdout.Write([]byte("some-manifest"))
-					return nil
+				gcpInterpolateInput = bosh.InterpolateInput{
+					IAAS: "gcp",
+					JumpboxDeploymentVars: `
+internal_cidr: 10.0.0.0/24
+internal_gw: 10.0.0.1
+internal_ip: 10.0.0.5
+director_name: bosh-some-env
+external_ip: 1.2.3.4
+zone: some-zone
+network: some-network
+subnetwork: some-subnetwork
+tags: [some-bosh-open-tag, some-jumpbox-tag]
+project_id: some-project-id
+gcp_credentials_json: some-credential-json
+`,
+					DirectorDeploymentVars: `
+internal_cidr: 10.0.0.0/24
+internal_gw: 10.0.0.1
+internal_ip: 10.0.0.6
+director_name: bosh-some-env
+zone: some-zone
+network: some-network
+subnetwork: some-subnetwork
+tags: [some-bosh-director-tag]
+project_id: some-project-id
+gcp_credentials_json: some-credential-json
+`,
 				}
 			})
 
 			It("interpolates the jumpbox and bosh manifests", func() {
-				gcpInterpolateInput.JumpboxDeploymentVars = "internal_cidr: 10.0.0.0/24"
-				gcpInterpolateInput.OpsFile = ""
-
 				jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(gcpInterpolateInput)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(cmd.RunCallCount()).To(Equal(1))
-				Expect(tempDirCallCount).To(Equal(1))
-
-				expectedArgs := append([]string{
-					"interpolate", fmt.Sprintf("%s/jumpbox.yml", tempDir),
-					"--var-errs",
-					"--vars-store", fmt.Sprintf("%s/variables.yml", tempDir),
-					"--vars-file", fmt.Sprintf("%s/jumpbox-deployment-vars.yml", tempDir),
-					"-o", fmt.Sprintf("%s/cpi.yml", tempDir),
-				})
-
-				_, _, args := cmd.RunArgsForCall(0)
-				Expect(args).To(Equal(expectedArgs))
-
-				Expect(jumpboxInterpolateOutput.Manifest).To(Equal("some-manifest"))
-				Expect(jumpboxInterpolateOutput.Variables).To(gomegamatchers.MatchYAML("key: value"))
+				Expect(jumpboxInterpolateOutput.Manifest).To(ContainSubstring("name: bosh-google-cpi"))
+				Expect(jumpboxInterpolateOutput.Manifest).To(ContainSubstring("- some-jumpbox-tag"))
+				Expect(jumpboxInterpolateOutput.Manifest).NotTo(ContainSubstring("(("))
 
 				interpolateOutput, err := executor.DirectorInterpolate(gcpInterpolateInput)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(cmd.RunCallCount()).To(Equal(2))
-				Expect(tempDirCallCount).To(Equal(2))
-
-				expectedArgs = append([]string{
-					"interpolate", fmt.Sprintf("%s/bosh.yml", tempDir),
-					"--var-errs",
-					"--var-errs-unused",
-					"--vars-store", fmt.Sprintf("%s/variables.yml", tempDir),
-					"--vars-file", fmt.Sprintf("%s/deployment-vars.yml", tempDir),
-					"-o", fmt.Sprintf("%s/cpi.yml", tempDir),
-					"-o", fmt.Sprintf("%s/jumpbox-user.yml", tempDir),
-					"-o", fmt.Sprintf("%s/uaa.yml", tempDir),
-					"-o", fmt.Sprintf("%s/credhub.yml", tempDir),
-					"-o", fmt.Sprintf("%s/gcp-bosh-director-ephemeral-ip-ops.yml", tempDir),
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("ephemeral_external_ip: true"))
+				Expect(interpolateOutput.Manifest).To(ContainSubstring("- some-bosh-director-tag"))
+				Expect(interpolateOutput.Manifest).NotTo(ContainSubstring("(("))
+			})
+
+			Context("when tags are provided", func() {
+				BeforeEach(func() {
+					gcpInterpolateInput.Tags = map[string]string{"team": "some-team"}
 				})
 
-				_, _, args = cmd.RunArgsForCall(1)
-				Expect(args).To(Equal(expectedArgs))
+				It("adds the tags to the jumpbox and bosh manifests", func() {
+					jumpboxInterpolateOutput, err := executor.JumpboxInterpolate(gcpInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(jumpboxInterpolateOutput.Manifest).To(ContainSubstring("tags:\n  team: some-team\n"))
 
-				Expect(interpolateOutput.Manifest).To(Equal("some-manifest"))
-				Expect(jumpboxInterpolateOutput.Variables).To(gomegamatchers.MatchYAML("key: value"))
+					interpolateOutput, err := executor.DirectorInterpolate(gcpInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(interpolateOutput.Manifest).To(ContainSubstring("tags:\n  team: some-team\n"))
+				})
 			})
 
 			Context("when a user opsfile is provided", func() {
-				It("re-interpolates the bosh manifest", func() {
-					opsfile := `
+				It("applies it to the interpolated bosh manifest", func() {
+					gcpInterpolateInput.OpsFiles = []storage.OpsFile{
+						{Name: "isolation.yml", Contents: `
 ---
 - type: replace
-path: /networks/name=default/subnets/0/cloud_properties/tags/-
-value: sabeti-bosh-isolation
-`
-					gcpInterpolateInput.OpsFile = opsfile
-
-					manifest := `
----
-networks
-- name: default
-  cloud_properties:
-    tags:
-      - some-tag
-`
-					manifestWithUserOpsFile := `
----
-networks
-- name: default
-  cloud_properties:
-    tags:
-      - some-tag
-      - sabeti-bosh-isolation
-`
-
-					writtenManifest := []byte{}
-					cmd.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
-						for _, arg := range args {
-							if arg == fmt.Sprintf("%s/user-ops-file.yml", tempDir) {
-								var err error
-								writtenManifest, err = ioutil.ReadFile(fmt.Sprintf("%s/bosh.yml", tempDir))
-								if err != nil {
-									return err
-								}
-								stdout.Write([]byte(manifestWithUserOpsFile))
-								return nil
-							}
-						}
-						stdout.Write([]byte(manifest))
-						return nil
+  path: /networks/name=default/subnets/0/cloud_properties/tags/-
+  value: sabeti-bosh-isolation
+`},
 					}
 
 					interpolateOutput, err := executor.DirectorInterpolate(gcpInterpolateInput)
 					Expect(err).NotTo(HaveOccurred())
 
-					Expect(cmd.RunCallCount()).To(Equal(2))
-					Expect(tempDirCallCount).To(Equal(1))
-
-					expectedArgs := append([]string{
-						"interpolate", fmt.Sprintf("%s/bosh.yml", tempDir),
-						"--var-errs",
-						"--var-errs-unused",
-						"--vars-store", fmt.Sprintf("%s/variables.yml", tempDir),
-						"--vars-file", fmt.Sprintf("%s/deployment-vars.yml", tempDir),
-						"-o", fmt.Sprintf("%s/cpi.yml", tempDir),
-						"-o", fmt.Sprintf("%s/jumpbox-user.yml", tempDir),
-						"-o", fmt.Sprintf("%s/uaa.yml", tempDir),
-						"-o", fmt.Sprintf("%s/credhub.yml", tempDir),
-						"-o", fmt.Sprintf("%s/gcp-bosh-director-ephemeral-ip-ops.yml", tempDir),
-					})
+					Expect(interpolateOutput.Manifest).To(ContainSubstring("- some-bosh-director-tag"))
+					Expect(interpolateOutput.Manifest).To(ContainSubstring("- sabeti-bosh-isolation"))
+				})
 
-					_, _, args := cmd.RunArgsForCall(0)
-					Expect(args).To(Equal(expectedArgs))
+				It("generates the variables it adds", func() {
+					gcpInterpolateInput.OpsFiles = []storage.OpsFile{
+						{Name: "bbr.yml", Contents: `
+- type: replace
+  path: /variables/-
+  value:
+    name: some-bbr-password
+    type: password
+- type: replace
+  path: /bbr_password?
+  value: ((some-bbr-password))
+`},
+					}
 
-					expectedArgsWithUserOpsfile := append([]string{
-						"interpolate", fmt.Sprintf("%s/bosh.yml", tempDir),
-						"--var-errs",
-						"--vars-store", fmt.Sprintf("%s/variables.yml", tempDir),
-						"--vars-file", fmt.Sprintf("%s/deployment-vars.yml", tempDir),
-						"-o", fmt.Sprintf("%s/user-ops-file.yml", tempDir),
-					})
+					interpolateOutput, err := executor.DirectorInterpolate(gcpInterpolateInput)
+					Expect(err).NotTo(HaveOccurred())
 
-					_, _, args = cmd.RunArgsForCall(1)
-					Expect(args).To(Equal(expectedArgsWithUserOpsfile))
+					variables := map[string]interface{}{}
+					Expect(yaml.Unmarshal([]byte(interpolateOutput.Variables), &variables)).To(Succeed())
+					Expect(variables).To(HaveKey("some-bbr-password"))
+					Expect(interpolateOutput.Manifest).To(ContainSubstring(fmt.Sprintf("bbr_password: %s", variables["some-bbr-password"])))
+				})
+			})
 
-					opsFileContents, err := ioutil.ReadFile(fmt.Sprintf("%s/user-ops-file.yml", tempDir))
+			Context("when several user opsfiles are provided", func() {
+				It("applies them in order", func() {
+					gcpInterpolateInput.OpsFiles = []storage.OpsFile{
+						{Name: "syslog.yml", Contents: "- type: replace\n  path: /some-key?\n  value: some-syslog-value\n"},
+						{Name: "bbr.yml", Contents: "- type: replace\n  path: /some-key\n  value: some-bbr-value\n"},
+					}
+
+					interpolateOutput, err := executor.DirectorInterpolate(gcpInterpolateInput)
 					Expect(err).NotTo(HaveOccurred())
-					Expect(string(opsFileContents)).To(Equal(opsfile))
-					Expect(string(writtenManifest)).To(Equal(manifest))
 
-					Expect(interpolateOutput.Manifest).To(Equal(manifestWithUserOpsFile))
-					Expect(interpolateOutput.Variables).To(Equal("key: value"))
+					Expect(interpolateOutput.Manifest).To(ContainSubstring("some-key: some-bbr-value"))
 				})
 			})
 		})
 
 		Describe("failure cases", func() {
-			Context("when trying to run a command fails", func() {
-				BeforeEach(func() {
-					cmd.RunReturnsOnCall(0, errors.New("failed to run command"))
-				})
+			var awsInterpolateInput bosh.InterpolateInput
 
-				It("returns an error", func() {
-					executor = bosh.NewExecutor(cmd, tempDirFunc, ioutil.ReadFile, json.Unmarshal, json.Marshal, ioutil.WriteFile)
-					_, err := executor.DirectorInterpolate(bosh.InterpolateInput{
-						IAAS: "aws",
-					})
-					Expect(err).To(MatchError("failed to run command"))
-				})
+			BeforeEach(func() {
+				awsInterpolateInput = bosh.InterpolateInput{
+					IAAS:                   "aws",
+					DirectorDeploymentVars: "internal_cidr: 10.0.0.0/24",
+					JumpboxDeploymentVars:  "internal_cidr: 10.0.0.0/24",
+				}
 			})
 
-			Context("when trying to run the command to interpolate with the user opsfile fails", func() {
-				BeforeEach(func() {
-					cmd.RunReturnsOnCall(1, errors.New("failed to run command"))
+			Context("when deployment vars are missing", func() {
+				It("returns an error", func() {
+					_, err := executor.DirectorInterpolate(awsInterpolateInput)
+					Expect(err).To(MatchError(ContainSubstring("interpolate director manifest: Expected to find variables:\n    - access_key_id\n")))
+
+					_, err = executor.JumpboxInterpolate(awsInterpolateInput)
+					Expect(err).To(MatchError(ContainSubstring("interpolate jumpbox manifest: Expected to find variables:\n    - access_key_id\n")))
 				})
+			})
 
+			Context("when deployment vars are unused", func() {
 				It("returns an error", func() {
-					executor = bosh.NewExecutor(cmd, tempDirFunc, ioutil.ReadFile, json.Unmarshal, json.Marshal, ioutil.WriteFile)
-					_, err := executor.DirectorInterpolate(bosh.InterpolateInput{
-						IAAS:    "aws",
-						OpsFile: "some-ops-file",
-					})
-					Expect(err).To(MatchError("failed to run command"))
+					awsInterpolateInput.DirectorDeploymentVars = "some-unused-var: some-value"
+
+					_, err := executor.DirectorInterpolate(awsInterpolateInput)
+					Expect(err).To(MatchError(ContainSubstring("Expected to use variables:\n    - some-unused-var")))
 				})
 			})
 
-			Context("when the variables file fails to be read", func() {
+			Context("when a user opsfile cannot be applied", func() {
 				It("returns an error", func() {
-					readFileFunc := func(path string) ([]byte, error) {
-						return []byte{}, errors.New("failed to read variables file")
+					awsInterpolateInput.OpsFiles = []storage.OpsFile{
+						{Name: "some-ops-file", Contents: "- type: remove\n  path: /some-missing-key\n"},
 					}
 
-					executor = bosh.NewExecutor(cmd, tempDirFunc, readFileFunc, json.Unmarshal, json.Marshal, ioutil.WriteFile)
-					_, err := executor.DirectorInterpolate(bosh.InterpolateInput{
-						IAAS: "aws",
-					})
-					Expect(err).To(MatchError("failed to read variables file"))
+					_, err := executor.JumpboxInterpolate(awsInterpolateInput)
+					Expect(err).To(MatchError("interpolate jumpbox manifest: Operation [0] (remove /some-missing-key): Expected to find a map key 'some-missing-key' for path '/some-missing-key'"))
 				})
 			})
 		})
@@ -816,3 +943,11 @@ networks
 		})
 	})
 })
+
+func writeDeploymentFile(dir, path, contents string) {
+	err := os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), os.ModePerm)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = ioutil.WriteFile(filepath.Join(dir, path), []byte(contents), os.ModePerm)
+	Expect(err).NotTo(HaveOccurred())
+}
@@ -1,7 +1,9 @@
 package bosh
 
 import (
+	"net"
 	"os"
+	"time"
 
 	"golang.org/x/net/proxy"
 )
@@ -29,3 +31,15 @@ func SetProxySOCKS5(f func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dia
 func ResetProxySOCKS5() {
 	proxySOCKS5 = proxy.SOCKS5
 }
+
+func SetNetDialTimeout(f func(string, string, time.Duration) (net.Conn, error)) {
+	netDialTimeout = f
+}
+
+func ResetNetDialTimeout() {
+	netDialTimeout = net.DialTimeout
+}
+
+func ManifestHash(manifest, variables string) string {
+	return manifestHash(manifest, variables)
+}
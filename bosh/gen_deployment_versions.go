@@ -0,0 +1,20 @@
+//go:build ignore
+// +build ignore
+
+// gen_deployment_versions writes deployment-versions.txt from the SHAs of the
+// vendored deployments.
+package main
+
+import (
+	"io/ioutil"
+	"log"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+)
+
+func main() {
+	err := ioutil.WriteFile("../deployment-versions.txt", []byte(bosh.DeploymentVersions()), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,28 +1,33 @@
 package bosh
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"net"
 	"os"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"golang.org/x/net/proxy"
 )
 
 var (
-	osSetenv   = os.Setenv
-	osUnsetenv = os.Unsetenv
+	osSetenv       = os.Setenv
+	osUnsetenv     = os.Unsetenv
+	netDialTimeout = net.DialTimeout
 )
 
 const (
-	DIRECTOR_USERNAME    = "admin"
-	DIRECTOR_INTERNAL_IP = "10.0.0.6"
+	DIRECTOR_USERNAME = "admin"
 )
 
 type Manager struct {
-	executor    executor
-	logger      logger
-	socks5Proxy socks5Proxy
+	executor       executor
+	logger         logger
+	socks5Proxy    socks5Proxy
+	deploymentDirs DeploymentDirs
 }
 
 type directorVars struct {
@@ -76,12 +81,13 @@ type AWSYAML struct {
 }
 
 type GCPYAML struct {
-	Zone           string   `yaml:"zone,omitempty"`
-	Network        string   `yaml:"network,omitempty"`
-	Subnetwork     string   `yaml:"subnetwork,omitempty"`
-	Tags           []string `yaml:"tags,omitempty"`
-	ProjectID      string   `yaml:"project_id,omitempty"`
-	CredentialJSON string   `yaml:"gcp_credentials_json,omitempty"`
+	Zone             string   `yaml:"zone,omitempty"`
+	Network          string   `yaml:"network,omitempty"`
+	Subnetwork       string   `yaml:"subnetwork,omitempty"`
+	Tags             []string `yaml:"tags,omitempty"`
+	ProjectID        string   `yaml:"project_id,omitempty"`
+	XPNHostProjectID string   `yaml:"xpn_host_project_id,omitempty"`
+	CredentialJSON   string   `yaml:"gcp_credentials_json,omitempty"`
 }
 
 type AzureYAML struct {
@@ -114,11 +120,12 @@ type socks5Proxy interface {
 	Addr() string
 }
 
-func NewManager(executor executor, logger logger, socks5Proxy socks5Proxy) *Manager {
+func NewManager(executor executor, logger logger, socks5Proxy socks5Proxy, deploymentDirs DeploymentDirs) *Manager {
 	return &Manager{
-		executor:    executor,
-		logger:      logger,
-		socks5Proxy: socks5Proxy,
+		executor:       executor,
+		logger:         logger,
+		socks5Proxy:    socks5Proxy,
+		deploymentDirs: deploymentDirs,
 	}
 }
 
@@ -134,11 +141,26 @@ func (m *Manager) Version() (string, error) {
 func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs map[string]interface{}) (storage.State, error) {
 	m.logger.Step("creating jumpbox")
 
+	jumpboxVars, err := m.GetJumpboxDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return storage.State{}, err
+	}
+
+	directorVars, err := m.GetDirectorDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return storage.State{}, err
+	}
+
 	iaasInputs := InterpolateInput{
 		IAAS: state.IAAS,
-		JumpboxDeploymentVars:  m.GetJumpboxDeploymentVars(state, terraformOutputs),
-		DirectorDeploymentVars: m.GetDirectorDeploymentVars(state, terraformOutputs),
+		JumpboxDeploymentVars:  jumpboxVars,
+		DirectorDeploymentVars: directorVars,
 		Variables:              state.Jumpbox.Variables,
+		OpsFiles:               state.Jumpbox.UserOpsFiles,
+		Tags:                   state.Tags,
+		Private:                state.Network.Private,
+		SharedVPC:              state.GCP.NetworkProject != "",
+		DeploymentDirs:         m.deploymentDirs,
 	}
 
 	interpolateOutputs, err := m.executor.JumpboxInterpolate(iaasInputs)
@@ -146,44 +168,59 @@ func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs map[string
 		return storage.State{}, fmt.Errorf("jumpbox interpolate: %s", err)
 	}
 
-	variables, err := yaml.Marshal(interpolateOutputs.Variables)
+	jumpboxPrivateKey, err := getJumpboxPrivateKey(interpolateOutputs.Variables)
 	if err != nil {
-		return storage.State{}, fmt.Errorf("marshal yaml: %s", err)
+		return storage.State{}, fmt.Errorf("jumpbox key: %s", err)
 	}
 
-	osUnsetenv("BOSH_ALL_PROXY")
-	createEnvOutputs, err := m.executor.CreateEnv(CreateEnvInput{
-		Manifest:  interpolateOutputs.Manifest,
-		State:     state.Jumpbox.State,
-		Variables: string(variables),
-	})
-	switch err.(type) {
-	case CreateEnvError:
-		ceErr := err.(CreateEnvError)
-		state.Jumpbox = storage.Jumpbox{
-			Variables: interpolateOutputs.Variables,
-			State:     ceErr.BOSHState(),
+	jumpboxURL := terraformOutputs["jumpbox_url"].(string)
+	manifestHash := manifestHash(interpolateOutputs.Manifest, interpolateOutputs.Variables)
+	jumpboxState := state.Jumpbox.State
+
+	if manifestHash == state.Jumpbox.ManifestHash && jumpboxReachable(jumpboxURL) {
+		m.logger.Step("jumpbox is unchanged, skipping create-env")
+	} else {
+		variables, err := yaml.Marshal(interpolateOutputs.Variables)
+		if err != nil {
+			return storage.State{}, fmt.Errorf("marshal yaml: %s", err)
+		}
+
+		osUnsetenv("BOSH_ALL_PROXY")
+		createEnvOutputs, err := m.executor.CreateEnv(CreateEnvInput{
 			Manifest:  interpolateOutputs.Manifest,
+			State:     state.Jumpbox.State,
+			Variables: string(variables),
+		})
+		switch err.(type) {
+		case CreateEnvError:
+			ceErr := err.(CreateEnvError)
+			state.Jumpbox = storage.Jumpbox{
+				Variables:        interpolateOutputs.Variables,
+				State:            ceErr.BOSHState(),
+				Manifest:         interpolateOutputs.Manifest,
+				UserOpsFiles:     state.Jumpbox.UserOpsFiles,
+				DeploymentSource: interpolateOutputs.DeploymentSource,
+			}
+			return storage.State{}, fmt.Errorf("create env error: %s", NewManagerCreateError(state, err))
+		case error:
+			return storage.State{}, fmt.Errorf("create env: %s", err)
 		}
-		return storage.State{}, fmt.Errorf("create env error: %s", NewManagerCreateError(state, err))
-	case error:
-		return storage.State{}, fmt.Errorf("create env: %s", err)
+		m.logger.Step("created jumpbox")
+
+		jumpboxState = createEnvOutputs.State
 	}
-	m.logger.Step("created jumpbox")
 
 	state.Jumpbox = storage.Jumpbox{
-		Variables: interpolateOutputs.Variables,
-		State:     createEnvOutputs.State,
-		Manifest:  interpolateOutputs.Manifest,
-		URL:       terraformOutputs["jumpbox_url"].(string),
+		Variables:        interpolateOutputs.Variables,
+		State:            jumpboxState,
+		Manifest:         interpolateOutputs.Manifest,
+		ManifestHash:     manifestHash,
+		URL:              jumpboxURL,
+		UserOpsFiles:     state.Jumpbox.UserOpsFiles,
+		DeploymentSource: interpolateOutputs.DeploymentSource,
 	}
 
 	m.logger.Step("starting socks5 proxy to jumpbox")
-	jumpboxPrivateKey, err := getJumpboxPrivateKey(interpolateOutputs.Variables)
-	if err != nil {
-		return storage.State{}, fmt.Errorf("jumpbox key: %s", err)
-	}
-
 	err = m.socks5Proxy.Start(jumpboxPrivateKey, state.Jumpbox.URL)
 	if err != nil {
 		return storage.State{}, fmt.Errorf("start proxy: %s", err)
@@ -198,67 +235,216 @@ func (m *Manager) CreateJumpbox(state storage.State, terraformOutputs map[string
 func (m *Manager) CreateDirector(state storage.State, terraformOutputs map[string]interface{}) (storage.State, error) {
 	m.logger.Step("creating bosh director")
 
-	iaasInputs := InterpolateInput{
-		IAAS: state.IAAS,
-		DirectorDeploymentVars: m.GetDirectorDeploymentVars(state, terraformOutputs),
-		JumpboxDeploymentVars:  m.GetJumpboxDeploymentVars(state, terraformOutputs),
-		Variables:              state.BOSH.Variables,
-		OpsFile:                state.BOSH.UserOpsFile,
+	networkPlan, err := newNetworkPlan(state)
+	if err != nil {
+		return storage.State{}, err
 	}
 
-	interpolateOutputs, err := m.executor.DirectorInterpolate(iaasInputs)
+	interpolateOutputs, err := m.interpolateDirector(state, terraformOutputs)
 	if err != nil {
 		return storage.State{}, err
 	}
 
+	manifestHash := manifestHash(interpolateOutputs.Manifest, interpolateOutputs.Variables)
+	boshState := state.BOSH.State
+
+	if manifestHash == state.BOSH.ManifestHash && m.directorReachable(networkPlan) {
+		m.logger.Step("bosh director is unchanged, skipping create-env")
+	} else {
+		createEnvOutputs, err := m.executor.CreateEnv(CreateEnvInput{
+			Manifest:  interpolateOutputs.Manifest,
+			State:     state.BOSH.State,
+			Variables: interpolateOutputs.Variables,
+		})
+
+		switch err.(type) {
+		case CreateEnvError:
+			ceErr := err.(CreateEnvError)
+			state.BOSH.Variables = interpolateOutputs.Variables
+			state.BOSH.State = ceErr.BOSHState()
+			state.BOSH.Manifest = interpolateOutputs.Manifest
+			state.BOSH.ManifestHash = ""
+			return storage.State{}, NewManagerCreateError(state, err)
+		case error:
+			return storage.State{}, err
+		}
+
+		boshState = createEnvOutputs.State
+	}
+
+	directorVars, err := getDirectorVars(interpolateOutputs.Variables)
+	if err != nil {
+		return storage.State{}, fmt.Errorf("failed to get director outputs:\n%s", err.Error())
+	}
+
+	// Only the fields that come from create-env are set, settings such as the
+	// cloud config and runtime config are kept as they are.
+	state.BOSH.DirectorName = fmt.Sprintf("bosh-%s", state.EnvID)
+	state.BOSH.DirectorAddress = fmt.Sprintf("https://%s:25555", networkPlan.DirectorIP())
+	state.BOSH.DirectorUsername = DIRECTOR_USERNAME
+	state.BOSH.DirectorPassword = directorVars.directorPassword
+	state.BOSH.DirectorSSLCA = directorVars.directorSSLCA
+	state.BOSH.DirectorSSLCertificate = directorVars.directorSSLCertificate
+	state.BOSH.DirectorSSLPrivateKey = directorVars.directorSSLPrivateKey
+	state.BOSH.Variables = interpolateOutputs.Variables
+	state.BOSH.State = boshState
+	state.BOSH.Manifest = interpolateOutputs.Manifest
+	state.BOSH.ManifestHash = manifestHash
+	state.BOSH.DeploymentSource = interpolateOutputs.DeploymentSource
+
+	m.logger.Step("created bosh director")
+	return state, nil
+}
+
+// InterpolateDirector returns the manifest the director would be created
+// from, with the releases and stemcell pinned by the versions file.
+func (m *Manager) InterpolateDirector(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	interpolateOutputs, err := m.interpolateDirector(state, terraformOutputs)
+	if err != nil {
+		return "", err
+	}
+
+	return interpolateOutputs.Manifest, nil
+}
+
+// UpgradeDirector runs create-env for the director outside of bbl up, so the
+// proxy to the jumpbox is started here. The manifest being replaced is kept
+// in the state for a rollback.
+func (m *Manager) UpgradeDirector(state storage.State, terraformOutputs map[string]interface{}) (storage.State, error) {
+	err := m.startProxy(state)
+	if err != nil {
+		return storage.State{}, err
+	}
+
+	previousManifest := state.BOSH.Manifest
+	state, err = m.CreateDirector(state, terraformOutputs)
+	switch err.(type) {
+	case ManagerCreateError:
+		failedState := err.(ManagerCreateError).State()
+		failedState.BOSH.PreviousManifest = previousManifest
+		return storage.State{}, NewManagerCreateError(failedState, err)
+	case error:
+		return storage.State{}, err
+	}
+
+	state.BOSH.PreviousManifest = previousManifest
+	return state, nil
+}
+
+// RollbackDirector runs create-env with the manifest the director was
+// created from before its last upgrade, as it was, rather than interpolating
+// a new one. There is nothing left to roll back to afterwards.
+func (m *Manager) RollbackDirector(state storage.State) (storage.State, error) {
+	err := m.startProxy(state)
+	if err != nil {
+		return storage.State{}, err
+	}
+
+	m.logger.Step("rolling back bosh director")
+
+	manifest := state.BOSH.PreviousManifest
 	createEnvOutputs, err := m.executor.CreateEnv(CreateEnvInput{
-		Manifest:  interpolateOutputs.Manifest,
+		Manifest:  manifest,
 		State:     state.BOSH.State,
-		Variables: interpolateOutputs.Variables,
+		Variables: state.BOSH.Variables,
 	})
-
 	switch err.(type) {
 	case CreateEnvError:
 		ceErr := err.(CreateEnvError)
-		state.BOSH = storage.BOSH{
-			Variables: interpolateOutputs.Variables,
-			State:     ceErr.BOSHState(),
-			Manifest:  interpolateOutputs.Manifest,
-		}
+		state.BOSH.State = ceErr.BOSHState()
+		state.BOSH.ManifestHash = ""
 		return storage.State{}, NewManagerCreateError(state, err)
 	case error:
 		return storage.State{}, err
 	}
 
-	directorVars, err := getDirectorVars(interpolateOutputs.Variables)
+	state.BOSH.State = createEnvOutputs.State
+	state.BOSH.Manifest = manifest
+	state.BOSH.ManifestHash = manifestHash(manifest, state.BOSH.Variables)
+	state.BOSH.PreviousManifest = ""
+
+	m.logger.Step("rolled back bosh director")
+	return state, nil
+}
+
+func (m *Manager) startProxy(state storage.State) error {
+	jumpboxPrivateKey, err := getJumpboxPrivateKey(state.Jumpbox.Variables)
 	if err != nil {
-		return storage.State{}, fmt.Errorf("failed to get director outputs:\n%s", err.Error())
+		return err
 	}
 
-	state.BOSH = storage.BOSH{
-		DirectorName:           fmt.Sprintf("bosh-%s", state.EnvID),
-		DirectorAddress:        fmt.Sprintf("https://%s:25555", DIRECTOR_INTERNAL_IP),
-		DirectorUsername:       DIRECTOR_USERNAME,
-		DirectorPassword:       directorVars.directorPassword,
-		DirectorSSLCA:          directorVars.directorSSLCA,
-		DirectorSSLCertificate: directorVars.directorSSLCertificate,
-		DirectorSSLPrivateKey:  directorVars.directorSSLPrivateKey,
-		Variables:              interpolateOutputs.Variables,
-		State:                  createEnvOutputs.State,
-		Manifest:               interpolateOutputs.Manifest,
-		UserOpsFile:            state.BOSH.UserOpsFile,
+	err = m.socks5Proxy.Start(jumpboxPrivateKey, state.Jumpbox.URL)
+	if err != nil {
+		return fmt.Errorf("start proxy: %s", err)
 	}
 
-	m.logger.Step("created bosh director")
-	return state, nil
+	osSetenv("BOSH_ALL_PROXY", fmt.Sprintf("socks5://%s", m.socks5Proxy.Addr()))
+	return nil
+}
+
+func (m *Manager) interpolateDirector(state storage.State, terraformOutputs map[string]interface{}) (InterpolateOutput, error) {
+	opsFiles, err := directorOpsFiles(state)
+	if err != nil {
+		return InterpolateOutput{}, err
+	}
+
+	directorVars, err := m.GetDirectorDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return InterpolateOutput{}, err
+	}
+
+	jumpboxVars, err := m.GetJumpboxDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return InterpolateOutput{}, err
+	}
+
+	return m.executor.DirectorInterpolate(InterpolateInput{
+		IAAS: state.IAAS,
+		DirectorDeploymentVars: directorVars,
+		JumpboxDeploymentVars:  jumpboxVars,
+		Variables:              state.BOSH.Variables,
+		OpsFiles:               opsFiles,
+		Tags:                   state.Tags,
+		Private:                state.Network.Private,
+		SharedVPC:              state.GCP.NetworkProject != "",
+		DeploymentDirs:         m.deploymentDirs,
+	})
+}
+
+// directorOpsFiles are the user ops files followed by the ops file for the
+// versions file, so that pinned versions win.
+func directorOpsFiles(state storage.State) ([]storage.OpsFile, error) {
+	if state.BOSH.VersionsFile == "" {
+		return state.BOSH.UserOpsFiles, nil
+	}
+
+	versions, err := ParseVersionsFile(state.BOSH.VersionsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	opsFiles := append([]storage.OpsFile{}, state.BOSH.UserOpsFiles...)
+	return append(opsFiles, storage.OpsFile{
+		Name:     "versions",
+		Contents: string(versions.OpsFile()),
+	}), nil
 }
 
 func (m *Manager) DeleteDirector(state storage.State, terraformOutputs map[string]interface{}) error {
+	opsFiles, err := directorOpsFiles(state)
+	if err != nil {
+		return err
+	}
+
 	iaasInputs := InterpolateInput{
-		IAAS:      state.IAAS,
-		BOSHState: state.BOSH.State,
-		Variables: state.BOSH.Variables,
-		OpsFile:   state.BOSH.UserOpsFile,
+		IAAS:           state.IAAS,
+		BOSHState:      state.BOSH.State,
+		Variables:      state.BOSH.Variables,
+		OpsFiles:       opsFiles,
+		Tags:           state.Tags,
+		Private:        state.Network.Private,
+		SharedVPC:      state.GCP.NetworkProject != "",
+		DeploymentDirs: m.deploymentDirs,
 	}
 
 	jumpboxPrivateKey, err := getJumpboxPrivateKey(state.Jumpbox.Variables)
@@ -273,8 +459,15 @@ func (m *Manager) DeleteDirector(state storage.State, terraformOutputs map[strin
 
 	osSetenv("BOSH_ALL_PROXY", fmt.Sprintf("socks5://%s", m.socks5Proxy.Addr()))
 
-	iaasInputs.JumpboxDeploymentVars = m.GetJumpboxDeploymentVars(state, terraformOutputs)
-	iaasInputs.DirectorDeploymentVars = m.GetDirectorDeploymentVars(state, terraformOutputs)
+	iaasInputs.JumpboxDeploymentVars, err = m.GetJumpboxDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return err
+	}
+
+	iaasInputs.DirectorDeploymentVars, err = m.GetDirectorDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return err
+	}
 
 	interpolateOutputs, err := m.executor.DirectorInterpolate(iaasInputs)
 	if err != nil {
@@ -301,10 +494,20 @@ func (m *Manager) DeleteDirector(state storage.State, terraformOutputs map[strin
 func (m *Manager) DeleteJumpbox(state storage.State, terraformOutputs map[string]interface{}) error {
 	m.logger.Step("destroying jumpbox")
 
+	jumpboxVars, err := m.GetJumpboxDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return err
+	}
+
 	iaasInputs := InterpolateInput{
 		IAAS:                  state.IAAS,
 		Variables:             state.Jumpbox.Variables,
-		JumpboxDeploymentVars: m.GetJumpboxDeploymentVars(state, terraformOutputs),
+		JumpboxDeploymentVars: jumpboxVars,
+		OpsFiles:              state.Jumpbox.UserOpsFiles,
+		Tags:                  state.Tags,
+		Private:               state.Network.Private,
+		SharedVPC:             state.GCP.NetworkProject != "",
+		DeploymentDirs:        m.deploymentDirs,
 	}
 
 	interpolateOutputs, err := m.executor.JumpboxInterpolate(iaasInputs)
@@ -329,11 +532,16 @@ func (m *Manager) DeleteJumpbox(state storage.State, terraformOutputs map[string
 	return nil
 }
 
-func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) string {
+func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	networkPlan, err := newNetworkPlan(state)
+	if err != nil {
+		return "", err
+	}
+
 	vars := sharedDeploymentVarsYAML{
-		InternalCIDR: "10.0.0.0/24",
-		InternalGW:   "10.0.0.1",
-		InternalIP:   "10.0.0.5",
+		InternalCIDR: networkPlan.InternalCIDR(),
+		InternalGW:   networkPlan.InternalGW(),
+		InternalIP:   networkPlan.JumpboxIP(),
 		DirectorName: fmt.Sprintf("bosh-%s", state.EnvID),
 		ExternalIP:   getTerraformOutput("external_ip", terraformOutputs),
 	}
@@ -341,12 +549,13 @@ func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs
 	switch state.IAAS {
 	case "gcp":
 		vars.GCPYAML = GCPYAML{
-			Zone:           state.GCP.Zone,
-			Network:        getTerraformOutput("network_name", terraformOutputs),
-			Subnetwork:     getTerraformOutput("subnetwork_name", terraformOutputs),
-			Tags:           []string{getTerraformOutput("bosh_open_tag_name", terraformOutputs), getTerraformOutput("jumpbox_tag_name", terraformOutputs)},
-			ProjectID:      state.GCP.ProjectID,
-			CredentialJSON: state.GCP.ServiceAccountKey,
+			Zone:             state.GCP.Zone,
+			Network:          getTerraformOutput("network_name", terraformOutputs),
+			Subnetwork:       getTerraformOutput("subnetwork_name", terraformOutputs),
+			Tags:             []string{getTerraformOutput("bosh_open_tag_name", terraformOutputs), getTerraformOutput("jumpbox_tag_name", terraformOutputs)},
+			ProjectID:        state.GCP.ProjectID,
+			XPNHostProjectID: state.GCP.NetworkProject,
+			CredentialJSON:   state.GCP.ServiceAccountKey,
 		}
 	case "aws":
 		vars.AWSYAML = AWSYAML{
@@ -362,7 +571,40 @@ func (m *Manager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs
 		}
 	}
 
-	return string(mustMarshal(vars))
+	return string(mustMarshal(vars)), nil
+}
+
+// manifestHash identifies an interpolated manifest and its variables, so
+// create-env can be skipped when neither has changed since the last deploy.
+func manifestHash(manifest, variables string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(manifest+"\x00"+variables)))
+}
+
+func (m *Manager) directorReachable(networkPlan NetworkPlan) bool {
+	dialer, err := proxySOCKS5("tcp", m.socks5Proxy.Addr(), nil, proxy.Direct)
+	if err != nil {
+		return false
+	}
+
+	conn, err := dialer.Dial("tcp", net.JoinHostPort(networkPlan.DirectorIP(), "25555"))
+	if err != nil {
+		return false
+	}
+	conn.Close()
+
+	return true
+}
+
+// jumpboxReachable reports whether the ssh port of the jumpbox accepts
+// connections. The proxy is only started once the jumpbox is up to date.
+func jumpboxReachable(jumpboxURL string) bool {
+	conn, err := netDialTimeout("tcp", jumpboxURL, 10*time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+
+	return true
 }
 
 func mustMarshal(yamlStruct interface{}) []byte {
@@ -374,6 +616,14 @@ func mustMarshal(yamlStruct interface{}) []byte {
 	return yamlBytes
 }
 
+func newNetworkPlan(state storage.State) (NetworkPlan, error) {
+	networkPlan, err := PlanNetwork(state)
+	if err != nil {
+		return NetworkPlan{}, fmt.Errorf("network plan: %s", err)
+	}
+	return networkPlan, nil
+}
+
 func getTerraformOutput(key string, outputs map[string]interface{}) string {
 	if value, ok := outputs[key]; ok {
 		return fmt.Sprintf("%s", value)
@@ -381,23 +631,29 @@ func getTerraformOutput(key string, outputs map[string]interface{}) string {
 	return ""
 }
 
-func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) string {
+func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	networkPlan, err := newNetworkPlan(state)
+	if err != nil {
+		return "", err
+	}
+
 	vars := sharedDeploymentVarsYAML{
-		InternalCIDR: "10.0.0.0/24",
-		InternalGW:   "10.0.0.1",
-		InternalIP:   DIRECTOR_INTERNAL_IP,
+		InternalCIDR: networkPlan.InternalCIDR(),
+		InternalGW:   networkPlan.InternalGW(),
+		InternalIP:   networkPlan.DirectorIP(),
 		DirectorName: fmt.Sprintf("bosh-%s", state.EnvID),
 	}
 
 	switch state.IAAS {
 	case "gcp":
 		vars.GCPYAML = GCPYAML{
-			Zone:           state.GCP.Zone,
-			Network:        getTerraformOutput("network_name", terraformOutputs),
-			Subnetwork:     getTerraformOutput("subnetwork_name", terraformOutputs),
-			Tags:           []string{getTerraformOutput("bosh_director_tag_name", terraformOutputs)},
-			ProjectID:      state.GCP.ProjectID,
-			CredentialJSON: state.GCP.ServiceAccountKey,
+			Zone:             state.GCP.Zone,
+			Network:          getTerraformOutput("network_name", terraformOutputs),
+			Subnetwork:       getTerraformOutput("subnetwork_name", terraformOutputs),
+			Tags:             []string{getTerraformOutput("bosh_director_tag_name", terraformOutputs)},
+			ProjectID:        state.GCP.ProjectID,
+			XPNHostProjectID: state.GCP.NetworkProject,
+			CredentialJSON:   state.GCP.ServiceAccountKey,
 		}
 	case "aws":
 		vars.AWSYAML = AWSYAML{
@@ -426,7 +682,7 @@ func (m *Manager) GetDirectorDeploymentVars(state storage.State, terraformOutput
 		}
 	}
 
-	return string(mustMarshal(vars))
+	return string(mustMarshal(vars)), nil
 }
 
 func getJumpboxPrivateKey(v string) (string, error) {
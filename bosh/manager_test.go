@@ -3,10 +3,13 @@ package bosh_test
 import (
 	"errors"
 	"fmt"
+	"net"
+	"time"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"golang.org/x/net/proxy"
 
 	"github.com/pivotal-cf-experimental/gomegamatchers"
 
@@ -33,7 +36,7 @@ var _ = Describe("Manager", func() {
 		boshExecutor = &fakes.BOSHExecutor{}
 		logger = &fakes.Logger{}
 		socks5Proxy = &fakes.Socks5Proxy{}
-		boshManager = bosh.NewManager(boshExecutor, logger, socks5Proxy)
+		boshManager = bosh.NewManager(boshExecutor, logger, socks5Proxy, bosh.DeploymentDirs{})
 
 		boshVars = `admin_password: some-admin-password
 director_ssl:
@@ -68,6 +71,65 @@ director_ssl:
 				State: map[string]interface{}{"some-new-key": "some-new-value"}}
 		})
 
+		It("passes the tags to the executor", func() {
+			_, err := boshManager.CreateDirector(storage.State{
+				IAAS:  "gcp",
+				EnvID: "some-env-id",
+				Tags:  map[string]string{"team": "some-team"},
+			}, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.Tags).To(Equal(map[string]string{"team": "some-team"}))
+		})
+
+		It("pins the versions of the versions file after the user ops files and keeps it in the state", func() {
+			versionsFile := "stemcell: {url: some-stemcell-url, sha1: some-stemcell-sha1}"
+
+			state, err := boshManager.CreateDirector(storage.State{
+				IAAS:  "gcp",
+				EnvID: "some-env-id",
+				BOSH: storage.BOSH{
+					UserOpsFiles: []storage.OpsFile{
+						{Name: "some-ops-file", Contents: "some-ops-file-contents"},
+					},
+					VersionsFile:     versionsFile,
+					PreviousManifest: "some-previous-manifest",
+				},
+			}, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			opsFiles := boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.OpsFiles
+			Expect(opsFiles).To(HaveLen(2))
+			Expect(opsFiles[0].Name).To(Equal("some-ops-file"))
+			Expect(opsFiles[1].Name).To(Equal("versions"))
+			Expect(opsFiles[1].Contents).To(ContainSubstring("path: /resource_pools/name=vms/stemcell?"))
+
+			Expect(state.BOSH.VersionsFile).To(Equal(versionsFile))
+			Expect(state.BOSH.PreviousManifest).To(Equal("some-previous-manifest"))
+		})
+
+		It("returns an error when the versions file is not valid", func() {
+			_, err := boshManager.CreateDirector(storage.State{
+				IAAS:  "gcp",
+				EnvID: "some-env-id",
+				BOSH: storage.BOSH{
+					VersionsFile: "releases: []",
+				},
+			}, map[string]interface{}{})
+			Expect(err).To(MatchError("versions file does not pin any releases or a stemcell"))
+		})
+
+		It("passes the private network mode to the executor", func() {
+			_, err := boshManager.CreateDirector(storage.State{
+				IAAS:    "gcp",
+				EnvID:   "some-env-id",
+				Network: storage.Network{Private: true},
+			}, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.Private).To(BeTrue())
+		})
+
 		Context("gcp", func() {
 			var incomingGCPState storage.State
 			BeforeEach(func() {
@@ -95,7 +157,9 @@ director_ssl:
 						State: map[string]interface{}{
 							"some-key": "some-value",
 						},
-						UserOpsFile: "some-ops-file",
+						UserOpsFiles: []storage.OpsFile{
+							{Name: "some-ops-file", Contents: "some-ops-file-contents"},
+						},
 					},
 				}
 			})
@@ -128,6 +192,7 @@ gcp_credentials_json: some-credential-json
 					},
 					Variables:              boshVars,
 					Manifest:               "some-manifest",
+					ManifestHash:           bosh.ManifestHash("some-manifest", boshVars),
 					DirectorName:           "bosh-some-env-id",
 					DirectorAddress:        "https://10.0.0.6:25555",
 					DirectorUsername:       "admin",
@@ -135,9 +200,78 @@ gcp_credentials_json: some-credential-json
 					DirectorSSLCA:          "some-ca",
 					DirectorSSLCertificate: "some-certificate",
 					DirectorSSLPrivateKey:  "some-private-key",
-					UserOpsFile:            "some-ops-file",
+					UserOpsFiles: []storage.OpsFile{
+						{Name: "some-ops-file", Contents: "some-ops-file-contents"},
+					},
 				}))
 			})
+
+			It("keeps the settings that do not come from create-env", func() {
+				incomingGCPState.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "dev"}
+				incomingGCPState.BOSH.PreemptibleCompilation = true
+				incomingGCPState.BOSH.RuntimeConfig = storage.RuntimeConfig{Name: "bosh-dns"}
+				incomingGCPState.BOSH.VersionsFile = "stemcell: {url: some-stemcell-url, sha1: some-stemcell-sha1}"
+				incomingGCPState.BOSH.PreviousManifest = "some-previous-manifest"
+
+				stateWithDirector, err := boshManager.CreateDirector(incomingGCPState, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stateWithDirector.BOSH.CloudConfigProfile).To(Equal(storage.CloudConfigProfile{Name: "dev"}))
+				Expect(stateWithDirector.BOSH.PreemptibleCompilation).To(BeTrue())
+				Expect(stateWithDirector.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{Name: "bosh-dns"}))
+				Expect(stateWithDirector.BOSH.VersionsFile).To(Equal(incomingGCPState.BOSH.VersionsFile))
+				Expect(stateWithDirector.BOSH.PreviousManifest).To(Equal("some-previous-manifest"))
+			})
+		})
+
+		Context("when the director manifest and variables are unchanged", func() {
+			var (
+				incomingState    storage.State
+				fakeSocks5Client *fakes.Socks5Client
+			)
+
+			BeforeEach(func() {
+				incomingState = storage.State{
+					IAAS:  "gcp",
+					EnvID: "some-env-id",
+					BOSH: storage.BOSH{
+						State:        map[string]interface{}{"some-key": "some-value"},
+						ManifestHash: bosh.ManifestHash("some-manifest", boshVars),
+					},
+				}
+
+				_, conn := net.Pipe()
+				fakeSocks5Client = &fakes.Socks5Client{}
+				fakeSocks5Client.DialCall.Returns.Connection = conn
+				bosh.SetProxySOCKS5(func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dialer, error) {
+					return fakeSocks5Client, nil
+				})
+			})
+
+			AfterEach(func() {
+				bosh.ResetProxySOCKS5()
+			})
+
+			It("skips create-env when the director is reachable", func() {
+				state, err := boshManager.CreateDirector(incomingState, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSocks5Client.DialCall.Receives.Addr).To(Equal("10.0.0.6:25555"))
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+				Expect(logger.StepCall.Messages).To(ContainElement("bosh director is unchanged, skipping create-env"))
+				Expect(state.BOSH.State).To(Equal(map[string]interface{}{"some-key": "some-value"}))
+				Expect(state.BOSH.DirectorPassword).To(Equal("some-admin-password"))
+			})
+
+			It("runs create-env when the director is not reachable", func() {
+				fakeSocks5Client.DialCall.Returns.Error = errors.New("connection refused")
+
+				state, err := boshManager.CreateDirector(incomingState, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(1))
+				Expect(state.BOSH.State).To(Equal(map[string]interface{}{"some-new-key": "some-new-value"}))
+			})
 		})
 
 		Context("aws", func() {
@@ -153,8 +287,10 @@ gcp_credentials_json: some-credential-json
 							Region:          "some-region",
 						},
 						BOSH: storage.BOSH{
-							State:       map[string]interface{}{"some-key": "some-value"},
-							UserOpsFile: "some-yaml",
+							State: map[string]interface{}{"some-key": "some-value"},
+							UserOpsFiles: []storage.OpsFile{
+								{Name: "some-ops-file", Contents: "some-yaml"},
+							},
 						},
 					}
 					terraformOutputs = map[string]interface{}{
@@ -196,6 +332,7 @@ kms_key_arn: some-kms-arn
 						},
 						Variables:              boshVars,
 						Manifest:               "some-manifest",
+						ManifestHash:           bosh.ManifestHash("some-manifest", boshVars),
 						DirectorName:           "bosh-some-env-id",
 						DirectorAddress:        "https://10.0.0.6:25555",
 						DirectorUsername:       "admin",
@@ -203,7 +340,9 @@ kms_key_arn: some-kms-arn
 						DirectorSSLCA:          "some-ca",
 						DirectorSSLCertificate: "some-certificate",
 						DirectorSSLPrivateKey:  "some-private-key",
-						UserOpsFile:            "some-yaml",
+						UserOpsFiles: []storage.OpsFile{
+							{Name: "some-ops-file", Contents: "some-yaml"},
+						},
 					}))
 				})
 			})
@@ -268,6 +407,203 @@ kms_key_arn: some-kms-arn
 		})
 	})
 
+	Describe("InterpolateDirector", func() {
+		It("returns the manifest the director would be created from", func() {
+			boshExecutor.DirectorInterpolateCall.Returns.Output = bosh.InterpolateOutput{
+				Manifest:  "some-manifest",
+				Variables: boshVars,
+			}
+
+			manifest, err := boshManager.InterpolateDirector(storage.State{
+				IAAS:  "gcp",
+				EnvID: "some-env-id",
+				BOSH: storage.BOSH{
+					Variables: boshVars,
+				},
+			}, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(manifest).To(Equal("some-manifest"))
+			Expect(boshExecutor.DirectorInterpolateCall.Receives.InterpolateInput.Variables).To(Equal(boshVars))
+			Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+		})
+
+		It("returns an error when the interpolation fails", func() {
+			boshExecutor.DirectorInterpolateCall.Returns.Error = errors.New("failed to interpolate")
+
+			_, err := boshManager.InterpolateDirector(storage.State{}, map[string]interface{}{})
+			Expect(err).To(MatchError("failed to interpolate"))
+		})
+	})
+
+	Describe("UpgradeDirector", func() {
+		var incomingState storage.State
+
+		BeforeEach(func() {
+			boshExecutor.DirectorInterpolateCall.Returns.Output = bosh.InterpolateOutput{
+				Manifest:  "some-new-manifest",
+				Variables: boshVars,
+			}
+			boshExecutor.CreateEnvCall.Returns.Output = bosh.CreateEnvOutput{
+				State: map[string]interface{}{"some-new-key": "some-new-value"},
+			}
+			socks5Proxy.AddrCall.Returns.Addr = "localhost:1234"
+
+			incomingState = storage.State{
+				IAAS:  "gcp",
+				EnvID: "some-env-id",
+				Jumpbox: storage.Jumpbox{
+					Variables: jumpboxVars,
+					URL:       "some-jumpbox-url",
+				},
+				BOSH: storage.BOSH{
+					Manifest:               "some-old-manifest",
+					State:                  map[string]interface{}{"some-key": "some-value"},
+					Variables:              boshVars,
+					VersionsFile:           "stemcell: {url: some-stemcell-url, sha1: some-stemcell-sha1}",
+					CloudConfigProfile:     storage.CloudConfigProfile{Name: "dev"},
+					PreemptibleCompilation: true,
+					RuntimeConfig:          storage.RuntimeConfig{Name: "bosh-dns"},
+				},
+			}
+		})
+
+		It("runs create-env through the proxy to the jumpbox and keeps the previous manifest", func() {
+			state, err := boshManager.UpgradeDirector(incomingState, map[string]interface{}{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(socks5Proxy.StartCall.Receives.JumpboxPrivateKey).To(Equal("some-jumpbox-private-key"))
+			Expect(socks5Proxy.StartCall.Receives.JumpboxExternalURL).To(Equal("some-jumpbox-url"))
+			Expect(osSetenvKey).To(Equal("BOSH_ALL_PROXY"))
+			Expect(osSetenvValue).To(Equal("socks5://localhost:1234"))
+
+			Expect(boshExecutor.CreateEnvCall.Receives.Input.Manifest).To(Equal("some-new-manifest"))
+
+			Expect(state.BOSH.Manifest).To(Equal("some-new-manifest"))
+			Expect(state.BOSH.PreviousManifest).To(Equal("some-old-manifest"))
+			Expect(state.BOSH.State).To(Equal(map[string]interface{}{"some-new-key": "some-new-value"}))
+			Expect(state.BOSH.VersionsFile).To(Equal(incomingState.BOSH.VersionsFile))
+			Expect(state.BOSH.CloudConfigProfile).To(Equal(storage.CloudConfigProfile{Name: "dev"}))
+			Expect(state.BOSH.PreemptibleCompilation).To(BeTrue())
+			Expect(state.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{Name: "bosh-dns"}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the proxy cannot be started", func() {
+				socks5Proxy.StartCall.Returns.Error = errors.New("failed to start socks5Proxy")
+
+				_, err := boshManager.UpgradeDirector(incomingState, map[string]interface{}{})
+				Expect(err).To(MatchError("start proxy: failed to start socks5Proxy"))
+			})
+
+			It("returns a bosh manager create error that keeps the rest of the director state", func() {
+				boshState := map[string]interface{}{"partial": "bosh-state"}
+				createEnvError := bosh.NewCreateEnvError(boshState, errors.New("failed to create env"))
+				boshExecutor.CreateEnvCall.Returns.Error = createEnvError
+
+				_, err := boshManager.UpgradeDirector(incomingState, map[string]interface{}{})
+				Expect(err).To(MatchError("failed to create env"))
+
+				managerCreateError, ok := err.(bosh.ManagerCreateError)
+				Expect(ok).To(BeTrue())
+
+				failedBOSH := managerCreateError.State().BOSH
+				Expect(failedBOSH.State).To(Equal(boshState))
+				Expect(failedBOSH.Manifest).To(Equal("some-new-manifest"))
+				Expect(failedBOSH.PreviousManifest).To(Equal("some-old-manifest"))
+				Expect(failedBOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{Name: "bosh-dns"}))
+			})
+
+			It("returns an error when create-env fails", func() {
+				boshExecutor.CreateEnvCall.Returns.Error = errors.New("failed to create env")
+
+				_, err := boshManager.UpgradeDirector(incomingState, map[string]interface{}{})
+				Expect(err).To(MatchError("failed to create env"))
+			})
+		})
+	})
+
+	Describe("RollbackDirector", func() {
+		var incomingState storage.State
+
+		BeforeEach(func() {
+			boshExecutor.CreateEnvCall.Returns.Output = bosh.CreateEnvOutput{
+				State: map[string]interface{}{"some-new-key": "some-new-value"},
+			}
+			socks5Proxy.AddrCall.Returns.Addr = "localhost:1234"
+
+			incomingState = storage.State{
+				IAAS:  "gcp",
+				EnvID: "some-env-id",
+				Jumpbox: storage.Jumpbox{
+					Variables: jumpboxVars,
+					URL:       "some-jumpbox-url",
+				},
+				BOSH: storage.BOSH{
+					Manifest:         "some-new-manifest",
+					ManifestHash:     "some-hash",
+					PreviousManifest: "some-old-manifest",
+					State:            map[string]interface{}{"some-key": "some-value"},
+					Variables:        boshVars,
+					RuntimeConfig:    storage.RuntimeConfig{Name: "bosh-dns"},
+				},
+			}
+		})
+
+		It("runs create-env with the previous manifest as it was", func() {
+			state, err := boshManager.RollbackDirector(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(socks5Proxy.StartCall.Receives.JumpboxExternalURL).To(Equal("some-jumpbox-url"))
+			Expect(osSetenvValue).To(Equal("socks5://localhost:1234"))
+
+			Expect(boshExecutor.DirectorInterpolateCall.CallCount).To(Equal(0))
+			Expect(boshExecutor.CreateEnvCall.Receives.Input).To(Equal(bosh.CreateEnvInput{
+				Manifest:  "some-old-manifest",
+				State:     map[string]interface{}{"some-key": "some-value"},
+				Variables: boshVars,
+			}))
+
+			Expect(state.BOSH.Manifest).To(Equal("some-old-manifest"))
+			Expect(state.BOSH.ManifestHash).To(Equal(bosh.ManifestHash("some-old-manifest", boshVars)))
+			Expect(state.BOSH.PreviousManifest).To(BeEmpty())
+			Expect(state.BOSH.State).To(Equal(map[string]interface{}{"some-new-key": "some-new-value"}))
+			Expect(state.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{Name: "bosh-dns"}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the proxy cannot be started", func() {
+				socks5Proxy.StartCall.Returns.Error = errors.New("failed to start socks5Proxy")
+
+				_, err := boshManager.RollbackDirector(incomingState)
+				Expect(err).To(MatchError("start proxy: failed to start socks5Proxy"))
+			})
+
+			It("returns a bosh manager create error with the state of the failed create-env", func() {
+				boshState := map[string]interface{}{"partial": "bosh-state"}
+				boshExecutor.CreateEnvCall.Returns.Error = bosh.NewCreateEnvError(boshState, errors.New("failed to create env"))
+
+				_, err := boshManager.RollbackDirector(incomingState)
+				Expect(err).To(MatchError("failed to create env"))
+
+				managerCreateError, ok := err.(bosh.ManagerCreateError)
+				Expect(ok).To(BeTrue())
+
+				failedBOSH := managerCreateError.State().BOSH
+				Expect(failedBOSH.State).To(Equal(boshState))
+				Expect(failedBOSH.ManifestHash).To(BeEmpty())
+				Expect(failedBOSH.PreviousManifest).To(Equal("some-old-manifest"))
+			})
+
+			It("returns an error when create-env fails", func() {
+				boshExecutor.CreateEnvCall.Returns.Error = errors.New("failed to create env")
+
+				_, err := boshManager.RollbackDirector(incomingState)
+				Expect(err).To(MatchError("failed to create env"))
+			})
+		})
+	})
+
 	Describe("CreateJumpbox", func() {
 		var (
 			jumpboxDeploymentVars string
@@ -353,6 +689,101 @@ gcp_credentials_json: some-credential-json
 			bosh.ResetOSSetenv()
 		})
 
+		It("passes the tags to the executor", func() {
+			incomingGCPState.Tags = map[string]string{"team": "some-team"}
+
+			_, err := boshManager.CreateJumpbox(incomingGCPState, terraformOutputs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.Tags).To(Equal(map[string]string{"team": "some-team"}))
+		})
+
+		It("passes the jumpbox ops files to the executor and keeps them in the state", func() {
+			opsFiles := []storage.OpsFile{{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"}}
+			incomingGCPState.Jumpbox.UserOpsFiles = opsFiles
+
+			state, err := boshManager.CreateJumpbox(incomingGCPState, terraformOutputs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.OpsFiles).To(Equal(opsFiles))
+			Expect(state.Jumpbox.UserOpsFiles).To(Equal(opsFiles))
+		})
+
+		Context("when the jumpbox manifest and variables are unchanged", func() {
+			var (
+				dialAddress string
+				dialError   error
+			)
+
+			BeforeEach(func() {
+				incomingGCPState.Jumpbox.ManifestHash = bosh.ManifestHash("name: jumpbox", "jumpbox_ssh:\n  private_key: some-jumpbox-private-key")
+
+				dialError = nil
+				bosh.SetNetDialTimeout(func(network, address string, timeout time.Duration) (net.Conn, error) {
+					dialAddress = address
+					if dialError != nil {
+						return nil, dialError
+					}
+					conn, _ := net.Pipe()
+					return conn, nil
+				})
+			})
+
+			AfterEach(func() {
+				bosh.ResetNetDialTimeout()
+			})
+
+			It("skips create-env when the jumpbox is reachable", func() {
+				state, err := boshManager.CreateJumpbox(incomingGCPState, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(dialAddress).To(Equal("some-jumpbox-url"))
+				Expect(socks5Proxy.StartCall.CallCount).To(Equal(1))
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(0))
+				Expect(logger.StepCall.Messages).To(ContainElement("jumpbox is unchanged, skipping create-env"))
+				Expect(state.Jumpbox.State).To(Equal(map[string]interface{}{"some-key": "some-value"}))
+				Expect(state.Jumpbox.ManifestHash).To(Equal(incomingGCPState.Jumpbox.ManifestHash))
+			})
+
+			It("runs create-env when the jumpbox is not reachable", func() {
+				dialError = errors.New("connection refused")
+
+				_, err := boshManager.CreateJumpbox(incomingGCPState, terraformOutputs)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(1))
+				Expect(socks5Proxy.StartCall.CallCount).To(Equal(1))
+			})
+		})
+
+		It("runs create-env when the jumpbox manifest has changed", func() {
+			incomingGCPState.Jumpbox.ManifestHash = "some-old-hash"
+
+			_, err := boshManager.CreateJumpbox(incomingGCPState, terraformOutputs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshExecutor.CreateEnvCall.CallCount).To(Equal(1))
+			Expect(socks5Proxy.StartCall.CallCount).To(Equal(1))
+		})
+
+		It("reads the deployment from the configured dirs and records its source in the state", func() {
+			boshManager = bosh.NewManager(boshExecutor, logger, socks5Proxy, bosh.DeploymentDirs{
+				BOSH:    "/some/bosh-deployment",
+				Jumpbox: "/some/jumpbox-deployment",
+			})
+			deploymentSource := storage.DeploymentSource{Source: "/some/jumpbox-deployment", SHA: "some-sha"}
+			boshExecutor.JumpboxInterpolateCall.Returns.Output.DeploymentSource = deploymentSource
+
+			state, err := boshManager.CreateJumpbox(incomingGCPState, terraformOutputs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.DeploymentDirs).To(Equal(bosh.DeploymentDirs{
+				BOSH:    "/some/bosh-deployment",
+				Jumpbox: "/some/jumpbox-deployment",
+			}))
+			Expect(state.Jumpbox.DeploymentSource).To(Equal(deploymentSource))
+		})
+
 		It("starts a socks5 proxy for the duration of creating the bosh director", func() {
 			socks5ProxyAddr := "localhost:1234"
 			socks5Proxy.AddrCall.Returns.Addr = socks5ProxyAddr
@@ -401,9 +832,10 @@ gcp_credentials_json: some-credential-json
 						ServiceAccountKey: "some-credential-json",
 					},
 					Jumpbox: storage.Jumpbox{
-						URL:       "some-jumpbox-url",
-						Variables: "jumpbox_ssh:\n  private_key: some-jumpbox-private-key",
-						Manifest:  "name: jumpbox",
+						URL:          "some-jumpbox-url",
+						Variables:    "jumpbox_ssh:\n  private_key: some-jumpbox-private-key",
+						Manifest:     "name: jumpbox",
+						ManifestHash: bosh.ManifestHash("name: jumpbox", "jumpbox_ssh:\n  private_key: some-jumpbox-private-key"),
 						State: map[string]interface{}{
 							"some-new-key": "some-new-value",
 						},
@@ -414,6 +846,7 @@ gcp_credentials_json: some-credential-json
 						},
 						Variables:              boshVars,
 						Manifest:               "some-manifest",
+						ManifestHash:           bosh.ManifestHash("some-manifest", boshVars),
 						DirectorName:           "bosh-some-env-id",
 						DirectorAddress:        "https://10.0.0.6:25555",
 						DirectorUsername:       "admin",
@@ -509,6 +942,16 @@ gcp_credentials_json: some-credential-json
 			Expect(boshExecutor.DeleteEnvCall.Receives.Input.Variables).To(Equal("some-new-jumpbox-vars"))
 		})
 
+		It("interpolates the jumpbox with its ops files", func() {
+			incomingState.Jumpbox.UserOpsFiles = []storage.OpsFile{{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"}}
+
+			err := boshManager.DeleteJumpbox(incomingState, map[string]interface{}{"jumpbox_ssh": "nick-da-quick"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(boshExecutor.JumpboxInterpolateCall.Receives.InterpolateInput.OpsFiles).To(Equal([]storage.OpsFile{
+				{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"},
+			}))
+		})
+
 		Context("when an error occurs", func() {
 			Context("when the executor's delete env call fails with delete env error", func() {
 				var (
@@ -675,7 +1118,7 @@ gcp_credentials_json: some-credential-json
 			})
 
 			It("returns a correct yaml string of bosh deployment variables", func() {
-				vars := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{
+				vars, err := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{
 					"network_name":                  "some-network",
 					"bosh_subnet_id":                "some-subnetwork",
 					"bosh_subnet_availability_zone": "some-zone",
@@ -685,6 +1128,7 @@ gcp_credentials_json: some-credential-json
 					"jumpbox_security_group":        "some-security-group",
 					"external_ip":                   "some-external-ip",
 				})
+				Expect(err).NotTo(HaveOccurred())
 				Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.5
@@ -720,13 +1164,14 @@ private_key: some-private-key
 			})
 
 			It("returns a correct yaml string of bosh deployment variables", func() {
-				vars := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{
+				vars, err := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{
 					"network_name":       "some-network",
 					"subnetwork_name":    "some-subnetwork",
 					"bosh_open_tag_name": "some-jumpbox-tag",
 					"jumpbox_tag_name":   "some-jumpbox-fw-tag",
 					"external_ip":        "some-external-ip",
 				})
+				Expect(err).NotTo(HaveOccurred())
 				Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.5
@@ -740,12 +1185,59 @@ tags:
 - some-jumpbox-fw-tag
 project_id: some-project-id
 gcp_credentials_json: some-credential-json
+`))
+			})
+
+			It("includes the Shared VPC host project of the network", func() {
+				incomingState.GCP.NetworkProject = "some-host-project"
+
+				vars, err := boshManager.GetJumpboxDeploymentVars(incomingState, map[string]interface{}{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vars).To(ContainSubstring("xpn_host_project_id: some-host-project\n"))
+			})
+		})
+
+		Context("when a network cidr is provided", func() {
+			It("derives the internal network and jumpbox ip from it", func() {
+				vars, err := boshManager.GetJumpboxDeploymentVars(storage.State{
+					IAAS:    "gcp",
+					EnvID:   "some-env-id",
+					Network: storage.Network{CIDR: "172.16.0.0/16"},
+				}, map[string]interface{}{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vars).To(HavePrefix(`internal_cidr: 172.16.0.0/24
+internal_gw: 172.16.0.1
+internal_ip: 172.16.0.5
 `))
 			})
 		})
 	})
 
 	Describe("GetDirectorDeploymentVars", func() {
+		It("returns an error when the network cidr in the state cannot be planned", func() {
+			_, err := boshManager.GetDirectorDeploymentVars(storage.State{
+				IAAS:    "aws",
+				Network: storage.Network{CIDR: "10.0.0.0/24"},
+			}, map[string]interface{}{})
+			Expect(err).To(MatchError(`network plan: "10.0.0.0/24" is too small, the network must be at least a /20`))
+		})
+
+		Context("when a network cidr is provided", func() {
+			It("derives the internal network and director ip from it", func() {
+				vars, err := boshManager.GetDirectorDeploymentVars(storage.State{
+					IAAS:    "aws",
+					EnvID:   "some-env-id",
+					Network: storage.Network{CIDR: "172.16.0.0/16"},
+				}, map[string]interface{}{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vars).To(HavePrefix(`internal_cidr: 172.16.0.0/24
+internal_gw: 172.16.0.1
+internal_ip: 172.16.0.6
+`))
+			})
+		})
+
+
 		Context("gcp", func() {
 			var incomingState storage.State
 			BeforeEach(func() {
@@ -765,7 +1257,7 @@ gcp_credentials_json: some-credential-json
 				}
 			})
 			It("returns a correct yaml string of bosh deployment variables", func() {
-				vars := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{
+				vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{
 					"network_name":           "some-network",
 					"subnetwork_name":        "some-subnetwork",
 					"bosh_open_tag_name":     "some-jumpbox-tag",
@@ -775,6 +1267,7 @@ gcp_credentials_json: some-credential-json
 					"external_ip":            "some-external-ip",
 					"director_address":       "some-director-address",
 				})
+				Expect(err).NotTo(HaveOccurred())
 				Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.6
@@ -791,7 +1284,8 @@ gcp_credentials_json: some-credential-json
 
 			Context("when terraform outputs are missing", func() {
 				It("returns valid yaml", func() {
-					vars := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					Expect(err).NotTo(HaveOccurred())
 					Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.6
@@ -804,6 +1298,14 @@ gcp_credentials_json: some-credential-json
 `))
 				})
 			})
+
+			It("includes the Shared VPC host project of the network", func() {
+				incomingState.GCP.NetworkProject = "some-host-project"
+
+				vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vars).To(ContainSubstring("xpn_host_project_id: some-host-project\n"))
+			})
 		})
 
 		Context("aws", func() {
@@ -826,7 +1328,7 @@ gcp_credentials_json: some-credential-json
 
 			Context("when terraform was used to standup infrastructure", func() {
 				It("returns a correct yaml string of bosh deployment variables", func() {
-					vars := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{
+					vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{
 						"bosh_iam_instance_profile":     "some-bosh-iam-instance-profile",
 						"bosh_subnet_availability_zone": "some-bosh-subnet-az",
 						"bosh_security_group":           "some-bosh-security-group",
@@ -837,6 +1339,7 @@ gcp_credentials_json: some-credential-json
 						"director_address":              "some-director-address",
 						"kms_key_arn":                   "some-kms-arn",
 					})
+					Expect(err).NotTo(HaveOccurred())
 					Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.6
@@ -858,7 +1361,8 @@ kms_key_arn: some-kms-arn
 
 			Context("when terraform outputs are missing", func() {
 				It("returns valid yaml", func() {
-					vars := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					vars, err := boshManager.GetDirectorDeploymentVars(incomingState, map[string]interface{}{})
+					Expect(err).NotTo(HaveOccurred())
 					Expect(vars).To(Equal(`internal_cidr: 10.0.0.0/24
 internal_gw: 10.0.0.1
 internal_ip: 10.0.0.6
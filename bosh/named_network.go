@@ -0,0 +1,126 @@
+package bosh
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const (
+	MAX_NAMED_NETWORK_MASK_BITS = 25
+
+	namedNetworkAZSubnetNewBits = 3
+)
+
+var (
+	namedNetworkName     = regexp.MustCompile(`^[a-z]([a-z0-9-]{0,18}[a-z0-9])?$`)
+	reservedNetworkNames = []string{"default", "private"}
+)
+
+// ParseNamedNetwork parses a network given as name:cidr. The name becomes the
+// name of the network in the cloud config and part of the names of the
+// terraform resources, so it is limited to what every IAAS accepts.
+func ParseNamedNetwork(network string) (storage.NamedNetwork, error) {
+	parts := strings.SplitN(network, ":", 2)
+	if len(parts) != 2 {
+		return storage.NamedNetwork{}, fmt.Errorf("%q is not a network, use name:cidr", network)
+	}
+	name, cidr := parts[0], parts[1]
+
+	if !namedNetworkName.MatchString(name) {
+		return storage.NamedNetwork{}, fmt.Errorf("network name %q must start with a letter and only contain lowercase letters, digits and hyphens, up to 20 characters", name)
+	}
+
+	for _, reserved := range reservedNetworkNames {
+		if name == reserved {
+			return storage.NamedNetwork{}, fmt.Errorf("network name %q is already used by the cloud config", name)
+		}
+	}
+
+	block, err := parseNetworkCIDR(cidr)
+	if err != nil {
+		return storage.NamedNetwork{}, err
+	}
+
+	if block.maskBits > MAX_NAMED_NETWORK_MASK_BITS {
+		return storage.NamedNetwork{}, fmt.Errorf("%q is too small, a named network must be at least a /%d", cidr, MAX_NAMED_NETWORK_MASK_BITS)
+	}
+
+	return storage.NamedNetwork{Name: name, CIDR: cidr}, nil
+}
+
+// CheckNamedNetworks returns an error when the named networks cannot be
+// created next to the network of the plan. AWS subnets have to be carved out
+// of the VPC, past the block that holds the bosh, lb and nat subnets and the
+// internal subnets of the availability zones. GCP subnetworks live next to the
+// subnetwork that covers the whole network.
+func (n NetworkPlan) CheckNamedNetworks(iaas string, networks []storage.NamedNetwork, zones []string) error {
+	reserved, err := n.network.Subnet(azSubnetNewBits, 0)
+	if err != nil {
+		return err // not tested
+	}
+
+	var azSubnets []CIDRBlock
+	for i := range zones {
+		azSubnet, err := n.AZSubnet(i)
+		if err != nil {
+			return err
+		}
+		azSubnets = append(azSubnets, azSubnet)
+	}
+
+	blocks := map[string]CIDRBlock{}
+	for i, network := range networks {
+		if _, ok := blocks[network.Name]; ok {
+			return fmt.Errorf("network %q is given more than once", network.Name)
+		}
+
+		block, err := ParseCIDRBlock(network.CIDR)
+		if err != nil {
+			return err
+		}
+
+		switch iaas {
+		case "aws":
+			if !n.network.Contains(block) {
+				return fmt.Errorf("network %q must be inside the network cidr %s", network.Name, n.network)
+			}
+			if block.Overlaps(reserved) {
+				return fmt.Errorf("network %q overlaps %s, which is used for the bosh, lb and nat subnets", network.Name, reserved)
+			}
+			for i, azSubnet := range azSubnets {
+				if block.Overlaps(azSubnet) {
+					return fmt.Errorf("network %q overlaps %s, which is the internal subnet of %s", network.Name, azSubnet, zones[i])
+				}
+			}
+		default:
+			if block.Overlaps(n.network) {
+				return fmt.Errorf("network %q must not overlap the network cidr %s", network.Name, n.network)
+			}
+		}
+
+		for _, other := range networks[:i] {
+			if block.Overlaps(blocks[other.Name]) {
+				return fmt.Errorf("networks %q and %q overlap", other.Name, network.Name)
+			}
+		}
+
+		blocks[network.Name] = block
+	}
+
+	return nil
+}
+
+// NamedNetworkAZSubnet returns the subnet of a named network for the
+// availability zone at index. The terraform templates apply the same
+// cidrsubnet rule.
+func NamedNetworkAZSubnet(network storage.NamedNetwork, index int) (CIDRBlock, error) {
+	block, err := ParseCIDRBlock(network.CIDR)
+	if err != nil {
+		return CIDRBlock{}, err
+	}
+
+	return block.Subnet(namedNetworkAZSubnetNewBits, index)
+}
@@ -0,0 +1,142 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NamedNetwork", func() {
+	Describe("ParseNamedNetwork", func() {
+		It("parses the name and cidr of the network", func() {
+			network, err := bosh.ParseNamedNetwork("iso-seg-1:10.0.128.0/20")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network).To(Equal(storage.NamedNetwork{Name: "iso-seg-1", CIDR: "10.0.128.0/20"}))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the network has no cidr", func() {
+				_, err := bosh.ParseNamedNetwork("services")
+				Expect(err).To(MatchError(`"services" is not a network, use name:cidr`))
+			})
+
+			It("returns an error when the name is not valid", func() {
+				_, err := bosh.ParseNamedNetwork("Services_1:10.0.128.0/20")
+				Expect(err).To(MatchError(`network name "Services_1" must start with a letter and only contain lowercase letters, digits and hyphens, up to 20 characters`))
+
+				_, err = bosh.ParseNamedNetwork("a-very-long-network-name:10.0.128.0/20")
+				Expect(err).To(MatchError(ContainSubstring("up to 20 characters")))
+			})
+
+			It("returns an error when the name is used by the cloud config", func() {
+				_, err := bosh.ParseNamedNetwork("default:10.0.128.0/20")
+				Expect(err).To(MatchError(`network name "default" is already used by the cloud config`))
+			})
+
+			It("returns an error when the cidr is not a network address", func() {
+				_, err := bosh.ParseNamedNetwork("services:10.0.128.1/20")
+				Expect(err).To(MatchError(`"10.0.128.1/20" is not a network address, did you mean "10.0.128.0/20"?`))
+			})
+
+			It("returns an error when the network is too small", func() {
+				_, err := bosh.ParseNamedNetwork("services:10.0.128.0/26")
+				Expect(err).To(MatchError(`"10.0.128.0/26" is too small, a named network must be at least a /25`))
+			})
+		})
+	})
+
+	Describe("CheckNamedNetworks", func() {
+		var plan bosh.NetworkPlan
+
+		BeforeEach(func() {
+			var err error
+			plan, err = bosh.NewNetworkPlan("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("on aws", func() {
+			It("accepts networks inside the network cidr", func() {
+				err := plan.CheckNamedNetworks("aws", []storage.NamedNetwork{
+					{Name: "services", CIDR: "10.0.128.0/20"},
+					{Name: "iso-seg", CIDR: "10.0.144.0/20"},
+				}, []string{"us-east-1a", "us-east-1b", "us-east-1c"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error when a network is outside the network cidr", func() {
+				err := plan.CheckNamedNetworks("aws", []storage.NamedNetwork{
+					{Name: "services", CIDR: "10.1.0.0/20"},
+				}, nil)
+				Expect(err).To(MatchError(`network "services" must be inside the network cidr 10.0.0.0/16`))
+			})
+
+			It("returns an error when a network overlaps the bosh subnets", func() {
+				err := plan.CheckNamedNetworks("aws", []storage.NamedNetwork{
+					{Name: "services", CIDR: "10.0.8.0/24"},
+				}, nil)
+				Expect(err).To(MatchError(`network "services" overlaps 10.0.0.0/20, which is used for the bosh, lb and nat subnets`))
+			})
+
+			It("returns an error when a network overlaps the subnet of an availability zone", func() {
+				err := plan.CheckNamedNetworks("aws", []storage.NamedNetwork{
+					{Name: "services", CIDR: "10.0.128.0/20"},
+					{Name: "iso-seg", CIDR: "10.0.48.0/20"},
+				}, []string{"us-east-1a", "us-east-1b", "us-east-1c"})
+				Expect(err).To(MatchError(`network "iso-seg" overlaps 10.0.48.0/20, which is the internal subnet of us-east-1c`))
+			})
+		})
+
+		Context("on gcp", func() {
+			It("accepts networks next to the network cidr", func() {
+				err := plan.CheckNamedNetworks("gcp", []storage.NamedNetwork{
+					{Name: "services", CIDR: "10.1.0.0/20"},
+				}, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error when a network overlaps the network cidr", func() {
+				err := plan.CheckNamedNetworks("gcp", []storage.NamedNetwork{
+					{Name: "services", CIDR: "10.0.128.0/20"},
+				}, nil)
+				Expect(err).To(MatchError(`network "services" must not overlap the network cidr 10.0.0.0/16`))
+			})
+		})
+
+		It("returns an error when a network is given twice", func() {
+			err := plan.CheckNamedNetworks("gcp", []storage.NamedNetwork{
+				{Name: "services", CIDR: "10.1.0.0/20"},
+				{Name: "services", CIDR: "10.2.0.0/20"},
+			}, nil)
+			Expect(err).To(MatchError(`network "services" is given more than once`))
+		})
+
+		It("returns an error when networks overlap", func() {
+			err := plan.CheckNamedNetworks("aws", []storage.NamedNetwork{
+				{Name: "services", CIDR: "10.0.128.0/20"},
+				{Name: "iso-seg", CIDR: "10.0.136.0/24"},
+			}, nil)
+			Expect(err).To(MatchError(`networks "services" and "iso-seg" overlap`))
+		})
+	})
+
+	Describe("NamedNetworkAZSubnet", func() {
+		It("splits the network into a subnet for each az", func() {
+			network := storage.NamedNetwork{Name: "services", CIDR: "10.0.128.0/20"}
+
+			subnet, err := bosh.NamedNetworkAZSubnet(network, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.String()).To(Equal("10.0.128.0/23"))
+
+			subnet, err = bosh.NamedNetworkAZSubnet(network, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.String()).To(Equal("10.0.132.0/23"))
+		})
+
+		It("returns an error when there are more azs than subnets", func() {
+			_, err := bosh.NamedNetworkAZSubnet(storage.NamedNetwork{Name: "services", CIDR: "10.0.128.0/20"}, 8)
+			Expect(err).To(MatchError("subnet 8 does not fit in 10.0.128.0/20 with 3 new bits"))
+		})
+	})
+})
@@ -0,0 +1,152 @@
+package bosh
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const (
+	MAX_NETWORK_MASK_BITS = 20
+
+	// MAX_SUBNETWORK_MASK_BITS is the smallest existing subnetwork bbl can
+	// plan on, such as one handed out by a Shared VPC host project.
+	MAX_SUBNETWORK_MASK_BITS = 24
+
+	// the bosh subnet and the AZ subnets of a small subnetwork are kept to
+	// at least a /28
+	maxSubnetMaskBits = 28
+
+	boshSubnetNewBits = 8
+	azSubnetNewBits   = 4
+	lbSubnetNewBits   = 8
+
+	// elastic load balancers need a subnet of at least a /27
+	awsMaxLBSubnetMaskBits = 27
+)
+
+// NetworkPlan derives the bosh subnet, per-AZ subnets and the jumpbox and
+// director addresses from the CIDR of the network bbl deploys into. The
+// terraform templates apply the same cidrsubnet rules.
+type NetworkPlan struct {
+	network    CIDRBlock
+	boshSubnet CIDRBlock
+}
+
+func NewNetworkPlan(cidr string) (NetworkPlan, error) {
+	network, err := parseNetworkCIDR(cidr)
+	if err != nil {
+		return NetworkPlan{}, err
+	}
+
+	if network.maskBits > MAX_NETWORK_MASK_BITS {
+		return NetworkPlan{}, fmt.Errorf("%q is too small, the network must be at least a /%d", cidr, MAX_NETWORK_MASK_BITS)
+	}
+
+	return newPlan(network)
+}
+
+// NewSubnetworkPlan plans the addresses of bbl inside the range of an existing
+// subnetwork, which may be smaller than a network bbl creates. The jumpbox and
+// director still get the 5th and 6th addresses of the range, so they have to
+// be checked for other VMs before the environment is created.
+func NewSubnetworkPlan(cidr string) (NetworkPlan, error) {
+	network, err := parseNetworkCIDR(cidr)
+	if err != nil {
+		return NetworkPlan{}, err
+	}
+
+	if network.maskBits > MAX_SUBNETWORK_MASK_BITS {
+		return NetworkPlan{}, fmt.Errorf("%q is too small, the subnetwork must be at least a /%d", cidr, MAX_SUBNETWORK_MASK_BITS)
+	}
+
+	return newPlan(network)
+}
+
+// PlanNetwork returns the plan of the network of state, which is the range
+// of the subnetwork for an existing GCP network.
+func PlanNetwork(state storage.State) (NetworkPlan, error) {
+	if state.GCP.Network != "" {
+		return NewSubnetworkPlan(state.Network.GetCIDR())
+	}
+	return NewNetworkPlan(state.Network.GetCIDR())
+}
+
+func newPlan(network CIDRBlock) (NetworkPlan, error) {
+	newBits := boshSubnetNewBits
+	if network.maskBits+newBits > maxSubnetMaskBits {
+		newBits = maxSubnetMaskBits - network.maskBits
+	}
+
+	boshSubnet, err := network.Subnet(newBits, 0)
+	if err != nil {
+		return NetworkPlan{}, err // not tested
+	}
+
+	return NetworkPlan{
+		network:    network,
+		boshSubnet: boshSubnet,
+	}, nil
+}
+
+// MaxNetworkMaskBits returns the mask bits of the smallest network bbl can
+// plan on iaas. On AWS the load balancer subnets are carved out of the
+// network and have to be big enough for an elastic load balancer.
+func MaxNetworkMaskBits(iaas string) int {
+	if iaas == "aws" {
+		return awsMaxLBSubnetMaskBits - lbSubnetNewBits
+	}
+	return MAX_NETWORK_MASK_BITS
+}
+
+// CheckIAAS returns an error when the subnets of the plan are too small for
+// iaas.
+func (n NetworkPlan) CheckIAAS(iaas string) error {
+	maxMaskBits := MaxNetworkMaskBits(iaas)
+	if n.network.maskBits > maxMaskBits {
+		return fmt.Errorf("%q is too small, the network must be at least a /%d on %s", n.network, maxMaskBits, iaas)
+	}
+	return nil
+}
+
+func (n NetworkPlan) NetworkCIDR() string {
+	return n.network.String()
+}
+
+func (n NetworkPlan) InternalCIDR() string {
+	return n.boshSubnet.String()
+}
+
+func (n NetworkPlan) InternalGW() string {
+	return n.boshSubnet.GetFirstIP().Add(1).String()
+}
+
+func (n NetworkPlan) JumpboxIP() string {
+	return n.boshSubnet.GetFirstIP().Add(5).String()
+}
+
+func (n NetworkPlan) DirectorIP() string {
+	return n.boshSubnet.GetFirstIP().Add(6).String()
+}
+
+// AZSubnet returns the internal subnet for the availability zone at index.
+// The first block of the network is left for the bosh and lb subnets.
+func (n NetworkPlan) AZSubnet(index int) (CIDRBlock, error) {
+	return n.network.Subnet(azSubnetNewBits, index+1)
+}
+
+// parseNetworkCIDR parses cidr, which must be the network address of an
+// IPv4 CIDR block.
+func parseNetworkCIDR(cidr string) (CIDRBlock, error) {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return CIDRBlock{}, fmt.Errorf("%q is not a valid IPv4 CIDR block", cidr)
+	}
+
+	if !ip.Equal(ipNet.IP) {
+		return CIDRBlock{}, fmt.Errorf("%q is not a network address, did you mean %q?", cidr, ipNet.String())
+	}
+
+	return ParseCIDRBlock(cidr)
+}
@@ -0,0 +1,120 @@
+package bosh_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPlan", func() {
+	Context("when the default network cidr is used", func() {
+		It("derives the same ranges and ips bbl has always used", func() {
+			plan, err := bosh.NewNetworkPlan("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.NetworkCIDR()).To(Equal("10.0.0.0/16"))
+			Expect(plan.InternalCIDR()).To(Equal("10.0.0.0/24"))
+			Expect(plan.InternalGW()).To(Equal("10.0.0.1"))
+			Expect(plan.JumpboxIP()).To(Equal("10.0.0.5"))
+			Expect(plan.DirectorIP()).To(Equal("10.0.0.6"))
+
+			subnet, err := plan.AZSubnet(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.String()).To(Equal("10.0.16.0/20"))
+
+			subnet, err = plan.AZSubnet(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.String()).To(Equal("10.0.48.0/20"))
+		})
+	})
+
+	Context("when a custom network cidr is used", func() {
+		It("derives the ranges and ips from the network cidr", func() {
+			plan, err := bosh.NewNetworkPlan("172.20.64.0/20")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.InternalCIDR()).To(Equal("172.20.64.0/28"))
+			Expect(plan.InternalGW()).To(Equal("172.20.64.1"))
+			Expect(plan.JumpboxIP()).To(Equal("172.20.64.5"))
+			Expect(plan.DirectorIP()).To(Equal("172.20.64.6"))
+
+			subnet, err := plan.AZSubnet(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.String()).To(Equal("172.20.66.0/24"))
+		})
+	})
+
+	Context("when an existing subnetwork is used", func() {
+		It("keeps the bosh subnet to at least a /28 in a /24", func() {
+			plan, err := bosh.NewSubnetworkPlan("10.0.0.0/24")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.InternalCIDR()).To(Equal("10.0.0.0/28"))
+			Expect(plan.InternalGW()).To(Equal("10.0.0.1"))
+			Expect(plan.JumpboxIP()).To(Equal("10.0.0.5"))
+			Expect(plan.DirectorIP()).To(Equal("10.0.0.6"))
+
+			subnet, err := plan.AZSubnet(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnet.String()).To(Equal("10.0.0.16/28"))
+		})
+
+		It("returns an error when the subnetwork is too small", func() {
+			_, err := bosh.NewSubnetworkPlan("10.0.0.0/25")
+			Expect(err).To(MatchError(`"10.0.0.0/25" is too small, the subnetwork must be at least a /24`))
+		})
+	})
+
+	Describe("PlanNetwork", func() {
+		It("plans the range of the subnetwork of an existing gcp network", func() {
+			plan, err := bosh.PlanNetwork(storage.State{
+				GCP:     storage.GCP{Network: "some-network"},
+				Network: storage.Network{CIDR: "10.0.0.0/24"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.InternalCIDR()).To(Equal("10.0.0.0/28"))
+		})
+
+		It("plans the network cidr otherwise", func() {
+			_, err := bosh.PlanNetwork(storage.State{
+				Network: storage.Network{CIDR: "10.0.0.0/24"},
+			})
+			Expect(err).To(MatchError(`"10.0.0.0/24" is too small, the network must be at least a /20`))
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the cidr is not valid", func() {
+			_, err := bosh.NewNetworkPlan("not-a-cidr")
+			Expect(err).To(MatchError(`"not-a-cidr" is not a valid IPv4 CIDR block`))
+		})
+
+		It("returns an error when the cidr is not a network address", func() {
+			_, err := bosh.NewNetworkPlan("10.1.2.3/16")
+			Expect(err).To(MatchError(`"10.1.2.3/16" is not a network address, did you mean "10.1.0.0/16"?`))
+		})
+
+		It("returns an error when the network is too small", func() {
+			_, err := bosh.NewNetworkPlan("10.0.0.0/24")
+			Expect(err).To(MatchError(`"10.0.0.0/24" is too small, the network must be at least a /20`))
+		})
+
+		It("returns an error when the load balancer subnets would be too small for AWS", func() {
+			plan, err := bosh.NewNetworkPlan("10.0.0.0/20")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(plan.CheckIAAS("gcp")).To(Succeed())
+			Expect(plan.CheckIAAS("aws")).To(MatchError(`"10.0.0.0/20" is too small, the network must be at least a /19 on aws`))
+		})
+
+		It("returns an error when there are more azs than subnets", func() {
+			plan, err := bosh.NewNetworkPlan("10.0.0.0/16")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = plan.AZSubnet(15)
+			Expect(err).To(MatchError("subnet 16 does not fit in 10.0.0.0/16 with 4 new bits"))
+		})
+	})
+})
@@ -0,0 +1,159 @@
+package bosh
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"golang.org/x/net/proxy"
+)
+
+var STATUS_TIMEOUT = 10 * time.Second
+
+const (
+	uaaPort     = "8443"
+	credhubPort = "8844"
+)
+
+type ComponentStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+type jumpboxDialer interface {
+	Dialer(jumpbox storage.Jumpbox) (proxy.Dialer, error)
+}
+
+// StatusChecker reports whether each component of an environment is
+// reachable. Every request is made once, without the retries the director
+// client uses, so that it can be polled.
+type StatusChecker struct {
+	jumpboxDialer jumpboxDialer
+}
+
+func NewStatusChecker(jumpboxDialer jumpboxDialer) StatusChecker {
+	return StatusChecker{
+		jumpboxDialer: jumpboxDialer,
+	}
+}
+
+func (s StatusChecker) Check(state storage.State) []ComponentStatus {
+	statuses := []ComponentStatus{terraformStatus(state)}
+	if state.NoDirector {
+		return statuses
+	}
+
+	var dialer proxy.Dialer = proxy.Direct
+	if state.Jumpbox.URL != "" {
+		var err error
+		dialer, err = s.jumpboxDialer.Dialer(state.Jumpbox)
+		if err != nil {
+			statuses = append(statuses, ComponentStatus{Name: "jumpbox", Message: err.Error()})
+			for _, name := range directorComponents(state) {
+				statuses = append(statuses, ComponentStatus{Name: name, Message: "jumpbox is unreachable"})
+			}
+			return statuses
+		}
+		statuses = append(statuses, ComponentStatus{Name: "jumpbox", Healthy: true, Message: state.Jumpbox.URL})
+	}
+
+	directorURL, err := url.Parse(state.BOSH.DirectorAddress)
+	if err != nil || directorURL.Hostname() == "" {
+		for _, name := range directorComponents(state) {
+			statuses = append(statuses, ComponentStatus{Name: name, Message: fmt.Sprintf("invalid director address %q", state.BOSH.DirectorAddress)})
+		}
+		return statuses
+	}
+	host := directorURL.Hostname()
+
+	caCerts := state.BOSH.DirectorSSLCA + credhubCA(state.BOSH.Variables)
+	httpClient := ClientProvider{}.HTTPClient(dialer, []byte(caCerts))
+	httpClient.Timeout = STATUS_TIMEOUT
+
+	for _, name := range directorComponents(state) {
+		switch name {
+		case "director":
+			statuses = append(statuses, directorStatus(httpClient, state.BOSH.DirectorAddress))
+		case "uaa":
+			statuses = append(statuses, endpointStatus(httpClient, name, fmt.Sprintf("https://%s/info", net.JoinHostPort(host, uaaPort))))
+		case "credhub":
+			statuses = append(statuses, endpointStatus(httpClient, name, fmt.Sprintf("https://%s/health", net.JoinHostPort(host, credhubPort))))
+		}
+	}
+
+	return statuses
+}
+
+// directorComponents lists the services running on the director. bbl only
+// deploys UAA and CredHub alongside the director on aws and gcp.
+func directorComponents(state storage.State) []string {
+	if state.IAAS == "azure" {
+		return []string{"director"}
+	}
+	return []string{"director", "uaa", "credhub"}
+}
+
+func terraformStatus(state storage.State) ComponentStatus {
+	if state.TFState == "" {
+		return ComponentStatus{Name: "terraform", Message: "no terraform state"}
+	}
+	return ComponentStatus{Name: "terraform", Healthy: true, Message: "terraform state present"}
+}
+
+func directorStatus(httpClient *http.Client, directorAddress string) ComponentStatus {
+	response, err := httpClient.Get(fmt.Sprintf("%s/info", directorAddress))
+	if err != nil {
+		return ComponentStatus{Name: "director", Message: err.Error()}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return ComponentStatus{Name: "director", Message: fmt.Sprintf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))}
+	}
+
+	var info Info
+	err = json.NewDecoder(response.Body).Decode(&info)
+	if err != nil {
+		return ComponentStatus{Name: "director", Message: fmt.Sprintf("decode info: %s", err)}
+	}
+
+	return ComponentStatus{Name: "director", Healthy: true, Message: fmt.Sprintf("%s (version %s)", info.Name, info.Version)}
+}
+
+func endpointStatus(httpClient *http.Client, name, endpoint string) ComponentStatus {
+	response, err := httpClient.Get(endpoint)
+	if err != nil {
+		return ComponentStatus{Name: name, Message: err.Error()}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return ComponentStatus{Name: name, Message: fmt.Sprintf("unexpected http response %d %s", response.StatusCode, http.StatusText(response.StatusCode))}
+	}
+
+	return ComponentStatus{Name: name, Healthy: true}
+}
+
+// credhubCA returns the CA that signs the CredHub certificate, which is not
+// the CA that signs the director certificate.
+func credhubCA(vars string) string {
+	var variables struct {
+		CredhubCA struct {
+			Certificate string `yaml:"certificate"`
+		} `yaml:"credhub_ca"`
+	}
+
+	err := yaml.Unmarshal([]byte(vars), &variables)
+	if err != nil {
+		return ""
+	}
+
+	return variables.CredhubCA.Certificate
+}
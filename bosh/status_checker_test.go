@@ -0,0 +1,149 @@
+package bosh_test
+
+import (
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// portDialer sends connections for each port to a test server.
+type portDialer map[string]string
+
+func (p portDialer) Dial(network, addr string) (net.Conn, error) {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	target, ok := p[port]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+
+	return net.Dial(network, target)
+}
+
+var _ = Describe("StatusChecker", func() {
+	var (
+		clientProvider *fakes.BOSHClientProvider
+		statusChecker  bosh.StatusChecker
+
+		director *httptest.Server
+		uaa      *httptest.Server
+		credhub  *httptest.Server
+
+		state storage.State
+	)
+
+	serverHost := func(server *httptest.Server) string {
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		return serverURL.Host
+	}
+
+	BeforeEach(func() {
+		director = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/info"))
+			w.Write([]byte(`{"name": "bosh-some-env", "version": "262.3.0"}`))
+		}))
+		uaa = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/info"))
+		}))
+		credhub = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/health"))
+		}))
+
+		clientProvider = &fakes.BOSHClientProvider{}
+		clientProvider.DialerCall.Returns.Dialer = portDialer{
+			"25555": serverHost(director),
+			"8443":  serverHost(uaa),
+			"8844":  serverHost(credhub),
+		}
+
+		// httptest servers share a self-signed certificate for 127.0.0.1
+		caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: director.Certificate().Raw}))
+
+		state = storage.State{
+			IAAS:    "gcp",
+			TFState: "some-tf-state",
+			Jumpbox: storage.Jumpbox{
+				URL: "some-jumpbox-url:22",
+			},
+			BOSH: storage.BOSH{
+				DirectorAddress: "https://127.0.0.1:25555",
+				DirectorSSLCA:   caCert,
+			},
+		}
+
+		statusChecker = bosh.NewStatusChecker(clientProvider)
+	})
+
+	AfterEach(func() {
+		director.Close()
+		uaa.Close()
+		credhub.Close()
+	})
+
+	It("reports every component as healthy", func() {
+		statuses := statusChecker.Check(state)
+
+		Expect(clientProvider.DialerCall.Receives.Jumpbox).To(Equal(state.Jumpbox))
+		Expect(statuses).To(Equal([]bosh.ComponentStatus{
+			{Name: "terraform", Healthy: true, Message: "terraform state present"},
+			{Name: "jumpbox", Healthy: true, Message: "some-jumpbox-url:22"},
+			{Name: "director", Healthy: true, Message: "bosh-some-env (version 262.3.0)"},
+			{Name: "uaa", Healthy: true},
+			{Name: "credhub", Healthy: true},
+		}))
+	})
+
+	It("reports components that cannot be reached or are unhealthy", func() {
+		state.TFState = ""
+		uaa.Close()
+		credhub.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		statuses := statusChecker.Check(state)
+
+		Expect(statuses[0]).To(Equal(bosh.ComponentStatus{Name: "terraform", Message: "no terraform state"}))
+		Expect(statuses[3].Healthy).To(BeFalse())
+		Expect(statuses[3].Message).To(ContainSubstring("connection refused"))
+		Expect(statuses[4]).To(Equal(bosh.ComponentStatus{Name: "credhub", Message: "unexpected http response 503 Service Unavailable"}))
+	})
+
+	It("reports the director components as unreachable when the jumpbox is", func() {
+		clientProvider.DialerCall.Returns.Error = errors.New("start proxy: ssh: handshake failed")
+
+		statuses := statusChecker.Check(state)
+
+		Expect(statuses).To(Equal([]bosh.ComponentStatus{
+			{Name: "terraform", Healthy: true, Message: "terraform state present"},
+			{Name: "jumpbox", Message: "start proxy: ssh: handshake failed"},
+			{Name: "director", Message: "jumpbox is unreachable"},
+			{Name: "uaa", Message: "jumpbox is unreachable"},
+			{Name: "credhub", Message: "jumpbox is unreachable"},
+		}))
+	})
+
+	It("only checks terraform when there is no director", func() {
+		state.NoDirector = true
+
+		statuses := statusChecker.Check(state)
+
+		Expect(statuses).To(Equal([]bosh.ComponentStatus{
+			{Name: "terraform", Healthy: true, Message: "terraform state present"},
+		}))
+		Expect(clientProvider.DialerCall.CallCount).To(Equal(0))
+	})
+})
@@ -0,0 +1,260 @@
+package bosh
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// LatestStemcell uploads the latest bosh.io stemcell for the IAAS.
+	LatestStemcell = "latest"
+
+	// RuntimeConfigName is the name of the runtime config bbl manages on the
+	// director. Runtime configs uploaded under other names are merged with it.
+	RuntimeConfigName = "bbl"
+)
+
+// StemcellNames are the names of the bosh.io stemcells, by IAAS, for a
+// director whose manifest does not name a bosh.io stemcell.
+var StemcellNames = map[string]string{
+	"aws":   "bosh-aws-xen-hvm-ubuntu-trusty-go_agent",
+	"azure": "bosh-azure-hyperv-ubuntu-trusty-go_agent",
+	"gcp":   "bosh-google-kvm-ubuntu-trusty-go_agent",
+}
+
+// RuntimeConfigs are the built-in runtime configs, by name, as paths in
+// bosh-deployment.
+var RuntimeConfigs = map[string]string{
+	"bosh-dns": "runtime-configs/dns.yml",
+}
+
+// configServerVariable matches an absolute variable, such as
+// ((/dns_healthcheck_tls_ca)), which the director reads from CredHub.
+var configServerVariable = regexp.MustCompile(`\(\(/[^()]+\)\)`)
+
+type clientProvider interface {
+	Client(jumpbox storage.Jumpbox, directorAddress, directorUsername, directorPassword, directorCACert string) (Client, error)
+}
+
+// Uploader uploads stemcells and the runtime config to the director, so that
+// a new environment can be deployed to right away.
+type Uploader struct {
+	clientProvider clientProvider
+	logger         logger
+}
+
+func NewUploader(clientProvider clientProvider, logger logger) Uploader {
+	return Uploader{
+		clientProvider: clientProvider,
+		logger:         logger,
+	}
+}
+
+// UploadStemcell uploads "latest", the url of a stemcell or the path to a
+// stemcell tarball.
+func (u Uploader) UploadStemcell(state storage.State, stemcell string) error {
+	boshClient, err := u.client(state)
+	if err != nil {
+		return err
+	}
+
+	if stemcell == LatestStemcell {
+		stemcell = latestStemcellURL(state)
+	}
+
+	u.logger.Step("uploading stemcell %s", stemcell)
+
+	var taskID int
+	if IsURL(stemcell) {
+		taskID, err = boshClient.UploadRemoteStemcell(stemcell)
+	} else {
+		taskID, err = uploadStemcellFile(boshClient, stemcell)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = boshClient.WaitForTask(taskID, func(event TaskEvent) {
+		u.logger.Println(event.String())
+	})
+	return err
+}
+
+// latestStemcellURL returns the bosh.io url of the latest version of the
+// stemcell the director runs on, so that deployments get the same line of
+// stemcell, such as trusty or xenial, as bosh-deployment picked for it.
+func latestStemcellURL(state storage.State) string {
+	name := StemcellNames[state.IAAS]
+
+	versions, err := ManifestVersions(state.BOSH.Manifest)
+	if err == nil {
+		stemcellURL, err := url.Parse(versions.Stemcell.URL)
+		if err == nil && stemcellURL.Host == "bosh.io" && path.Dir(stemcellURL.Path) == "/d/stemcells" {
+			name = path.Base(stemcellURL.Path)
+		}
+	}
+
+	return fmt.Sprintf("https://bosh.io/d/stemcells/%s", name)
+}
+
+func uploadStemcellFile(boshClient Client, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err //not tested
+	}
+
+	return boshClient.UploadStemcell(file, info.Size())
+}
+
+// UpdateRuntimeConfig uploads the releases of the runtime config of state
+// that the director does not have yet and applies it, if there is one.
+func (u Uploader) UpdateRuntimeConfig(state storage.State) error {
+	runtimeConfig := state.BOSH.RuntimeConfig
+	if runtimeConfig.Name == "" {
+		return nil
+	}
+
+	contents, err := runtimeConfigContents(runtimeConfig, state.BOSH.DeploymentSource)
+	if err != nil {
+		return err
+	}
+
+	err = CheckRuntimeConfig(state.IAAS, storage.RuntimeConfig{Name: runtimeConfig.Name, Contents: string(contents)})
+	if err != nil {
+		return err
+	}
+
+	boshClient, err := u.client(state)
+	if err != nil {
+		return err
+	}
+
+	err = u.uploadReleases(boshClient, contents)
+	if err != nil {
+		return err
+	}
+
+	u.logger.Step("applying runtime config %s", runtimeConfig.Name)
+	return boshClient.UpdateConfig("runtime", RuntimeConfigName, contents)
+}
+
+// uploadReleases has the director download every release of the runtime
+// config that has a url, unless it already has that version. Releases
+// without a url must have been uploaded already.
+func (u Uploader) uploadReleases(boshClient Client, runtimeConfig []byte) error {
+	var parsed struct {
+		Releases []ReleaseVersion `yaml:"releases"`
+	}
+	err := yaml.Unmarshal(runtimeConfig, &parsed)
+	if err != nil {
+		return fmt.Errorf("parse runtime config: %s", err)
+	}
+
+	var uploaded []Release
+	for _, release := range parsed.Releases {
+		if release.URL == "" {
+			continue
+		}
+
+		if uploaded == nil {
+			uploaded, err = boshClient.Releases()
+			if err != nil {
+				return err
+			}
+		}
+
+		if hasRelease(uploaded, release) {
+			continue
+		}
+
+		u.logger.Step("uploading release %s/%s", release.Name, release.Version)
+		taskID, err := boshClient.UploadRemoteRelease(release.URL, release.SHA1)
+		if err != nil {
+			return err
+		}
+
+		_, err = boshClient.WaitForTask(taskID, func(event TaskEvent) {
+			u.logger.Println(event.String())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasRelease(releases []Release, release ReleaseVersion) bool {
+	for _, r := range releases {
+		if r.Name != release.Name {
+			continue
+		}
+		for _, v := range r.ReleaseVersions {
+			if v.Version == release.Version {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckRuntimeConfig returns an error for a runtime config that uses
+// absolute variables on azure. The director reads them from CredHub, which
+// bbl only deploys on aws and gcp. The built-in runtime configs all use them.
+func CheckRuntimeConfig(iaas string, runtimeConfig storage.RuntimeConfig) error {
+	if iaas != "azure" {
+		return nil
+	}
+
+	_, builtIn := RuntimeConfigs[runtimeConfig.Name]
+	if (builtIn && runtimeConfig.Contents == "") || configServerVariable.MatchString(runtimeConfig.Contents) {
+		return fmt.Errorf("runtime config %s uses CredHub variables, but bbl does not deploy CredHub on azure", runtimeConfig.Name)
+	}
+
+	return nil
+}
+
+func (u Uploader) client(state storage.State) (Client, error) {
+	return u.clientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+}
+
+// IsURL reports whether stemcell is downloaded by the director rather than
+// read from a local file.
+func IsURL(stemcell string) bool {
+	return strings.HasPrefix(stemcell, "https://") || strings.HasPrefix(stemcell, "http://")
+}
+
+// runtimeConfigContents reads a built-in runtime config from the same
+// bosh-deployment the director was created from.
+func runtimeConfigContents(runtimeConfig storage.RuntimeConfig, source storage.DeploymentSource) ([]byte, error) {
+	if runtimeConfig.Contents != "" {
+		return []byte(runtimeConfig.Contents), nil
+	}
+
+	path, ok := RuntimeConfigs[runtimeConfig.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown runtime config %q", runtimeConfig.Name)
+	}
+
+	if source.Source == "" || source.Source == VendoredSource {
+		return MustAsset(filepath.Join(boshDeploymentVendorDir, path)), nil
+	}
+
+	return ioutil.ReadFile(filepath.Join(source.Source, path))
+}
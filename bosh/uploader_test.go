@@ -0,0 +1,315 @@
+package bosh_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Uploader", func() {
+	var (
+		boshClient         *fakes.BOSHClient
+		boshClientProvider *fakes.BOSHClientProvider
+		logger             *fakes.Logger
+		uploader           bosh.Uploader
+		state              storage.State
+	)
+
+	BeforeEach(func() {
+		boshClient = &fakes.BOSHClient{}
+		boshClientProvider = &fakes.BOSHClientProvider{}
+		boshClientProvider.ClientCall.Returns.Client = boshClient
+		logger = &fakes.Logger{}
+
+		uploader = bosh.NewUploader(boshClientProvider, logger)
+
+		state = storage.State{
+			IAAS: "gcp",
+			Jumpbox: storage.Jumpbox{
+				URL: "some-jumpbox-url",
+			},
+			BOSH: storage.BOSH{
+				DirectorAddress:  "some-director-address",
+				DirectorUsername: "some-director-username",
+				DirectorPassword: "some-director-password",
+				DirectorSSLCA:    "some-director-ca",
+			},
+		}
+	})
+
+	Describe("UploadStemcell", func() {
+		It("has the director download the latest bosh.io stemcell for the iaas", func() {
+			err := uploader.UploadStemcell(state, "latest")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClientProvider.ClientCall.Receives.Jumpbox.URL).To(Equal("some-jumpbox-url"))
+			Expect(boshClientProvider.ClientCall.Receives.DirectorAddress).To(Equal("some-director-address"))
+			Expect(boshClientProvider.ClientCall.Receives.DirectorCACert).To(Equal("some-director-ca"))
+
+			Expect(boshClient.UploadRemoteStemcellCall.Receives.URL).To(Equal("https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-trusty-go_agent"))
+			Expect(logger.StepCall.Messages).To(Equal([]string{"uploading stemcell https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-trusty-go_agent"}))
+		})
+
+		It("waits for the task and prints its events", func() {
+			boshClient.UploadRemoteStemcellCall.Returns.TaskID = 12
+			boshClient.WaitForTaskCall.Returns.Events = []bosh.TaskEvent{
+				{Time: 1500000000, Stage: "Update stemcell", Task: "Extracting stemcell archive", Index: 1, Total: 5, State: "started"},
+			}
+
+			err := uploader.UploadStemcell(state, "latest")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClient.WaitForTaskCall.Receives.ID).To(Equal(12))
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+				"02:40:00 | Update stemcell: Extracting stemcell archive (1/5) started",
+			}))
+		})
+
+		It("has the director download the latest version of the stemcell the director runs on", func() {
+			state.BOSH.Manifest = `resource_pools:
+- name: vms
+  stemcell:
+    url: https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-xenial-go_agent?v=97.12
+    sha1: some-sha1
+`
+
+			err := uploader.UploadStemcell(state, "latest")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClient.UploadRemoteStemcellCall.Receives.URL).To(Equal("https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-xenial-go_agent"))
+		})
+
+		It("has the director download a stemcell url", func() {
+			err := uploader.UploadStemcell(state, "https://example.com/some-stemcell.tgz")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClient.UploadRemoteStemcellCall.Receives.URL).To(Equal("https://example.com/some-stemcell.tgz"))
+			Expect(boshClient.UploadStemcellCall.CallCount).To(Equal(0))
+		})
+
+		It("uploads a local stemcell tarball", func() {
+			stemcell, err := ioutil.TempFile("", "stemcell")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.Remove(stemcell.Name())
+
+			_, err = stemcell.WriteString("some-stemcell")
+			Expect(err).NotTo(HaveOccurred())
+
+			boshClient.UploadStemcellCall.Returns.TaskID = 13
+
+			err = uploader.UploadStemcell(state, stemcell.Name())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClient.UploadStemcellCall.Receives.Stemcell).To(Equal([]byte("some-stemcell")))
+			Expect(boshClient.UploadStemcellCall.Receives.Size).To(Equal(int64(13)))
+			Expect(boshClient.WaitForTaskCall.Receives.ID).To(Equal(13))
+			Expect(boshClient.UploadRemoteStemcellCall.CallCount).To(Equal(0))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the client cannot be created", func() {
+				boshClientProvider.ClientCall.Returns.Error = errors.New("failed to start proxy")
+
+				err := uploader.UploadStemcell(state, "latest")
+				Expect(err).To(MatchError("failed to start proxy"))
+			})
+
+			It("returns an error when the stemcell cannot be read", func() {
+				err := uploader.UploadStemcell(state, "/some/missing/stemcell.tgz")
+				Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+			})
+
+			It("returns an error when the upload fails", func() {
+				boshClient.UploadRemoteStemcellCall.Returns.Error = errors.New("unexpected http response 400 Bad Request")
+
+				err := uploader.UploadStemcell(state, "latest")
+				Expect(err).To(MatchError("unexpected http response 400 Bad Request"))
+				Expect(boshClient.WaitForTaskCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the task fails", func() {
+				boshClient.WaitForTaskCall.Returns.Error = bosh.TaskError{Task: bosh.Task{ID: 1, State: "error", Result: "some-result"}}
+
+				err := uploader.UploadStemcell(state, "latest")
+				Expect(err).To(MatchError("task 1 error: some-result"))
+			})
+		})
+	})
+
+	Describe("UpdateRuntimeConfig", func() {
+		It("does nothing without a runtime config", func() {
+			err := uploader.UpdateRuntimeConfig(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClientProvider.ClientCall.CallCount).To(Equal(0))
+		})
+
+		It("applies the bosh-dns runtime config of the vendored bosh-deployment", func() {
+			state.BOSH.RuntimeConfig = storage.RuntimeConfig{Name: "bosh-dns"}
+
+			err := uploader.UpdateRuntimeConfig(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClient.UpdateConfigCall.Receives.Type).To(Equal("runtime"))
+			Expect(boshClient.UpdateConfigCall.Receives.Name).To(Equal("bbl"))
+			Expect(string(boshClient.UpdateConfigCall.Receives.Content)).To(ContainSubstring("release: bosh-dns"))
+			Expect(boshClient.UploadRemoteReleaseCall.CallCount).To(Equal(1))
+			Expect(logger.StepCall.Messages).To(Equal([]string{
+				"uploading release bosh-dns/0.0.8",
+				"applying runtime config bosh-dns",
+			}))
+		})
+
+		Context("when the runtime config has releases", func() {
+			BeforeEach(func() {
+				state.BOSH.RuntimeConfig = storage.RuntimeConfig{
+					Name: "addons.yml",
+					Contents: `releases:
+- name: some-release
+  version: 1.2.3
+  url: https://example.com/some-release.tgz
+  sha1: some-sha1
+- name: other-release
+  version: 4.5.6
+  url: https://example.com/other-release.tgz
+  sha1: other-sha1
+- name: local-release
+  version: 7.8.9
+`,
+				}
+			})
+
+			It("uploads every release with a url and waits for it before applying the runtime config", func() {
+				boshClient.UploadRemoteReleaseCall.Returns.TaskID = 14
+				boshClient.WaitForTaskCall.Returns.Events = []bosh.TaskEvent{
+					{Time: 1500000000, Stage: "Extracting release", Task: "Extracting release", Index: 1, Total: 1, State: "started"},
+				}
+
+				err := uploader.UpdateRuntimeConfig(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClient.UploadRemoteReleaseCall.Receives).To(Equal([]fakes.UploadRemoteReleaseCallReceive{
+					{URL: "https://example.com/some-release.tgz", SHA1: "some-sha1"},
+					{URL: "https://example.com/other-release.tgz", SHA1: "other-sha1"},
+				}))
+				Expect(boshClient.WaitForTaskCall.CallCount).To(Equal(2))
+				Expect(boshClient.WaitForTaskCall.Receives.ID).To(Equal(14))
+				Expect(logger.PrintlnCall.Messages).To(HaveLen(2))
+				Expect(logger.StepCall.Messages).To(Equal([]string{
+					"uploading release some-release/1.2.3",
+					"uploading release other-release/4.5.6",
+					"applying runtime config addons.yml",
+				}))
+			})
+
+			It("skips releases the director already has", func() {
+				boshClient.ReleasesCall.Returns.Releases = []bosh.Release{
+					{Name: "some-release", ReleaseVersions: []bosh.UploadedVersion{{Version: "1.2.3"}}},
+				}
+
+				err := uploader.UpdateRuntimeConfig(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClient.UploadRemoteReleaseCall.Receives).To(Equal([]fakes.UploadRemoteReleaseCallReceive{
+					{URL: "https://example.com/other-release.tgz", SHA1: "other-sha1"},
+				}))
+			})
+
+			It("returns an error when the releases cannot be listed", func() {
+				boshClient.ReleasesCall.Returns.Error = errors.New("unexpected http response 500 Internal Server Error")
+
+				err := uploader.UpdateRuntimeConfig(state)
+				Expect(err).To(MatchError("unexpected http response 500 Internal Server Error"))
+				Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when a release cannot be uploaded", func() {
+				boshClient.WaitForTaskCall.Returns.Error = bosh.TaskError{Task: bosh.Task{ID: 14, State: "error", Result: "some-result"}}
+
+				err := uploader.UpdateRuntimeConfig(state)
+				Expect(err).To(MatchError("task 14 error: some-result"))
+				Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+			})
+		})
+
+		It("reads the bosh-dns runtime config from a local bosh-deployment", func() {
+			dir, err := ioutil.TempDir("", "bosh-deployment")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			err = os.MkdirAll(filepath.Join(dir, "runtime-configs"), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(dir, "runtime-configs", "dns.yml"), []byte("some-local: dns"), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			state.BOSH.RuntimeConfig = storage.RuntimeConfig{Name: "bosh-dns"}
+			state.BOSH.DeploymentSource = storage.DeploymentSource{Source: dir}
+
+			err = uploader.UpdateRuntimeConfig(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(boshClient.UpdateConfigCall.Receives.Content)).To(Equal("some-local: dns"))
+		})
+
+		It("applies the contents of a runtime config read from a file", func() {
+			state.BOSH.RuntimeConfig = storage.RuntimeConfig{Name: "addons.yml", Contents: "addons: []"}
+
+			err := uploader.UpdateRuntimeConfig(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(boshClient.UpdateConfigCall.Receives.Content)).To(Equal("addons: []"))
+		})
+
+		Context("failure cases", func() {
+			It("returns an error for an unknown runtime config", func() {
+				state.BOSH.RuntimeConfig = storage.RuntimeConfig{Name: "some-runtime-config"}
+
+				err := uploader.UpdateRuntimeConfig(state)
+				Expect(err).To(MatchError(`unknown runtime config "some-runtime-config"`))
+			})
+
+			It("returns an error for a runtime config with CredHub variables on azure", func() {
+				state.IAAS = "azure"
+				state.BOSH.RuntimeConfig = storage.RuntimeConfig{Name: "bosh-dns"}
+
+				err := uploader.UpdateRuntimeConfig(state)
+				Expect(err).To(MatchError("runtime config bosh-dns uses CredHub variables, but bbl does not deploy CredHub on azure"))
+				Expect(boshClientProvider.ClientCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the runtime config cannot be applied", func() {
+				state.BOSH.RuntimeConfig = storage.RuntimeConfig{Name: "bosh-dns"}
+				boshClient.UpdateConfigCall.Returns.Error = errors.New("unexpected http response 500 Internal Server Error")
+
+				err := uploader.UpdateRuntimeConfig(state)
+				Expect(err).To(MatchError("unexpected http response 500 Internal Server Error"))
+			})
+		})
+	})
+})
+
+var _ = Describe("CheckRuntimeConfig", func() {
+	It("allows runtime configs with CredHub variables on aws and gcp", func() {
+		err := bosh.CheckRuntimeConfig("gcp", storage.RuntimeConfig{Name: "bosh-dns"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("allows runtime configs without CredHub variables on azure", func() {
+		err := bosh.CheckRuntimeConfig("azure", storage.RuntimeConfig{Name: "addons.yml", Contents: "addons: [((some-variable))]"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns an error for a runtime config with CredHub variables on azure", func() {
+		err := bosh.CheckRuntimeConfig("azure", storage.RuntimeConfig{Name: "addons.yml", Contents: "ca: ((/some-ca.ca))"})
+		Expect(err).To(MatchError("runtime config addons.yml uses CredHub variables, but bbl does not deploy CredHub on azure"))
+	})
+})
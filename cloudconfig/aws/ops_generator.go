@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	yaml "gopkg.in/yaml.v2"
@@ -65,6 +66,35 @@ type lbCloudProperties struct {
 	SecurityGroups []string `yaml:"security_groups"`
 }
 
+type vmType struct {
+	Name            string
+	CloudProperties vmTypeCloudProperties `yaml:"cloud_properties"`
+}
+
+type vmTypeCloudProperties struct {
+	InstanceType        string        `yaml:"instance_type"`
+	EphemeralDisk       ephemeralDisk `yaml:"ephemeral_disk"`
+	spotCloudProperties `yaml:",inline"`
+}
+
+type ephemeralDisk struct {
+	Size int
+	Type string
+}
+
+type spot struct {
+	Name            string
+	CloudProperties spotCloudProperties `yaml:"cloud_properties"`
+}
+
+type spotCloudProperties struct {
+	SpotBidPrice         float64 `yaml:"spot_bid_price"`
+	SpotOndemandFallback bool    `yaml:"spot_ondemand_fallback"`
+}
+
+// compilationInstanceType matches the compilation vm_type of BaseOps.
+const compilationInstanceType = "c3.large"
+
 var marshal func(interface{}) ([]byte, error) = yaml.Marshal
 
 func NewOpsGenerator(terraformManager terraformManager) OpsGenerator {
@@ -140,10 +170,20 @@ func (a OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		})
 		ops = append(ops, azOp)
 
+		cidr, ok := internalAZSubnetCIDRMap[myAZ].(string)
+		if !ok {
+			return []op{}, fmt.Errorf("missing internal subnet cidr in %s", myAZ)
+		}
+
+		subnetID, ok := internalAZSubnetIDMap[myAZ].(string)
+		if !ok {
+			return []op{}, fmt.Errorf("missing internal subnet id in %s", myAZ)
+		}
+
 		subnet, err := generateNetworkSubnet(
 			fmt.Sprintf("z%d", i+1),
-			internalAZSubnetCIDRMap[myAZ].(string),
-			internalAZSubnetIDMap[myAZ].(string),
+			cidr,
+			subnetID,
 			internalSecurityGroup,
 		)
 		if err != nil {
@@ -165,6 +205,30 @@ func (a OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		Type:    "manual",
 	}))
 
+	for _, named := range state.Network.Named {
+		namedNetwork, err := generateNamedNetwork(named.Name, azs, terraformOutputs)
+		if err != nil {
+			return []op{}, err
+		}
+
+		ops = append(ops, createOp("replace", "/networks/-", namedNetwork))
+	}
+
+	spotBidPrice, err := strconv.ParseFloat(state.AWS.GetSpotBidPrice(), 64)
+	if err != nil {
+		return []op{}, fmt.Errorf("invalid spot bid price %q", state.AWS.SpotBidPrice)
+	}
+
+	spotProperties := spotCloudProperties{
+		SpotBidPrice:         spotBidPrice,
+		SpotOndemandFallback: true,
+	}
+
+	ops = append(ops, createOp("replace", "/vm_extensions/-", spot{
+		Name:            "spot",
+		CloudProperties: spotProperties,
+	}))
+
 	switch state.LB.Type {
 	case "cf":
 		tfOutputs := []map[string]string{
@@ -220,9 +284,77 @@ func (a OpsGenerator) generateOps(state storage.State) ([]op, error) {
 		}))
 	}
 
+	if state.BOSH.PreemptibleCompilation {
+		ops = append(ops, createOp("replace", "/vm_types/name=compilation?", vmType{
+			Name: "compilation",
+			CloudProperties: vmTypeCloudProperties{
+				InstanceType: compilationInstanceType,
+				EphemeralDisk: ephemeralDisk{
+					Size: 10240,
+					Type: "gp2",
+				},
+				spotCloudProperties: spotProperties,
+			},
+		}))
+
+		ops = append(ops, createOp("replace", "/compilation/vm_type", "compilation"))
+	}
+
 	return ops, nil
 }
 
+// generateNamedNetwork returns the network of a named network, with its subnets
+// in the same azs as the internal subnets.
+func generateNamedNetwork(name string, azs []string, terraformOutputs map[string]interface{}) (network, error) {
+	prefix := fmt.Sprintf("network_%s", name)
+
+	subnetIDMap, ok := terraformOutputs[prefix+"_az_subnet_id_mapping"].(map[string]interface{})
+	if !ok {
+		return network{}, fmt.Errorf("missing %s_az_subnet_id_mapping terraform output", prefix)
+	}
+
+	subnetCIDRMap, ok := terraformOutputs[prefix+"_az_subnet_cidr_mapping"].(map[string]interface{})
+	if !ok {
+		return network{}, fmt.Errorf("missing %s_az_subnet_cidr_mapping terraform output", prefix)
+	}
+
+	securityGroup, ok := terraformOutputs[prefix+"_security_group"].(string)
+	if !ok {
+		return network{}, fmt.Errorf("missing %s_security_group terraform output", prefix)
+	}
+
+	var subnets []networkSubnet
+	for i, myAZ := range azs {
+		cidr, ok := subnetCIDRMap[myAZ].(string)
+		if !ok {
+			return network{}, fmt.Errorf("missing subnet of network %s in %s", name, myAZ)
+		}
+
+		subnetID, ok := subnetIDMap[myAZ].(string)
+		if !ok {
+			return network{}, fmt.Errorf("missing subnet id of network %s in %s", name, myAZ)
+		}
+
+		subnet, err := generateNetworkSubnet(
+			fmt.Sprintf("z%d", i+1),
+			cidr,
+			subnetID,
+			securityGroup,
+		)
+		if err != nil {
+			return network{}, err
+		}
+
+		subnets = append(subnets, subnet)
+	}
+
+	return network{
+		Name:    name,
+		Subnets: subnets,
+		Type:    "manual",
+	}, nil
+}
+
 func generateNetworkSubnet(az, cidr, subnet, securityGroup string) (networkSubnet, error) {
 	parsedCidr, err := bosh.ParseCIDRBlock(cidr)
 	if err != nil {
@@ -121,6 +121,114 @@ var _ = Describe("OpsGenerator", func() {
 			})
 		})
 
+		Context("when a spot bid price is provided", func() {
+			It("returns an ops file with the bid on the spot vm_extension", func() {
+				incomingState.AWS.SpotBidPrice = "0.05"
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring("spot_bid_price: 0.05"))
+			})
+		})
+
+		Context("when compilation is preemptible", func() {
+			BeforeEach(func() {
+				baseOpsYAMLContents, err := ioutil.ReadFile(filepath.Join("fixtures", "aws-ops.yml"))
+				Expect(err).NotTo(HaveOccurred())
+				expectedOpsYAML = string(baseOpsYAMLContents)
+			})
+
+			It("returns an ops file with a spot vm_type for the compilation workers", func() {
+				incomingState.BOSH.PreemptibleCompilation = true
+
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(gomegamatchers.MatchYAML(strings.Join([]string{expectedOpsYAML, `
+- type: replace
+  path: /vm_types/name=compilation?
+  value:
+    name: compilation
+    cloud_properties:
+      instance_type: c3.large
+      ephemeral_disk:
+        size: 10240
+        type: gp2
+      spot_bid_price: 0.1
+      spot_ondemand_fallback: true
+
+- type: replace
+  path: /compilation/vm_type
+  value: compilation
+`}, "\n")))
+			})
+		})
+
+		Context("when named networks are provided", func() {
+			BeforeEach(func() {
+				incomingState.Network.Named = []storage.NamedNetwork{
+					{Name: "services", CIDR: "10.0.128.0/20"},
+				}
+
+				terraformManager.GetOutputsCall.Returns.Outputs["network_services_security_group"] = "some-services-security-group"
+				terraformManager.GetOutputsCall.Returns.Outputs["network_services_az_subnet_id_mapping"] = map[string]interface{}{
+					"us-east-1c": "some-services-subnet-ids-3",
+					"us-east-1a": "some-services-subnet-ids-1",
+					"us-east-1b": "some-services-subnet-ids-2",
+				}
+				terraformManager.GetOutputsCall.Returns.Outputs["network_services_az_subnet_cidr_mapping"] = map[string]interface{}{
+					"us-east-1a": "10.0.128.0/23",
+					"us-east-1c": "10.0.132.0/23",
+					"us-east-1b": "10.0.130.0/23",
+				}
+			})
+
+			It("returns an ops file with a manual network that uses the subnets and security group of each network", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /networks/-
+  value:
+    name: services
+    type: manual
+    subnets:
+    - az: z1
+      gateway: 10.0.128.1
+      range: 10.0.128.0/23
+      reserved:
+      - 10.0.128.2-10.0.128.3
+      - 10.0.129.255
+      static:
+      - 10.0.129.190-10.0.129.254
+      cloud_properties:
+        subnet: some-services-subnet-ids-1
+        security_groups:
+        - some-services-security-group
+    - az: z2
+      gateway: 10.0.130.1
+      range: 10.0.130.0/23`))
+			})
+
+			DescribeTable("when a terraform output of the network is missing", func(outputKey string) {
+				delete(terraformManager.GetOutputsCall.Returns.Outputs, outputKey)
+				_, err := opsGenerator.Generate(incomingState)
+				Expect(err).To(MatchError(fmt.Sprintf("missing %s terraform output", outputKey)))
+			},
+				Entry("when the subnet id mapping is missing", "network_services_az_subnet_id_mapping"),
+				Entry("when the subnet cidr mapping is missing", "network_services_az_subnet_cidr_mapping"),
+				Entry("when the security group is missing", "network_services_security_group"),
+			)
+
+			It("returns an error when the subnet id of an az is missing", func() {
+				delete(terraformManager.GetOutputsCall.Returns.Outputs["network_services_az_subnet_id_mapping"].(map[string]interface{}), "us-east-1b")
+
+				_, err := opsGenerator.Generate(incomingState)
+				Expect(err).To(MatchError("missing subnet id of network services in us-east-1b"))
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when terraform fails to get outputs", func() {
 				It("returns an error", func() {
@@ -130,6 +238,15 @@ var _ = Describe("OpsGenerator", func() {
 				})
 			})
 
+			Context("when the internal subnet id of an az is missing", func() {
+				It("returns an error", func() {
+					terraformManager.GetOutputsCall.Returns.Outputs["internal_az_subnet_id_mapping"].(map[string]interface{})["us-east-1a"] = nil
+
+					_, err := opsGenerator.Generate(storage.State{})
+					Expect(err).To(MatchError("missing internal subnet id in us-east-1a"))
+				})
+			})
+
 			Context("when cidr block parsing fails", func() {
 				It("returns an error", func() {
 					terraformManager.GetOutputsCall.Returns.Outputs["internal_az_subnet_cidr_mapping"] = map[string]interface{}{
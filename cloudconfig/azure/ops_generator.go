@@ -1,6 +1,7 @@
 package azure
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -59,19 +60,42 @@ func (o OpsGenerator) Generate(state storage.State) (string, error) {
 		return "", err
 	}
 
+	networkPlan, err := bosh.NewNetworkPlan(state.Network.GetCIDR())
+	if err != nil {
+		return "", err
+	}
+
+	networkName, ok := terraformOutputs["bosh_network_name"].(string)
+	if !ok {
+		return "", errors.New("missing bosh_network_name terraform output")
+	}
+
+	subnetName, ok := terraformOutputs["bosh_subnet_name"].(string)
+	if !ok {
+		return "", errors.New("missing bosh_subnet_name terraform output")
+	}
+
+	securityGroup, ok := terraformOutputs["bosh_default_security_group"].(string)
+	if !ok {
+		return "", errors.New("missing bosh_default_security_group terraform output")
+	}
+
 	zones := []string{"z1", "z2", "z3"}
 	var subnets []networkSubnet
 	for i, _ := range zones {
-		cidr := fmt.Sprintf("10.0.%d.0/20", 16*(i+1))
+		cidr, err := networkPlan.AZSubnet(i)
+		if err != nil {
+			return "", err
+		}
+
 		subnet, err := generateNetworkSubnet(
 			fmt.Sprintf("z%d", i+1),
-			cidr,
-			terraformOutputs["bosh_network_name"].(string),
-			terraformOutputs["bosh_subnet_name"].(string),
-			terraformOutputs["bosh_default_security_group"].(string),
+			cidr.String(),
+			networkName,
+			subnetName,
+			securityGroup,
 		)
 		if err != nil {
-			panic(err)
 			return "", err
 		}
 
@@ -2,6 +2,7 @@ package azure_test
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-cf-experimental/gomegamatchers"
 )
@@ -64,6 +66,17 @@ var _ = Describe("AzureOpsGenerator", func() {
 				})
 			})
 
+			DescribeTable("when a terraform output is missing", func(outputKey string) {
+				delete(terraformManager.GetOutputsCall.Returns.Outputs, outputKey)
+
+				_, err := opsGenerator.Generate(incomingState)
+				Expect(err).To(MatchError(fmt.Sprintf("missing %s terraform output", outputKey)))
+			},
+				Entry("when bosh_network_name is missing", "bosh_network_name"),
+				Entry("when bosh_subnet_name is missing", "bosh_subnet_name"),
+				Entry("when bosh_default_security_group is missing", "bosh_default_security_group"),
+			)
+
 			Context("when ops fail to marshal", func() {
 				BeforeEach(func() {
 					azure.SetMarshal(func(interface{}) ([]byte, error) {
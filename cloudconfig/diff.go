@@ -0,0 +1,130 @@
+package cloudconfig
+
+import (
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const diffContext = 3
+
+// diffYAML returns the lines that differ between the current and desired
+// YAML documents, prefixed with "+" or "-" and surrounded by up to
+// diffContext unchanged lines. Both documents are re-marshalled first so
+// that formatting and key order do not show up as changes. It returns an
+// empty string when the documents are the same.
+func diffYAML(current, desired string) (string, error) {
+	currentLines, err := yamlLines(current)
+	if err != nil {
+		return "", fmt.Errorf("parse current cloud config: %s", err)
+	}
+
+	desiredLines, err := yamlLines(desired)
+	if err != nil {
+		return "", fmt.Errorf("parse desired cloud config: %s", err)
+	}
+
+	edits := diffLines(currentLines, desiredLines)
+
+	var changed []int
+	for i, edit := range edits {
+		if edit[0] != ' ' {
+			changed = append(changed, i)
+		}
+	}
+
+	if len(changed) == 0 {
+		return "", nil
+	}
+
+	var output []string
+	last := -1
+	for _, i := range changed {
+		start := i - diffContext
+		if start <= last+1 {
+			start = last + 1
+		} else if last != -1 {
+			output = append(output, "...")
+		}
+
+		end := i + diffContext
+		if end >= len(edits) {
+			end = len(edits) - 1
+		}
+
+		for j := start; j <= end; j++ {
+			output = append(output, edits[j])
+			last = j
+		}
+	}
+
+	return strings.Join(output, "\n"), nil
+}
+
+func yamlLines(document string) ([]string, error) {
+	if strings.TrimSpace(document) == "" {
+		return nil, nil
+	}
+
+	var contents interface{}
+	err := yaml.Unmarshal([]byte(document), &contents)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := yaml.Marshal(contents)
+	if err != nil {
+		return nil, err //not tested
+	}
+
+	return strings.Split(strings.TrimSuffix(string(normalized), "\n"), "\n"), nil
+}
+
+// diffLines returns every line of current and desired marked as unchanged,
+// removed or added, using their longest common subsequence.
+func diffLines(current, desired []string) []string {
+	common := make([][]int, len(current)+1)
+	for i := range common {
+		common[i] = make([]int, len(desired)+1)
+	}
+
+	for i := len(current) - 1; i >= 0; i-- {
+		for j := len(desired) - 1; j >= 0; j-- {
+			if current[i] == desired[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	var edits []string
+	i, j := 0, 0
+	for i < len(current) && j < len(desired) {
+		switch {
+		case current[i] == desired[j]:
+			edits = append(edits, "  "+current[i])
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			edits = append(edits, "- "+current[i])
+			i++
+		default:
+			edits = append(edits, "+ "+desired[j])
+			j++
+		}
+	}
+
+	for ; i < len(current); i++ {
+		edits = append(edits, "- "+current[i])
+	}
+
+	for ; j < len(desired); j++ {
+		edits = append(edits, "+ "+desired[j])
+	}
+
+	return edits
+}
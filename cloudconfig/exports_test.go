@@ -1,27 +1,6 @@
 package cloudconfig
 
-import (
-	"io/ioutil"
-	"os"
-
-	"golang.org/x/net/proxy"
-)
-
-func SetTempDir(f func(string, string) (string, error)) {
-	tempDir = f
-}
-
-func ResetTempDir() {
-	tempDir = ioutil.TempDir
-}
-
-func SetWriteFile(f func(string, []byte, os.FileMode) error) {
-	writeFile = f
-}
-
-func ResetWriteFile() {
-	writeFile = ioutil.WriteFile
-}
+import "golang.org/x/net/proxy"
 
 func SetProxySOCKS5(f func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dialer, error)) {
 	proxySOCKS5 = f
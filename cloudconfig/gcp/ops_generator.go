@@ -52,6 +52,7 @@ type subnetCloudProperties struct {
 	EphemeralExternalIP bool   `yaml:"ephemeral_external_ip"`
 	NetworkName         string `yaml:"network_name"`
 	SubnetworkName      string `yaml:"subnetwork_name"`
+	XPNHostProjectID    string `yaml:"xpn_host_project_id,omitempty"`
 	Tags                []string
 }
 
@@ -66,6 +67,21 @@ type lbCloudProperties struct {
 	Tags           []string `yaml:",omitempty"`
 }
 
+type vmType struct {
+	Name            string
+	CloudProperties vmTypeCloudProperties `yaml:"cloud_properties"`
+}
+
+type vmTypeCloudProperties struct {
+	MachineType    string `yaml:"machine_type"`
+	RootDiskSizeGB int    `yaml:"root_disk_size_gb"`
+	RootDiskType   string `yaml:"root_disk_type"`
+	Preemptible    bool   `yaml:"preemptible"`
+}
+
+// compilationMachineType matches the compilation vm_type of BaseOps.
+const compilationMachineType = "n1-highcpu-8"
+
 var marshal func(interface{}) ([]byte, error) = yaml.Marshal
 
 func NewOpsGenerator(terraformManager terraformManager) OpsGenerator {
@@ -118,15 +134,41 @@ func (o *OpsGenerator) generateGCPOps(state storage.State) ([]op, error) {
 		}))
 	}
 
+	networkPlan, err := bosh.PlanNetwork(state)
+	if err != nil {
+		return []op{}, err
+	}
+
+	networkName, err := stringOutput(terraformOutputs, "network_name")
+	if err != nil {
+		return []op{}, err
+	}
+
+	subnetworkName, err := stringOutput(terraformOutputs, "subnetwork_name")
+	if err != nil {
+		return []op{}, err
+	}
+
+	internalTagName, err := stringOutput(terraformOutputs, "internal_tag_name")
+	if err != nil {
+		return []op{}, err
+	}
+
 	var subnets []networkSubnet
 	for i, _ := range state.GCP.Zones {
-		cidr := fmt.Sprintf("10.0.%d.0/20", 16*(i+1))
+		cidr, err := networkPlan.AZSubnet(i)
+		if err != nil {
+			return []op{}, err
+		}
+
 		subnet, err := generateNetworkSubnet(
 			fmt.Sprintf("z%d", i+1),
-			cidr,
-			terraformOutputs["network_name"].(string),
-			terraformOutputs["subnetwork_name"].(string),
-			terraformOutputs["internal_tag_name"].(string),
+			cidr.String(),
+			networkName,
+			subnetworkName,
+			internalTagName,
+			state.GCP.NetworkProject,
+			!state.Network.Private,
 		)
 		if err != nil {
 			return []op{}, err
@@ -147,24 +189,78 @@ func (o *OpsGenerator) generateGCPOps(state storage.State) ([]op, error) {
 		Type:    "manual",
 	}))
 
+	for _, named := range state.Network.Named {
+		namedSubnetworkName, err := stringOutput(terraformOutputs, fmt.Sprintf("network_%s_subnetwork_name", named.Name))
+		if err != nil {
+			return []op{}, err
+		}
+
+		namedTagName, err := stringOutput(terraformOutputs, fmt.Sprintf("network_%s_tag_name", named.Name))
+		if err != nil {
+			return []op{}, err
+		}
+
+		var namedSubnets []networkSubnet
+		for i, _ := range state.GCP.Zones {
+			cidr, err := bosh.NamedNetworkAZSubnet(named, i)
+			if err != nil {
+				return []op{}, err
+			}
+
+			subnet, err := generateNetworkSubnet(
+				fmt.Sprintf("z%d", i+1),
+				cidr.String(),
+				networkName,
+				namedSubnetworkName,
+				namedTagName,
+				state.GCP.NetworkProject,
+				!state.Network.Private,
+			)
+			if err != nil {
+				return []op{}, err
+			}
+
+			namedSubnets = append(namedSubnets, subnet)
+		}
+
+		ops = append(ops, createOp("replace", "/networks/-", network{
+			Name:    named.Name,
+			Subnets: namedSubnets,
+			Type:    "manual",
+		}))
+	}
+
 	if state.LB.Type == "concourse" {
+		concourseTargetPool, err := stringOutput(terraformOutputs, "concourse_target_pool")
+		if err != nil {
+			return []op{}, err
+		}
+
 		ops = append(ops, createOp("replace", "/vm_extensions/-", lb{
 			Name: "lb",
 			CloudProperties: lbCloudProperties{
-				TargetPool: terraformOutputs["concourse_target_pool"].(string),
+				TargetPool: concourseTargetPool,
 			},
 		}))
 	}
 
 	if state.LB.Type == "cf" {
+		lbOutputs := map[string]string{}
+		for _, name := range []string{"router_backend_service", "ws_target_pool", "ssh_proxy_target_pool", "tcp_router_target_pool"} {
+			lbOutputs[name], err = stringOutput(terraformOutputs, name)
+			if err != nil {
+				return []op{}, err
+			}
+		}
+
 		ops = append(ops, createOp("replace", "/vm_extensions/-", lb{
 			Name: "cf-router-network-properties",
 			CloudProperties: lbCloudProperties{
-				BackendService: terraformOutputs["router_backend_service"].(string),
-				TargetPool:     terraformOutputs["ws_target_pool"].(string),
+				BackendService: lbOutputs["router_backend_service"],
+				TargetPool:     lbOutputs["ws_target_pool"],
 				Tags: []string{
-					terraformOutputs["router_backend_service"].(string),
-					terraformOutputs["ws_target_pool"].(string),
+					lbOutputs["router_backend_service"],
+					lbOutputs["ws_target_pool"],
 				},
 			},
 		}))
@@ -172,9 +268,9 @@ func (o *OpsGenerator) generateGCPOps(state storage.State) ([]op, error) {
 		ops = append(ops, createOp("replace", "/vm_extensions/-", lb{
 			Name: "diego-ssh-proxy-network-properties",
 			CloudProperties: lbCloudProperties{
-				TargetPool: terraformOutputs["ssh_proxy_target_pool"].(string),
+				TargetPool: lbOutputs["ssh_proxy_target_pool"],
 				Tags: []string{
-					terraformOutputs["ssh_proxy_target_pool"].(string),
+					lbOutputs["ssh_proxy_target_pool"],
 				},
 			},
 		}))
@@ -182,18 +278,42 @@ func (o *OpsGenerator) generateGCPOps(state storage.State) ([]op, error) {
 		ops = append(ops, createOp("replace", "/vm_extensions/-", lb{
 			Name: "cf-tcp-router-network-properties",
 			CloudProperties: lbCloudProperties{
-				TargetPool: terraformOutputs["tcp_router_target_pool"].(string),
+				TargetPool: lbOutputs["tcp_router_target_pool"],
 				Tags: []string{
-					terraformOutputs["tcp_router_target_pool"].(string),
+					lbOutputs["tcp_router_target_pool"],
 				},
 			},
 		}))
 	}
 
+	if state.BOSH.PreemptibleCompilation {
+		ops = append(ops, createOp("replace", "/vm_types/name=compilation?", vmType{
+			Name: "compilation",
+			CloudProperties: vmTypeCloudProperties{
+				MachineType:    compilationMachineType,
+				RootDiskSizeGB: 10,
+				RootDiskType:   "pd-ssd",
+				Preemptible:    true,
+			},
+		}))
+
+		ops = append(ops, createOp("replace", "/compilation/vm_type", "compilation"))
+	}
+
 	return ops, nil
 }
 
-func generateNetworkSubnet(az, cidr, networkName, subnetworkName, internalTag string) (networkSubnet, error) {
+// stringOutput returns the terraform output called name, which must be a
+// string.
+func stringOutput(terraformOutputs map[string]interface{}, name string) (string, error) {
+	value, ok := terraformOutputs[name].(string)
+	if !ok {
+		return "", fmt.Errorf("missing %s terraform output", name)
+	}
+	return value, nil
+}
+
+func generateNetworkSubnet(az, cidr, networkName, subnetworkName, internalTag, xpnHostProjectID string, ephemeralExternalIP bool) (networkSubnet, error) {
 	parsedCidr, err := bosh.ParseCIDRBlock(cidr)
 	if err != nil {
 		return networkSubnet{}, err
@@ -218,9 +338,10 @@ func generateNetworkSubnet(az, cidr, networkName, subnetworkName, internalTag st
 			fmt.Sprintf("%s-%s", firstStatic, lastStatic),
 		},
 		CloudProperties: subnetCloudProperties{
-			EphemeralExternalIP: true,
+			EphemeralExternalIP: ephemeralExternalIP,
 			NetworkName:         networkName,
 			SubnetworkName:      subnetworkName,
+			XPNHostProjectID:    xpnHostProjectID,
 			Tags:                []string{internalTag},
 		},
 	}, nil
@@ -101,7 +101,129 @@ var _ = Describe("GCPOpsGenerator", func() {
 				}),
 		)
 
+		Context("when a network cidr is provided", func() {
+			BeforeEach(func() {
+				incomingState.Network.CIDR = "172.16.0.0/16"
+			})
+
+			It("returns an ops file with subnets derived from the network cidr", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring("range: 172.16.16.0/20"))
+				Expect(opsYAML).To(ContainSubstring("gateway: 172.16.32.1"))
+				Expect(opsYAML).To(ContainSubstring("range: 172.16.48.0/20"))
+				Expect(opsYAML).NotTo(ContainSubstring("10.0."))
+			})
+		})
+
+		Context("when named networks are provided", func() {
+			BeforeEach(func() {
+				incomingState.Network.Named = []storage.NamedNetwork{
+					{Name: "services", CIDR: "10.1.0.0/20"},
+				}
+
+				terraformManager.GetOutputsCall.Returns.Outputs["network_services_subnetwork_name"] = "some-services-subnetwork-name"
+				terraformManager.GetOutputsCall.Returns.Outputs["network_services_tag_name"] = "some-services-tag"
+			})
+
+			It("returns an ops file with a manual network that splits the network into a subnet for each zone", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /networks/-
+  value:
+    name: services
+    subnets:
+    - az: z1
+      gateway: 10.1.0.1
+      range: 10.1.0.0/23
+      reserved:
+      - 10.1.0.2-10.1.0.3
+      - 10.1.1.255
+      static:
+      - 10.1.1.190-10.1.1.254
+      cloud_properties:
+        ephemeral_external_ip: true
+        network_name: some-network-name
+        subnetwork_name: some-services-subnetwork-name
+        tags:
+        - some-services-tag
+    - az: z2
+      gateway: 10.1.2.1
+      range: 10.1.2.0/23`))
+				Expect(opsYAML).To(ContainSubstring("range: 10.1.4.0/23"))
+			})
+		})
+
+		Context("when the network is in a Shared VPC host project", func() {
+			BeforeEach(func() {
+				incomingState.GCP.Network = "some-network"
+				incomingState.GCP.NetworkProject = "some-host-project"
+			})
+
+			It("returns an ops file with subnets that look up the network in the host project", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strings.Count(opsYAML, "xpn_host_project_id: some-host-project")).To(Equal(6))
+			})
+		})
+
+		Context("when the network is private", func() {
+			BeforeEach(func() {
+				incomingState.Network.Private = true
+			})
+
+			It("returns an ops file without ephemeral external ips on the subnets", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring("ephemeral_external_ip: false"))
+				Expect(opsYAML).NotTo(MatchRegexp("(?s)subnets:.*ephemeral_external_ip: true"))
+			})
+		})
+
+		Context("when compilation is preemptible", func() {
+			BeforeEach(func() {
+				incomingState.BOSH.PreemptibleCompilation = true
+			})
+
+			It("returns an ops file with a preemptible vm_type for the compilation workers", func() {
+				opsYAML, err := opsGenerator.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedOps := strings.Join([]string{string(expectedOpsFile), `
+- type: replace
+  path: /vm_types/name=compilation?
+  value:
+    name: compilation
+    cloud_properties:
+      machine_type: n1-highcpu-8
+      root_disk_size_gb: 10
+      root_disk_type: pd-ssd
+      preemptible: true
+
+- type: replace
+  path: /compilation/vm_type
+  value: compilation
+`}, "\n")
+
+				Expect(opsYAML).To(gomegamatchers.MatchYAML(expectedOps))
+			})
+		})
+
 		Context("failure cases", func() {
+			Context("when the network cidr is invalid", func() {
+				It("returns an error", func() {
+					_, err := opsGenerator.Generate(storage.State{
+						Network: storage.Network{CIDR: "not-a-cidr"},
+					})
+					Expect(err).To(MatchError(`"not-a-cidr" is not a valid IPv4 CIDR block`))
+				})
+			})
+
 			Context("when terraform output provider fails to retrieve", func() {
 				BeforeEach(func() {
 					terraformManager.GetOutputsCall.Returns.Error = errors.New("failed to output")
@@ -113,6 +235,33 @@ var _ = Describe("GCPOpsGenerator", func() {
 				})
 			})
 
+			DescribeTable("when a terraform output is missing", func(outputKey, lbType string) {
+				incomingState.LB.Type = lbType
+				incomingState.Network.Named = []storage.NamedNetwork{{Name: "services", CIDR: "10.1.0.0/20"}}
+				terraformManager.GetOutputsCall.Returns.Outputs["network_services_subnetwork_name"] = "some-services-subnetwork-name"
+				terraformManager.GetOutputsCall.Returns.Outputs["network_services_tag_name"] = "some-services-tag"
+				terraformManager.GetOutputsCall.Returns.Outputs["concourse_target_pool"] = "concourse-target-pool"
+				terraformManager.GetOutputsCall.Returns.Outputs["router_backend_service"] = "router-backend-service"
+				terraformManager.GetOutputsCall.Returns.Outputs["ws_target_pool"] = "ws-target-pool"
+				terraformManager.GetOutputsCall.Returns.Outputs["ssh_proxy_target_pool"] = "ssh-proxy-target-pool"
+				terraformManager.GetOutputsCall.Returns.Outputs["tcp_router_target_pool"] = "tcp-router-target-pool"
+				delete(terraformManager.GetOutputsCall.Returns.Outputs, outputKey)
+
+				_, err := opsGenerator.Generate(incomingState)
+				Expect(err).To(MatchError(fmt.Sprintf("missing %s terraform output", outputKey)))
+			},
+				Entry("when network_name is missing", "network_name", ""),
+				Entry("when subnetwork_name is missing", "subnetwork_name", ""),
+				Entry("when internal_tag_name is missing", "internal_tag_name", ""),
+				Entry("when the subnetwork of a named network is missing", "network_services_subnetwork_name", ""),
+				Entry("when the tag of a named network is missing", "network_services_tag_name", ""),
+				Entry("when concourse_target_pool is missing", "concourse_target_pool", "concourse"),
+				Entry("when router_backend_service is missing", "router_backend_service", "cf"),
+				Entry("when ws_target_pool is missing", "ws_target_pool", "cf"),
+				Entry("when ssh_proxy_target_pool is missing", "ssh_proxy_target_pool", "cf"),
+				Entry("when tcp_router_target_pool is missing", "tcp_router_target_pool", "cf"),
+			)
+
 			Context("when ops fail to marshal", func() {
 				BeforeEach(func() {
 					gcp.SetMarshal(func(interface{}) ([]byte, error) {
@@ -1,40 +1,43 @@
 package cloudconfig
 
 import (
-	"bytes"
+	"errors"
+	"fmt"
 	"io"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"strings"
 
 	"golang.org/x/net/proxy"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/interpolate"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
-var (
-	tempDir     func(string, string) (string, error)                                  = ioutil.TempDir
-	writeFile   func(string, []byte, os.FileMode) error                               = ioutil.WriteFile
-	proxySOCKS5 func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dialer, error) = proxy.SOCKS5
-)
+// ConfigName is the name of the cloud config bbl manages on the director.
+// Cloud configs uploaded under other names are merged with it and never
+// changed by bbl.
+const ConfigName = "bbl"
+
+// ErrNotApplied is returned by ConfirmUpdate when the user declines to apply
+// the cloud config.
+var ErrNotApplied = errors.New("the cloud config was not applied")
+
+var proxySOCKS5 func(string, string, *proxy.Auth, proxy.Dialer) (proxy.Dialer, error) = proxy.SOCKS5
 
 type Manager struct {
 	logger             logger
-	command            command
 	opsGenerator       OpsGenerator
 	boshClientProvider boshClientProvider
 	socks5Proxy        socks5Proxy
 	terraformManager   terraformManager
 	sshKeyGetter       sshKeyGetter
+	stdin              io.Reader
 }
 
 type logger interface {
 	Step(string, ...interface{})
-}
-
-type command interface {
-	Run(stdout io.Writer, workingDirectory string, args []string) error
+	Println(string)
+	Prompt(string)
 }
 
 type OpsGenerator interface {
@@ -58,55 +61,88 @@ type sshKeyGetter interface {
 	Get(storage.State) (string, error)
 }
 
-func NewManager(logger logger, cmd command, opsGenerator OpsGenerator, boshClientProvider boshClientProvider,
-	socks5Proxy socks5Proxy, terraformManager terraformManager, sshKeyGetter sshKeyGetter) Manager {
+func NewManager(logger logger, opsGenerator OpsGenerator, boshClientProvider boshClientProvider,
+	socks5Proxy socks5Proxy, terraformManager terraformManager, sshKeyGetter sshKeyGetter, stdin io.Reader) Manager {
 	return Manager{
 		logger:             logger,
-		command:            cmd,
 		opsGenerator:       opsGenerator,
 		boshClientProvider: boshClientProvider,
 		socks5Proxy:        socks5Proxy,
 		terraformManager:   terraformManager,
 		sshKeyGetter:       sshKeyGetter,
+		stdin:              stdin,
 	}
 }
 
 func (m Manager) Generate(state storage.State) (string, error) {
-	buf := bytes.NewBuffer([]byte{})
-	workingDir, err := tempDir("", "")
+	ops, err := m.opsGenerator.Generate(state)
 	if err != nil {
 		return "", err
 	}
 
-	err = writeFile(filepath.Join(workingDir, "cloud-config.yml"), []byte(BaseCloudConfig), os.ModePerm)
-	if err != nil {
-		return "", err
+	opsFiles := [][]byte{[]byte(ops)}
+
+	if state.BOSH.CloudConfigProfile.Name != "" {
+		profile, err := LoadProfile(state.BOSH.CloudConfigProfile, state.IAAS)
+		if err != nil {
+			return "", err
+		}
+
+		sizingOps, err := profileOps(profile, state.IAAS)
+		if err != nil {
+			return "", err //not tested
+		}
+		opsFiles = append(opsFiles, []byte(sizingOps))
 	}
 
-	ops, err := m.opsGenerator.Generate(state)
-	if err != nil {
-		return "", err
+	for _, opsFile := range state.BOSH.CloudConfigOpsFiles {
+		opsFiles = append(opsFiles, []byte(opsFile.Contents))
 	}
 
-	err = writeFile(filepath.Join(workingDir, "ops.yml"), []byte(ops), os.ModePerm)
+	output, err := interpolate.Interpolate(interpolate.Input{
+		Manifest: []byte(BaseCloudConfig),
+		OpsFiles: opsFiles,
+	})
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("interpolate cloud config: %s", err)
 	}
 
-	args := []string{
-		"interpolate", filepath.Join(workingDir, "cloud-config.yml"),
-		"-o", filepath.Join(workingDir, "ops.yml"),
+	return string(output.Manifest), nil
+}
+
+// Diff returns the changes between the cloud config on the director and the
+// one bbl generates for state.
+func (m Manager) Diff(state storage.State) (string, error) {
+	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
+	if err != nil {
+		return "", err // not tested
 	}
 
-	err = m.command.Run(buf, workingDir, args)
+	cloudConfig, err := m.Generate(state)
 	if err != nil {
 		return "", err
 	}
 
-	return buf.String(), nil
+	configs, err := boshClient.LatestConfigs("cloud")
+	if err != nil {
+		return "", fmt.Errorf("list cloud configs: %s", err)
+	}
+
+	return diffYAML(currentCloudConfig(configs), cloudConfig)
 }
 
 func (m Manager) Update(state storage.State) error {
+	return m.update(state, false)
+}
+
+// ConfirmUpdate is like Update, but asks before applying a cloud config that
+// changes the one on the director. A director without any cloud config, such
+// as a new one, is not asked about.
+func (m Manager) ConfirmUpdate(state storage.State) error {
+	return m.update(state, true)
+}
+
+func (m Manager) update(state storage.State, confirm bool) error {
 	boshClient, err := m.boshClientProvider.Client(state.Jumpbox, state.BOSH.DirectorAddress, state.BOSH.DirectorUsername, state.BOSH.DirectorPassword, state.BOSH.DirectorSSLCA)
 	if err != nil {
 		return err // not tested
@@ -118,11 +154,100 @@ func (m Manager) Update(state storage.State) error {
 		return err
 	}
 
+	configs, err := boshClient.LatestConfigs("cloud")
+	if err != nil {
+		return fmt.Errorf("list cloud configs: %s", err)
+	}
+
+	diff, err := diffYAML(currentCloudConfig(configs), cloudConfig)
+	if err != nil {
+		return err
+	}
+
+	if diff == "" && hasConfig(configs, ConfigName) {
+		m.logger.Step("cloud config is up to date")
+		return nil
+	}
+
+	if diff != "" {
+		m.logger.Println(diff)
+	}
+
+	// Before bbl managed a named cloud config it replaced the default one,
+	// so a default config on a director without a bbl config was written by
+	// bbl and now duplicates it. It is printed in full before it is deleted,
+	// in case it was changed outside of bbl.
+	defaultConfig, migrate := findConfig(configs, "default")
+	migrate = migrate && !hasConfig(configs, ConfigName)
+	if migrate {
+		m.logger.Println(fmt.Sprintf("The default cloud config will be replaced by the %q cloud config. It was:\n%s", ConfigName, defaultConfig.Content))
+	}
+
+	if confirm && len(configs) > 0 && (diff != "" || migrate) {
+		prompt := "Do you want to apply these changes to the cloud config?"
+		if migrate {
+			prompt = "Do you want to delete the default cloud config and apply these changes to the cloud config?"
+		}
+		m.logger.Prompt(prompt)
+
+		var proceed string
+		fmt.Fscanln(m.stdin, &proceed)
+
+		proceed = strings.ToLower(proceed)
+		if proceed != "yes" && proceed != "y" {
+			m.logger.Step("not applying cloud config")
+			return ErrNotApplied
+		}
+	}
+
+	// The default config is deleted first so the director never merges it
+	// with its copy, and restored if the bbl config cannot be applied.
+	if migrate {
+		m.logger.Step("moving the default cloud config to %q", ConfigName)
+		err = boshClient.DeleteConfig("cloud", "default")
+		if err != nil {
+			return fmt.Errorf("delete default cloud config: %s", err)
+		}
+	}
+
 	m.logger.Step("applying cloud config")
-	err = boshClient.UpdateCloudConfig([]byte(cloudConfig))
+	err = boshClient.UpdateConfig("cloud", ConfigName, []byte(cloudConfig))
 	if err != nil {
+		if migrate {
+			restoreErr := boshClient.UpdateConfig("cloud", "default", []byte(defaultConfig.Content))
+			if restoreErr != nil {
+				return fmt.Errorf("%s, and the default cloud config could not be restored: %s", err, restoreErr)
+			}
+		}
 		return err
 	}
 
 	return nil
 }
+
+// currentCloudConfig returns the cloud config bbl last applied, which is the
+// default cloud config on directors that bbl has not migrated yet.
+func currentCloudConfig(configs []bosh.Config) string {
+	for _, name := range []string{ConfigName, "default"} {
+		for _, config := range configs {
+			if config.Name == name {
+				return config.Content
+			}
+		}
+	}
+	return ""
+}
+
+func hasConfig(configs []bosh.Config, name string) bool {
+	_, ok := findConfig(configs, name)
+	return ok
+}
+
+func findConfig(configs []bosh.Config, name string) (bosh.Config, bool) {
+	for _, c := range configs {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return bosh.Config{}, false
+}
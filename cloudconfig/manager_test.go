@@ -1,16 +1,16 @@
 package cloudconfig_test
 
 import (
+	"bytes"
 	"errors"
-	"fmt"
-	"io"
 	"io/ioutil"
-	"os"
 	"strings"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/pivotal-cf-experimental/gomegamatchers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,16 +19,15 @@ import (
 var _ = Describe("Manager", func() {
 	var (
 		logger             *fakes.Logger
-		cmd                *fakes.BOSHCommand
 		opsGenerator       *fakes.CloudConfigOpsGenerator
 		boshClientProvider *fakes.BOSHClientProvider
 		boshClient         *fakes.BOSHClient
 		socks5Proxy        *fakes.Socks5Proxy
 		terraformManager   *fakes.TerraformManager
 		sshKeyGetter       *fakes.SSHKeyGetter
+		stdin              *bytes.Buffer
 		manager            cloudconfig.Manager
 
-		tempDir       string
 		incomingState storage.State
 
 		baseCloudConfig []byte
@@ -36,29 +35,16 @@ var _ = Describe("Manager", func() {
 
 	BeforeEach(func() {
 		logger = &fakes.Logger{}
-		cmd = &fakes.BOSHCommand{}
 		opsGenerator = &fakes.CloudConfigOpsGenerator{}
 		boshClient = &fakes.BOSHClient{}
 		boshClientProvider = &fakes.BOSHClientProvider{}
 		socks5Proxy = &fakes.Socks5Proxy{}
 		terraformManager = &fakes.TerraformManager{}
 		sshKeyGetter = &fakes.SSHKeyGetter{}
+		stdin = bytes.NewBuffer([]byte{})
 
 		boshClientProvider.ClientCall.Returns.Client = boshClient
 
-		var err error
-		tempDir, err = ioutil.TempDir("", "")
-		Expect(err).NotTo(HaveOccurred())
-
-		cloudconfig.SetTempDir(func(string, string) (string, error) {
-			return tempDir, nil
-		})
-
-		cmd.RunStub = func(stdout io.Writer, workingDirectory string, args []string) error {
-			stdout.Write([]byte("some-cloud-config"))
-			return nil
-		}
-
 		incomingState = storage.State{
 			IAAS: "gcp",
 			BOSH: storage.BOSH{
@@ -68,124 +54,206 @@ var _ = Describe("Manager", func() {
 			},
 		}
 
-		opsGenerator.GenerateCall.Returns.OpsYAML = "some-ops"
+		opsGenerator.GenerateCall.Returns.OpsYAML = `
+- type: replace
+  path: /vm_types/name=default/cloud_properties?
+  value:
+    machine_type: some-machine-type
+`
 
+		var err error
 		baseCloudConfig, err = ioutil.ReadFile("fixtures/base-cloud-config.yml")
 		Expect(err).NotTo(HaveOccurred())
 
-		manager = cloudconfig.NewManager(logger, cmd, opsGenerator, boshClientProvider, socks5Proxy, terraformManager, sshKeyGetter)
-	})
-
-	AfterEach(func() {
-		cloudconfig.ResetTempDir()
+		manager = cloudconfig.NewManager(logger, opsGenerator, boshClientProvider, socks5Proxy, terraformManager, sshKeyGetter, stdin)
 	})
 
 	Describe("Generate", func() {
 		It("returns a cloud config yaml provided a valid bbl state", func() {
-			expectedArgs := []string{
-				"interpolate", fmt.Sprintf("%s/cloud-config.yml", tempDir),
-				"-o", fmt.Sprintf("%s/ops.yml", tempDir),
-			}
+			cloudConfigYAML, err := manager.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(opsGenerator.GenerateCall.Receives.State).To(Equal(incomingState))
+
+			Expect(cloudConfigYAML).To(ContainSubstring("machine_type: some-machine-type"))
+			Expect(cloudConfigYAML).To(ContainSubstring("reuse_compilation_vms: true"))
+		})
+
+		It("leaves the base cloud config unchanged when there are no ops", func() {
+			opsGenerator.GenerateCall.Returns.OpsYAML = "[]"
 
 			cloudConfigYAML, err := manager.Generate(incomingState)
 			Expect(err).NotTo(HaveOccurred())
 
-			cloudConfig, err := ioutil.ReadFile(fmt.Sprintf("%s/cloud-config.yml", tempDir))
+			Expect(cloudConfigYAML).To(gomegamatchers.MatchYAML(string(baseCloudConfig)))
+		})
+
+		It("applies the user cloud config ops files after the generated ops", func() {
+			incomingState.BOSH.CloudConfigOpsFiles = []storage.OpsFile{{
+				Name: "machine-type.yml",
+				Contents: `
+- type: replace
+  path: /vm_types/name=default/cloud_properties/machine_type
+  value: some-user-machine-type
+`,
+			}, {
+				Name: "vm-extension.yml",
+				Contents: `
+- type: replace
+  path: /vm_extensions/-
+  value:
+    name: some-user-vm-extension
+`,
+			}}
+
+			cloudConfigYAML, err := manager.Generate(incomingState)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(cloudConfig).To(Equal(baseCloudConfig))
 
-			Expect(opsGenerator.GenerateCall.Receives.State).To(Equal(incomingState))
+			Expect(cloudConfigYAML).To(ContainSubstring("machine_type: some-user-machine-type"))
+			Expect(cloudConfigYAML).NotTo(ContainSubstring("machine_type: some-machine-type"))
+			Expect(cloudConfigYAML).To(ContainSubstring("name: some-user-vm-extension"))
+		})
+
+		It("sizes the vm_types with the cloud config profile before the user ops files", func() {
+			incomingState.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "dev"}
+			incomingState.BOSH.CloudConfigOpsFiles = []storage.OpsFile{{
+				Name: "machine-type.yml",
+				Contents: `
+- type: replace
+  path: /vm_types/name=large/cloud_properties/machine_type
+  value: some-user-machine-type
+`,
+			}}
 
-			ops, err := ioutil.ReadFile(fmt.Sprintf("%s/ops.yml", tempDir))
+			cloudConfigYAML, err := manager.Generate(incomingState)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(string(ops)).To(Equal("some-ops"))
 
-			Expect(cmd.RunCallCount()).To(Equal(1))
-			_, workingDirectory, args := cmd.RunArgsForCall(0)
-			Expect(workingDirectory).To(Equal(tempDir))
-			Expect(args).To(Equal(expectedArgs))
+			Expect(cloudConfigYAML).To(ContainSubstring("machine_type: e2-medium"))
+			Expect(cloudConfigYAML).NotTo(ContainSubstring("machine_type: some-machine-type"))
+			Expect(cloudConfigYAML).To(ContainSubstring("machine_type: some-user-machine-type"))
+			Expect(cloudConfigYAML).To(ContainSubstring("vm_type: compilation"))
+		})
+
+		It("sizes the preemptible compilation vm_type with the cloud config profile", func() {
+			incomingState.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "dev"}
+			opsGenerator.GenerateCall.Returns.OpsYAML = `
+- type: replace
+  path: /vm_types/name=compilation?
+  value:
+    name: compilation
+    cloud_properties:
+      machine_type: some-machine-type
+      preemptible: true
+`
+
+			cloudConfigYAML, err := manager.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
 
-			Expect(cloudConfigYAML).To(Equal("some-cloud-config"))
+			Expect(strings.Count(cloudConfigYAML, "name: compilation")).To(Equal(1))
+			Expect(cloudConfigYAML).To(ContainSubstring("machine_type: e2-highcpu-4"))
+			Expect(cloudConfigYAML).To(ContainSubstring("preemptible: true"))
 		})
 
 		Context("failure cases", func() {
-			Context("when temp dir fails", func() {
+			Context("when ops generator fails to generate", func() {
 				BeforeEach(func() {
-					cloudconfig.SetTempDir(func(string, string) (string, error) {
-						return "", errors.New("failed to create temp dir")
-					})
-				})
-
-				AfterEach(func() {
-					cloudconfig.ResetTempDir()
+					opsGenerator.GenerateCall.Returns.Error = errors.New("failed to generate")
 				})
 
 				It("returns an error", func() {
 					_, err := manager.Generate(storage.State{})
-					Expect(err).To(MatchError("failed to create temp dir"))
+					Expect(err).To(MatchError("failed to generate"))
 				})
 			})
 
-			Context("when write file fails to write cloud-config.yml", func() {
+			Context("when the ops cannot be applied", func() {
 				BeforeEach(func() {
-					cloudconfig.SetWriteFile(func(filename string, body []byte, mode os.FileMode) error {
-						if strings.Contains(filename, "cloud-config.yml") {
-							return errors.New("failed to write file")
-						}
-						return nil
-					})
-				})
-
-				AfterEach(func() {
-					cloudconfig.ResetWriteFile()
+					opsGenerator.GenerateCall.Returns.OpsYAML = "- type: remove\n  path: /some-missing-key\n"
 				})
 
 				It("returns an error", func() {
 					_, err := manager.Generate(storage.State{})
-					Expect(err).To(MatchError("failed to write file"))
+					Expect(err).To(MatchError("interpolate cloud config: Operation [0] (remove /some-missing-key): Expected to find a map key 'some-missing-key' for path '/some-missing-key'"))
 				})
 			})
 
-			Context("when ops generator fails to generate", func() {
-				BeforeEach(func() {
-					opsGenerator.GenerateCall.Returns.Error = errors.New("failed to generate")
-				})
-
+			Context("when the cloud config profile is not valid", func() {
 				It("returns an error", func() {
-					_, err := manager.Generate(storage.State{})
-					Expect(err).To(MatchError("failed to generate"))
+					incomingState.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "some-profile"}
+
+					_, err := manager.Generate(incomingState)
+					Expect(err).To(MatchError(`unknown cloud config profile "some-profile", use "dev", "prod" or the path to a profile`))
 				})
 			})
+		})
+	})
 
-			Context("when write file fails to write ops.yml", func() {
-				BeforeEach(func() {
-					cloudconfig.SetWriteFile(func(filename string, body []byte, mode os.FileMode) error {
-						if strings.Contains(filename, "ops.yml") {
-							return errors.New("failed to write file")
-						}
-						return nil
-					})
-				})
+	Describe("Diff", func() {
+		var cloudConfigYAML string
 
-				AfterEach(func() {
-					cloudconfig.ResetWriteFile()
-				})
+		BeforeEach(func() {
+			var err error
+			cloudConfigYAML, err = manager.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+		})
 
-				It("returns an error", func() {
-					_, err := manager.Generate(storage.State{})
-					Expect(err).To(MatchError("failed to write file"))
-				})
+		It("returns nothing when the director has the generated cloud config", func() {
+			boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+				{Type: "cloud", Name: "bbl", Content: cloudConfigYAML},
+			}
+
+			diff, err := manager.Diff(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
+
+		It("returns the changed lines of the bbl cloud config with some context", func() {
+			boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+				{Type: "cloud", Name: "default", Content: "some: default-config"},
+				{Type: "cloud", Name: "bbl", Content: strings.Replace(cloudConfigYAML, "some-machine-type", "some-old-machine-type", 1)},
+			}
+
+			diff, err := manager.Diff(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(ContainSubstring("\n-     machine_type: some-old-machine-type\n+     machine_type: some-machine-type\n"))
+			Expect(strings.Split(diff, "\n")).To(HaveLen(8))
+		})
+
+		It("compares against the default cloud config when there is no bbl cloud config", func() {
+			boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+				{Type: "cloud", Name: "default", Content: cloudConfigYAML},
+			}
+
+			diff, err := manager.Diff(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
+
+		It("ignores differences in formatting", func() {
+			boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+				{Type: "cloud", Name: "bbl", Content: "# some comment\n" + strings.Replace(cloudConfigYAML, ": ", ":   ", -1)},
+			}
+
+			diff, err := manager.Diff(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the cloud configs cannot be listed", func() {
+				boshClient.LatestConfigsCall.Returns.Error = errors.New("failed to list")
+
+				_, err := manager.Diff(incomingState)
+				Expect(err).To(MatchError("list cloud configs: failed to list"))
 			})
 
-			Context("when command fails to run", func() {
-				BeforeEach(func() {
-					cmd.RunReturns(errors.New("failed to run"))
-				})
+			It("returns an error when the cloud config on the director is not yaml", func() {
+				boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+					{Type: "cloud", Name: "bbl", Content: "%%%"},
+				}
 
-				It("returns an error", func() {
-					_, err := manager.Generate(storage.State{})
-					Expect(err).To(MatchError("failed to run"))
-				})
+				_, err := manager.Diff(incomingState)
+				Expect(err).To(MatchError(ContainSubstring("parse current cloud config")))
 			})
 		})
 	})
@@ -208,24 +276,154 @@ var _ = Describe("Manager", func() {
 			Expect(boshClientProvider.ClientCall.Receives.DirectorUsername).To(Equal("some-director-username"))
 			Expect(boshClientProvider.ClientCall.Receives.DirectorPassword).To(Equal("some-director-password"))
 
-			Expect(boshClient.UpdateCloudConfigCall.Receives.Yaml).To(Equal([]byte("some-cloud-config")))
+			cloudConfigYAML, err := manager.Generate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(boshClient.UpdateConfigCall.Receives.Type).To(Equal("cloud"))
+			Expect(boshClient.UpdateConfigCall.Receives.Name).To(Equal("bbl"))
+			Expect(boshClient.UpdateConfigCall.Receives.Content).To(Equal([]byte(cloudConfigYAML)))
+		})
+
+		It("prints the changes to the cloud config", func() {
+			err := manager.Update(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintlnCall.Messages).To(HaveLen(1))
+			Expect(logger.PrintlnCall.Messages[0]).To(ContainSubstring("+     machine_type: some-machine-type"))
+		})
+
+		Context("when the director already has the generated cloud config", func() {
+			It("does not apply it again", func() {
+				cloudConfigYAML, err := manager.Generate(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+					{Type: "cloud", Name: "bbl", Content: cloudConfigYAML},
+				}
+
+				err = manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+				Expect(logger.StepCall.Messages).To(ContainElement("cloud config is up to date"))
+			})
+		})
+
+		It("leaves other cloud configs alone", func() {
+			boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+				{Type: "cloud", Name: "bbl"},
+				{Type: "cloud", Name: "default"},
+				{Type: "cloud", Name: "some-team"},
+			}
+
+			err := manager.Update(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshClient.LatestConfigsCall.Receives.Type).To(Equal("cloud"))
+			Expect(boshClient.DeleteConfigCall.CallCount).To(Equal(0))
+		})
+
+		Context("when the director only has the default cloud config written by an earlier bbl", func() {
+			BeforeEach(func() {
+				boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+					{Type: "cloud", Name: "default", Content: "some: default-config"},
+				}
+			})
+
+			It("prints the default cloud config and deletes it before applying the bbl cloud config", func() {
+				boshClient.UpdateConfigCall.Stub = func(string, string, []byte) error {
+					Expect(boshClient.DeleteConfigCall.CallCount).To(Equal(1))
+					return nil
+				}
+
+				err := manager.Update(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("The default cloud config will be replaced by the \"bbl\" cloud config. It was:\nsome: default-config"))
+				Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(1))
+				Expect(boshClient.DeleteConfigCall.CallCount).To(Equal(1))
+				Expect(boshClient.DeleteConfigCall.Receives.Type).To(Equal("cloud"))
+				Expect(boshClient.DeleteConfigCall.Receives.Name).To(Equal("default"))
+				Expect(logger.StepCall.Messages).To(ContainElement(`moving the default cloud config to "bbl"`))
+			})
+
+			It("asks before deleting the default cloud config", func() {
+				stdin.Write([]byte("no\n"))
+
+				err := manager.ConfirmUpdate(incomingState)
+				Expect(err).To(Equal(cloudconfig.ErrNotApplied))
+
+				Expect(logger.PromptCall.Receives.Message).To(Equal("Do you want to delete the default cloud config and apply these changes to the cloud config?"))
+				Expect(boshClient.DeleteConfigCall.CallCount).To(Equal(0))
+				Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the default cloud config cannot be deleted", func() {
+				boshClient.DeleteConfigCall.Returns.Error = errors.New("failed to delete")
+
+				err := manager.Update(incomingState)
+				Expect(err).To(MatchError("delete default cloud config: failed to delete"))
+				Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+			})
+
+			Context("when the bbl cloud config cannot be applied", func() {
+				var restored []byte
+
+				BeforeEach(func() {
+					restored = nil
+					boshClient.UpdateConfigCall.Stub = func(configType, name string, content []byte) error {
+						if name == "default" {
+							restored = content
+							return nil
+						}
+						return errors.New("failed to update")
+					}
+				})
+
+				It("restores the default cloud config", func() {
+					err := manager.Update(incomingState)
+					Expect(err).To(MatchError("failed to update"))
+
+					Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(2))
+					Expect(string(restored)).To(Equal("some: default-config"))
+				})
+
+				It("returns both errors when the default cloud config cannot be restored", func() {
+					boshClient.UpdateConfigCall.Stub = func(string, string, []byte) error {
+						return errors.New("failed to update")
+					}
+
+					err := manager.Update(incomingState)
+					Expect(err).To(MatchError("failed to update, and the default cloud config could not be restored: failed to update"))
+				})
+			})
 		})
 
 		Context("failure cases", func() {
-			Context("when manager generate's command fails to run", func() {
+			Context("when manager generate fails", func() {
 				BeforeEach(func() {
-					cmd.RunReturns(errors.New("failed to run"))
+					opsGenerator.GenerateCall.Returns.Error = errors.New("failed to generate")
 				})
 
 				It("returns an error", func() {
 					err := manager.Update(storage.State{})
-					Expect(err).To(MatchError("failed to run"))
+					Expect(err).To(MatchError("failed to generate"))
+				})
+			})
+
+			Context("when bosh client fails to list the cloud configs", func() {
+				BeforeEach(func() {
+					boshClient.LatestConfigsCall.Returns.Error = errors.New("failed to list")
+				})
+
+				It("returns an error", func() {
+					err := manager.Update(storage.State{})
+					Expect(err).To(MatchError("list cloud configs: failed to list"))
 				})
 			})
 
 			Context("when bosh client fails to update cloud config", func() {
 				BeforeEach(func() {
-					boshClient.UpdateCloudConfigCall.Returns.Error = errors.New("failed to update")
+					boshClient.UpdateConfigCall.Returns.Error = errors.New("failed to update")
 				})
 
 				It("returns an error", func() {
@@ -235,4 +433,42 @@ var _ = Describe("Manager", func() {
 			})
 		})
 	})
+
+	Describe("ConfirmUpdate", func() {
+		BeforeEach(func() {
+			boshClient.LatestConfigsCall.Returns.Configs = []bosh.Config{
+				{Type: "cloud", Name: "bbl", Content: "some: old-config"},
+			}
+		})
+
+		It("applies the cloud config when the user confirms", func() {
+			stdin.Write([]byte("yes\n"))
+
+			err := manager.ConfirmUpdate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PromptCall.Receives.Message).To(Equal("Do you want to apply these changes to the cloud config?"))
+			Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(1))
+		})
+
+		It("does not apply the cloud config when the user declines", func() {
+			stdin.Write([]byte("no\n"))
+
+			err := manager.ConfirmUpdate(incomingState)
+			Expect(err).To(Equal(cloudconfig.ErrNotApplied))
+
+			Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(0))
+			Expect(logger.StepCall.Messages).To(ContainElement("not applying cloud config"))
+		})
+
+		It("applies the first cloud config of a new director without asking", func() {
+			boshClient.LatestConfigsCall.Returns.Configs = nil
+
+			err := manager.ConfirmUpdate(incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PromptCall.CallCount).To(Equal(0))
+			Expect(boshClient.UpdateConfigCall.CallCount).To(Equal(1))
+		})
+	})
 })
@@ -2,6 +2,9 @@ package cloudconfig
 
 import (
 	"errors"
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
@@ -12,6 +15,12 @@ type OpsGeneratorWrapper struct {
 	azureOpsGenerator OpsGenerator
 }
 
+type tagsOp struct {
+	Type  string
+	Path  string
+	Value map[string]string
+}
+
 func NewOpsGenerator(awsOpsGenerator OpsGenerator, gcpOpsGenerator OpsGenerator, azureOpsGenerator OpsGenerator) OpsGeneratorWrapper {
 	return OpsGeneratorWrapper{
 		awsOpsGenerator:   awsOpsGenerator,
@@ -21,14 +30,68 @@ func NewOpsGenerator(awsOpsGenerator OpsGenerator, gcpOpsGenerator OpsGenerator,
 }
 
 func (o OpsGeneratorWrapper) Generate(state storage.State) (string, error) {
+	var (
+		ops string
+		err error
+	)
+
 	switch state.IAAS {
 	case "gcp":
-		return o.gcpOpsGenerator.Generate(state)
+		ops, err = o.gcpOpsGenerator.Generate(state)
 	case "aws":
-		return o.awsOpsGenerator.Generate(state)
+		ops, err = o.awsOpsGenerator.Generate(state)
 	case "azure":
-		return o.azureOpsGenerator.Generate(state)
+		ops, err = o.azureOpsGenerator.Generate(state)
 	default:
 		return "", errors.New("invalid iaas type")
 	}
+	if err != nil {
+		return "", err
+	}
+
+	if len(state.Tags) == 0 {
+		return ops, nil
+	}
+
+	tagOps, err := generateTagsOps(state)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n%s", ops, tagOps), nil
+}
+
+// generateTagsOps adds the user provided tags to the cloud properties of
+// every vm type in the base cloud config. GCP calls them labels.
+func generateTagsOps(state storage.State) (string, error) {
+	var baseCloudConfig struct {
+		VMTypes []struct {
+			Name string
+		} `yaml:"vm_types"`
+	}
+	err := yaml.Unmarshal([]byte(BaseCloudConfig), &baseCloudConfig)
+	if err != nil {
+		return "", err //not tested
+	}
+
+	property := "tags"
+	if state.IAAS == "gcp" {
+		property = "labels"
+	}
+
+	var ops []tagsOp
+	for _, vmType := range baseCloudConfig.VMTypes {
+		ops = append(ops, tagsOp{
+			Type:  "replace",
+			Path:  fmt.Sprintf("/vm_types/name=%s/cloud_properties?/%s", vmType.Name, property),
+			Value: state.Tags,
+		})
+	}
+
+	opsYAML, err := yaml.Marshal(ops)
+	if err != nil {
+		return "", err //not tested
+	}
+
+	return string(opsYAML), nil
 }
@@ -52,6 +52,34 @@ var _ = Describe("OpsGenerator", func() {
 			}, "some-azure-ops"),
 		)
 
+		Context("when tags are provided", func() {
+			It("adds the tags to the cloud properties of every vm type", func() {
+				opsYAML, err := opsGenerator.Generate(storage.State{
+					IAAS: "aws",
+					Tags: map[string]string{"team": "some-team"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(HavePrefix("some-aws-ops\n"))
+				Expect(opsYAML).To(ContainSubstring(`- type: replace
+  path: /vm_types/name=default/cloud_properties?/tags
+  value:
+    team: some-team
+`))
+				Expect(opsYAML).To(ContainSubstring("path: /vm_types/name=extra-large/cloud_properties?/tags"))
+			})
+
+			It("adds them as labels on gcp", func() {
+				opsYAML, err := opsGenerator.Generate(storage.State{
+					IAAS: "gcp",
+					Tags: map[string]string{"team": "some-team"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(opsYAML).To(ContainSubstring("path: /vm_types/name=default/cloud_properties?/labels"))
+			})
+		})
+
 		Context("failure cases", func() {
 			It("returns an error if iaas is invalid", func() {
 				incomingState = storage.State{
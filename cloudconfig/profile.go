@@ -0,0 +1,250 @@
+package cloudconfig
+
+import (
+	"fmt"
+	"sort"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// Profile sizes the standard vm_types and the compilation workers of the
+// cloud config for one IAAS.
+type Profile struct {
+	VMTypes     map[string]string  `yaml:"vm_types"`
+	Compilation CompilationProfile `yaml:"compilation"`
+}
+
+type CompilationProfile struct {
+	InstanceType string `yaml:"instance_type"`
+	Workers      int    `yaml:"workers"`
+}
+
+// StandardVMTypes are the vm_types of the base cloud config that a profile
+// can size.
+var StandardVMTypes = []string{"default", "minimal", "sharedcpu", "small", "medium", "large", "extra-large"}
+
+// Profiles are the built-in profiles, by name and then by IAAS.
+var Profiles = map[string]map[string]Profile{
+	"dev": {
+		"aws": {
+			VMTypes: map[string]string{
+				"default":     "t3.medium",
+				"minimal":     "t3.small",
+				"sharedcpu":   "t3.small",
+				"small":       "t3.large",
+				"medium":      "m5.large",
+				"large":       "m5.xlarge",
+				"extra-large": "m5.2xlarge",
+			},
+			Compilation: CompilationProfile{InstanceType: "c5.large", Workers: 4},
+		},
+		"gcp": {
+			VMTypes: map[string]string{
+				"default":     "e2-medium",
+				"minimal":     "e2-small",
+				"sharedcpu":   "e2-micro",
+				"small":       "e2-standard-2",
+				"medium":      "e2-standard-4",
+				"large":       "e2-standard-8",
+				"extra-large": "e2-standard-16",
+			},
+			Compilation: CompilationProfile{InstanceType: "e2-highcpu-4", Workers: 4},
+		},
+		"azure": {
+			VMTypes: map[string]string{
+				"default":     "Standard_B2s",
+				"minimal":     "Standard_B1ms",
+				"sharedcpu":   "Standard_B1s",
+				"small":       "Standard_D2s_v3",
+				"medium":      "Standard_D4s_v3",
+				"large":       "Standard_D8s_v3",
+				"extra-large": "Standard_D16s_v3",
+			},
+			Compilation: CompilationProfile{InstanceType: "Standard_F4s_v2", Workers: 4},
+		},
+	},
+	"prod": {
+		"aws": {
+			VMTypes: map[string]string{
+				"default":     "m5.large",
+				"minimal":     "m5.large",
+				"sharedcpu":   "t3.medium",
+				"small":       "m5.large",
+				"medium":      "m5.xlarge",
+				"large":       "m5.2xlarge",
+				"extra-large": "m5.4xlarge",
+			},
+			Compilation: CompilationProfile{InstanceType: "c5.xlarge", Workers: 6},
+		},
+		"gcp": {
+			VMTypes: map[string]string{
+				"default":     "n2-standard-2",
+				"minimal":     "n2-standard-2",
+				"sharedcpu":   "e2-small",
+				"small":       "n2-standard-2",
+				"medium":      "n2-standard-4",
+				"large":       "n2-standard-8",
+				"extra-large": "n2-standard-16",
+			},
+			Compilation: CompilationProfile{InstanceType: "n2-highcpu-8", Workers: 6},
+		},
+		"azure": {
+			VMTypes: map[string]string{
+				"default":     "Standard_D2s_v3",
+				"minimal":     "Standard_D2s_v3",
+				"sharedcpu":   "Standard_B2s",
+				"small":       "Standard_D2s_v3",
+				"medium":      "Standard_D4s_v3",
+				"large":       "Standard_D8s_v3",
+				"extra-large": "Standard_D16s_v3",
+			},
+			Compilation: CompilationProfile{InstanceType: "Standard_F8s_v2", Workers: 6},
+		},
+	},
+}
+
+// LoadProfile returns the part of a built-in or custom profile for iaas. A
+// profile without contents is a built-in one, custom profiles are YAML files
+// keyed by IAAS and may leave out vm_types or the compilation settings, but
+// not the IAAS itself.
+func LoadProfile(profile storage.CloudConfigProfile, iaas string) (Profile, error) {
+	profiles, ok := Profiles[profile.Name]
+	if profile.Contents != "" {
+		profiles = map[string]Profile{}
+		err := yaml.UnmarshalStrict([]byte(profile.Contents), &profiles)
+		if err != nil {
+			return Profile{}, fmt.Errorf("parse cloud config profile %q: %s", profile.Name, err)
+		}
+	} else if !ok {
+		return Profile{}, fmt.Errorf("unknown cloud config profile %q, use \"dev\", \"prod\" or the path to a profile", profile.Name)
+	}
+
+	iaasProfile, ok := profiles[iaas]
+	if !ok {
+		return Profile{}, fmt.Errorf("cloud config profile %q has no sizes for %s", profile.Name, iaas)
+	}
+
+	for name := range iaasProfile.VMTypes {
+		if !isStandardVMType(name) {
+			return Profile{}, fmt.Errorf("cloud config profile %q sizes unknown vm_type %q", profile.Name, name)
+		}
+	}
+
+	return iaasProfile, nil
+}
+
+// InstanceTypes returns the distinct instance types the profile uses.
+func (p Profile) InstanceTypes() []string {
+	seen := map[string]bool{}
+	for _, instanceType := range p.VMTypes {
+		seen[instanceType] = true
+	}
+	if p.Compilation.InstanceType != "" {
+		seen[p.Compilation.InstanceType] = true
+	}
+
+	var instanceTypes []string
+	for instanceType := range seen {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	sort.Strings(instanceTypes)
+
+	return instanceTypes
+}
+
+// profileOps returns the ops that size the cloud config for iaas. The
+// compilation workers get a vm_type of their own so that sizing them does
+// not change any of the standard vm_types. It is updated in place when the
+// ops generator already added it for preemptible compilation.
+func profileOps(profile Profile, iaas string) (string, error) {
+	var ops []op
+
+	for _, name := range StandardVMTypes {
+		instanceType, ok := profile.VMTypes[name]
+		if !ok {
+			continue
+		}
+
+		ops = append(ops, op{
+			Type:  "replace",
+			Path:  fmt.Sprintf("/vm_types/name=%s/cloud_properties?/%s", name, instanceTypeProperty(iaas)),
+			Value: instanceType,
+		})
+	}
+
+	if profile.Compilation.InstanceType != "" {
+		cloudProperties := map[string]interface{}{
+			instanceTypeProperty(iaas): profile.Compilation.InstanceType,
+		}
+		switch iaas {
+		case "aws":
+			cloudProperties["ephemeral_disk"] = map[string]interface{}{"size": 10240, "type": "gp2"}
+		case "gcp":
+			cloudProperties["root_disk_size_gb"] = 10
+			cloudProperties["root_disk_type"] = "pd-ssd"
+		}
+
+		var properties []string
+		for property := range cloudProperties {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+
+		for _, property := range properties {
+			ops = append(ops, op{
+				Type:  "replace",
+				Path:  fmt.Sprintf("/vm_types/name=compilation?/cloud_properties?/%s", property),
+				Value: cloudProperties[property],
+			})
+		}
+
+		ops = append(ops, op{
+			Type:  "replace",
+			Path:  "/compilation/vm_type",
+			Value: "compilation",
+		})
+	}
+
+	if profile.Compilation.Workers > 0 {
+		ops = append(ops, op{
+			Type:  "replace",
+			Path:  "/compilation/workers",
+			Value: profile.Compilation.Workers,
+		})
+	}
+
+	if len(ops) == 0 {
+		return "[]", nil
+	}
+
+	output, err := yaml.Marshal(ops)
+	if err != nil {
+		return "", err //not tested
+	}
+
+	return string(output), nil
+}
+
+type op struct {
+	Type  string
+	Path  string
+	Value interface{}
+}
+
+func instanceTypeProperty(iaas string) string {
+	if iaas == "gcp" {
+		return "machine_type"
+	}
+	return "instance_type"
+}
+
+func isStandardVMType(name string) bool {
+	for _, standard := range StandardVMTypes {
+		if name == standard {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package cloudconfig_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoadProfile", func() {
+	It("returns a built-in profile for the iaas", func() {
+		profile, err := cloudconfig.LoadProfile(storage.CloudConfigProfile{Name: "prod"}, "aws")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(profile).To(Equal(cloudconfig.Profiles["prod"]["aws"]))
+	})
+
+	It("sizes every standard vm_type in the built-in profiles", func() {
+		for _, profiles := range cloudconfig.Profiles {
+			for _, iaas := range []string{"aws", "gcp", "azure"} {
+				Expect(profiles[iaas].VMTypes).To(HaveLen(len(cloudconfig.StandardVMTypes)))
+				Expect(profiles[iaas].Compilation.InstanceType).NotTo(BeEmpty())
+			}
+		}
+	})
+
+	It("parses a custom profile", func() {
+		profile, err := cloudconfig.LoadProfile(storage.CloudConfigProfile{
+			Name: "custom.yml",
+			Contents: `
+gcp:
+  vm_types:
+    default: n1-standard-2
+    large: n1-standard-8
+  compilation:
+    instance_type: n1-highcpu-16
+    workers: 8
+aws:
+  vm_types:
+    default: m5.large
+`,
+		}, "gcp")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(profile).To(Equal(cloudconfig.Profile{
+			VMTypes: map[string]string{
+				"default": "n1-standard-2",
+				"large":   "n1-standard-8",
+			},
+			Compilation: cloudconfig.CompilationProfile{
+				InstanceType: "n1-highcpu-16",
+				Workers:      8,
+			},
+		}))
+		Expect(profile.InstanceTypes()).To(Equal([]string{"n1-highcpu-16", "n1-standard-2", "n1-standard-8"}))
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the built-in profile does not exist", func() {
+			_, err := cloudconfig.LoadProfile(storage.CloudConfigProfile{Name: "some-profile"}, "aws")
+			Expect(err).To(MatchError(`unknown cloud config profile "some-profile", use "dev", "prod" or the path to a profile`))
+		})
+
+		It("returns an error when the custom profile is not valid yaml", func() {
+			_, err := cloudconfig.LoadProfile(storage.CloudConfigProfile{Name: "custom.yml", Contents: "%%%"}, "aws")
+			Expect(err).To(MatchError(ContainSubstring(`parse cloud config profile "custom.yml": `)))
+		})
+
+		It("returns an error when the custom profile sizes an unknown vm_type", func() {
+			_, err := cloudconfig.LoadProfile(storage.CloudConfigProfile{
+				Name:     "custom.yml",
+				Contents: "aws:\n  vm_types:\n    huge: m5.24xlarge\n",
+			}, "aws")
+			Expect(err).To(MatchError(`cloud config profile "custom.yml" sizes unknown vm_type "huge"`))
+		})
+
+		It("returns an error when the custom profile has no sizes for the iaas", func() {
+			_, err := cloudconfig.LoadProfile(storage.CloudConfigProfile{
+				Name:     "custom.yml",
+				Contents: "gcp:\n  vm_types:\n    default: n2-standard-2\n",
+			}, "aws")
+			Expect(err).To(MatchError(`cloud config profile "custom.yml" has no sizes for aws`))
+		})
+	})
+})
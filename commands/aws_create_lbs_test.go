@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -92,7 +93,41 @@ var _ = Describe("AWS Create LBs", func() {
 
 				Expect(terraformManager.ApplyCall.Receives.BBLState).To(Equal(statePassedToTerraform))
 				Expect(stateStore.SetCall.Receives[1].State).To(Equal(stateReturnedFromTerraform))
+				Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.LB.Type).To(Equal("cf"))
+			})
+
+			It("does not ask before updating the cloud config with --no-confirm", func() {
+				err := command.Execute(
+					commands.CreateLBsConfig{
+						AWS: commands.AWSCreateLBsConfig{
+							LBType:   "cf",
+							CertPath: certPath,
+							KeyPath:  keyPath,
+						},
+						NoConfirm: true,
+					},
+					incomingState,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
 				Expect(cloudConfigManager.UpdateCall.Receives.State.LB.Type).To(Equal("cf"))
+				Expect(cloudConfigManager.ConfirmUpdateCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the cloud config changes are declined", func() {
+				cloudConfigManager.ConfirmUpdateCall.Returns.Error = cloudconfig.ErrNotApplied
+
+				err := command.Execute(
+					commands.CreateLBsConfig{
+						AWS: commands.AWSCreateLBsConfig{
+							LBType:   "cf",
+							CertPath: certPath,
+							KeyPath:  keyPath,
+						},
+					},
+					incomingState,
+				)
+				Expect(err).To(MatchError("The cloud config changes were declined. Run the command again to apply them, or pass --no-confirm."))
 			})
 
 			Context("when the optional chain is provided", func() {
@@ -271,7 +306,7 @@ var _ = Describe("AWS Create LBs", func() {
 					incomingState,
 				)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+				Expect(cloudConfigManager.ConfirmUpdateCall.CallCount).To(Equal(0))
 			})
 		})
 
@@ -474,7 +509,7 @@ var _ = Describe("AWS Create LBs", func() {
 			})
 
 			It("returns an error when cloud config manager update fails", func() {
-				cloudConfigManager.UpdateCall.Returns.Error = errors.New("failed to update cloud config")
+				cloudConfigManager.ConfirmUpdateCall.Returns.Error = errors.New("failed to update cloud config")
 
 				err := command.Execute(
 					commands.CreateLBsConfig{
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+	"github.com/cloudfoundry/bosh-bootloader/aws/iam"
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type AWSDoctor struct {
+	awsClient         awsDoctorClient
+	templateResources awsTemplateResources
+	permissionChecker awsPermissionChecker
+	regions           *awsRegions
+}
+
+// awsRegions keeps the regions listed by CheckCredentials so CheckRegion does
+// not list them again.
+type awsRegions struct {
+	retrieved bool
+	regions   []string
+	err       error
+}
+
+type awsDoctorClient interface {
+	RetrieveRegions() ([]string, error)
+	RetrieveAvailabilityZones(string) ([]string, error)
+	CheckQuotas(string, ec2.Resources, ec2.Limits) error
+	CheckInstanceTypes([]string) error
+}
+
+type awsTemplateResources interface {
+	Resources(storage.State) ec2.Resources
+}
+
+type awsPermissionChecker interface {
+	CheckPermissions(features []string) error
+}
+
+func NewAWSDoctor(awsClient awsDoctorClient, templateResources awsTemplateResources, permissionChecker awsPermissionChecker) AWSDoctor {
+	return AWSDoctor{
+		awsClient:         awsClient,
+		templateResources: templateResources,
+		permissionChecker: permissionChecker,
+		regions:           &awsRegions{},
+	}
+}
+
+func (d AWSDoctor) CheckCredentials(state storage.State) error {
+	_, err := d.retrieveRegions()
+	if err != nil {
+		return fmt.Errorf("Retrieving regions: %s", err)
+	}
+
+	return nil
+}
+
+func (d AWSDoctor) CheckRegion(state storage.State) error {
+	regions, err := d.retrieveRegions()
+	if err != nil {
+		return fmt.Errorf("Retrieving regions: %s", err)
+	}
+
+	var found bool
+	for _, region := range regions {
+		if region == state.AWS.Region {
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("Region %q does not exist or is not enabled for this account", state.AWS.Region)
+	}
+
+	zones, err := d.awsClient.RetrieveAvailabilityZones(state.AWS.Region)
+	if err != nil {
+		return fmt.Errorf("Retrieving availability zones: %s", err)
+	}
+
+	if len(zones) == 0 {
+		return fmt.Errorf("Region %q has no availability zones", state.AWS.Region)
+	}
+
+	// The internal subnets of the availability zones are carved out of the
+	// VPC, so named networks can only be checked once the zones are known.
+	if len(state.Network.Named) > 0 {
+		networkPlan, err := bosh.NewNetworkPlan(state.Network.GetCIDR())
+		if err != nil {
+			return fmt.Errorf("Invalid network CIDR: %s", err)
+		}
+
+		err = networkPlan.CheckNamedNetworks("aws", state.Network.Named, zones)
+		if err != nil {
+			return fmt.Errorf("Invalid network: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (d AWSDoctor) retrieveRegions() ([]string, error) {
+	if !d.regions.retrieved {
+		d.regions.regions, d.regions.err = d.awsClient.RetrieveRegions()
+		d.regions.retrieved = true
+	}
+
+	return d.regions.regions, d.regions.err
+}
+
+// CheckQuotas checks there is room for the resources in desired that current
+// has not already created.
+func (d AWSDoctor) CheckQuotas(current, desired storage.State) error {
+	resources := d.templateResources.Resources(desired)
+	if current.TFState != "" {
+		resources = resources.Minus(d.templateResources.Resources(current))
+	}
+
+	if resources == (ec2.Resources{}) {
+		return nil
+	}
+
+	return d.awsClient.CheckQuotas(desired.AWS.Region, resources, ec2.Limits{
+		VPCs:        desired.AWS.VPCLimit,
+		NATGateways: desired.AWS.NATGatewayLimit,
+	})
+}
+
+// CheckPermissions checks the credentials are allowed every IAM action the
+// environment described by state needs.
+func (d AWSDoctor) CheckPermissions(state storage.State) error {
+	features := []string{iam.FeatureBase, iam.FeatureKMS}
+
+	switch state.LB.Type {
+	case "cf":
+		features = append(features, iam.FeatureCFLB)
+		if state.LB.Domain != "" {
+			features = append(features, iam.FeatureDNS)
+		}
+	case "concourse":
+		features = append(features, iam.FeatureConcourseLB)
+	}
+
+	return d.permissionChecker.CheckPermissions(features)
+}
+
+// CheckMachineTypes checks that the instance types of the cloud config profile
+// are known EC2 instance types. bbl only knows a list of instance types that
+// may be older than the region, so unknown ones are a warning.
+func (d AWSDoctor) CheckMachineTypes(state storage.State) error {
+	profile, err := cloudconfig.LoadProfile(state.BOSH.CloudConfigProfile, "aws")
+	if err != nil {
+		return err
+	}
+
+	err = d.awsClient.CheckInstanceTypes(profile.InstanceTypes())
+	if err != nil {
+		return DoctorWarning{Err: err}
+	}
+
+	return nil
+}
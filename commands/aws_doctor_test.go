@@ -0,0 +1,251 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+	"github.com/cloudfoundry/bosh-bootloader/aws/iam"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AWSDoctor", func() {
+	var (
+		awsDoctor         commands.AWSDoctor
+		awsClient         *fakes.AWSDoctorClient
+		templateResources *fakes.AWSTemplateResources
+		permissionChecker *fakes.AWSPermissionChecker
+		state             storage.State
+	)
+
+	BeforeEach(func() {
+		awsClient = &fakes.AWSDoctorClient{}
+		templateResources = &fakes.AWSTemplateResources{}
+		permissionChecker = &fakes.AWSPermissionChecker{}
+		awsClient.RetrieveRegionsCall.Returns.Regions = []string{"us-east-1", "eu-west-1"}
+		awsClient.RetrieveAvailabilityZonesCall.Returns.AZs = []string{"eu-west-1a"}
+		awsDoctor = commands.NewAWSDoctor(awsClient, templateResources, permissionChecker)
+
+		state = storage.State{AWS: storage.AWS{Region: "eu-west-1"}}
+	})
+
+	Describe("CheckCredentials", func() {
+		It("lists the regions", func() {
+			err := awsDoctor.CheckCredentials(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(awsClient.RetrieveRegionsCall.CallCount).To(Equal(1))
+		})
+
+		Context("when the credentials are invalid", func() {
+			It("returns an error", func() {
+				awsClient.RetrieveRegionsCall.Returns.Error = errors.New("AuthFailure")
+
+				err := awsDoctor.CheckCredentials(state)
+				Expect(err).To(MatchError("Retrieving regions: AuthFailure"))
+			})
+		})
+	})
+
+	Describe("CheckRegion", func() {
+		It("checks the region exists and has availability zones", func() {
+			err := awsDoctor.CheckRegion(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(awsClient.RetrieveAvailabilityZonesCall.Receives.Region).To(Equal("eu-west-1"))
+		})
+
+		It("reuses the regions listed when checking credentials", func() {
+			err := awsDoctor.CheckCredentials(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = awsDoctor.CheckRegion(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(awsClient.RetrieveRegionsCall.CallCount).To(Equal(1))
+		})
+
+		Context("when the region does not exist", func() {
+			It("returns an error", func() {
+				state.AWS.Region = "mars-north-1"
+
+				err := awsDoctor.CheckRegion(state)
+				Expect(err).To(MatchError(`Region "mars-north-1" does not exist or is not enabled for this account`))
+			})
+		})
+
+		Context("when the region has no availability zones", func() {
+			It("returns an error", func() {
+				awsClient.RetrieveAvailabilityZonesCall.Returns.AZs = []string{}
+
+				err := awsDoctor.CheckRegion(state)
+				Expect(err).To(MatchError(`Region "eu-west-1" has no availability zones`))
+			})
+		})
+
+		Context("when retrieving availability zones fails", func() {
+			It("returns an error", func() {
+				awsClient.RetrieveAvailabilityZonesCall.Returns.Error = errors.New("throttled")
+
+				err := awsDoctor.CheckRegion(state)
+				Expect(err).To(MatchError("Retrieving availability zones: throttled"))
+			})
+		})
+
+		Context("when there are named networks", func() {
+			BeforeEach(func() {
+				awsClient.RetrieveAvailabilityZonesCall.Returns.AZs = []string{"eu-west-1a", "eu-west-1b", "eu-west-1c"}
+				state.Network.CIDR = "10.0.0.0/16"
+			})
+
+			It("accepts networks past the subnets of the availability zones", func() {
+				state.Network.Named = []storage.NamedNetwork{{Name: "services", CIDR: "10.0.128.0/20"}}
+
+				err := awsDoctor.CheckRegion(state)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error when a network overlaps the subnet of an availability zone", func() {
+				state.Network.Named = []storage.NamedNetwork{{Name: "services", CIDR: "10.0.32.0/20"}}
+
+				err := awsDoctor.CheckRegion(state)
+				Expect(err).To(MatchError(`Invalid network: network "services" overlaps 10.0.32.0/20, which is the internal subnet of eu-west-1b`))
+			})
+		})
+	})
+
+	Describe("CheckQuotas", func() {
+		BeforeEach(func() {
+			templateResources.ResourcesCall.Stub = func(state storage.State) ec2.Resources {
+				if state.AWS.NATMode == storage.AWSNATModeGatewayPerAZ {
+					return ec2.Resources{VPCs: 1, ElasticIPs: 1, NATGatewaysPerAZ: 1}
+				}
+				return ec2.Resources{VPCs: 1, ElasticIPs: 2, NATGateways: 1}
+			}
+		})
+
+		Context("when the environment is new", func() {
+			It("checks quotas for everything the template creates", func() {
+				err := awsDoctor.CheckQuotas(state, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(awsClient.CheckQuotasCall.Receives.Region).To(Equal("eu-west-1"))
+				Expect(awsClient.CheckQuotasCall.Receives.Resources).To(Equal(ec2.Resources{VPCs: 1, ElasticIPs: 2, NATGateways: 1}))
+				Expect(awsClient.CheckQuotasCall.Receives.Limits).To(Equal(ec2.Limits{}))
+			})
+
+			It("checks against the limits saved in the state", func() {
+				state.AWS.VPCLimit = 10
+				state.AWS.NATGatewayLimit = 8
+
+				err := awsDoctor.CheckQuotas(state, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(awsClient.CheckQuotasCall.Receives.Limits).To(Equal(ec2.Limits{VPCs: 10, NATGateways: 8}))
+			})
+		})
+
+		Context("when the environment already exists", func() {
+			BeforeEach(func() {
+				state.TFState = "some-tf-state"
+			})
+
+			It("does not check quotas for resources that already exist", func() {
+				err := awsDoctor.CheckQuotas(state, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(awsClient.CheckQuotasCall.CallCount).To(Equal(0))
+			})
+
+			It("checks quotas for resources the change adds", func() {
+				desired := state
+				desired.AWS.NATMode = storage.AWSNATModeGatewayPerAZ
+
+				err := awsDoctor.CheckQuotas(state, desired)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(awsClient.CheckQuotasCall.Receives.Resources).To(Equal(ec2.Resources{NATGatewaysPerAZ: 1}))
+			})
+		})
+
+		Context("when there is not enough quota", func() {
+			It("returns the error", func() {
+				awsClient.CheckQuotasCall.Returns.Error = errors.New("Not enough quota")
+
+				err := awsDoctor.CheckQuotas(state, state)
+				Expect(err).To(MatchError("Not enough quota"))
+			})
+		})
+	})
+
+	Describe("CheckPermissions", func() {
+		It("checks the permissions for the base environment", func() {
+			err := awsDoctor.CheckPermissions(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(permissionChecker.CheckPermissionsCall.Receives.Features).To(Equal([]string{iam.FeatureBase, iam.FeatureKMS}))
+		})
+
+		It("checks the permissions for cf load balancers and dns", func() {
+			state.LB = storage.LB{Type: "cf", Domain: "cf.example.com"}
+
+			err := awsDoctor.CheckPermissions(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(permissionChecker.CheckPermissionsCall.Receives.Features).To(Equal([]string{iam.FeatureBase, iam.FeatureKMS, iam.FeatureCFLB, iam.FeatureDNS}))
+		})
+
+		It("checks the permissions for a concourse load balancer", func() {
+			state.LB = storage.LB{Type: "concourse"}
+
+			err := awsDoctor.CheckPermissions(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(permissionChecker.CheckPermissionsCall.Receives.Features).To(Equal([]string{iam.FeatureBase, iam.FeatureKMS, iam.FeatureConcourseLB}))
+		})
+
+		Context("when permissions are missing", func() {
+			It("returns the error", func() {
+				permissionChecker.CheckPermissionsCall.Returns.Error = errors.New("missing permissions")
+
+				err := awsDoctor.CheckPermissions(state)
+				Expect(err).To(MatchError("missing permissions"))
+			})
+		})
+	})
+
+	Describe("CheckMachineTypes", func() {
+		It("checks the instance types of the cloud config profile are known", func() {
+			state.BOSH.CloudConfigProfile = storage.CloudConfigProfile{
+				Name:     "custom.yml",
+				Contents: "aws:\n  vm_types:\n    default: m5.large\n  compilation:\n    instance_type: c5.large\n",
+			}
+
+			err := awsDoctor.CheckMachineTypes(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(awsClient.CheckInstanceTypesCall.Receives.InstanceTypes).To(Equal([]string{"c5.large", "m5.large"}))
+		})
+
+		Context("when the profile is not valid", func() {
+			It("returns an error", func() {
+				state.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "some-profile"}
+
+				err := awsDoctor.CheckMachineTypes(state)
+				Expect(err).To(MatchError(`unknown cloud config profile "some-profile", use "dev", "prod" or the path to a profile`))
+			})
+		})
+
+		Context("when instance types are not known", func() {
+			It("returns a warning", func() {
+				state.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "dev"}
+				awsClient.CheckInstanceTypesCall.Returns.Error = errors.New("unknown")
+
+				err := awsDoctor.CheckMachineTypes(state)
+				Expect(err).To(MatchError("unknown"))
+				Expect(err).To(BeAssignableToTypeOf(commands.DoctorWarning{}))
+			})
+		})
+	})
+})
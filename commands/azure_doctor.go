@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type AzureDoctor struct {
+	azureClient azureDoctorClient
+}
+
+type azureDoctorClient interface {
+	ValidateCredentials(subscriptionID, tenantID, clientID, clientSecret string) error
+	ValidateLocation(subscriptionID, tenantID, clientID, clientSecret, location string) error
+}
+
+func NewAzureDoctor(azureClient azureDoctorClient) AzureDoctor {
+	return AzureDoctor{
+		azureClient: azureClient,
+	}
+}
+
+func (d AzureDoctor) CheckCredentials(state storage.State) error {
+	err := d.azureClient.ValidateCredentials(state.Azure.SubscriptionID, state.Azure.TenantID, state.Azure.ClientID, state.Azure.ClientSecret)
+	if err != nil {
+		return fmt.Errorf("Validate credentials: %s", err)
+	}
+
+	return nil
+}
+
+func (d AzureDoctor) CheckRegion(state storage.State) error {
+	err := d.azureClient.ValidateLocation(state.Azure.SubscriptionID, state.Azure.TenantID, state.Azure.ClientID, state.Azure.ClientSecret, state.Azure.Location)
+	if err != nil {
+		return fmt.Errorf("Validate location: %s", err)
+	}
+
+	return nil
+}
+
+// CheckQuotas does not check anything, bbl does not know the Azure quotas.
+func (d AzureDoctor) CheckQuotas(current, desired storage.State) error {
+	return nil
+}
+
+// CheckPermissions does not check anything, bbl only simulates AWS policies.
+func (d AzureDoctor) CheckPermissions(state storage.State) error {
+	return nil
+}
+
+// CheckMachineTypes does not check anything, bbl does not list the Azure
+// instance types.
+func (d AzureDoctor) CheckMachineTypes(state storage.State) error {
+	return nil
+}
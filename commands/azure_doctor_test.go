@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AzureDoctor", func() {
+	var (
+		azureDoctor commands.AzureDoctor
+		azureClient *fakes.AzureClient
+		state       storage.State
+	)
+
+	BeforeEach(func() {
+		azureClient = &fakes.AzureClient{}
+		azureDoctor = commands.NewAzureDoctor(azureClient)
+
+		state = storage.State{
+			Azure: storage.Azure{
+				SubscriptionID: "subscription-id",
+				TenantID:       "tenant-id",
+				ClientID:       "client-id",
+				ClientSecret:   "client-secret",
+				Location:       "westus",
+			},
+		}
+	})
+
+	Describe("CheckCredentials", func() {
+		It("validates the credentials", func() {
+			err := azureDoctor.CheckCredentials(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(azureClient.ValidateCredentialsCall.Receives.SubscriptionID).To(Equal("subscription-id"))
+			Expect(azureClient.ValidateCredentialsCall.Receives.ClientSecret).To(Equal("client-secret"))
+		})
+
+		Context("given invalid credentials", func() {
+			It("returns the error", func() {
+				azureClient.ValidateCredentialsCall.Returns.Error = errors.New("fig")
+
+				err := azureDoctor.CheckCredentials(state)
+				Expect(err).To(MatchError("Validate credentials: fig"))
+			})
+		})
+	})
+
+	Describe("CheckRegion", func() {
+		It("validates the location", func() {
+			err := azureDoctor.CheckRegion(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(azureClient.ValidateLocationCall.Receives.TenantID).To(Equal("tenant-id"))
+			Expect(azureClient.ValidateLocationCall.Receives.Location).To(Equal("westus"))
+		})
+
+		Context("given an unknown location", func() {
+			It("returns the error", func() {
+				azureClient.ValidateLocationCall.Returns.Error = errors.New(`location "moon" does not support virtual machines`)
+
+				err := azureDoctor.CheckRegion(state)
+				Expect(err).To(MatchError(`Validate location: location "moon" does not support virtual machines`))
+			})
+		})
+	})
+})
@@ -44,7 +44,11 @@ func (b BOSHDeploymentVars) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("get terraform outputs: %s", err)
 	}
 
-	vars := b.boshManager.GetDirectorDeploymentVars(state, terraformOutputs)
+	vars, err := b.boshManager.GetDirectorDeploymentVars(state, terraformOutputs)
+	if err != nil {
+		return err
+	}
+
 	b.logger.Println(vars)
 	return nil
 }
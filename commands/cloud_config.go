@@ -1,6 +1,12 @@
 package commands
 
-import "github.com/cloudfoundry/bosh-bootloader/storage"
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
 
 const (
 	CloudConfigCommand = "cloud-config"
@@ -30,6 +36,27 @@ func (c CloudConfig) CheckFastFails(subcommandFlags []string, state storage.Stat
 }
 
 func (c CloudConfig) Execute(args []string, state storage.State) error {
+	var diff bool
+	cloudConfigFlags := flags.New("cloud-config")
+	cloudConfigFlags.Bool(&diff, "", "diff", false)
+
+	err := cloudConfigFlags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if diff {
+		changes, err := c.cloudConfigManager.Diff(state)
+		if err != nil {
+			return err
+		}
+
+		if changes != "" {
+			c.logger.Println(changes)
+		}
+		return nil
+	}
+
 	contents, err := c.cloudConfigManager.Generate(state)
 	if err != nil {
 		return err
@@ -37,3 +64,21 @@ func (c CloudConfig) Execute(args []string, state storage.State) error {
 	c.logger.Println(string(contents))
 	return nil
 }
+
+// errCloudConfigDeclined is returned when the user declines the changes to
+// the cloud config, so the command exits with them still unapplied.
+var errCloudConfigDeclined = errors.New("The cloud config changes were declined. Run the command again to apply them, or pass --no-confirm.")
+
+// updateCloudConfig applies the cloud config for state, asking before it
+// changes the one on the director unless noConfirm is set.
+func updateCloudConfig(manager cloudConfigManager, state storage.State, noConfirm bool) error {
+	if noConfirm {
+		return manager.Update(state)
+	}
+
+	err := manager.ConfirmUpdate(state)
+	if err == cloudconfig.ErrNotApplied {
+		return errCloudConfigDeclined
+	}
+	return err
+}
@@ -55,6 +55,30 @@ var _ = Describe("CloudConfig", func() {
 			Expect(logger.PrintlnCall.Messages).To(ContainElement("some-cloud-config"))
 		})
 
+		Context("when --diff is passed", func() {
+			It("prints the changes to the cloud config on the director", func() {
+				cloudConfigManager.DiffCall.Returns.Diff = "- some-old-line\n+ some-new-line"
+
+				err := cloudConfig.Execute([]string{"--diff"}, state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cloudConfigManager.DiffCall.Receives.State).To(Equal(state))
+				Expect(cloudConfigManager.GenerateCall.CallCount).To(Equal(0))
+				Expect(logger.PrintlnCall.Messages).To(Equal([]string{"- some-old-line\n+ some-new-line"}))
+			})
+
+			It("prints nothing when the cloud config is unchanged", func() {
+				err := cloudConfig.Execute([]string{"--diff"}, state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger.PrintlnCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the cloud config manager fails to diff", func() {
+				cloudConfigManager.DiffCall.Returns.Error = errors.New("failed to diff")
+				err := cloudConfig.Execute([]string{"--diff"}, state)
+				Expect(err).To(MatchError("failed to diff"))
+			})
+		})
+
 		Context("failure cases", func() {
 			It("returns an error when the cloud config manager fails to generate", func() {
 				cloudConfigManager.GenerateCall.Returns.Error = errors.New("failed to generate cloud configuration")
@@ -18,18 +18,44 @@ var _ = Describe("Commands Usage", func() {
 
   --iaas                     IAAS to deploy your BOSH director onto. Valid options: "aws", "azure", "gcp" (Defaults to environment variable BBL_IAAS)
   [--name]                   Name to assign to your BOSH director (optional, will be randomly generated)
-  [--ops-file]               Path to BOSH ops file (optional)
+  [--ops-file]               Path to a BOSH ops file or a directory of ops files, may be repeated (optional)
+  [--remove-ops-file]        Name of a previously provided ops file to stop applying, may be repeated (optional)
+  [--jumpbox-ops-file]       Path to an ops file or a directory of ops files for the jumpbox, may be repeated (optional)
+  [--remove-jumpbox-ops-file]  Name of a previously provided jumpbox ops file to stop applying, may be repeated (optional)
+  [--cloud-config-ops-file]  Path to an ops file or a directory of ops files applied to the generated cloud config, may be repeated (optional)
+  [--remove-cloud-config-ops-file]  Name of a previously provided cloud config ops file to stop applying, may be repeated (optional)
+  [--cloud-config-profile]   Sizes the vm_types of the cloud config with "dev", "prod" or the path to a profile (optional)
   [--no-director]            Skips creating BOSH environment
+  [--force-create-env]       Runs bosh create-env for the jumpbox and director even when their manifests are unchanged
+  [--force-terraform]        Runs terraform apply even when the template and variables are unchanged
+  [--network-cidr]           CIDR block of the network to create (optional, defaults to 10.0.0.0/16, must be at least a /20, or a /19 on AWS)
+  [--network]                Extra manual network for the cloud config with subnets and firewall rules of its own, in the form name:cidr, may be repeated, replaces the networks given before (optional, AWS and GCP only)
+  [--clear-networks]         Remove every named network given with --network before
+  [--private]                Creates the jumpbox and director without public IPs, they must be reachable over private routing
+  [--no-confirm]             Applies the changes to the cloud config without asking for confirmation (optional)
+  [--preemptible-compilation]  Compiles releases on GCP preemptible VMs or AWS spot instances
+  [--no-preemptible-compilation]  Compiles releases on regular VMs again
+  [--upload-stemcell]        Stemcell to upload once the director is up: "latest", a URL or the path to a tarball (optional)
+  [--runtime-config]         Runtime config to apply: "bosh-dns" or the path to a runtime config (optional)
+  [--tag]                    Tag to apply to every resource bbl creates, in the form key=value, may be repeated (Defaults to environment variable BBL_TAGS, a JSON object of tags)
+  [--clear-tags]             Remove the saved tags from every resource bbl creates
 
   --aws-access-key-id        AWS Access Key ID to use (Defaults to environment variable BBL_AWS_ACCESS_KEY_ID)
   --aws-secret-access-key    AWS Secret Access Key to use (Defaults to environment variable BBL_AWS_SECRET_ACCESS_KEY)
   --aws-region               AWS Region to use (Defaults to environment variable BBL_AWS_REGION)
   [--aws-bosh-az]            AWS Availability Zone to use for BOSH director (Defaults to environment variable BBL_AWS_BOSH_AZ)
+  [--aws-nat-mode]           NAT for internal subnets. Valid options: "gateway", "gateway-per-az", "instance" (Defaults to environment variable BBL_AWS_NAT_MODE, or "gateway" for new environments)
+  [--aws-spot-bid-price]     Most to pay per hour for instances with the spot vm_extension, in US dollars (Defaults to environment variable BBL_AWS_SPOT_BID_PRICE, or "0.10")
+  [--aws-vpc-limit]          VPC limit of the region when it has been raised, used by the quota checks (Defaults to environment variable BBL_AWS_VPC_LIMIT, or 5)
+  [--aws-nat-gateway-limit]  NAT gateway limit per availability zone when it has been raised, used by the quota checks (Defaults to environment variable BBL_AWS_NAT_GATEWAY_LIMIT, or 5)
 
   --gcp-service-account-key  GCP Service Access Key to use (Defaults to environment variable BBL_GCP_SERVICE_ACCOUNT_KEY)
   --gcp-project-id           GCP Project ID to use (Defaults to environment variable BBL_GCP_PROJECT_ID)
   --gcp-zone                 GCP Zone to use for BOSH director (Defaults to environment variable BBL_GCP_ZONE)
   --gcp-region               GCP Region to use (Defaults to environment variable BBL_GCP_REGION)
+  [--gcp-network]            Existing GCP Network to deploy into (Defaults to environment variable BBL_GCP_NETWORK)
+  [--gcp-subnetwork]         Existing GCP Subnetwork to deploy into, required with --gcp-network (Defaults to environment variable BBL_GCP_SUBNETWORK)
+  [--gcp-network-project]    GCP Shared VPC host project that owns the network (Defaults to environment variable BBL_GCP_NETWORK_PROJECT)
 
   --azure-subscription-id    Azure Subscription ID to use (Defaults to environment variable BBL_AZURE_SUBSCRIPTION_ID)
   --azure-tenant-id          Azure Tenant ID to use (Defaults to environment variable BBL_AZURE_TENANT_ID)
@@ -52,6 +78,7 @@ var _ = Describe("Commands Usage", func() {
   [--key]             Path to SSL certificate key (conditionally required; refer to table below)
   [--chain]           Path to SSL certificate chain (optional; only supported on aws)
   [--domain]          Creates a DNS zone and records for the given domain (supported when type="cf")
+  [--no-confirm]      Applies the changes to the cloud config without asking for confirmation (optional)
 
   --cert/--key requirements:
   ------------------------------
@@ -84,8 +111,25 @@ var _ = Describe("Commands Usage", func() {
 				usageText := command.Usage()
 				Expect(usageText).To(Equal(`Tears down BOSH director infrastructure
 
-  [--no-confirm]       Do not ask for confirmation (optional)
-  [--skip-if-missing]  Gracefully exit if there is no state file (optional)`))
+  [--no-confirm]          Do not ask for confirmation (optional)
+  [--skip-if-missing]     Gracefully exit if there is no state file (optional)
+  [--delete-deployments]  Delete every deployment on the director before tearing it down (optional)
+  [--force]               Ignore errors while deleting deployments, requires --delete-deployments (optional)`))
+			})
+		})
+	})
+
+	Describe("UpgradeDirector", func() {
+		Describe("Usage", func() {
+			It("returns string describing usage", func() {
+				command := commands.UpgradeDirector{}
+				usageText := command.Usage()
+				Expect(usageText).To(Equal(`Shows the current and target director, UAA, CredHub and CPI versions and stemcell, and upgrades the BOSH director
+
+  [--versions-file]  Path to a versions file pinning releases and a stemcell, kept in the state (optional)
+  [--unpin]          Remove the pinned versions and upgrade to the versions of this bbl (optional)
+  [--rollback]       Roll back to the director before the last upgrade and pin its versions (optional)
+  [--no-confirm]     Do not ask for confirmation (optional)`))
 			})
 		})
 	})
@@ -100,6 +144,34 @@ var _ = Describe("Commands Usage", func() {
 		})
 	})
 
+	Describe("Status", func() {
+		Describe("Usage", func() {
+			It("returns string describing usage", func() {
+				command := commands.Status{}
+				usageText := command.Usage()
+				Expect(usageText).To(Equal(`Checks that the jumpbox, director, UAA and CredHub are reachable and healthy
+
+  [--json]  Prints the status of each component as JSON (optional)
+
+  Exits 0 when every component is healthy and 2 when any component is unhealthy.`))
+			})
+		})
+	})
+
+	Describe("Doctor", func() {
+		Describe("Usage", func() {
+			It("returns string describing usage", func() {
+				command := commands.Doctor{}
+				usageText := command.Usage()
+				Expect(usageText).To(Equal(`Checks that bbl can create an environment and prints what to fix
+
+  Checks the terraform and bosh binaries, the IAAS credentials, region, zone,
+  quotas and IAM permissions, the state directory and a local port for the
+  jumpbox proxy. The same checks run before "bbl up".`))
+			})
+		})
+	})
+
 	DescribeTable("command description", func(command commands.Command, expectedDescription string) {
 		usageText := command.Usage()
 		Expect(usageText).To(Equal(expectedDescription))
@@ -118,7 +190,7 @@ var _ = Describe("Commands Usage", func() {
 		Entry("bosh-deployment-vars", commands.BOSHDeploymentVars{}, "Prints required variables for BOSH deployment"),
 		Entry("jumpbox-deployment-vars", commands.JumpboxDeploymentVars{}, "Prints required variables for jumpbox deployment"),
 		Entry("version", commands.Version{}, "Prints version"),
-		Entry("cloud-config", commands.CloudConfig{}, "Prints suggested cloud configuration for BOSH environment"),
+		Entry("cloud-config", commands.CloudConfig{}, "Prints suggested cloud configuration for BOSH environment\n\n  [--diff]  Prints the changes from the cloud config on the director instead (optional)"),
 	)
 })
 
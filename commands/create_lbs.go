@@ -14,6 +14,12 @@ type CreateLBs struct {
 	certificateValidator certificateValidator
 	logger               logger
 	stateValidator       stateValidator
+	preflightChecker     preflightChecker
+}
+
+type preflightChecker interface {
+	CheckQuotas(current, desired storage.State) error
+	CheckPermissions(storage.State) error
 }
 
 type CreateLBsCmd interface {
@@ -21,19 +27,21 @@ type CreateLBsCmd interface {
 }
 
 type CreateLBsConfig struct {
-	AWS AWSCreateLBsConfig
-	GCP GCPCreateLBsConfig
+	AWS       AWSCreateLBsConfig
+	GCP       GCPCreateLBsConfig
+	NoConfirm bool
 }
 
 var LBNotFound error = errors.New("no load balancer has been found for this bbl environment")
 
-func NewCreateLBs(createLBsCmd CreateLBsCmd, logger logger, stateValidator stateValidator, certificateValidator certificateValidator, boshManager boshManager) CreateLBs {
+func NewCreateLBs(createLBsCmd CreateLBsCmd, logger logger, stateValidator stateValidator, certificateValidator certificateValidator, boshManager boshManager, preflightChecker preflightChecker) CreateLBs {
 	return CreateLBs{
 		createLBsCmd:         createLBsCmd,
 		boshManager:          boshManager,
 		logger:               logger,
 		stateValidator:       stateValidator,
 		certificateValidator: certificateValidator,
+		preflightChecker:     preflightChecker,
 	}
 }
 
@@ -69,6 +77,22 @@ func (c CreateLBs) CheckFastFails(subcommandFlags []string, state storage.State)
 		}
 	}
 
+	if c.preflightChecker != nil {
+		desired := state
+		desired.LB.Type = getLBType(config)
+		desired.LB.Domain = getDomain(config)
+
+		err = c.preflightChecker.CheckQuotas(state, desired)
+		if err != nil {
+			return err
+		}
+
+		err = c.preflightChecker.CheckPermissions(desired)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -103,6 +127,7 @@ func parseFlags(subcommandFlags []string, iaas string, existingLBType string) (C
 		lbFlags.String(&config.GCP.KeyPath, "key", "")
 		lbFlags.String(&config.GCP.Domain, "domain", "")
 	}
+	lbFlags.Bool(&config.NoConfirm, "n", "no-confirm", false)
 
 	if err := lbFlags.Parse(subcommandFlags); err != nil {
 		return config, err
@@ -19,6 +19,7 @@ var _ = Describe("create-lbs", func() {
 		certificateValidator *fakes.CertificateValidator
 		logger               *fakes.Logger
 		stateValidator       *fakes.StateValidator
+		preflightChecker     *fakes.IAASChecker
 	)
 
 	BeforeEach(func() {
@@ -28,8 +29,9 @@ var _ = Describe("create-lbs", func() {
 		certificateValidator = &fakes.CertificateValidator{}
 		logger = &fakes.Logger{}
 		stateValidator = &fakes.StateValidator{}
+		preflightChecker = &fakes.IAASChecker{}
 
-		command = commands.NewCreateLBs(createLBsCmd, logger, stateValidator, certificateValidator, boshManager)
+		command = commands.NewCreateLBs(createLBsCmd, logger, stateValidator, certificateValidator, boshManager, preflightChecker)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -129,6 +131,56 @@ var _ = Describe("create-lbs", func() {
 				Expect(err).To(MatchError("--domain is not implemented for concourse load balancers. Remove the --domain flag and try again."))
 			})
 		})
+
+		It("checks the quotas for the load balancers", func() {
+			state := storage.State{IAAS: "gcp", TFState: "some-tf-state"}
+			err := command.CheckFastFails([]string{
+				"--type", "cf",
+				"--domain", "cf.example.com",
+			}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(preflightChecker.CheckQuotasCall.CallCount).To(Equal(1))
+			Expect(preflightChecker.CheckQuotasCall.Receives.Current).To(Equal(state))
+			Expect(preflightChecker.CheckQuotasCall.Receives.Desired.LB.Type).To(Equal("cf"))
+			Expect(preflightChecker.CheckQuotasCall.Receives.Desired.LB.Domain).To(Equal("cf.example.com"))
+		})
+
+		Context("when there is not enough quota for the load balancers", func() {
+			It("returns an error", func() {
+				preflightChecker.CheckQuotasCall.Returns.Error = errors.New("Not enough quota")
+				err := command.CheckFastFails([]string{
+					"--type", "concourse",
+				}, storage.State{
+					IAAS: "gcp",
+				})
+				Expect(err).To(MatchError("Not enough quota"))
+			})
+		})
+
+		It("checks the permissions for the load balancers", func() {
+			err := command.CheckFastFails([]string{
+				"--type", "concourse",
+			}, storage.State{
+				IAAS: "aws",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(preflightChecker.CheckPermissionsCall.CallCount).To(Equal(1))
+			Expect(preflightChecker.CheckPermissionsCall.Receives.State.LB.Type).To(Equal("concourse"))
+		})
+
+		Context("when permissions are missing for the load balancers", func() {
+			It("returns an error", func() {
+				preflightChecker.CheckPermissionsCall.Returns.Error = errors.New("missing permissions")
+				err := command.CheckFastFails([]string{
+					"--type", "concourse",
+				}, storage.State{
+					IAAS: "gcp",
+				})
+				Expect(err).To(MatchError("missing permissions"))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
@@ -187,6 +239,17 @@ var _ = Describe("create-lbs", func() {
 			))
 		})
 
+		It("passes --no-confirm to the command", func() {
+			err := command.Execute([]string{
+				"--type", "concourse",
+				"-n",
+			}, storage.State{
+				IAAS: "gcp",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createLBsCmd.ExecuteCall.Receives.Config.NoConfirm).To(BeTrue())
+		})
+
 		Context("when an LB already exists", func() {
 			Context("using GCP", func() {
 				It("creates a GCP lb using the existing LB type", func() {
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -19,11 +20,14 @@ type Destroy struct {
 	stateValidator           stateValidator
 	terraformManager         terraformDestroyer
 	networkDeletionValidator NetworkDeletionValidator
+	deploymentDeleter        deploymentDeleter
 }
 
 type destroyConfig struct {
-	NoConfirm     bool
-	SkipIfMissing bool
+	NoConfirm         bool
+	SkipIfMissing     bool
+	DeleteDeployments bool
+	Force             bool
 }
 
 type NetworkDeletionValidator interface {
@@ -32,7 +36,8 @@ type NetworkDeletionValidator interface {
 
 func NewDestroy(logger logger, stdin io.Reader,
 	boshManager boshManager, stateStore stateStore, stateValidator stateValidator,
-	terraformManager terraformDestroyer, networkDeletionValidator NetworkDeletionValidator) Destroy {
+	terraformManager terraformDestroyer, networkDeletionValidator NetworkDeletionValidator,
+	deploymentDeleter deploymentDeleter) Destroy {
 	return Destroy{
 		logger:                   logger,
 		stdin:                    stdin,
@@ -41,6 +46,7 @@ func NewDestroy(logger logger, stdin io.Reader,
 		stateValidator:           stateValidator,
 		terraformManager:         terraformManager,
 		networkDeletionValidator: networkDeletionValidator,
+		deploymentDeleter:        deploymentDeleter,
 	}
 }
 
@@ -62,6 +68,14 @@ func (d Destroy) CheckFastFails(subcommandFlags []string, state storage.State) e
 		return err
 	}
 
+	if config.Force && !config.DeleteDeployments {
+		return errors.New("--force can only be used with --delete-deployments")
+	}
+
+	if config.DeleteDeployments && state.NoDirector {
+		return errors.New("There is no director to delete deployments from.")
+	}
+
 	if config.SkipIfMissing && state.EnvID == "" {
 		d.logger.Step("state file not found, and --skip-if-missing flag provided, exiting")
 		return nil
@@ -72,11 +86,23 @@ func (d Destroy) CheckFastFails(subcommandFlags []string, state storage.State) e
 		return err
 	}
 
+	// The deployments are deleted before the network, so VMs left in it are
+	// not a reason to stop yet. Execute checks the network once they are gone.
+	if config.DeleteDeployments && !state.BOSH.IsEmpty() {
+		return nil
+	}
+
 	terraformOutputs, err := d.terraformManager.GetOutputs(state)
 	if err != nil {
 		return nil
 	}
 
+	return d.validateSafeToDelete(state, terraformOutputs)
+}
+
+// validateSafeToDelete returns an error when the network has VMs that bbl did
+// not create in it.
+func (d Destroy) validateSafeToDelete(state storage.State, terraformOutputs map[string]interface{}) error {
 	var networkName string
 	if state.IAAS == "gcp" {
 		output, ok := terraformOutputs["network_name"]
@@ -94,12 +120,7 @@ func (d Destroy) CheckFastFails(subcommandFlags []string, state storage.State) e
 		return nil
 	}
 
-	err = d.networkDeletionValidator.ValidateSafeToDelete(networkName, state.EnvID)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return d.networkDeletionValidator.ValidateSafeToDelete(networkName, state.EnvID)
 }
 
 func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
@@ -109,7 +130,11 @@ func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 	}
 
 	if !config.NoConfirm {
-		d.logger.Prompt(fmt.Sprintf("Are you sure you want to delete infrastructure for %q? This operation cannot be undone!", state.EnvID))
+		prompt := fmt.Sprintf("Are you sure you want to delete infrastructure for %q? This operation cannot be undone!", state.EnvID)
+		if config.DeleteDeployments {
+			prompt = fmt.Sprintf("Are you sure you want to delete all deployments and infrastructure for %q? This operation cannot be undone!", state.EnvID)
+		}
+		d.logger.Prompt(prompt)
 
 		var proceed string
 		fmt.Fscanln(d.stdin, &proceed)
@@ -126,6 +151,18 @@ func (d Destroy) Execute(subcommandFlags []string, state storage.State) error {
 		return err
 	}
 
+	if config.DeleteDeployments && !state.NoDirector && !state.BOSH.IsEmpty() {
+		err = d.deploymentDeleter.DeleteDeployments(state, config.Force)
+		if err != nil {
+			return fmt.Errorf("Delete deployments: %s", err)
+		}
+
+		err = d.validateSafeToDelete(state, terraformOutputs)
+		if err != nil {
+			return err
+		}
+	}
+
 	state, err = d.deleteBOSH(state, terraformOutputs)
 	switch err.(type) {
 	case bosh.ManagerDeleteError:
@@ -164,6 +201,8 @@ func (d Destroy) parseFlags(subcommandFlags []string) (destroyConfig, error) {
 	config := destroyConfig{}
 	destroyFlags.Bool(&config.NoConfirm, "n", "no-confirm", false)
 	destroyFlags.Bool(&config.SkipIfMissing, "", "skip-if-missing", false)
+	destroyFlags.Bool(&config.DeleteDeployments, "", "delete-deployments", false)
+	destroyFlags.Bool(&config.Force, "", "force", false)
 
 	err := destroyFlags.Parse(subcommandFlags)
 	if err != nil {
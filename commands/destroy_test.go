@@ -27,6 +27,7 @@ var _ = Describe("Destroy", func() {
 		terraformManager         *fakes.TerraformManager
 		terraformManagerError    *fakes.TerraformManagerError
 		networkDeletionValidator *fakes.NetworkDeletionValidator
+		deploymentDeleter        *fakes.DeploymentDeleter
 		stdin                    *bytes.Buffer
 	)
 
@@ -41,9 +42,10 @@ var _ = Describe("Destroy", func() {
 		terraformManager = &fakes.TerraformManager{}
 		terraformManagerError = &fakes.TerraformManagerError{}
 		networkDeletionValidator = &fakes.NetworkDeletionValidator{}
+		deploymentDeleter = &fakes.DeploymentDeleter{}
 
 		destroy = commands.NewDestroy(logger, stdin, boshManager, stateStore,
-			stateValidator, terraformManager, networkDeletionValidator)
+			stateValidator, terraformManager, networkDeletionValidator, deploymentDeleter)
 	})
 
 	Describe("CheckFastFails", func() {
@@ -82,6 +84,16 @@ var _ = Describe("Destroy", func() {
 			Expect(logger.StepCall.Receives.Message).To(Equal("state file not found, and --skip-if-missing flag provided, exiting"))
 		})
 
+		It("returns an error when --force is passed without --delete-deployments", func() {
+			err := destroy.CheckFastFails([]string{"--force"}, storage.State{})
+			Expect(err).To(MatchError("--force can only be used with --delete-deployments"))
+		})
+
+		It("returns an error when --delete-deployments is passed without a director", func() {
+			err := destroy.CheckFastFails([]string{"--delete-deployments"}, storage.State{NoDirector: true})
+			Expect(err).To(MatchError("There is no director to delete deployments from."))
+		})
+
 		It("returns an error when state validator fails", func() {
 			stateValidator.ValidateCall.Returns.Error = errors.New("state validator failed")
 			err := destroy.CheckFastFails([]string{}, storage.State{})
@@ -163,6 +175,27 @@ var _ = Describe("Destroy", func() {
 				Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.EnvID).To(Equal("some-env-id"))
 			})
 
+			Context("when --delete-deployments is passed", func() {
+				It("does not check for VMs in the VPC when there is a director to delete them", func() {
+					terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{"vpc_id": "some-vpc-id"}
+					networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("vpc some-vpc-id is not safe to delete")
+					state.BOSH = storage.BOSH{DirectorName: "some-director"}
+
+					err := destroy.CheckFastFails([]string{"--delete-deployments"}, state)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(0))
+				})
+
+				It("checks for VMs in the VPC when the director is already gone", func() {
+					terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{"vpc_id": "some-vpc-id"}
+					networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("vpc some-vpc-id is not safe to delete")
+
+					err := destroy.CheckFastFails([]string{"--delete-deployments"}, state)
+					Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete"))
+				})
+			})
+
 			Context("when terraform manager fails to get outputs", func() {
 				It("does not fast fail", func() {
 					terraformManager.GetOutputsCall.Returns.Error = errors.New("failed to get outputs")
@@ -299,6 +332,96 @@ var _ = Describe("Destroy", func() {
 			Expect(stateStore.SetCall.Receives[1].State).To(Equal(storage.State{}))
 		})
 
+		Context("when --delete-deployments is passed", func() {
+			var state storage.State
+
+			BeforeEach(func() {
+				state = storage.State{
+					EnvID: "some-lake",
+					BOSH: storage.BOSH{
+						DirectorName: "some-director",
+					},
+				}
+			})
+
+			It("deletes the deployments before the director", func() {
+				err := destroy.Execute([]string{"--no-confirm", "--delete-deployments"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(deploymentDeleter.DeleteDeploymentsCall.CallCount).To(Equal(1))
+				Expect(deploymentDeleter.DeleteDeploymentsCall.Receives.State).To(Equal(state))
+				Expect(deploymentDeleter.DeleteDeploymentsCall.Receives.Force).To(BeFalse())
+				Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(1))
+			})
+
+			It("forces the deletes with --force", func() {
+				err := destroy.Execute([]string{"--no-confirm", "--delete-deployments", "--force"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(deploymentDeleter.DeleteDeploymentsCall.Receives.Force).To(BeTrue())
+			})
+
+			It("asks to confirm deleting the deployments", func() {
+				stdin.Write([]byte("yes\n"))
+
+				err := destroy.Execute([]string{"--delete-deployments"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PromptCall.Receives.Message).To(Equal(`Are you sure you want to delete all deployments and infrastructure for "some-lake"? This operation cannot be undone!`))
+			})
+
+			It("does not delete deployments when the director is already gone", func() {
+				err := destroy.Execute([]string{"--no-confirm", "--delete-deployments"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(deploymentDeleter.DeleteDeploymentsCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error and keeps the director when a deployment cannot be deleted", func() {
+				deploymentDeleter.DeleteDeploymentsCall.Returns.Error = errors.New("delete deployment cf: task 7 error: some-result")
+
+				err := destroy.Execute([]string{"--no-confirm", "--delete-deployments"}, state)
+				Expect(err).To(MatchError("Delete deployments: delete deployment cf: task 7 error: some-result"))
+
+				Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(0))
+			})
+
+			It("checks for VMs left in the VPC once the deployments are deleted", func() {
+				state.IAAS = "aws"
+				terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{"vpc_id": "some-vpc-id"}
+
+				err := destroy.Execute([]string{"--no-confirm", "--delete-deployments"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(networkDeletionValidator.ValidateSafeToDeleteCall.CallCount).To(Equal(1))
+				Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.NetworkName).To(Equal("some-vpc-id"))
+				Expect(networkDeletionValidator.ValidateSafeToDeleteCall.Receives.EnvID).To(Equal("some-lake"))
+			})
+
+			It("returns an error and keeps the director when VMs are left in the VPC", func() {
+				state.IAAS = "aws"
+				terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{"vpc_id": "some-vpc-id"}
+				networkDeletionValidator.ValidateSafeToDeleteCall.Returns.Error = errors.New("vpc some-vpc-id is not safe to delete")
+
+				err := destroy.Execute([]string{"--no-confirm", "--delete-deployments"}, state)
+				Expect(err).To(MatchError("vpc some-vpc-id is not safe to delete"))
+
+				Expect(deploymentDeleter.DeleteDeploymentsCall.CallCount).To(Equal(1))
+				Expect(boshManager.DeleteDirectorCall.CallCount).To(Equal(0))
+			})
+		})
+
+		It("does not delete deployments without --delete-deployments", func() {
+			err := destroy.Execute([]string{"--no-confirm"}, storage.State{
+				BOSH: storage.BOSH{
+					DirectorName: "some-director",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deploymentDeleter.DeleteDeploymentsCall.CallCount).To(Equal(0))
+		})
+
 		Context("failure cases", func() {
 			BeforeEach(func() {
 				stdin.Write([]byte("yes\n"))
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+)
+
+type Doctor struct {
+	logger           logger
+	terraformManager terraformVersionValidator
+	boshManager      boshManager
+	iaasChecker      IAASChecker
+	portChecker      portChecker
+	stateDir         string
+}
+
+type terraformVersionValidator interface {
+	ValidateVersion() error
+}
+
+type IAASChecker interface {
+	CheckCredentials(storage.State) error
+	CheckRegion(storage.State) error
+	CheckQuotas(current, desired storage.State) error
+	CheckPermissions(storage.State) error
+	CheckMachineTypes(storage.State) error
+}
+
+type portChecker interface {
+	CheckPort() error
+}
+
+type DoctorCheck struct {
+	Name  string
+	Error error
+	Fix   string
+}
+
+// DoctorWarning is returned by a check that cannot be sure of the problem it
+// found. It is reported, but does not fail the check.
+type DoctorWarning struct {
+	Err error
+}
+
+func (w DoctorWarning) Error() string {
+	return w.Err.Error()
+}
+
+func (c DoctorCheck) failed() bool {
+	_, warning := c.Error.(DoctorWarning)
+	return c.Error != nil && !warning
+}
+
+func NewDoctor(logger logger, terraformManager terraformVersionValidator, boshManager boshManager,
+	iaasChecker IAASChecker, portChecker portChecker, stateDir string) Doctor {
+	return Doctor{
+		logger:           logger,
+		terraformManager: terraformManager,
+		boshManager:      boshManager,
+		iaasChecker:      iaasChecker,
+		portChecker:      portChecker,
+		stateDir:         stateDir,
+	}
+}
+
+func (d Doctor) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	return nil
+}
+
+func (d Doctor) Execute(subcommandFlags []string, state storage.State) error {
+	checks := d.Run(state)
+
+	var failed int
+	for _, check := range checks {
+		d.logger.Println(check.String())
+		if check.failed() {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+
+	return nil
+}
+
+// Check runs every check for desired and returns an error listing the ones
+// that failed. Warnings are printed and do not stop bbl. The quotas only have
+// to make room for the resources that the current state has not already
+// created.
+func (d Doctor) Check(current, desired storage.State) error {
+	var failed []string
+	for _, check := range d.run(current, desired) {
+		if check.failed() {
+			failed = append(failed, check.String())
+		} else if check.Error != nil {
+			d.logger.Println(check.String())
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("bbl doctor found problems with your environment:\n%s", strings.Join(failed, "\n"))
+	}
+
+	return nil
+}
+
+// Run checks that bbl can create an environment described by state. The bosh
+// cli is not checked when the environment has no director.
+func (d Doctor) Run(state storage.State) []DoctorCheck {
+	return d.run(state, state)
+}
+
+func (d Doctor) run(current, state storage.State) []DoctorCheck {
+	checks := []DoctorCheck{{
+		Name:  "terraform binary",
+		Error: d.terraformManager.ValidateVersion(),
+		Fix:   fmt.Sprintf("Install terraform v%s or later and make sure it is on your PATH.", terraform.MinimumVersion),
+	}}
+
+	if !state.NoDirector {
+		checks = append(checks, DoctorCheck{
+			Name:  "bosh binary",
+			Error: fastFailBOSHVersion(d.boshManager),
+			Fix:   fmt.Sprintf("Install the bosh cli v%s or later and make sure it is on your PATH.", minimumBOSHVersion),
+		})
+	}
+
+	if d.iaasChecker != nil {
+		checks = append(checks, DoctorCheck{
+			Name:  fmt.Sprintf("%s credentials", state.IAAS),
+			Error: d.iaasChecker.CheckCredentials(state),
+			Fix:   fmt.Sprintf("Check the %s credentials passed to bbl with flags or environment variables.", state.IAAS),
+		}, DoctorCheck{
+			Name:  fmt.Sprintf("%s region", state.IAAS),
+			Error: d.iaasChecker.CheckRegion(state),
+			Fix:   "Check that the region and zone passed to bbl exist and are enabled for your account, and that no --network overlaps the subnets of its availability zones.",
+		}, DoctorCheck{
+			Name:  fmt.Sprintf("%s quotas", state.IAAS),
+			Error: d.iaasChecker.CheckQuotas(current, state),
+			Fix:   "Free up resources or request a quota increase.",
+		}, DoctorCheck{
+			Name:  fmt.Sprintf("%s permissions", state.IAAS),
+			Error: d.iaasChecker.CheckPermissions(state),
+			Fix:   "Grant the missing actions to the credentials passed to bbl.",
+		})
+
+		if state.BOSH.CloudConfigProfile.Name != "" {
+			checks = append(checks, DoctorCheck{
+				Name:  fmt.Sprintf("%s machine types", state.IAAS),
+				Error: d.iaasChecker.CheckMachineTypes(state),
+				Fix:   "Choose a cloud config profile with instance types that are offered in your region.",
+			})
+		}
+	}
+
+	checks = append(checks, DoctorCheck{
+		Name:  "state directory",
+		Error: checkWritable(d.stateDir),
+		Fix:   fmt.Sprintf("Make sure %s exists and is writable, or choose another directory with --state-dir.", d.stateDir),
+	}, DoctorCheck{
+		Name:  "proxy port",
+		Error: d.portChecker.CheckPort(),
+		Fix:   "bbl proxies to the director through a local port, make sure one is free.",
+	})
+
+	return checks
+}
+
+func (c DoctorCheck) String() string {
+	if c.Error == nil {
+		return fmt.Sprintf("[ok]   %s", c.Name)
+	}
+
+	if !c.failed() {
+		return fmt.Sprintf("[warn] %s: %s\n       %s", c.Name, c.Error, c.Fix)
+	}
+
+	return fmt.Sprintf("[fail] %s: %s\n       %s", c.Name, c.Error, c.Fix)
+}
+
+func checkWritable(dir string) error {
+	file, err := ioutil.TempFile(dir, ".bbl-doctor")
+	if err != nil {
+		return err
+	}
+	file.Close()
+
+	return os.Remove(file.Name())
+}
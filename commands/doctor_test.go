@@ -0,0 +1,271 @@
+package commands_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Doctor", func() {
+	var (
+		command commands.Doctor
+
+		logger           *fakes.Logger
+		terraformManager *fakes.TerraformManager
+		boshManager      *fakes.BOSHManager
+		iaasChecker      *fakes.IAASChecker
+		socks5Proxy      *fakes.Socks5Proxy
+		stateDir         string
+		state            storage.State
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		terraformManager = &fakes.TerraformManager{}
+		boshManager = &fakes.BOSHManager{}
+		boshManager.VersionCall.Returns.Version = "2.0.24"
+		iaasChecker = &fakes.IAASChecker{}
+		socks5Proxy = &fakes.Socks5Proxy{}
+
+		var err error
+		stateDir, err = ioutil.TempDir("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		state = storage.State{IAAS: "gcp"}
+
+		command = commands.NewDoctor(logger, terraformManager, boshManager, iaasChecker, socks5Proxy, stateDir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(stateDir)
+	})
+
+	Describe("Execute", func() {
+		It("prints a checklist", func() {
+			err := command.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+				"[ok]   terraform binary",
+				"[ok]   bosh binary",
+				"[ok]   gcp credentials",
+				"[ok]   gcp region",
+				"[ok]   gcp quotas",
+				"[ok]   gcp permissions",
+				"[ok]   state directory",
+				"[ok]   proxy port",
+			}))
+
+			Expect(terraformManager.ValidateVersionCall.CallCount).To(Equal(1))
+			Expect(iaasChecker.CheckCredentialsCall.Receives.State).To(Equal(state))
+			Expect(iaasChecker.CheckRegionCall.Receives.State).To(Equal(state))
+			Expect(iaasChecker.CheckQuotasCall.Receives.Current).To(Equal(state))
+			Expect(iaasChecker.CheckQuotasCall.Receives.Desired).To(Equal(state))
+			Expect(iaasChecker.CheckPermissionsCall.Receives.State).To(Equal(state))
+			Expect(iaasChecker.CheckMachineTypesCall.CallCount).To(Equal(0))
+			Expect(socks5Proxy.CheckPortCall.CallCount).To(Equal(1))
+		})
+
+		It("does not leave files in the state directory", func() {
+			err := command.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			files, err := ioutil.ReadDir(stateDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(BeEmpty())
+		})
+
+		Context("when checks fail", func() {
+			BeforeEach(func() {
+				iaasChecker.CheckCredentialsCall.Returns.Error = errors.New("invalid key")
+				socks5Proxy.CheckPortCall.Returns.Error = errors.New("no free ports")
+			})
+
+			It("prints how to fix each failure and returns an error", func() {
+				err := command.Execute([]string{}, state)
+				Expect(err).To(MatchError("2 of 8 checks failed"))
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement(
+					"[fail] gcp credentials: invalid key\n       Check the gcp credentials passed to bbl with flags or environment variables.",
+				))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement(
+					"[fail] proxy port: no free ports\n       bbl proxies to the director through a local port, make sure one is free.",
+				))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("when the version of BOSH is a dev build", func() {
+			It("does not fail", func() {
+				boshManager.VersionCall.Returns.Error = bosh.NewBOSHVersionError(errors.New("BOSH version could not be parsed"))
+
+				checks := command.Run(state)
+				Expect(checks[1].Name).To(Equal("bosh binary"))
+				Expect(checks[1].Error).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the version of BOSH is lower than 2.0.24", func() {
+			It("fails the bosh check", func() {
+				boshManager.VersionCall.Returns.Version = "1.9.1"
+
+				checks := command.Run(state)
+				Expect(checks[1].Error).To(MatchError("BOSH version must be at least v2.0.24"))
+			})
+
+			Context("when the environment has no director", func() {
+				It("does not check the bosh cli", func() {
+					boshManager.VersionCall.Returns.Version = "1.9.1"
+					state.NoDirector = true
+
+					checks := command.Run(state)
+					for _, check := range checks {
+						Expect(check.Name).NotTo(Equal("bosh binary"))
+						Expect(check.Error).NotTo(HaveOccurred())
+					}
+					Expect(boshManager.VersionCall.CallCount).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the version of BOSH cannot be retrieved", func() {
+			It("fails the bosh check", func() {
+				boshManager.VersionCall.Returns.Error = errors.New("BOOM")
+
+				checks := command.Run(state)
+				Expect(checks[1].Error).To(MatchError("BOOM"))
+			})
+		})
+
+		Context("when the version of BOSH is invalid", func() {
+			It("fails the bosh check", func() {
+				boshManager.VersionCall.Returns.Version = "X.5.2"
+
+				checks := command.Run(state)
+				Expect(checks[1].Error.Error()).To(ContainSubstring("invalid syntax"))
+			})
+		})
+
+		Context("when the terraform version is not supported", func() {
+			It("fails the terraform check", func() {
+				terraformManager.ValidateVersionCall.Returns.Error = errors.New("Terraform version must be at least v0.10.0")
+
+				checks := command.Run(state)
+				Expect(checks[0].Name).To(Equal("terraform binary"))
+				Expect(checks[0].Error).To(MatchError("Terraform version must be at least v0.10.0"))
+			})
+		})
+
+		Context("when the region check fails", func() {
+			It("fails the region check", func() {
+				iaasChecker.CheckRegionCall.Returns.Error = errors.New("no such region")
+
+				checks := command.Run(state)
+				Expect(checks[3].Name).To(Equal("gcp region"))
+				Expect(checks[3].Error).To(MatchError("no such region"))
+			})
+		})
+
+		Context("when there is not enough quota", func() {
+			It("fails the quotas check", func() {
+				iaasChecker.CheckQuotasCall.Returns.Error = errors.New("Not enough quota")
+
+				checks := command.Run(state)
+				Expect(checks[4].Name).To(Equal("gcp quotas"))
+				Expect(checks[4].Error).To(MatchError("Not enough quota"))
+			})
+		})
+
+		Context("when permissions are missing", func() {
+			It("fails the permissions check", func() {
+				iaasChecker.CheckPermissionsCall.Returns.Error = errors.New("missing permissions")
+
+				checks := command.Run(state)
+				Expect(checks[5].Name).To(Equal("gcp permissions"))
+				Expect(checks[5].Error).To(MatchError("missing permissions"))
+			})
+		})
+
+		Context("when the state has a cloud config profile", func() {
+			It("checks the machine types of the profile", func() {
+				state.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "dev"}
+				iaasChecker.CheckMachineTypesCall.Returns.Error = errors.New("not offered")
+
+				checks := command.Run(state)
+				Expect(checks[6].Name).To(Equal("gcp machine types"))
+				Expect(checks[6].Error).To(MatchError("not offered"))
+				Expect(iaasChecker.CheckMachineTypesCall.Receives.State).To(Equal(state))
+			})
+
+			It("reports a warning without failing", func() {
+				state.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "dev"}
+				iaasChecker.CheckMachineTypesCall.Returns.Error = commands.DoctorWarning{Err: errors.New("Unknown instance types: m9.large")}
+
+				err := command.Execute([]string{}, state)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("[warn] gcp machine types: Unknown instance types: m9.large\n" +
+					"       Choose a cloud config profile with instance types that are offered in your region."))
+			})
+		})
+
+		Context("when the state directory does not exist", func() {
+			It("fails the state directory check", func() {
+				missingDir := filepath.Join(stateDir, "missing")
+				command = commands.NewDoctor(logger, terraformManager, boshManager, iaasChecker, socks5Proxy, missingDir)
+
+				checks := command.Run(state)
+				Expect(checks[6].Name).To(Equal("state directory"))
+				Expect(checks[6].Error).To(HaveOccurred())
+				Expect(checks[6].Fix).To(ContainSubstring(missingDir))
+			})
+		})
+	})
+
+	Describe("Check", func() {
+		It("returns nil when every check passes", func() {
+			err := command.Check(state, state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.PrintlnCall.CallCount).To(Equal(0))
+		})
+
+		It("returns an error listing the failed checks", func() {
+			terraformManager.ValidateVersionCall.Returns.Error = errors.New("executable file not found in $PATH")
+
+			err := command.Check(state, state)
+			Expect(err).To(MatchError("bbl doctor found problems with your environment:\n" +
+				"[fail] terraform binary: executable file not found in $PATH\n" +
+				"       Install terraform v0.10.0 or later and make sure it is on your PATH."))
+		})
+
+		It("prints warnings without returning them", func() {
+			state.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "dev"}
+			iaasChecker.CheckMachineTypesCall.Returns.Error = commands.DoctorWarning{Err: errors.New("Unknown instance types: m9.large")}
+
+			err := command.Check(state, state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.PrintlnCall.CallCount).To(Equal(1))
+		})
+
+		It("checks the quotas for what desired adds to current", func() {
+			desired := state
+			desired.Network.Private = true
+
+			err := command.Check(state, desired)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(iaasChecker.CheckQuotasCall.Receives.Current).To(Equal(state))
+			Expect(iaasChecker.CheckQuotasCall.Receives.Desired).To(Equal(desired))
+			Expect(iaasChecker.CheckRegionCall.Receives.State).To(Equal(desired))
+		})
+	})
+})
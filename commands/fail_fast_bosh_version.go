@@ -1,12 +1,15 @@
 package commands
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/coreos/go-semver/semver"
 )
 
+// minimumBOSHVersion is the oldest bosh cli that bbl works with.
+const minimumBOSHVersion = "2.0.24"
+
 func fastFailBOSHVersion(boshManager boshManager) error {
 	version, err := boshManager.Version()
 	switch err.(type) {
@@ -22,13 +25,13 @@ func fastFailBOSHVersion(boshManager boshManager) error {
 	}
 
 	// This shouldn't fail, so there is no test for capturing the error.
-	minimumVersion, err := semver.NewVersion("2.0.24")
+	minimumVersion, err := semver.NewVersion(minimumBOSHVersion)
 	if err != nil {
 		return err
 	}
 
 	if currentVersion.LessThan(*minimumVersion) {
-		return errors.New("BOSH version must be at least v2.0.24")
+		return fmt.Errorf("BOSH version must be at least v%s", minimumBOSHVersion)
 	}
 
 	return nil
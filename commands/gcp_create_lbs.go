@@ -96,7 +96,7 @@ func (c GCPCreateLBs) Execute(config CreateLBsConfig, state storage.State) error
 	}
 
 	if !state.NoDirector {
-		err = c.cloudConfigManager.Update(state)
+		err = updateCloudConfig(c.cloudConfigManager, state, config.NoConfirm)
 		if err != nil {
 			return err
 		}
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/cloudfoundry/bosh-bootloader/application"
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -129,8 +130,30 @@ var _ = Describe("GCPCreateLBs", func() {
 			}}, bblState)
 			Expect(err).NotTo(HaveOccurred())
 
+			Expect(cloudConfigManager.ConfirmUpdateCall.CallCount).To(Equal(1))
+			Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State).To(Equal(bblState))
+		})
+
+		It("does not ask before uploading the cloud-config with --no-confirm", func() {
+			terraformManager.ApplyCall.Returns.BBLState = bblState
+
+			err := command.Execute(commands.CreateLBsConfig{GCP: commands.GCPCreateLBsConfig{
+				LBType: "concourse",
+			}, NoConfirm: true}, bblState)
+			Expect(err).NotTo(HaveOccurred())
+
 			Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(1))
-			Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(bblState))
+			Expect(cloudConfigManager.ConfirmUpdateCall.CallCount).To(Equal(0))
+		})
+
+		It("returns an error when the cloud-config changes are declined", func() {
+			terraformManager.ApplyCall.Returns.BBLState = bblState
+			cloudConfigManager.ConfirmUpdateCall.Returns.Error = cloudconfig.ErrNotApplied
+
+			err := command.Execute(commands.CreateLBsConfig{GCP: commands.GCPCreateLBsConfig{
+				LBType: "concourse",
+			}}, bblState)
+			Expect(err).To(MatchError("The cloud config changes were declined. Run the command again to apply them, or pass --no-confirm."))
 		})
 
 		Context("when there is no BOSH director", func() {
@@ -141,7 +164,7 @@ var _ = Describe("GCPCreateLBs", func() {
 					NoDirector: true,
 				})
 				Expect(err).NotTo(HaveOccurred())
-				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+				Expect(cloudConfigManager.ConfirmUpdateCall.CallCount).To(Equal(0))
 			})
 		})
 
@@ -250,7 +273,7 @@ var _ = Describe("GCPCreateLBs", func() {
 			})
 
 			It("returns an error when the cloud config fails to be updated", func() {
-				cloudConfigManager.UpdateCall.Returns.Error = errors.New("failed to update cloud config")
+				cloudConfigManager.ConfirmUpdateCall.Returns.Error = errors.New("failed to update cloud config")
 
 				err := command.Execute(commands.CreateLBsConfig{GCP: commands.GCPCreateLBsConfig{
 					LBType: "concourse",
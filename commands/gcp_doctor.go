@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	compute "google.golang.org/api/compute/v1"
+)
+
+type GCPDoctor struct {
+	gcpClient         gcpDoctorClient
+	templateResources gcpTemplateResources
+}
+
+type gcpDoctorClient interface {
+	GetProject() (*compute.Project, error)
+	GetRegion(string) (*compute.Region, error)
+	GetZone(string) (*compute.Zone, error)
+	CheckQuotas(string, gcp.Resources) error
+	CheckMachineTypes(string, []string) error
+}
+
+type gcpTemplateResources interface {
+	Resources(storage.State) gcp.Resources
+}
+
+func NewGCPDoctor(gcpClient gcpDoctorClient, templateResources gcpTemplateResources) GCPDoctor {
+	return GCPDoctor{
+		gcpClient:         gcpClient,
+		templateResources: templateResources,
+	}
+}
+
+func (d GCPDoctor) CheckCredentials(state storage.State) error {
+	_, err := d.gcpClient.GetProject()
+	if err != nil {
+		return fmt.Errorf("Get project %q: %s", state.GCP.ProjectID, err)
+	}
+
+	return nil
+}
+
+func (d GCPDoctor) CheckRegion(state storage.State) error {
+	_, err := d.gcpClient.GetRegion(state.GCP.Region)
+	if err != nil {
+		return fmt.Errorf("Get region %q: %s", state.GCP.Region, err)
+	}
+
+	if state.GCP.Zone == "" {
+		return nil
+	}
+
+	zone, err := d.gcpClient.GetZone(state.GCP.Zone)
+	if err != nil {
+		return fmt.Errorf("Get zone %q: %s", state.GCP.Zone, err)
+	}
+
+	if !strings.HasSuffix(zone.Region, "/regions/"+state.GCP.Region) {
+		return fmt.Errorf("Zone %q is not in region %q", state.GCP.Zone, state.GCP.Region)
+	}
+
+	return nil
+}
+
+// CheckQuotas checks there is room for the resources in desired that current
+// has not already created.
+func (d GCPDoctor) CheckQuotas(current, desired storage.State) error {
+	resources := d.templateResources.Resources(desired)
+	if current.TFState != "" {
+		resources = resources.Minus(d.templateResources.Resources(current))
+	}
+
+	if resources == (gcp.Resources{}) {
+		return nil
+	}
+
+	return d.gcpClient.CheckQuotas(desired.GCP.Region, resources)
+}
+
+// CheckPermissions does not check anything, bbl only simulates AWS policies.
+func (d GCPDoctor) CheckPermissions(state storage.State) error {
+	return nil
+}
+
+// CheckMachineTypes checks that the machine types of the cloud config profile
+// are available in every zone of the region.
+func (d GCPDoctor) CheckMachineTypes(state storage.State) error {
+	profile, err := cloudconfig.LoadProfile(state.BOSH.CloudConfigProfile, "gcp")
+	if err != nil {
+		return err
+	}
+
+	return d.gcpClient.CheckMachineTypes(state.GCP.Region, profile.InstanceTypes())
+}
@@ -0,0 +1,158 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	compute "google.golang.org/api/compute/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GCPDoctor", func() {
+	var (
+		gcpDoctor         commands.GCPDoctor
+		gcpClient         *fakes.GCPClient
+		templateResources *fakes.GCPTemplateResources
+		state             storage.State
+	)
+
+	BeforeEach(func() {
+		gcpClient = &fakes.GCPClient{}
+		gcpClient.GetZoneCall.Returns.Zone = &compute.Zone{
+			Region: "https://www.googleapis.com/compute/v1/projects/some-project/regions/us-west1",
+		}
+		templateResources = &fakes.GCPTemplateResources{}
+		gcpDoctor = commands.NewGCPDoctor(gcpClient, templateResources)
+
+		state = storage.State{
+			GCP: storage.GCP{
+				ProjectID: "some-project",
+				Region:    "us-west1",
+				Zone:      "us-west1-a",
+			},
+		}
+	})
+
+	Describe("CheckCredentials", func() {
+		It("gets the project", func() {
+			err := gcpDoctor.CheckCredentials(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gcpClient.GetProjectCall.CallCount).To(Equal(1))
+		})
+
+		Context("when the project cannot be retrieved", func() {
+			It("returns an error", func() {
+				gcpClient.GetProjectCall.Returns.Error = errors.New("forbidden")
+
+				err := gcpDoctor.CheckCredentials(state)
+				Expect(err).To(MatchError(`Get project "some-project": forbidden`))
+			})
+		})
+	})
+
+	Describe("CheckRegion", func() {
+		It("checks the region and zone exist", func() {
+			err := gcpDoctor.CheckRegion(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(gcpClient.GetRegionCall.Receives.Region).To(Equal("us-west1"))
+			Expect(gcpClient.GetZoneCall.Receives.Zone).To(Equal("us-west1-a"))
+		})
+
+		Context("when the region does not exist", func() {
+			It("returns an error", func() {
+				gcpClient.GetRegionCall.Returns.Error = errors.New("not found")
+
+				err := gcpDoctor.CheckRegion(state)
+				Expect(err).To(MatchError(`Get region "us-west1": not found`))
+			})
+		})
+
+		Context("when the zone does not exist", func() {
+			It("returns an error", func() {
+				gcpClient.GetZoneCall.Returns.Error = errors.New("not found")
+
+				err := gcpDoctor.CheckRegion(state)
+				Expect(err).To(MatchError(`Get zone "us-west1-a": not found`))
+			})
+		})
+
+		Context("when the zone is in another region", func() {
+			It("returns an error", func() {
+				state.GCP.Zone = "europe-west1-b"
+				gcpClient.GetZoneCall.Returns.Zone = &compute.Zone{
+					Region: "https://www.googleapis.com/compute/v1/projects/some-project/regions/europe-west1",
+				}
+
+				err := gcpDoctor.CheckRegion(state)
+				Expect(err).To(MatchError(`Zone "europe-west1-b" is not in region "us-west1"`))
+			})
+		})
+	})
+
+	Describe("CheckQuotas", func() {
+		BeforeEach(func() {
+			templateResources.ResourcesCall.Stub = func(state storage.State) gcp.Resources {
+				if state.LB.Type == "cf" {
+					return gcp.Resources{CPUs: 2, StaticAddresses: 5}
+				}
+				return gcp.Resources{CPUs: 2, StaticAddresses: 2}
+			}
+		})
+
+		It("checks quotas for everything the template creates in a new environment", func() {
+			err := gcpDoctor.CheckQuotas(state, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(gcpClient.CheckQuotasCall.Receives.Region).To(Equal("us-west1"))
+			Expect(gcpClient.CheckQuotasCall.Receives.Resources).To(Equal(gcp.Resources{CPUs: 2, StaticAddresses: 2}))
+		})
+
+		It("checks quotas for the load balancers added to an existing environment", func() {
+			state.TFState = "some-tf-state"
+			desired := state
+			desired.LB.Type = "cf"
+
+			err := gcpDoctor.CheckQuotas(state, desired)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(gcpClient.CheckQuotasCall.Receives.Resources).To(Equal(gcp.Resources{StaticAddresses: 3}))
+		})
+
+		Context("when there is not enough quota", func() {
+			It("returns the error", func() {
+				gcpClient.CheckQuotasCall.Returns.Error = errors.New("Not enough quota")
+
+				err := gcpDoctor.CheckQuotas(state, state)
+				Expect(err).To(MatchError("Not enough quota"))
+			})
+		})
+	})
+
+	Describe("CheckMachineTypes", func() {
+		It("checks the machine types of the cloud config profile are available in the region", func() {
+			state.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "prod"}
+
+			err := gcpDoctor.CheckMachineTypes(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(gcpClient.CheckMachineTypesCall.Receives.Region).To(Equal("us-west1"))
+			Expect(gcpClient.CheckMachineTypesCall.Receives.MachineTypes).To(ContainElement("n2-standard-2"))
+		})
+
+		Context("when machine types are not available", func() {
+			It("returns the error", func() {
+				state.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "prod"}
+				gcpClient.CheckMachineTypesCall.Returns.Error = errors.New("not available")
+
+				err := gcpDoctor.CheckMachineTypes(state)
+				Expect(err).To(MatchError("not available"))
+			})
+		})
+	})
+})
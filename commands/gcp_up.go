@@ -2,30 +2,72 @@ package commands
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
 type GCPUp struct {
-	gcpAvailabilityZoneRetriever gcpAvailabilityZoneRetriever
+	gcpClient gcpUpClient
 }
 
-type gcpAvailabilityZoneRetriever interface {
+type gcpUpClient interface {
 	GetZones(string) ([]string, error)
+	GetSubnetworkCIDR(name, region, projectID string) (string, error)
+	AddressesInUse(ips []string, subnetwork, region, networkProject string, zones []string) ([]string, error)
 }
 
-func NewGCPUp(gcpAvailabilityZoneRetriever gcpAvailabilityZoneRetriever) GCPUp {
+func NewGCPUp(gcpClient gcpUpClient) GCPUp {
 	return GCPUp{
-		gcpAvailabilityZoneRetriever: gcpAvailabilityZoneRetriever,
+		gcpClient: gcpClient,
 	}
 }
 
 func (u GCPUp) Execute(state storage.State) (storage.State, error) {
 	var err error
-	state.GCP.Zones, err = u.gcpAvailabilityZoneRetriever.GetZones(state.GCP.Region)
+	state.GCP.Zones, err = u.gcpClient.GetZones(state.GCP.Region)
 	if err != nil {
 		return storage.State{}, fmt.Errorf("Retrieving availability zones: %s", err)
 	}
 
+	// The jumpbox, director and cloud config subnets are planned from the
+	// range of an existing subnetwork rather than from --network-cidr.
+	if state.GCP.Network != "" {
+		cidr, err := u.gcpClient.GetSubnetworkCIDR(state.GCP.Subnetwork, state.GCP.Region, state.GCP.NetworkProject)
+		if err != nil {
+			return storage.State{}, fmt.Errorf("Retrieving subnetwork: %s", err)
+		}
+
+		networkPlan, err := bosh.NewSubnetworkPlan(cidr)
+		if err != nil {
+			return storage.State{}, fmt.Errorf("Invalid subnetwork %s: %s", state.GCP.Subnetwork, err)
+		}
+
+		// bbl does not own the subnetwork, so the addresses of the jumpbox
+		// and director may already be taken before it creates them.
+		if state.TFState == "" {
+			inUse, err := u.gcpClient.AddressesInUse([]string{networkPlan.JumpboxIP(), networkPlan.DirectorIP()},
+				state.GCP.Subnetwork, state.GCP.Region, state.GCP.NetworkProject, state.GCP.Zones)
+			if err != nil {
+				return storage.State{}, fmt.Errorf("Checking subnetwork addresses: %s", err)
+			}
+
+			if len(inUse) > 0 {
+				return storage.State{}, fmt.Errorf("The jumpbox and director need %s and %s of subnetwork %s, but %s already in use.",
+					networkPlan.JumpboxIP(), networkPlan.DirectorIP(), state.GCP.Subnetwork, describeInUse(inUse))
+			}
+		}
+
+		state.Network.CIDR = cidr
+	}
+
 	return state, nil
 }
+
+func describeInUse(ips []string) string {
+	if len(ips) == 1 {
+		return fmt.Sprintf("%s is", ips[0])
+	}
+	return fmt.Sprintf("%s are", strings.Join(ips, " and "))
+}
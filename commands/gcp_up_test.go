@@ -41,6 +41,78 @@ var _ = Describe("GCPUp", func() {
 			Expect(returnedState).To(Equal(expectedZonesState))
 		})
 
+		Context("when an existing network is used", func() {
+			BeforeEach(func() {
+				incomingState.GCP.Network = "some-network"
+				incomingState.GCP.Subnetwork = "some-subnetwork"
+				incomingState.GCP.NetworkProject = "some-host-project"
+				gcpZones.GetSubnetworkCIDRCall.Returns.CIDR = "10.10.0.0/16"
+			})
+
+			It("plans the network from the range of the subnetwork", func() {
+				returnedState, err := gcpUp.Execute(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(gcpZones.GetSubnetworkCIDRCall.Receives.Name).To(Equal("some-subnetwork"))
+				Expect(gcpZones.GetSubnetworkCIDRCall.Receives.Region).To(Equal("some-region"))
+				Expect(gcpZones.GetSubnetworkCIDRCall.Receives.ProjectID).To(Equal("some-host-project"))
+				Expect(returnedState.Network.CIDR).To(Equal("10.10.0.0/16"))
+			})
+
+			It("returns an error when the subnetwork cannot be retrieved", func() {
+				gcpZones.GetSubnetworkCIDRCall.Returns.Error = errors.New("not found")
+
+				_, err := gcpUp.Execute(incomingState)
+				Expect(err).To(MatchError("Retrieving subnetwork: not found"))
+			})
+
+			It("plans a /24 subnetwork and checks the jumpbox and director addresses are free", func() {
+				gcpZones.GetSubnetworkCIDRCall.Returns.CIDR = "10.10.0.0/24"
+
+				returnedState, err := gcpUp.Execute(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(gcpZones.AddressesInUseCall.CallCount).To(Equal(1))
+				Expect(gcpZones.AddressesInUseCall.Receives.IPs).To(Equal([]string{"10.10.0.5", "10.10.0.6"}))
+				Expect(gcpZones.AddressesInUseCall.Receives.Subnetwork).To(Equal("some-subnetwork"))
+				Expect(gcpZones.AddressesInUseCall.Receives.Region).To(Equal("some-region"))
+				Expect(gcpZones.AddressesInUseCall.Receives.NetworkProject).To(Equal("some-host-project"))
+				Expect(gcpZones.AddressesInUseCall.Receives.Zones).To(Equal([]string{"zone-1"}))
+				Expect(returnedState.Network.CIDR).To(Equal("10.10.0.0/24"))
+			})
+
+			It("does not check the addresses of an environment that already exists", func() {
+				incomingState.TFState = "some-tf-state"
+
+				_, err := gcpUp.Execute(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(gcpZones.AddressesInUseCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the jumpbox or director address is already in use", func() {
+				gcpZones.GetSubnetworkCIDRCall.Returns.CIDR = "10.10.0.0/24"
+				gcpZones.AddressesInUseCall.Returns.InUse = []string{"10.10.0.6"}
+
+				_, err := gcpUp.Execute(incomingState)
+				Expect(err).To(MatchError("The jumpbox and director need 10.10.0.5 and 10.10.0.6 of subnetwork some-subnetwork, but 10.10.0.6 is already in use."))
+			})
+
+			It("returns an error when the addresses cannot be checked", func() {
+				gcpZones.AddressesInUseCall.Returns.Error = errors.New("forbidden")
+
+				_, err := gcpUp.Execute(incomingState)
+				Expect(err).To(MatchError("Checking subnetwork addresses: forbidden"))
+			})
+
+			It("returns an error when the subnetwork is too small for bbl", func() {
+				gcpZones.GetSubnetworkCIDRCall.Returns.CIDR = "10.10.0.0/25"
+
+				_, err := gcpUp.Execute(incomingState)
+				Expect(err).To(MatchError(`Invalid subnetwork some-subnetwork: "10.10.0.0/25" is too small, the subnetwork must be at least a /24`))
+			})
+		})
+
 		Context("failure cases", func() {
 			It("returns an error when GCP AZs cannot be retrieved", func() {
 				gcpZones.GetZonesCall.Returns.Error = errors.New("canteloupe")
@@ -24,10 +24,13 @@ type terraformOutputter interface {
 type boshManager interface {
 	CreateDirector(bblState storage.State, terraformOutputs map[string]interface{}) (storage.State, error)
 	CreateJumpbox(bblState storage.State, terraformOutputs map[string]interface{}) (storage.State, error)
+	InterpolateDirector(bblState storage.State, terraformOutputs map[string]interface{}) (string, error)
+	UpgradeDirector(bblState storage.State, terraformOutputs map[string]interface{}) (storage.State, error)
+	RollbackDirector(bblState storage.State) (storage.State, error)
 	DeleteDirector(bblState storage.State, terraformOutputs map[string]interface{}) error
 	DeleteJumpbox(bblState storage.State, terraformOutputs map[string]interface{}) error
-	GetDirectorDeploymentVars(bblState storage.State, terraformOutputs map[string]interface{}) string
-	GetJumpboxDeploymentVars(bblState storage.State, terraformOutputs map[string]interface{}) string
+	GetDirectorDeploymentVars(bblState storage.State, terraformOutputs map[string]interface{}) (string, error)
+	GetJumpboxDeploymentVars(bblState storage.State, terraformOutputs map[string]interface{}) (string, error)
 	Version() (string, error)
 }
 
@@ -77,5 +80,16 @@ type stateStore interface {
 
 type cloudConfigManager interface {
 	Update(state storage.State) error
+	ConfirmUpdate(state storage.State) error
 	Generate(state storage.State) (string, error)
+	Diff(state storage.State) (string, error)
+}
+
+type directorUploader interface {
+	UploadStemcell(state storage.State, stemcell string) error
+	UpdateRuntimeConfig(state storage.State) error
+}
+
+type deploymentDeleter interface {
+	DeleteDeployments(state storage.State, force bool) error
 }
@@ -74,6 +74,13 @@ var _ = Describe("JumpboxDeploymentVars", func() {
 			Expect(logger.PrintlnCall.Messages).To(ContainElement("some-vars-yaml"))
 		})
 
+		It("returns an error when the deployment vars cannot be generated", func() {
+			boshManager.GetJumpboxDeploymentVarsCall.Returns.Error = errors.New("network plan: failed")
+
+			err := jumpboxDeploymentVars.Execute([]string{}, storage.State{})
+			Expect(err).To(MatchError("network plan: failed"))
+		})
+
 		Context("failure cases", func() {
 			BeforeEach(func() {
 				terraformManager.GetOutputsCall.Returns.Error = errors.New("coconut")
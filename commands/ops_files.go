@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// readOpsFiles reads the ops files at paths, in order. A directory
+// contributes every .yml and .yaml file in it, sorted by name. Ops files
+// are saved by name, so two files with the same name are rejected rather
+// than one silently replacing the other.
+func readOpsFiles(paths []string) ([]storage.OpsFile, error) {
+	var opsFiles []storage.OpsFile
+	seen := map[string]string{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		filePaths := []string{path}
+		if info.IsDir() {
+			filePaths, err = opsFilesInDir(path)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, filePath := range filePaths {
+			name := filepath.Base(filePath)
+			if other, ok := seen[name]; ok {
+				return nil, fmt.Errorf("%s and %s are both named %q, rename one of them", other, filePath, name)
+			}
+			seen[name] = filePath
+
+			contents, err := ioutil.ReadFile(filePath)
+			if err != nil {
+				return nil, err
+			}
+
+			opsFiles = append(opsFiles, storage.OpsFile{
+				Name:     name,
+				Contents: string(contents),
+			})
+		}
+	}
+
+	return opsFiles, nil
+}
+
+func opsFilesInDir(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err //not tested
+	}
+
+	var paths []string
+	for _, info := range infos {
+		switch filepath.Ext(info.Name()) {
+		case ".yml", ".yaml":
+			if !info.IsDir() {
+				paths = append(paths, filepath.Join(dir, info.Name()))
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// updateOpsFiles replaces ops files that are already in the list in place,
+// appends new ones and drops the removed ones.
+func updateOpsFiles(current, added []storage.OpsFile, removed []string) []storage.OpsFile {
+	updated := append([]storage.OpsFile{}, current...)
+
+	for _, opsFile := range added {
+		index := opsFileIndex(updated, opsFile.Name)
+		if index == -1 {
+			updated = append(updated, opsFile)
+		} else {
+			updated[index] = opsFile
+		}
+	}
+
+	for _, name := range removed {
+		if index := opsFileIndex(updated, name); index != -1 {
+			updated = append(updated[:index], updated[index+1:]...)
+		}
+	}
+
+	if len(updated) == 0 {
+		return nil
+	}
+
+	return updated
+}
+
+func opsFileIndex(opsFiles []storage.OpsFile, name string) int {
+	for i, opsFile := range opsFiles {
+		if opsFile.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+const UnhealthyExitCode = 2
+
+type Status struct {
+	logger         logger
+	stateValidator stateValidator
+	statusChecker  statusChecker
+}
+
+type statusChecker interface {
+	Check(state storage.State) []bosh.ComponentStatus
+}
+
+// UnhealthyError is returned when a component is unhealthy so that bbl exits
+// with UnhealthyExitCode rather than the exit code for a failed command.
+type UnhealthyError struct {
+	components []string
+}
+
+func (u UnhealthyError) Error() string {
+	return fmt.Sprintf("unhealthy components: %s", strings.Join(u.components, ", "))
+}
+
+func (UnhealthyError) ExitCode() int {
+	return UnhealthyExitCode
+}
+
+func NewStatus(logger logger, stateValidator stateValidator, statusChecker statusChecker) Status {
+	return Status{
+		logger:         logger,
+		stateValidator: stateValidator,
+		statusChecker:  statusChecker,
+	}
+}
+
+func (s Status) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	err := s.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s Status) Execute(subcommandFlags []string, state storage.State) error {
+	var jsonOutput bool
+	statusFlags := flags.New("status")
+	statusFlags.Bool(&jsonOutput, "", "json", false)
+
+	err := statusFlags.Parse(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	statuses := s.statusChecker.Check(state)
+
+	var unhealthy []string
+	for _, status := range statuses {
+		if !status.Healthy {
+			unhealthy = append(unhealthy, status.Name)
+		}
+	}
+
+	if jsonOutput {
+		output, err := json.Marshal(struct {
+			Healthy    bool                   `json:"healthy"`
+			Components []bosh.ComponentStatus `json:"components"`
+		}{
+			Healthy:    len(unhealthy) == 0,
+			Components: statuses,
+		})
+		if err != nil {
+			return err // not tested
+		}
+		s.logger.Println(string(output))
+	} else {
+		for _, status := range statuses {
+			health := "healthy"
+			if !status.Healthy {
+				health = "unhealthy"
+			}
+			s.logger.Println(strings.TrimSpace(fmt.Sprintf("%-10s %-10s %s", status.Name, health, status.Message)))
+		}
+	}
+
+	if len(unhealthy) > 0 {
+		return UnhealthyError{components: unhealthy}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Status", func() {
+	var (
+		status commands.Status
+
+		incomingState storage.State
+
+		stateValidator *fakes.StateValidator
+		logger         *fakes.Logger
+		statusChecker  *fakes.StatusChecker
+	)
+
+	BeforeEach(func() {
+		incomingState = storage.State{
+			EnvID: "some-env-id",
+		}
+
+		stateValidator = &fakes.StateValidator{}
+		logger = &fakes.Logger{}
+		statusChecker = &fakes.StatusChecker{}
+		statusChecker.CheckCall.Returns.Statuses = []bosh.ComponentStatus{
+			{Name: "terraform", Healthy: true, Message: "terraform state present"},
+			{Name: "director", Healthy: true, Message: "bosh-some-env (version 262.3.0)"},
+			{Name: "credhub", Healthy: true},
+		}
+
+		status = commands.NewStatus(logger, stateValidator, statusChecker)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("returns an error when state validator fails", func() {
+			stateValidator.ValidateCall.Returns.Error = errors.New("state validator failed")
+			err := status.CheckFastFails([]string{}, incomingState)
+
+			Expect(stateValidator.ValidateCall.CallCount).To(Equal(1))
+			Expect(err).To(MatchError("state validator failed"))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("prints the status of each component", func() {
+			err := status.Execute([]string{}, incomingState)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(statusChecker.CheckCall.Receives.State).To(Equal(incomingState))
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+				"terraform  healthy    terraform state present",
+				"director   healthy    bosh-some-env (version 262.3.0)",
+				"credhub    healthy",
+			}))
+		})
+
+		Context("when --json is provided", func() {
+			It("prints the status of each component as json", func() {
+				err := status.Execute([]string{"--json"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages).To(HaveLen(1))
+				Expect(logger.PrintlnCall.Messages[0]).To(MatchJSON(`{
+					"healthy": true,
+					"components": [
+						{"name": "terraform", "healthy": true, "message": "terraform state present"},
+						{"name": "director", "healthy": true, "message": "bosh-some-env (version 262.3.0)"},
+						{"name": "credhub", "healthy": true}
+					]
+				}`))
+			})
+		})
+
+		Context("when a component is unhealthy", func() {
+			BeforeEach(func() {
+				statusChecker.CheckCall.Returns.Statuses = []bosh.ComponentStatus{
+					{Name: "terraform", Healthy: true, Message: "terraform state present"},
+					{Name: "uaa", Message: "connection refused"},
+					{Name: "credhub", Message: "connection refused"},
+				}
+			})
+
+			It("returns an error with the unhealthy exit code", func() {
+				err := status.Execute([]string{}, incomingState)
+				Expect(err).To(MatchError("unhealthy components: uaa, credhub"))
+				Expect(err.(commands.UnhealthyError).ExitCode()).To(Equal(2))
+
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("uaa        unhealthy  connection refused"))
+			})
+
+			It("reports the environment as unhealthy in json", func() {
+				err := status.Execute([]string{"--json"}, incomingState)
+				Expect(err).To(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages[0]).To(ContainSubstring(`"healthy":false`))
+			})
+		})
+
+		It("returns an error when the flags cannot be parsed", func() {
+			err := status.Execute([]string{"--unknown-flag"}, incomingState)
+			Expect(err).To(MatchError("flag provided but not defined: -unknown-flag"))
+		})
+	})
+})
@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/interpolate"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
@@ -19,6 +21,13 @@ type Up struct {
 	stateStore         stateStore
 	envIDManager       envIDManager
 	terraformManager   terraformApplier
+	doctor             doctor
+	directorUploader   directorUploader
+	logger             logger
+}
+
+type doctor interface {
+	Check(current, desired storage.State) error
 }
 
 type UpCmd interface {
@@ -26,13 +35,30 @@ type UpCmd interface {
 }
 
 type UpConfig struct {
-	Name       string
-	OpsFile    string
-	NoDirector bool
+	Name                      string
+	OpsFiles                  []string
+	RemoveOpsFiles            []string
+	JumpboxOpsFiles           []string
+	RemoveJumpboxOpsFiles     []string
+	CloudConfigOpsFiles       []string
+	RemoveCloudConfigOpsFiles []string
+	CloudConfigProfile        string
+	ForceCreateEnv            bool
+	ForceTerraform            bool
+	NoDirector                bool
+	NetworkCIDR               string
+	Networks                  []string
+	ClearNetworks             bool
+	Private                   bool
+	NoConfirm                 bool
+	PreemptibleCompilation    bool
+	UploadStemcell            string
+	RuntimeConfig             string
 }
 
 func NewUp(upCmd UpCmd, boshManager boshManager, cloudConfigManager cloudConfigManager,
-	stateStore stateStore, envIDManager envIDManager, terraformManager terraformApplier) Up {
+	stateStore stateStore, envIDManager envIDManager, terraformManager terraformApplier, doctor doctor,
+	directorUploader directorUploader, logger logger) Up {
 	return Up{
 		upCmd:              upCmd,
 		boshManager:        boshManager,
@@ -40,6 +66,9 @@ func NewUp(upCmd UpCmd, boshManager boshManager, cloudConfigManager cloudConfigM
 		stateStore:         stateStore,
 		envIDManager:       envIDManager,
 		terraformManager:   terraformManager,
+		doctor:             doctor,
+		directorUploader:   directorUploader,
+		logger:             logger,
 	}
 }
 
@@ -49,17 +78,166 @@ func (u Up) CheckFastFails(args []string, state storage.State) error {
 		return err
 	}
 
-	if !config.NoDirector && !state.NoDirector {
-		err = fastFailBOSHVersion(u.boshManager)
+	doctorState := state
+	doctorState.NoDirector = config.NoDirector || state.NoDirector
+	doctorState.Network.Private = config.Private || state.Network.Private
+	if config.NetworkCIDR != "" {
+		doctorState.Network.CIDR = config.NetworkCIDR
+	}
+	if config.ClearNetworks {
+		if len(config.Networks) > 0 {
+			return errors.New("--network and --clear-networks cannot be used together.")
+		}
+		doctorState.Network.Named = nil
+	}
+	if len(config.Networks) > 0 {
+		doctorState.Network.Named, err = parseNamedNetworks(config.Networks)
+		if err != nil {
+			return fmt.Errorf("Invalid network: %s", err)
+		}
+	}
+
+	if config.CloudConfigProfile != "" {
+		profile, err := readCloudConfigProfile(config.CloudConfigProfile)
+		if err != nil {
+			return fmt.Errorf("Reading cloud-config-profile: %v", err)
+		}
+
+		_, err = cloudconfig.LoadProfile(profile, state.IAAS)
 		if err != nil {
 			return err
 		}
+
+		doctorState.BOSH.CloudConfigProfile = profile
+	}
+
+	err = u.doctor.Check(state, doctorState)
+	if err != nil {
+		return err
 	}
 
 	if state.EnvID != "" && config.Name != "" && config.Name != state.EnvID {
 		return fmt.Errorf("The director name cannot be changed for an existing environment. Current name is %s.", state.EnvID)
 	}
 
+	if config.NetworkCIDR != "" && state.GCP.Network != "" {
+		return errors.New("The network CIDR of an existing GCP network is the range of its subnetwork, \"--network-cidr\" cannot be used with \"--gcp-network\".")
+	}
+
+	networkCIDR := state.Network.GetCIDR()
+	if config.NetworkCIDR != "" {
+		networkCIDR = config.NetworkCIDR
+	}
+
+	// The range of an existing GCP subnetwork is checked by GCPUp once it
+	// has been looked up.
+	var networkPlan bosh.NetworkPlan
+	if state.GCP.Network != "" && state.Network.CIDR != "" {
+		networkPlan, err = bosh.NewSubnetworkPlan(networkCIDR)
+	} else {
+		networkPlan, err = bosh.NewNetworkPlan(networkCIDR)
+		if err == nil {
+			err = networkPlan.CheckIAAS(state.IAAS)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Invalid network CIDR: %s", err)
+	}
+
+	if config.NetworkCIDR != "" && state.EnvID != "" && config.NetworkCIDR != state.Network.GetCIDR() {
+		return fmt.Errorf("The network CIDR cannot be changed for an existing environment. Current network CIDR is %s.", state.Network.GetCIDR())
+	}
+
+	if len(config.Networks) > 0 {
+		if state.IAAS == "azure" {
+			return errors.New("Named networks are only supported on AWS and GCP.")
+		}
+
+		networks, err := parseNamedNetworks(config.Networks)
+		if err != nil {
+			return fmt.Errorf("Invalid network: %s", err)
+		}
+
+		// The doctor checks the networks against the availability zones
+		// of the region.
+		err = networkPlan.CheckNamedNetworks(state.IAAS, networks, nil)
+		if err != nil {
+			return fmt.Errorf("Invalid network: %s", err)
+		}
+	}
+
+	for _, name := range config.RemoveOpsFiles {
+		if opsFileIndex(state.BOSH.UserOpsFiles, name) == -1 {
+			return fmt.Errorf("There is no ops file named %q to remove.", name)
+		}
+	}
+
+	for _, name := range config.RemoveJumpboxOpsFiles {
+		if opsFileIndex(state.Jumpbox.UserOpsFiles, name) == -1 {
+			return fmt.Errorf("There is no jumpbox ops file named %q to remove.", name)
+		}
+	}
+
+	for _, name := range config.RemoveCloudConfigOpsFiles {
+		if opsFileIndex(state.BOSH.CloudConfigOpsFiles, name) == -1 {
+			return fmt.Errorf("There is no cloud config ops file named %q to remove.", name)
+		}
+	}
+
+	cloudConfigOpsFiles, err := readOpsFiles(config.CloudConfigOpsFiles)
+	if err != nil {
+		return fmt.Errorf("Reading cloud-config-ops-file contents: %v", err)
+	}
+
+	for _, opsFile := range cloudConfigOpsFiles {
+		_, err = interpolate.ParseOps([]byte(opsFile.Contents))
+		if err != nil {
+			return fmt.Errorf("Invalid cloud-config-ops-file %s: %s", opsFile.Name, err)
+		}
+	}
+
+	// The cloud config is generated from the terraform outputs, so the ops
+	// files can only be applied to it once the environment exists.
+	if len(cloudConfigOpsFiles) > 0 && state.TFState != "" && !state.BOSH.IsEmpty() {
+		cloudConfigState := doctorState
+		cloudConfigState.BOSH.CloudConfigOpsFiles = updateOpsFiles(state.BOSH.CloudConfigOpsFiles, cloudConfigOpsFiles, config.RemoveCloudConfigOpsFiles)
+
+		_, err = u.cloudConfigManager.Generate(cloudConfigState)
+		if err != nil {
+			return fmt.Errorf("Invalid cloud-config-ops-file: %s", err)
+		}
+	}
+
+	if config.PreemptibleCompilation && state.IAAS == "azure" {
+		return errors.New("Preemptible compilation workers are only supported on AWS and GCP.")
+	}
+
+	if doctorState.NoDirector && (config.UploadStemcell != "" || config.RuntimeConfig != "") {
+		return errors.New("A stemcell or runtime config cannot be uploaded without a director.")
+	}
+
+	if config.UploadStemcell != "" && config.UploadStemcell != bosh.LatestStemcell && !bosh.IsURL(config.UploadStemcell) {
+		if _, err := os.Stat(config.UploadStemcell); err != nil {
+			return fmt.Errorf("Reading upload-stemcell: %v", err)
+		}
+	}
+
+	if config.RuntimeConfig != "" {
+		runtimeConfig, err := readRuntimeConfig(config.RuntimeConfig)
+		if err != nil {
+			return fmt.Errorf("Reading runtime-config: %v", err)
+		}
+
+		err = bosh.CheckRuntimeConfig(state.IAAS, runtimeConfig)
+		if err != nil {
+			return fmt.Errorf("Invalid runtime-config: %s", err)
+		}
+	}
+
+	if state.EnvID != "" && config.Private && !state.Network.Private {
+		return errors.New("An existing environment with public IPs cannot be made private, you must re-create your environment to use \"--private\"")
+	}
+
 	return nil
 }
 
@@ -92,11 +270,56 @@ func (u Up) Execute(args []string, state storage.State) error {
 		state.NoDirector = true
 	}
 
-	var opsFileContents []byte
-	if config.OpsFile != "" {
-		opsFileContents, err = ioutil.ReadFile(config.OpsFile)
+	if config.NetworkCIDR != "" {
+		state.Network.CIDR = config.NetworkCIDR
+	}
+
+	if config.Private {
+		state.Network.Private = true
+	}
+
+	if len(config.Networks) > 0 {
+		state.Network.Named, err = parseNamedNetworks(config.Networks)
 		if err != nil {
-			return fmt.Errorf("Reading ops-file contents: %v", err)
+			return fmt.Errorf("Invalid network: %s", err)
+		}
+	}
+
+	if config.ClearNetworks {
+		state.Network.Named = nil
+	}
+
+	opsFiles, err := readOpsFiles(config.OpsFiles)
+	if err != nil {
+		return fmt.Errorf("Reading ops-file contents: %v", err)
+	}
+	userOpsFiles := updateOpsFiles(state.BOSH.UserOpsFiles, opsFiles, config.RemoveOpsFiles)
+
+	jumpboxOpsFiles, err := readOpsFiles(config.JumpboxOpsFiles)
+	if err != nil {
+		return fmt.Errorf("Reading jumpbox-ops-file contents: %v", err)
+	}
+	jumpboxUserOpsFiles := updateOpsFiles(state.Jumpbox.UserOpsFiles, jumpboxOpsFiles, config.RemoveJumpboxOpsFiles)
+
+	cloudConfigOpsFiles, err := readOpsFiles(config.CloudConfigOpsFiles)
+	if err != nil {
+		return fmt.Errorf("Reading cloud-config-ops-file contents: %v", err)
+	}
+	cloudConfigUserOpsFiles := updateOpsFiles(state.BOSH.CloudConfigOpsFiles, cloudConfigOpsFiles, config.RemoveCloudConfigOpsFiles)
+
+	cloudConfigProfile := state.BOSH.CloudConfigProfile
+	if config.CloudConfigProfile != "" {
+		cloudConfigProfile, err = readCloudConfigProfile(config.CloudConfigProfile)
+		if err != nil {
+			return fmt.Errorf("Reading cloud-config-profile: %v", err)
+		}
+	}
+
+	runtimeConfig := state.BOSH.RuntimeConfig
+	if config.RuntimeConfig != "" {
+		runtimeConfig, err = readRuntimeConfig(config.RuntimeConfig)
+		if err != nil {
+			return fmt.Errorf("Reading runtime-config: %v", err)
 		}
 	}
 
@@ -110,6 +333,10 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Save state after sync: %s", err)
 	}
 
+	if config.ForceTerraform {
+		state.TFFingerprint = ""
+	}
+
 	state, err = u.terraformManager.Apply(state)
 	if err != nil {
 		return handleTerraformError(err, u.stateStore)
@@ -129,6 +356,10 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Parse terraform outputs: %s", err)
 	}
 
+	state.Jumpbox.UserOpsFiles = jumpboxUserOpsFiles
+	if config.ForceCreateEnv {
+		state.Jumpbox.ManifestHash = ""
+	}
 	state, err = u.boshManager.CreateJumpbox(state, terraformOutputs)
 	if err != nil {
 		return fmt.Errorf("Create jumpbox: %s", err)
@@ -139,7 +370,10 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Save state after create jumpbox: %s", err)
 	}
 
-	state.BOSH.UserOpsFile = string(opsFileContents)
+	state.BOSH.UserOpsFiles = userOpsFiles
+	if config.ForceCreateEnv {
+		state.BOSH.ManifestHash = ""
+	}
 	state, err = u.boshManager.CreateDirector(state, terraformOutputs)
 	switch err.(type) {
 	case bosh.ManagerCreateError:
@@ -157,36 +391,133 @@ func (u Up) Execute(args []string, state storage.State) error {
 		return fmt.Errorf("Save state after create director: %s", err)
 	}
 
-	err = u.cloudConfigManager.Update(state)
+	// The cloud config and runtime config inputs are only saved once the
+	// director has them, so a failed update is not re-applied by later runs
+	// without being passed.
+	cloudConfigState := state
+	cloudConfigState.BOSH.CloudConfigOpsFiles = cloudConfigUserOpsFiles
+	cloudConfigState.BOSH.CloudConfigProfile = cloudConfigProfile
+	cloudConfigState.BOSH.PreemptibleCompilation = config.PreemptibleCompilation
+	err = updateCloudConfig(u.cloudConfigManager, cloudConfigState, config.NoConfirm)
+	if err == errCloudConfigDeclined {
+		u.logger.Step("skipped updating the cloud config, uploading the stemcell and updating the runtime config")
+		return err
+	}
 	if err != nil {
 		return fmt.Errorf("Update cloud config: %s", err)
 	}
 
-	return nil
-}
+	state = cloudConfigState
+	err = u.stateStore.Set(state)
+	if err != nil {
+		return fmt.Errorf("Save state after update cloud config: %s", err)
+	}
 
-func (u Up) ParseArgs(args []string, state storage.State) (UpConfig, error) {
-	tempDir, err := ioutil.TempDir("", "")
+	if config.UploadStemcell != "" {
+		err = u.directorUploader.UploadStemcell(state, config.UploadStemcell)
+		if err != nil {
+			return fmt.Errorf("Upload stemcell: %s", err)
+		}
+	}
+
+	runtimeConfigState := state
+	runtimeConfigState.BOSH.RuntimeConfig = runtimeConfig
+	err = u.directorUploader.UpdateRuntimeConfig(runtimeConfigState)
 	if err != nil {
-		return UpConfig{}, err //not tested
+		return fmt.Errorf("Update runtime config: %s", err)
 	}
 
-	prevOpsFilePath := filepath.Join(tempDir, "user-ops-file")
-	err = ioutil.WriteFile(prevOpsFilePath, []byte(state.BOSH.UserOpsFile), os.ModePerm)
+	state = runtimeConfigState
+	err = u.stateStore.Set(state)
 	if err != nil {
-		return UpConfig{}, err //not tested
+		return fmt.Errorf("Save state after update runtime config: %s", err)
 	}
 
+	return nil
+}
+
+func (u Up) ParseArgs(args []string, state storage.State) (UpConfig, error) {
 	var config UpConfig
 	upFlags := flags.New("up")
 	upFlags.String(&config.Name, "name", "")
-	upFlags.String(&config.OpsFile, "ops-file", prevOpsFilePath)
+	upFlags.StringSlice(&config.OpsFiles, "ops-file")
+	upFlags.StringSlice(&config.RemoveOpsFiles, "remove-ops-file")
+	upFlags.StringSlice(&config.JumpboxOpsFiles, "jumpbox-ops-file")
+	upFlags.StringSlice(&config.RemoveJumpboxOpsFiles, "remove-jumpbox-ops-file")
+	upFlags.StringSlice(&config.CloudConfigOpsFiles, "cloud-config-ops-file")
+	upFlags.StringSlice(&config.RemoveCloudConfigOpsFiles, "remove-cloud-config-ops-file")
+	upFlags.String(&config.CloudConfigProfile, "cloud-config-profile", "")
+	upFlags.Bool(&config.ForceCreateEnv, "", "force-create-env", false)
+	upFlags.Bool(&config.ForceTerraform, "", "force-terraform", false)
 	upFlags.Bool(&config.NoDirector, "", "no-director", state.NoDirector)
-
-	err = upFlags.Parse(args)
+	upFlags.String(&config.NetworkCIDR, "network-cidr", "")
+	upFlags.StringSlice(&config.Networks, "network")
+	upFlags.Bool(&config.ClearNetworks, "", "clear-networks", false)
+	upFlags.Bool(&config.Private, "", "private", state.Network.Private)
+	upFlags.Bool(&config.NoConfirm, "n", "no-confirm", false)
+	upFlags.Bool(&config.PreemptibleCompilation, "", "preemptible-compilation", state.BOSH.PreemptibleCompilation)
+	upFlags.String(&config.UploadStemcell, "upload-stemcell", "")
+	upFlags.String(&config.RuntimeConfig, "runtime-config", "")
+
+	var noPreemptibleCompilation bool
+	upFlags.Bool(&noPreemptibleCompilation, "", "no-preemptible-compilation", false)
+
+	err := upFlags.Parse(args)
 	if err != nil {
 		return UpConfig{}, err
 	}
 
+	if noPreemptibleCompilation {
+		config.PreemptibleCompilation = false
+	}
+
 	return config, nil
 }
+
+func parseNamedNetworks(networks []string) ([]storage.NamedNetwork, error) {
+	var namedNetworks []storage.NamedNetwork
+	for _, network := range networks {
+		namedNetwork, err := bosh.ParseNamedNetwork(network)
+		if err != nil {
+			return nil, err
+		}
+		namedNetworks = append(namedNetworks, namedNetwork)
+	}
+	return namedNetworks, nil
+}
+
+// readCloudConfigProfile returns a built-in profile by name, anything else is
+// read as the path to a custom profile.
+func readCloudConfigProfile(name string) (storage.CloudConfigProfile, error) {
+	if _, ok := cloudconfig.Profiles[name]; ok {
+		return storage.CloudConfigProfile{Name: name}, nil
+	}
+
+	contents, err := ioutil.ReadFile(name)
+	if err != nil {
+		return storage.CloudConfigProfile{}, err
+	}
+
+	return storage.CloudConfigProfile{
+		Name:     filepath.Base(name),
+		Contents: string(contents),
+	}, nil
+}
+
+// readRuntimeConfig returns a built-in runtime config by name, anything else
+// is read as the path to a runtime config.
+func readRuntimeConfig(name string) (storage.RuntimeConfig, error) {
+	if _, ok := bosh.RuntimeConfigs[name]; ok {
+		return storage.RuntimeConfig{Name: name}, nil
+	}
+
+	contents, err := ioutil.ReadFile(name)
+	if err != nil {
+		return storage.RuntimeConfig{}, err
+	}
+
+	return storage.RuntimeConfig{
+		Name:     filepath.Base(name),
+		Contents: string(contents),
+	}, nil
+}
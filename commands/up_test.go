@@ -2,10 +2,13 @@ package commands_test
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/cloudconfig"
 	"github.com/cloudfoundry/bosh-bootloader/commands"
 	"github.com/cloudfoundry/bosh-bootloader/fakes"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -24,6 +27,9 @@ var _ = Describe("Up", func() {
 		cloudConfigManager *fakes.CloudConfigManager
 		stateStore         *fakes.StateStore
 		envIDManager       *fakes.EnvIDManager
+		doctor             *fakes.Doctor
+		directorUploader   *fakes.DirectorUploader
+		logger             *fakes.Logger
 	)
 
 	BeforeEach(func() {
@@ -35,56 +41,69 @@ var _ = Describe("Up", func() {
 		cloudConfigManager = &fakes.CloudConfigManager{}
 		stateStore = &fakes.StateStore{}
 		envIDManager = &fakes.EnvIDManager{}
+		doctor = &fakes.Doctor{}
+		directorUploader = &fakes.DirectorUploader{}
+		logger = &fakes.Logger{}
 
-		command = commands.NewUp(iaasUp, boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager)
+		command = commands.NewUp(iaasUp, boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager, doctor, directorUploader, logger)
 	})
 
 	Describe("CheckFastFails", func() {
-		Context("when the version of BOSH is a dev build", func() {
-			It("does not fail", func() {
-				boshManager.VersionCall.Returns.Error = bosh.NewBOSHVersionError(errors.New("BOSH version could not be parsed"))
-
-				err := command.CheckFastFails([]string{}, storage.State{Version: 999})
+		It("runs the doctor checks", func() {
+			err := command.CheckFastFails([]string{}, storage.State{Version: 999})
+			Expect(err).NotTo(HaveOccurred())
 
-				Expect(err).NotTo(HaveOccurred())
-			})
+			Expect(doctor.CheckCall.CallCount).To(Equal(1))
+			Expect(doctor.CheckCall.Receives.Current).To(Equal(storage.State{Version: 999}))
+			Expect(doctor.CheckCall.Receives.Desired).To(Equal(storage.State{Version: 999}))
 		})
 
-		Context("when the version of BOSH is lower than 2.0.24", func() {
-			It("returns a helpful error message when bbling up with a director", func() {
-				boshManager.VersionCall.Returns.Version = "1.9.1"
-				err := command.CheckFastFails([]string{}, storage.State{Version: 999})
+		Context("when an existing environment adds a NAT", func() {
+			var (
+				iaasChecker *fakes.IAASChecker
+				state       storage.State
+			)
 
-				Expect(err).To(MatchError("BOSH version must be at least v2.0.24"))
+			BeforeEach(func() {
+				iaasChecker = &fakes.IAASChecker{}
+				iaasChecker.CheckQuotasCall.Returns.Error = errors.New("Not enough quota for 1 more NAT gateway")
+
+				realDoctor := commands.NewDoctor(logger, terraformManager, boshManager, iaasChecker, &fakes.Socks5Proxy{}, os.TempDir())
+				command = commands.NewUp(iaasUp, boshManager, cloudConfigManager, stateStore, envIDManager, terraformManager, realDoctor, directorUploader, logger)
+
+				state = storage.State{
+					IAAS:    "aws",
+					EnvID:   "some-env",
+					TFState: "some-tf-state",
+					Network: storage.Network{CIDR: "10.0.0.0/16"},
+				}
 			})
 
-			Context("when the no-director flag is specified", func() {
-				It("does not return an error", func() {
-					boshManager.VersionCall.Returns.Version = "1.9.1"
-					err := command.CheckFastFails([]string{
-						"--no-director",
-					}, storage.State{Version: 999})
+			It("checks the quotas for the NAT against the persisted state", func() {
+				err := command.CheckFastFails([]string{"--private"}, state)
+				Expect(err).To(MatchError(ContainSubstring("[fail] aws quotas: Not enough quota for 1 more NAT gateway")))
 
-					Expect(err).NotTo(HaveOccurred())
-				})
+				Expect(iaasChecker.CheckQuotasCall.Receives.Current).To(Equal(state))
+				Expect(iaasChecker.CheckQuotasCall.Receives.Desired.Network.Private).To(BeTrue())
+				Expect(iaasChecker.CheckQuotasCall.Receives.Desired.TFState).To(Equal("some-tf-state"))
 			})
 		})
 
-		Context("when the version of BOSH cannot be retrieved", func() {
-			It("returns an error", func() {
-				boshManager.VersionCall.Returns.Error = errors.New("BOOM")
-				err := command.CheckFastFails([]string{}, storage.State{Version: 999})
+		Context("when the no-director flag is specified", func() {
+			It("does not check the bosh cli", func() {
+				err := command.CheckFastFails([]string{"--no-director"}, storage.State{Version: 999})
+				Expect(err).NotTo(HaveOccurred())
 
-				Expect(err.Error()).To(ContainSubstring("BOOM"))
+				Expect(doctor.CheckCall.Receives.Desired.NoDirector).To(BeTrue())
 			})
 		})
 
-		Context("when the version of BOSH is invalid", func() {
-			It("returns an error", func() {
-				boshManager.VersionCall.Returns.Version = "X.5.2"
-				err := command.CheckFastFails([]string{}, storage.State{Version: 999})
+		Context("when the doctor finds problems", func() {
+			It("returns the problems", func() {
+				doctor.CheckCall.Returns.Error = errors.New("bbl doctor found problems")
 
-				Expect(err.Error()).To(ContainSubstring("invalid syntax"))
+				err := command.CheckFastFails([]string{}, storage.State{Version: 999})
+				Expect(err).To(MatchError("bbl doctor found problems"))
 			})
 		})
 
@@ -106,6 +125,310 @@ var _ = Describe("Up", func() {
 					Expect(err).To(MatchError("The director name cannot be changed for an existing environment. Current name is some-name."))
 				})
 			})
+
+			Context("when the passed in network cidr does not match the state", func() {
+				It("returns an error", func() {
+					err := command.CheckFastFails([]string{
+						"--network-cidr", "172.16.0.0/16",
+					}, storage.State{EnvID: "some-name"})
+					Expect(err).To(MatchError("The network CIDR cannot be changed for an existing environment. Current network CIDR is 10.0.0.0/16."))
+				})
+			})
+
+			Context("when the passed in network cidr matches the state", func() {
+				It("returns no error", func() {
+					err := command.CheckFastFails([]string{
+						"--network-cidr", "172.16.0.0/16",
+					}, storage.State{
+						EnvID:   "some-name",
+						Network: storage.Network{CIDR: "172.16.0.0/16"},
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when --remove-ops-file names an ops file that is not in the state", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-ops-file", "some-missing-ops-file.yml",
+				}, storage.State{
+					BOSH: storage.BOSH{
+						UserOpsFiles: []storage.OpsFile{{Name: "some-ops-file.yml"}},
+					},
+				})
+				Expect(err).To(MatchError(`There is no ops file named "some-missing-ops-file.yml" to remove.`))
+			})
+		})
+
+		Context("when --remove-jumpbox-ops-file names an ops file that is not in the state", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-jumpbox-ops-file", "some-ops-file.yml",
+				}, storage.State{
+					BOSH: storage.BOSH{
+						UserOpsFiles: []storage.OpsFile{{Name: "some-ops-file.yml"}},
+					},
+				})
+				Expect(err).To(MatchError(`There is no jumpbox ops file named "some-ops-file.yml" to remove.`))
+			})
+		})
+
+		Context("when --remove-cloud-config-ops-file names an ops file that is not in the state", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{
+					"--remove-cloud-config-ops-file", "some-ops-file.yml",
+				}, storage.State{
+					BOSH: storage.BOSH{
+						UserOpsFiles: []storage.OpsFile{{Name: "some-ops-file.yml"}},
+					},
+				})
+				Expect(err).To(MatchError(`There is no cloud config ops file named "some-ops-file.yml" to remove.`))
+			})
+		})
+
+		Context("when --cloud-config-ops-file is passed", func() {
+			var (
+				opsFilePath string
+				bblState    storage.State
+			)
+
+			BeforeEach(func() {
+				opsFileDir, err := ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				opsFilePath = filepath.Join(opsFileDir, "some-ops-file.yml")
+				err = ioutil.WriteFile(opsFilePath, []byte("- type: remove\n  path: /vm_types/0\n"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				bblState = storage.State{
+					TFState: "some-tf-state",
+					BOSH:    storage.BOSH{DirectorName: "some-director"},
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(filepath.Dir(opsFilePath))
+			})
+
+			It("applies the ops files to the cloud config", func() {
+				err := command.CheckFastFails([]string{"--cloud-config-ops-file", opsFilePath}, bblState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.GenerateCall.CallCount).To(Equal(1))
+				Expect(cloudConfigManager.GenerateCall.Receives.State.BOSH.CloudConfigOpsFiles).To(Equal([]storage.OpsFile{
+					{Name: "some-ops-file.yml", Contents: "- type: remove\n  path: /vm_types/0\n"},
+				}))
+			})
+
+			It("does not generate the cloud config before the environment exists", func() {
+				err := command.CheckFastFails([]string{"--cloud-config-ops-file", opsFilePath}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.GenerateCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when an ops file cannot be parsed", func() {
+				err := ioutil.WriteFile(opsFilePath, []byte("- type: explode\n  path: /vm_types\n"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.CheckFastFails([]string{"--cloud-config-ops-file", opsFilePath}, bblState)
+				Expect(err).To(MatchError(ContainSubstring("Invalid cloud-config-ops-file some-ops-file.yml: ")))
+				Expect(cloudConfigManager.GenerateCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when an ops file cannot be applied", func() {
+				cloudConfigManager.GenerateCall.Returns.Error = errors.New("lychee")
+
+				err := command.CheckFastFails([]string{"--cloud-config-ops-file", opsFilePath}, bblState)
+				Expect(err).To(MatchError("Invalid cloud-config-ops-file: lychee"))
+			})
+
+			It("returns an error when an ops file cannot be read", func() {
+				err := command.CheckFastFails([]string{"--cloud-config-ops-file", "some/fake/path"}, bblState)
+				Expect(err).To(MatchError("Reading cloud-config-ops-file contents: stat some/fake/path: no such file or directory"))
+			})
+		})
+
+		Context("when --cloud-config-profile is passed", func() {
+			It("checks the machine types of the profile with the doctor", func() {
+				err := command.CheckFastFails([]string{
+					"--cloud-config-profile", "prod",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(doctor.CheckCall.Receives.Desired.BOSH.CloudConfigProfile).To(Equal(storage.CloudConfigProfile{Name: "prod"}))
+			})
+
+			It("returns an error when the profile is neither built-in nor a file", func() {
+				err := command.CheckFastFails([]string{
+					"--cloud-config-profile", "some/fake/path",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).To(MatchError("Reading cloud-config-profile: open some/fake/path: no such file or directory"))
+				Expect(doctor.CheckCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the profile sizes an unknown vm_type", func() {
+				profileFile, err := ioutil.TempFile("", "profile")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.Remove(profileFile.Name())
+
+				_, err = profileFile.WriteString("aws:\n  vm_types:\n    huge: m5.24xlarge\n")
+				Expect(err).NotTo(HaveOccurred())
+				profileFile.Close()
+
+				err = command.CheckFastFails([]string{
+					"--cloud-config-profile", profileFile.Name(),
+				}, storage.State{IAAS: "aws"})
+				Expect(err).To(MatchError(fmt.Sprintf(`cloud config profile %q sizes unknown vm_type "huge"`, filepath.Base(profileFile.Name()))))
+			})
+		})
+
+		Context("when --preemptible-compilation is passed on azure", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{
+					"--preemptible-compilation",
+				}, storage.State{IAAS: "azure"})
+				Expect(err).To(MatchError("Preemptible compilation workers are only supported on AWS and GCP."))
+			})
+		})
+
+		Context("when --private is passed for an existing public environment", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{
+					"--private",
+				}, storage.State{EnvID: "some-name"})
+				Expect(err).To(MatchError(`An existing environment with public IPs cannot be made private, you must re-create your environment to use "--private"`))
+			})
+		})
+
+		Context("when the network cidr is passed with an existing gcp network", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{
+					"--network-cidr", "172.16.0.0/16",
+				}, storage.State{IAAS: "gcp", GCP: storage.GCP{Network: "some-network"}})
+				Expect(err).To(MatchError(`The network CIDR of an existing GCP network is the range of its subnetwork, "--network-cidr" cannot be used with "--gcp-network".`))
+			})
+		})
+
+		Context("when the network cidr is invalid", func() {
+			It("returns an error", func() {
+				err := command.CheckFastFails([]string{
+					"--network-cidr", "10.0.0.0/24",
+				}, storage.State{})
+				Expect(err).To(MatchError(`Invalid network CIDR: "10.0.0.0/24" is too small, the network must be at least a /20`))
+			})
+
+			It("returns an error when the network is too small for the iaas", func() {
+				err := command.CheckFastFails([]string{
+					"--network-cidr", "10.0.0.0/20",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).To(MatchError(`Invalid network CIDR: "10.0.0.0/20" is too small, the network must be at least a /19 on aws`))
+			})
+
+			It("returns an error when the network cidr in the state cannot be planned", func() {
+				err := command.CheckFastFails([]string{}, storage.State{
+					IAAS:    "aws",
+					EnvID:   "some-env-id",
+					Network: storage.Network{CIDR: "10.0.0.0/20"},
+				})
+				Expect(err).To(MatchError(`Invalid network CIDR: "10.0.0.0/20" is too small, the network must be at least a /19 on aws`))
+			})
+		})
+
+		Context("when --network is passed", func() {
+			It("checks the networks against the network cidr", func() {
+				err := command.CheckFastFails([]string{
+					"--network", "services:10.0.128.0/20",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.CheckFastFails([]string{
+					"--network-cidr", "172.16.0.0/16",
+					"--network", "services:10.0.128.0/20",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).To(MatchError(`Invalid network: network "services" must be inside the network cidr 172.16.0.0/16`))
+			})
+
+			It("returns an error when a network is not valid", func() {
+				err := command.CheckFastFails([]string{
+					"--network", "services",
+				}, storage.State{IAAS: "gcp"})
+				Expect(err).To(MatchError(`Invalid network: "services" is not a network, use name:cidr`))
+			})
+
+			It("returns an error on azure", func() {
+				err := command.CheckFastFails([]string{
+					"--network", "services:10.1.0.0/20",
+				}, storage.State{IAAS: "azure"})
+				Expect(err).To(MatchError("Named networks are only supported on AWS and GCP."))
+			})
+
+			It("passes the networks and the network cidr to the doctor", func() {
+				err := command.CheckFastFails([]string{
+					"--network-cidr", "172.16.0.0/16",
+					"--network", "services:172.16.128.0/20",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(doctor.CheckCall.Receives.Desired.Network.CIDR).To(Equal("172.16.0.0/16"))
+				Expect(doctor.CheckCall.Receives.Desired.Network.Named).To(Equal([]storage.NamedNetwork{
+					{Name: "services", CIDR: "172.16.128.0/20"},
+				}))
+			})
+
+			It("returns an error with --clear-networks", func() {
+				err := command.CheckFastFails([]string{
+					"--network", "services:10.0.128.0/20",
+					"--clear-networks",
+				}, storage.State{IAAS: "aws"})
+				Expect(err).To(MatchError("--network and --clear-networks cannot be used together."))
+			})
+		})
+
+		Context("when --clear-networks is passed", func() {
+			It("does not check the saved networks with the doctor", func() {
+				err := command.CheckFastFails([]string{"--clear-networks"}, storage.State{
+					IAAS: "aws",
+					Network: storage.Network{
+						Named: []storage.NamedNetwork{{Name: "old", CIDR: "10.0.160.0/20"}},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(doctor.CheckCall.Receives.Desired.Network.Named).To(BeEmpty())
+			})
+		})
+
+		Context("when --upload-stemcell or --runtime-config is passed", func() {
+			It("returns an error without a director", func() {
+				err := command.CheckFastFails([]string{
+					"--no-director",
+					"--upload-stemcell", "latest",
+				}, storage.State{})
+				Expect(err).To(MatchError("A stemcell or runtime config cannot be uploaded without a director."))
+			})
+
+			It("returns an error when the stemcell tarball does not exist", func() {
+				err := command.CheckFastFails([]string{
+					"--upload-stemcell", "some/fake/stemcell.tgz",
+				}, storage.State{})
+				Expect(err).To(MatchError("Reading upload-stemcell: stat some/fake/stemcell.tgz: no such file or directory"))
+			})
+
+			It("returns an error when the runtime config cannot be read", func() {
+				err := command.CheckFastFails([]string{
+					"--runtime-config", "some/fake/path",
+				}, storage.State{})
+				Expect(err).To(MatchError("Reading runtime-config: open some/fake/path: no such file or directory"))
+			})
+
+			It("returns an error for the bosh-dns runtime config on azure", func() {
+				err := command.CheckFastFails([]string{
+					"--runtime-config", "bosh-dns",
+				}, storage.State{IAAS: "azure"})
+				Expect(err).To(MatchError("Invalid runtime-config: runtime config bosh-dns uses CredHub variables, but bbl does not deploy CredHub on azure"))
+			})
 		})
 	})
 
@@ -166,22 +489,61 @@ var _ = Describe("Up", func() {
 			Expect(boshManager.CreateDirectorCall.Receives.State).To(Equal(createJumpboxState))
 			Expect(stateStore.SetCall.Receives[4].State).To(Equal(createDirectorState))
 
-			Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(1))
-			Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
+			Expect(cloudConfigManager.ConfirmUpdateCall.CallCount).To(Equal(1))
+			Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State).To(Equal(createDirectorState))
+			Expect(stateStore.SetCall.Receives[5].State).To(Equal(createDirectorState))
+			Expect(stateStore.SetCall.Receives[6].State).To(Equal(createDirectorState))
+
+			Expect(stateStore.SetCall.CallCount).To(Equal(7))
+		})
+
+		Context("when the user declines the cloud config changes", func() {
+			BeforeEach(func() {
+				cloudConfigManager.ConfirmUpdateCall.Returns.Error = cloudconfig.ErrNotApplied
+			})
+
+			It("stops without saving the cloud config inputs or uploading anything", func() {
+				err := command.Execute([]string{"--upload-stemcell", "latest"}, incomingState)
+				Expect(err).To(MatchError("The cloud config changes were declined. Run the command again to apply them, or pass --no-confirm."))
+
+				Expect(logger.StepCall.Messages).To(ContainElement("skipped updating the cloud config, uploading the stemcell and updating the runtime config"))
+				Expect(stateStore.SetCall.CallCount).To(Equal(5))
+				Expect(directorUploader.UploadStemcellCall.CallCount).To(Equal(0))
+				Expect(directorUploader.UpdateRuntimeConfigCall.CallCount).To(Equal(0))
+			})
+		})
 
-			Expect(stateStore.SetCall.CallCount).To(Equal(5))
+		Context("when --no-confirm is passed", func() {
+			It("updates the cloud config without asking", func() {
+				err := command.Execute([]string{"--no-confirm"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(1))
+				Expect(cloudConfigManager.UpdateCall.Receives.State).To(Equal(createDirectorState))
+				Expect(cloudConfigManager.ConfirmUpdateCall.CallCount).To(Equal(0))
+			})
+
+			It("returns an error when the cloud config cannot be uploaded", func() {
+				cloudConfigManager.UpdateCall.Returns.Error = errors.New("coconut")
+
+				err := command.Execute([]string{"-n"}, incomingState)
+				Expect(err).To(MatchError("Update cloud config: coconut"))
+			})
 		})
 
 		Context("when the config has ops files", func() {
-			var opsFilePath string
+			var (
+				opsFileDir  string
+				opsFilePath string
+			)
 
 			BeforeEach(func() {
-				opsFile, err := ioutil.TempFile("", "ops-file")
+				var err error
+				opsFileDir, err = ioutil.TempDir("", "ops-files")
 				Expect(err).NotTo(HaveOccurred())
 
-				opsFilePath = opsFile.Name()
-				opsFileContents := "some-ops-file-contents"
-				err = ioutil.WriteFile(opsFilePath, []byte(opsFileContents), os.ModePerm)
+				opsFilePath = filepath.Join(opsFileDir, "some-ops-file.yml")
+				err = ioutil.WriteFile(opsFilePath, []byte("some-ops-file-contents"), os.ModePerm)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -189,7 +551,357 @@ var _ = Describe("Up", func() {
 				err := command.Execute([]string{"--ops-file", opsFilePath}, incomingState)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFile).To(Equal("some-ops-file-contents"))
+				Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFiles).To(Equal([]storage.OpsFile{
+					{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"},
+				}))
+			})
+
+			Context("when several ops files and a directory are provided", func() {
+				var otherOpsFilePath string
+
+				BeforeEach(func() {
+					err := ioutil.WriteFile(filepath.Join(opsFileDir, "another-ops-file.yml"), []byte("another-ops-file-contents"), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = ioutil.WriteFile(filepath.Join(opsFileDir, "some-readme.md"), []byte("not an ops file"), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+
+					otherOpsFile, err := ioutil.TempFile("", "other-ops-file")
+					Expect(err).NotTo(HaveOccurred())
+
+					otherOpsFilePath = otherOpsFile.Name()
+					err = ioutil.WriteFile(otherOpsFilePath, []byte("other-ops-file-contents"), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("passes them to the bosh manager in order", func() {
+					err := command.Execute([]string{"--ops-file", otherOpsFilePath, "--ops-file", opsFileDir}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: filepath.Base(otherOpsFilePath), Contents: "other-ops-file-contents"},
+						{Name: "another-ops-file.yml", Contents: "another-ops-file-contents"},
+						{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"},
+					}))
+				})
+			})
+
+			Context("when two ops files from different paths have the same name", func() {
+				var otherOpsFilePath string
+
+				BeforeEach(func() {
+					otherOpsFileDir, err := ioutil.TempDir("", "other-ops-files")
+					Expect(err).NotTo(HaveOccurred())
+
+					otherOpsFilePath = filepath.Join(otherOpsFileDir, "some-ops-file.yml")
+					err = ioutil.WriteFile(otherOpsFilePath, []byte("other-ops-file-contents"), os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns an error instead of replacing one with the other", func() {
+					err := command.Execute([]string{"--ops-file", opsFilePath, "--ops-file", otherOpsFilePath}, incomingState)
+					Expect(err).To(MatchError(fmt.Sprintf("Reading ops-file contents: %s and %s are both named \"some-ops-file.yml\", rename one of them", opsFilePath, otherOpsFilePath)))
+
+					Expect(boshManager.CreateDirectorCall.CallCount).To(Equal(0))
+				})
+			})
+
+			Context("when the state already has ops files", func() {
+				BeforeEach(func() {
+					iaasUp.ExecuteCall.Returns.State.BOSH.UserOpsFiles = []storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: "some-old-contents"},
+						{Name: "bbr.yml", Contents: "some-bbr-contents"},
+					}
+				})
+
+				It("replaces ops files with the same name in place and keeps the rest", func() {
+					err := command.Execute([]string{"--ops-file", opsFilePath}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"},
+						{Name: "bbr.yml", Contents: "some-bbr-contents"},
+					}))
+				})
+
+				It("re-applies them when no ops files are passed", func() {
+					err := command.Execute([]string{}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: "some-old-contents"},
+						{Name: "bbr.yml", Contents: "some-bbr-contents"},
+					}))
+				})
+
+				It("drops the ops files passed to --remove-ops-file", func() {
+					err := command.Execute([]string{"--remove-ops-file", "some-ops-file.yml"}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: "bbr.yml", Contents: "some-bbr-contents"},
+					}))
+				})
+			})
+
+			Context("when the ops files are for the jumpbox", func() {
+				It("passes the ops file contents to the bosh manager when creating the jumpbox", func() {
+					err := command.Execute([]string{"--jumpbox-ops-file", opsFilePath}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshManager.CreateJumpboxCall.Receives.State.Jumpbox.UserOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"},
+					}))
+					Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFiles).To(BeEmpty())
+				})
+
+				It("drops the ops files passed to --remove-jumpbox-ops-file", func() {
+					iaasUp.ExecuteCall.Returns.State.Jumpbox.UserOpsFiles = []storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: "some-old-contents"},
+						{Name: "some-other-ops-file.yml", Contents: "some-other-contents"},
+					}
+
+					err := command.Execute([]string{"--remove-jumpbox-ops-file", "some-ops-file.yml"}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(boshManager.CreateJumpboxCall.Receives.State.Jumpbox.UserOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: "some-other-ops-file.yml", Contents: "some-other-contents"},
+					}))
+				})
+			})
+
+			Context("when the ops files are for the cloud config", func() {
+				It("saves them and passes them to the cloud config manager", func() {
+					err := command.Execute([]string{"--cloud-config-ops-file", opsFilePath}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.BOSH.CloudConfigOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"},
+					}))
+					Expect(stateStore.SetCall.Receives[5].State.BOSH.CloudConfigOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: "some-ops-file-contents"},
+					}))
+					Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.UserOpsFiles).To(BeEmpty())
+				})
+
+				It("does not save them when the cloud config cannot be updated", func() {
+					cloudConfigManager.ConfirmUpdateCall.Returns.Error = errors.New("coconut")
+
+					err := command.Execute([]string{"--cloud-config-ops-file", opsFilePath}, incomingState)
+					Expect(err).To(MatchError("Update cloud config: coconut"))
+
+					Expect(stateStore.SetCall.CallCount).To(Equal(5))
+					for _, call := range stateStore.SetCall.Receives {
+						Expect(call.State.BOSH.CloudConfigOpsFiles).To(BeEmpty())
+					}
+				})
+
+				It("drops the ops files passed to --remove-cloud-config-ops-file", func() {
+					iaasUp.ExecuteCall.Returns.State.BOSH.CloudConfigOpsFiles = []storage.OpsFile{
+						{Name: "some-ops-file.yml", Contents: "some-old-contents"},
+						{Name: "some-other-ops-file.yml", Contents: "some-other-contents"},
+					}
+
+					err := command.Execute([]string{"--remove-cloud-config-ops-file", "some-ops-file.yml"}, incomingState)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.BOSH.CloudConfigOpsFiles).To(Equal([]storage.OpsFile{
+						{Name: "some-other-ops-file.yml", Contents: "some-other-contents"},
+					}))
+				})
+			})
+		})
+
+		Context("when --cloud-config-profile is passed", func() {
+			It("saves a built-in profile by name and passes it to the cloud config manager", func() {
+				err := command.Execute([]string{"--cloud-config-profile", "dev"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.BOSH.CloudConfigProfile).To(Equal(storage.CloudConfigProfile{Name: "dev"}))
+				Expect(stateStore.SetCall.Receives[5].State.BOSH.CloudConfigProfile).To(Equal(storage.CloudConfigProfile{Name: "dev"}))
+			})
+
+			It("saves the contents of a custom profile", func() {
+				profileDir, err := ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.RemoveAll(profileDir)
+
+				profilePath := filepath.Join(profileDir, "custom.yml")
+				err = ioutil.WriteFile(profilePath, []byte("some-profile-contents"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.Execute([]string{"--cloud-config-profile", profilePath}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.BOSH.CloudConfigProfile).To(Equal(storage.CloudConfigProfile{
+					Name:     "custom.yml",
+					Contents: "some-profile-contents",
+				}))
+			})
+
+			It("keeps the profile in the state when none is passed", func() {
+				iaasUp.ExecuteCall.Returns.State.BOSH.CloudConfigProfile = storage.CloudConfigProfile{Name: "prod"}
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.BOSH.CloudConfigProfile).To(Equal(storage.CloudConfigProfile{Name: "prod"}))
+			})
+		})
+
+		Context("when --preemptible-compilation is passed", func() {
+			It("saves it and passes it to the cloud config manager", func() {
+				err := command.Execute([]string{"--preemptible-compilation"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.BOSH.PreemptibleCompilation).To(BeTrue())
+				Expect(stateStore.SetCall.Receives[5].State.BOSH.PreemptibleCompilation).To(BeTrue())
+			})
+
+			It("keeps it on when the state has it and no flag is passed", func() {
+				iaasUp.ExecuteCall.Returns.State.BOSH.PreemptibleCompilation = true
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.BOSH.PreemptibleCompilation).To(BeTrue())
+			})
+
+			It("turns it off with --no-preemptible-compilation", func() {
+				iaasUp.ExecuteCall.Returns.State.BOSH.PreemptibleCompilation = true
+
+				err := command.Execute([]string{"--no-preemptible-compilation"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(cloudConfigManager.ConfirmUpdateCall.Receives.State.BOSH.PreemptibleCompilation).To(BeFalse())
+			})
+		})
+
+		Context("when --upload-stemcell is passed", func() {
+			It("uploads the stemcell once the cloud config is updated", func() {
+				err := command.Execute([]string{"--upload-stemcell", "latest"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorUploader.UploadStemcellCall.CallCount).To(Equal(1))
+				Expect(directorUploader.UploadStemcellCall.Receives.State).To(Equal(cloudConfigManager.ConfirmUpdateCall.Receives.State))
+				Expect(directorUploader.UploadStemcellCall.Receives.Stemcell).To(Equal("latest"))
+			})
+
+			It("does not upload a stemcell when it is not passed", func() {
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorUploader.UploadStemcellCall.CallCount).To(Equal(0))
+			})
+		})
+
+		Context("when --runtime-config is passed", func() {
+			It("saves a built-in runtime config by name and applies it", func() {
+				err := command.Execute([]string{"--runtime-config", "bosh-dns"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorUploader.UpdateRuntimeConfigCall.CallCount).To(Equal(1))
+				Expect(directorUploader.UpdateRuntimeConfigCall.Receives.State.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{Name: "bosh-dns"}))
+				Expect(stateStore.SetCall.Receives[6].State.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{Name: "bosh-dns"}))
+			})
+
+			It("does not save the runtime config before the director has it", func() {
+				err := command.Execute([]string{"--runtime-config", "bosh-dns"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, call := range stateStore.SetCall.Receives[:6] {
+					Expect(call.State.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{}))
+				}
+			})
+
+			It("does not save the runtime config when the cloud config changes are declined", func() {
+				cloudConfigManager.ConfirmUpdateCall.Returns.Error = cloudconfig.ErrNotApplied
+
+				err := command.Execute([]string{"--runtime-config", "bosh-dns"}, storage.State{})
+				Expect(err).To(HaveOccurred())
+
+				Expect(stateStore.SetCall.CallCount).To(Equal(5))
+				Expect(stateStore.SetCall.Receives[4].State.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{}))
+			})
+
+			It("does not save the runtime config when it cannot be applied", func() {
+				directorUploader.UpdateRuntimeConfigCall.Returns.Error = errors.New("guava")
+
+				err := command.Execute([]string{"--runtime-config", "bosh-dns"}, storage.State{})
+				Expect(err).To(MatchError("Update runtime config: guava"))
+
+				Expect(stateStore.SetCall.CallCount).To(Equal(6))
+				Expect(stateStore.SetCall.Receives[5].State.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{}))
+			})
+
+			It("saves the contents of a custom runtime config", func() {
+				runtimeConfigDir, err := ioutil.TempDir("", "")
+				Expect(err).NotTo(HaveOccurred())
+				defer os.RemoveAll(runtimeConfigDir)
+
+				runtimeConfigPath := filepath.Join(runtimeConfigDir, "addons.yml")
+				err = ioutil.WriteFile(runtimeConfigPath, []byte("some-runtime-config"), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = command.Execute([]string{"--runtime-config", runtimeConfigPath}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorUploader.UpdateRuntimeConfigCall.Receives.State.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{
+					Name:     "addons.yml",
+					Contents: "some-runtime-config",
+				}))
+			})
+
+			It("keeps the runtime config in the state when none is passed", func() {
+				iaasUp.ExecuteCall.Returns.State.BOSH.RuntimeConfig = storage.RuntimeConfig{Name: "bosh-dns"}
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(directorUploader.UpdateRuntimeConfigCall.Receives.State.BOSH.RuntimeConfig).To(Equal(storage.RuntimeConfig{Name: "bosh-dns"}))
+			})
+		})
+
+		Context("when --force-create-env is passed", func() {
+			BeforeEach(func() {
+				terraformManager.ApplyCall.Returns.BBLState.Jumpbox.ManifestHash = "some-jumpbox-hash"
+				boshManager.CreateJumpboxCall.Returns.State.BOSH.ManifestHash = "some-director-hash"
+			})
+
+			It("clears the manifest hashes so create-env is not skipped", func() {
+				err := command.Execute([]string{"--force-create-env"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.CreateJumpboxCall.Receives.State.Jumpbox.ManifestHash).To(BeEmpty())
+				Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.ManifestHash).To(BeEmpty())
+			})
+
+			It("keeps the manifest hashes when it is not passed", func() {
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.CreateJumpboxCall.Receives.State.Jumpbox.ManifestHash).To(Equal("some-jumpbox-hash"))
+				Expect(boshManager.CreateDirectorCall.Receives.State.BOSH.ManifestHash).To(Equal("some-director-hash"))
+			})
+		})
+
+		Context("when --force-terraform is passed", func() {
+			BeforeEach(func() {
+				envIDManager.SyncCall.Returns.State.TFFingerprint = "some-fingerprint"
+			})
+
+			It("clears the terraform fingerprint so terraform apply is not skipped", func() {
+				err := command.Execute([]string{"--force-terraform"}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(terraformManager.ApplyCall.Receives.BBLState.TFFingerprint).To(BeEmpty())
+			})
+
+			It("keeps the terraform fingerprint when it is not passed", func() {
+				err := command.Execute([]string{}, incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(terraformManager.ApplyCall.Receives.BBLState.TFFingerprint).To(Equal("some-fingerprint"))
 			})
 		})
 
@@ -202,6 +914,56 @@ var _ = Describe("Up", func() {
 			})
 		})
 
+		Context("when --network-cidr flag is passed", func() {
+			It("sets the network cidr on the state", func() {
+				err := command.Execute([]string{"--network-cidr", "172.16.0.0/16"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Network.CIDR).To(Equal("172.16.0.0/16"))
+			})
+		})
+
+		Context("when --network flags are passed", func() {
+			It("replaces the named networks on the state", func() {
+				err := command.Execute([]string{
+					"--network", "services:10.0.128.0/20",
+					"--network", "iso-seg:10.0.144.0/20",
+				}, storage.State{
+					Network: storage.Network{
+						Named: []storage.NamedNetwork{{Name: "old", CIDR: "10.0.160.0/20"}},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Network.Named).To(Equal([]storage.NamedNetwork{
+					{Name: "services", CIDR: "10.0.128.0/20"},
+					{Name: "iso-seg", CIDR: "10.0.144.0/20"},
+				}))
+			})
+		})
+
+		Context("when --clear-networks is passed", func() {
+			It("removes every named network from the state", func() {
+				err := command.Execute([]string{"--clear-networks"}, storage.State{
+					Network: storage.Network{
+						Named: []storage.NamedNetwork{{Name: "old", CIDR: "10.0.160.0/20"}},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Network.Named).To(BeEmpty())
+			})
+		})
+
+		Context("when --private flag is passed", func() {
+			It("makes the network private on the state", func() {
+				err := command.Execute([]string{"--private"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(envIDManager.SyncCall.Receives.State.Network.Private).To(BeTrue())
+			})
+		})
+
 		Context("when the config or state has the no-director flag set", func() {
 			BeforeEach(func() {
 				terraformManager.ApplyCall.Returns.BBLState.NoDirector = true
@@ -216,7 +978,8 @@ var _ = Describe("Up", func() {
 				Expect(boshManager.CreateDirectorCall.CallCount).To(Equal(0))
 				Expect(stateStore.SetCall.Receives[2].State.NoDirector).To(BeTrue())
 				Expect(stateStore.SetCall.CallCount).To(Equal(3))
-				Expect(cloudConfigManager.UpdateCall.CallCount).To(Equal(0))
+				Expect(cloudConfigManager.ConfirmUpdateCall.CallCount).To(Equal(0))
+				Expect(directorUploader.UpdateRuntimeConfigCall.CallCount).To(Equal(0))
 			})
 		})
 
@@ -255,7 +1018,27 @@ var _ = Describe("Up", func() {
 
 			It("returns an error when the ops file cannot be read", func() {
 				err := command.Execute([]string{"--ops-file", "some/fake/path"}, storage.State{})
-				Expect(err).To(MatchError("Reading ops-file contents: open some/fake/path: no such file or directory"))
+				Expect(err).To(MatchError("Reading ops-file contents: stat some/fake/path: no such file or directory"))
+			})
+
+			It("returns an error when the jumpbox ops file cannot be read", func() {
+				err := command.Execute([]string{"--jumpbox-ops-file", "some/fake/path"}, storage.State{})
+				Expect(err).To(MatchError("Reading jumpbox-ops-file contents: stat some/fake/path: no such file or directory"))
+			})
+
+			It("returns an error when the cloud config ops file cannot be read", func() {
+				err := command.Execute([]string{"--cloud-config-ops-file", "some/fake/path"}, storage.State{})
+				Expect(err).To(MatchError("Reading cloud-config-ops-file contents: stat some/fake/path: no such file or directory"))
+			})
+
+			It("returns an error when the cloud config profile cannot be read", func() {
+				err := command.Execute([]string{"--cloud-config-profile", "some/fake/path"}, storage.State{})
+				Expect(err).To(MatchError("Reading cloud-config-profile: open some/fake/path: no such file or directory"))
+			})
+
+			It("returns an error when the runtime config cannot be read", func() {
+				err := command.Execute([]string{"--runtime-config", "some/fake/path"}, storage.State{})
+				Expect(err).To(MatchError("Reading runtime-config: open some/fake/path: no such file or directory"))
 			})
 
 			It("returns an error when the env id manager fails", func() {
@@ -322,12 +1105,40 @@ var _ = Describe("Up", func() {
 			})
 
 			It("returns an error when the cloud config cannot be uploaded", func() {
-				cloudConfigManager.UpdateCall.Returns.Error = errors.New("coconut")
+				cloudConfigManager.ConfirmUpdateCall.Returns.Error = errors.New("coconut")
 
 				err := command.Execute([]string{}, storage.State{})
 				Expect(err).To(MatchError("Update cloud config: coconut"))
 			})
 
+			It("returns an error when the state cannot be saved after updating the cloud config", func() {
+				stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {}, {}, {}, {Error: errors.New("guava")}}
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("Save state after update cloud config: guava"))
+			})
+
+			It("returns an error when the stemcell cannot be uploaded", func() {
+				directorUploader.UploadStemcellCall.Returns.Error = errors.New("papaya")
+
+				err := command.Execute([]string{"--upload-stemcell", "latest"}, storage.State{})
+				Expect(err).To(MatchError("Upload stemcell: papaya"))
+			})
+
+			It("returns an error when the runtime config cannot be applied", func() {
+				directorUploader.UpdateRuntimeConfigCall.Returns.Error = errors.New("guava")
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("Update runtime config: guava"))
+			})
+
+			It("returns an error when the state cannot be saved after updating the runtime config", func() {
+				stateStore.SetCall.Returns = []fakes.SetCallReturn{{}, {}, {}, {}, {}, {}, {Error: errors.New("kiwi")}}
+
+				err := command.Execute([]string{}, storage.State{})
+				Expect(err).To(MatchError("Save state after update runtime config: kiwi"))
+			})
+
 			Context("when the terraform manager fails with terraformManagerError", func() {
 				var (
 					managerError *fakes.TerraformManagerError
@@ -404,30 +1215,57 @@ var _ = Describe("Up", func() {
 
 	Describe("ParseArgs", func() {
 		Context("when the --ops-file flag is specified", func() {
-			It("returns a config with the ops-file path", func() {
+			It("returns a config with the ops-file paths in order", func() {
 				config, err := command.ParseArgs([]string{
 					"--ops-file", "some-ops-file-path",
+					"--ops-file", "some-ops-file-dir",
 				}, storage.State{})
 				Expect(err).NotTo(HaveOccurred())
+				Expect(config.OpsFiles).To(Equal([]string{"some-ops-file-path", "some-ops-file-dir"}))
+			})
+		})
 
-				Expect(config.OpsFile).To(Equal("some-ops-file-path"))
+		Context("when the --remove-ops-file flag is specified", func() {
+			It("returns a config with the names of the ops files to remove", func() {
+				config, err := command.ParseArgs([]string{
+					"--remove-ops-file", "some-ops-file.yml",
+				}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.RemoveOpsFiles).To(Equal([]string{"some-ops-file.yml"}))
 			})
+		})
 
-			Context("when the --ops-file flag is not specified", func() {
-				It("creates a default ops-file with the contents of state.BOSH.UserOpsFile", func() {
-					config, err := command.ParseArgs([]string{}, storage.State{
-						BOSH: storage.BOSH{
-							UserOpsFile: "some-ops-file-contents",
-						},
-					})
-					Expect(err).NotTo(HaveOccurred())
+		Context("when the jumpbox ops file flags are specified", func() {
+			It("returns a config with the jumpbox ops files to add and remove", func() {
+				config, err := command.ParseArgs([]string{
+					"--jumpbox-ops-file", "some-ops-file-path",
+					"--remove-jumpbox-ops-file", "some-ops-file.yml",
+				}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.JumpboxOpsFiles).To(Equal([]string{"some-ops-file-path"}))
+				Expect(config.RemoveJumpboxOpsFiles).To(Equal([]string{"some-ops-file.yml"}))
+			})
+		})
 
-					filePath := config.OpsFile
-					fileContents, err := ioutil.ReadFile(filePath)
-					Expect(err).NotTo(HaveOccurred())
+		Context("when the cloud config ops file flags are specified", func() {
+			It("returns a config with the cloud config ops files to add and remove", func() {
+				config, err := command.ParseArgs([]string{
+					"--cloud-config-ops-file", "some-ops-file-path",
+					"--remove-cloud-config-ops-file", "some-ops-file.yml",
+				}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.CloudConfigOpsFiles).To(Equal([]string{"some-ops-file-path"}))
+				Expect(config.RemoveCloudConfigOpsFiles).To(Equal([]string{"some-ops-file.yml"}))
+			})
+		})
 
-					Expect(string(fileContents)).To(Equal("some-ops-file-contents"))
-				})
+		Context("when the cloud config profile flag is specified", func() {
+			It("returns a config with the cloud config profile", func() {
+				config, err := command.ParseArgs([]string{
+					"--cloud-config-profile", "prod",
+				}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.CloudConfigProfile).To(Equal("prod"))
 			})
 		})
 
@@ -441,6 +1279,26 @@ var _ = Describe("Up", func() {
 			})
 		})
 
+		Context("when the user provides the no-confirm flag", func() {
+			It("asks for confirmation by default", func() {
+				config, err := command.ParseArgs([]string{}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.NoConfirm).To(BeFalse())
+			})
+
+			It("does not ask for confirmation with --no-confirm", func() {
+				config, err := command.ParseArgs([]string{"--no-confirm"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.NoConfirm).To(BeTrue())
+			})
+
+			It("does not ask for confirmation with -n", func() {
+				config, err := command.ParseArgs([]string{"-n"}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.NoConfirm).To(BeTrue())
+			})
+		})
+
 		Context("when the user provides the no-director flag", func() {
 			It("passes NoDirector as true in the up config", func() {
 				config, err := command.ParseArgs([]string{
@@ -463,6 +1321,26 @@ var _ = Describe("Up", func() {
 			})
 		})
 
+		Context("when the user provides the network-cidr flag", func() {
+			It("passes the network cidr in the up config", func() {
+				config, err := command.ParseArgs([]string{
+					"--network-cidr", "172.16.0.0/16",
+				}, storage.State{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.NetworkCIDR).To(Equal("172.16.0.0/16"))
+			})
+		})
+
+		Context("when the state has a private network", func() {
+			It("sets private to true in the up config", func() {
+				config, err := command.ParseArgs([]string{}, storage.State{
+					Network: storage.Network{Private: true},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Private).To(BeTrue())
+			})
+		})
+
 		Context("failure cases", func() {
 			It("returns an error when undefined flags are passed", func() {
 				_, err := command.ParseArgs([]string{"--foo", "bar"}, storage.State{})
@@ -0,0 +1,267 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/flags"
+	"github.com/cloudfoundry/bosh-bootloader/helpers"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type UpgradeDirector struct {
+	logger           logger
+	stdin            io.Reader
+	boshManager      boshManager
+	stateStore       stateStore
+	stateValidator   stateValidator
+	terraformManager terraformOutputter
+}
+
+type upgradeDirectorConfig struct {
+	VersionsFile string
+	Unpin        bool
+	Rollback     bool
+	NoConfirm    bool
+}
+
+func NewUpgradeDirector(logger logger, stdin io.Reader, boshManager boshManager, stateStore stateStore,
+	stateValidator stateValidator, terraformManager terraformOutputter) UpgradeDirector {
+	return UpgradeDirector{
+		logger:           logger,
+		stdin:            stdin,
+		boshManager:      boshManager,
+		stateStore:       stateStore,
+		stateValidator:   stateValidator,
+		terraformManager: terraformManager,
+	}
+}
+
+func (u UpgradeDirector) CheckFastFails(subcommandFlags []string, state storage.State) error {
+	err := u.stateValidator.Validate()
+	if err != nil {
+		return err
+	}
+
+	if state.NoDirector || state.BOSH.IsEmpty() {
+		return errors.New("There is no director to upgrade.")
+	}
+
+	err = fastFailBOSHVersion(u.boshManager)
+	if err != nil {
+		return err
+	}
+
+	config, err := u.parseFlags(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	options := 0
+	for _, set := range []bool{config.VersionsFile != "", config.Unpin, config.Rollback} {
+		if set {
+			options++
+		}
+	}
+	if options > 1 {
+		return errors.New("--versions-file, --unpin and --rollback cannot be used together")
+	}
+
+	if config.Rollback && state.BOSH.PreviousManifest == "" {
+		return errors.New("There is no previous director to roll back to.")
+	}
+
+	if config.VersionsFile != "" {
+		_, err = readVersionsFile(config.VersionsFile)
+		if err != nil {
+			return fmt.Errorf("Versions file: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (u UpgradeDirector) Execute(subcommandFlags []string, state storage.State) error {
+	config, err := u.parseFlags(subcommandFlags)
+	if err != nil {
+		return err
+	}
+
+	if config.Rollback {
+		return u.rollback(state, config.NoConfirm)
+	}
+
+	switch {
+	case config.VersionsFile != "":
+		state.BOSH.VersionsFile, err = readVersionsFile(config.VersionsFile)
+		if err != nil {
+			return fmt.Errorf("Versions file: %s", err)
+		}
+	case config.Unpin:
+		state.BOSH.VersionsFile = ""
+	}
+
+	terraformOutputs, err := u.terraformManager.GetOutputs(state)
+	if err != nil {
+		return err
+	}
+
+	current, err := bosh.ManifestVersions(state.BOSH.Manifest)
+	if err != nil {
+		return fmt.Errorf("Current director: %s", err)
+	}
+
+	manifest, err := u.boshManager.InterpolateDirector(state, terraformOutputs)
+	if err != nil {
+		return fmt.Errorf("Interpolate director: %s", err)
+	}
+
+	target, err := bosh.ManifestVersions(manifest)
+	if err != nil {
+		return fmt.Errorf("Target director: %s", err)
+	}
+
+	u.printVersions(current, target)
+
+	if reflect.DeepEqual(current, target) {
+		u.logger.Step("bosh director is up to date")
+		return u.stateStore.Set(state)
+	}
+
+	if !config.NoConfirm && !u.confirm("Do you want to upgrade the bosh director?") {
+		return nil
+	}
+
+	state, err = u.boshManager.UpgradeDirector(state, terraformOutputs)
+	if err != nil {
+		return u.createEnvError("Upgrade bosh director", err)
+	}
+
+	err = u.stateStore.Set(state)
+	if err != nil {
+		return fmt.Errorf("Save state after upgrade director: %s", err)
+	}
+
+	return nil
+}
+
+// rollback runs create-env with the manifest from before the last upgrade.
+// The versions of that manifest are pinned, so that bbl up does not upgrade
+// the director again.
+func (u UpgradeDirector) rollback(state storage.State, noConfirm bool) error {
+	current, err := bosh.ManifestVersions(state.BOSH.Manifest)
+	if err != nil {
+		return fmt.Errorf("Current director: %s", err)
+	}
+
+	previous, err := bosh.ManifestVersions(state.BOSH.PreviousManifest)
+	if err != nil {
+		return fmt.Errorf("Previous director: %s", err)
+	}
+
+	u.printVersions(current, previous)
+
+	if !noConfirm && !u.confirm("Do you want to roll back the bosh director?") {
+		return nil
+	}
+
+	state.BOSH.VersionsFile = previous.VersionsFile()
+	state, err = u.boshManager.RollbackDirector(state)
+	if err != nil {
+		return u.createEnvError("Roll back bosh director", err)
+	}
+
+	err = u.stateStore.Set(state)
+	if err != nil {
+		return fmt.Errorf("Save state after roll back director: %s", err)
+	}
+
+	u.logger.Println("The director is pinned to the versions it was rolled back to. Run `bbl upgrade-director --unpin` to upgrade it again.")
+	return nil
+}
+
+func (u UpgradeDirector) confirm(prompt string) bool {
+	u.logger.Prompt(prompt)
+
+	var proceed string
+	fmt.Fscanln(u.stdin, &proceed)
+
+	proceed = strings.ToLower(proceed)
+	if proceed != "yes" && proceed != "y" {
+		u.logger.Step("exiting")
+		return false
+	}
+
+	return true
+}
+
+// createEnvError saves the state of a failed create-env, so that the next
+// attempt carries on from it.
+func (u UpgradeDirector) createEnvError(action string, err error) error {
+	switch err.(type) {
+	case bosh.ManagerCreateError:
+		mcErr := err.(bosh.ManagerCreateError)
+		setErr := u.stateStore.Set(mcErr.State())
+		if setErr != nil {
+			errorList := helpers.Errors{}
+			errorList.Add(err)
+			errorList.Add(setErr)
+			return errorList
+		}
+	}
+
+	return fmt.Errorf("%s: %s", action, err)
+}
+
+func (u UpgradeDirector) parseFlags(subcommandFlags []string) (upgradeDirectorConfig, error) {
+	upgradeDirectorFlags := flags.New("upgrade-director")
+
+	config := upgradeDirectorConfig{}
+	upgradeDirectorFlags.String(&config.VersionsFile, "versions-file", "")
+	upgradeDirectorFlags.Bool(&config.Unpin, "", "unpin", false)
+	upgradeDirectorFlags.Bool(&config.Rollback, "", "rollback", false)
+	upgradeDirectorFlags.Bool(&config.NoConfirm, "n", "no-confirm", false)
+
+	err := upgradeDirectorFlags.Parse(subcommandFlags)
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+func (u UpgradeDirector) printVersions(current, target bosh.DirectorVersions) {
+	currentSummary := current.Summary()
+	targetSummary := target.Summary()
+
+	width := len("current")
+	for _, component := range currentSummary {
+		if len(component.Version) > width {
+			width = len(component.Version)
+		}
+	}
+
+	u.logger.Println(fmt.Sprintf("%-10s %-*s %s", "", width, "current", "target"))
+	for i, component := range currentSummary {
+		u.logger.Println(fmt.Sprintf("%-10s %-*s %s", component.Name, width, component.Version, targetSummary[i].Version))
+	}
+}
+
+func readVersionsFile(path string) (string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = bosh.ParseVersionsFile(string(contents))
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents), nil
+}
@@ -0,0 +1,314 @@
+package commands_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/commands"
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpgradeDirector", func() {
+	const (
+		currentManifest = `---
+releases:
+- name: bosh
+  version: 263.2.0
+  url: https://bosh.io/d/github.com/cloudfoundry/bosh?v=263.2.0
+  sha1: some-bosh-sha1
+- name: bosh-google-cpi
+  version: 25.10.0
+  url: https://bosh.io/d/github.com/cloudfoundry-incubator/bosh-google-cpi-release?v=25.10.0
+  sha1: some-cpi-sha1
+resource_pools:
+- name: vms
+  stemcell:
+    url: https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-trusty-go_agent?v=3445.7
+    sha1: some-stemcell-sha1
+`
+		targetManifest = `---
+releases:
+- name: bosh
+  version: 264.1.0
+  url: https://bosh.io/d/github.com/cloudfoundry/bosh?v=264.1.0
+  sha1: some-new-bosh-sha1
+- name: bosh-google-cpi
+  version: 25.10.0
+  url: https://bosh.io/d/github.com/cloudfoundry-incubator/bosh-google-cpi-release?v=25.10.0
+  sha1: some-cpi-sha1
+resource_pools:
+- name: vms
+  stemcell:
+    url: https://bosh.io/d/stemcells/bosh-google-kvm-ubuntu-trusty-go_agent?v=3445.7
+    sha1: some-stemcell-sha1
+`
+		versionsFile = `releases:
+- name: bosh
+  version: 264.1.0
+  url: https://bosh.io/d/github.com/cloudfoundry/bosh?v=264.1.0
+  sha1: some-new-bosh-sha1
+`
+	)
+
+	var (
+		upgradeDirector  commands.UpgradeDirector
+		boshManager      *fakes.BOSHManager
+		logger           *fakes.Logger
+		stateStore       *fakes.StateStore
+		stateValidator   *fakes.StateValidator
+		terraformManager *fakes.TerraformManager
+		stdin            *bytes.Buffer
+		state            storage.State
+		versionsFilePath string
+	)
+
+	BeforeEach(func() {
+		stdin = bytes.NewBuffer([]byte{})
+		logger = &fakes.Logger{}
+		boshManager = &fakes.BOSHManager{}
+		boshManager.VersionCall.Returns.Version = "2.0.24"
+		boshManager.InterpolateDirectorCall.Returns.Manifest = targetManifest
+		stateStore = &fakes.StateStore{}
+		stateValidator = &fakes.StateValidator{}
+		terraformManager = &fakes.TerraformManager{}
+		terraformManager.GetOutputsCall.Returns.Outputs = map[string]interface{}{"some-key": "some-value"}
+
+		upgradeDirector = commands.NewUpgradeDirector(logger, stdin, boshManager, stateStore, stateValidator, terraformManager)
+
+		state = storage.State{
+			IAAS: "gcp",
+			BOSH: storage.BOSH{
+				DirectorName: "bosh-some-env",
+				Manifest:     currentManifest,
+			},
+		}
+
+		file, err := ioutil.TempFile("", "versions")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.WriteString(versionsFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+		versionsFilePath = file.Name()
+	})
+
+	AfterEach(func() {
+		os.Remove(versionsFilePath)
+	})
+
+	Describe("CheckFastFails", func() {
+		It("returns an error when the state is invalid", func() {
+			stateValidator.ValidateCall.Returns.Error = errors.New("state file not found")
+
+			err := upgradeDirector.CheckFastFails([]string{}, state)
+			Expect(err).To(MatchError("state file not found"))
+		})
+
+		It("returns an error when there is no director", func() {
+			err := upgradeDirector.CheckFastFails([]string{}, storage.State{NoDirector: true})
+			Expect(err).To(MatchError("There is no director to upgrade."))
+		})
+
+		It("returns an error when the bosh cli is too old", func() {
+			boshManager.VersionCall.Returns.Version = "1.9.0"
+
+			err := upgradeDirector.CheckFastFails([]string{}, state)
+			Expect(err).To(MatchError("BOSH version must be at least v2.0.24"))
+		})
+
+		It("returns an error when more than one of the versions flags is set", func() {
+			err := upgradeDirector.CheckFastFails([]string{"--unpin", "--rollback"}, state)
+			Expect(err).To(MatchError("--versions-file, --unpin and --rollback cannot be used together"))
+		})
+
+		It("returns an error when there is no previous director to roll back to", func() {
+			err := upgradeDirector.CheckFastFails([]string{"--rollback"}, state)
+			Expect(err).To(MatchError("There is no previous director to roll back to."))
+		})
+
+		It("returns an error when the versions file is invalid", func() {
+			err := ioutil.WriteFile(versionsFilePath, []byte("releases: []"), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = upgradeDirector.CheckFastFails([]string{"--versions-file", versionsFilePath}, state)
+			Expect(err).To(MatchError("Versions file: versions file does not pin any releases or a stemcell"))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("prints the current and target versions and upgrades the director when confirmed", func() {
+			stdin.Write([]byte("yes\n"))
+			boshManager.UpgradeDirectorCall.Returns.State = storage.State{
+				BOSH: storage.BOSH{Manifest: targetManifest, PreviousManifest: currentManifest},
+			}
+
+			err := upgradeDirector.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(boshManager.InterpolateDirectorCall.Receives.State).To(Equal(state))
+			Expect(boshManager.InterpolateDirectorCall.Receives.TerraformOutputs).To(Equal(map[string]interface{}{"some-key": "some-value"}))
+			Expect(logger.PrintlnCall.Messages).To(Equal([]string{
+				"           current                                       target",
+				"director   263.2.0                                       264.1.0",
+				"uaa        -                                             -",
+				"credhub    -                                             -",
+				"cpi        bosh-google-cpi/25.10.0                       bosh-google-cpi/25.10.0",
+				"stemcell   bosh-google-kvm-ubuntu-trusty-go_agent/3445.7 bosh-google-kvm-ubuntu-trusty-go_agent/3445.7",
+			}))
+			Expect(logger.PromptCall.Receives.Message).To(Equal("Do you want to upgrade the bosh director?"))
+
+			Expect(boshManager.UpgradeDirectorCall.CallCount).To(Equal(1))
+			Expect(boshManager.UpgradeDirectorCall.Receives.State).To(Equal(state))
+			Expect(stateStore.SetCall.Receives[0].State.BOSH.PreviousManifest).To(Equal(currentManifest))
+		})
+
+		It("does not upgrade the director when the upgrade is not confirmed", func() {
+			stdin.Write([]byte("no\n"))
+
+			err := upgradeDirector.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.StepCall.Messages).To(ContainElement("exiting"))
+			Expect(boshManager.UpgradeDirectorCall.CallCount).To(Equal(0))
+			Expect(stateStore.SetCall.CallCount).To(Equal(0))
+		})
+
+		It("does not ask for confirmation with --no-confirm", func() {
+			err := upgradeDirector.Execute([]string{"--no-confirm"}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.PromptCall.CallCount).To(Equal(0))
+			Expect(boshManager.UpgradeDirectorCall.CallCount).To(Equal(1))
+		})
+
+		It("saves the state without upgrading when the director is up to date", func() {
+			boshManager.InterpolateDirectorCall.Returns.Manifest = currentManifest
+
+			err := upgradeDirector.Execute([]string{}, state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger.StepCall.Messages).To(ContainElement("bosh director is up to date"))
+			Expect(logger.PromptCall.CallCount).To(Equal(0))
+			Expect(boshManager.UpgradeDirectorCall.CallCount).To(Equal(0))
+			Expect(stateStore.SetCall.Receives[0].State).To(Equal(state))
+		})
+
+		Context("with --versions-file", func() {
+			It("pins the versions in the state", func() {
+				err := upgradeDirector.Execute([]string{"--versions-file", versionsFilePath, "--no-confirm"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.InterpolateDirectorCall.Receives.State.BOSH.VersionsFile).To(Equal(versionsFile))
+				Expect(boshManager.UpgradeDirectorCall.Receives.State.BOSH.VersionsFile).To(Equal(versionsFile))
+			})
+		})
+
+		Context("with --unpin", func() {
+			It("removes the pinned versions from the state", func() {
+				state.BOSH.VersionsFile = versionsFile
+
+				err := upgradeDirector.Execute([]string{"--unpin", "--no-confirm"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.InterpolateDirectorCall.Receives.State.BOSH.VersionsFile).To(BeEmpty())
+			})
+		})
+
+		Context("with --rollback", func() {
+			BeforeEach(func() {
+				state.BOSH.Manifest = targetManifest
+				state.BOSH.PreviousManifest = currentManifest
+				boshManager.RollbackDirectorCall.Returns.State = storage.State{
+					BOSH: storage.BOSH{Manifest: currentManifest},
+				}
+			})
+
+			It("rolls back to the previous manifest and pins its versions", func() {
+				stdin.Write([]byte("yes\n"))
+
+				err := upgradeDirector.Execute([]string{"--rollback"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logger.PrintlnCall.Messages[1]).To(Equal("director   264.1.0                                       263.2.0"))
+				Expect(logger.PromptCall.Receives.Message).To(Equal("Do you want to roll back the bosh director?"))
+
+				Expect(boshManager.InterpolateDirectorCall.CallCount).To(Equal(0))
+				Expect(boshManager.UpgradeDirectorCall.CallCount).To(Equal(0))
+				Expect(boshManager.RollbackDirectorCall.CallCount).To(Equal(1))
+
+				pinned, err := bosh.ParseVersionsFile(boshManager.RollbackDirectorCall.Receives.State.BOSH.VersionsFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pinned.Summary()[0]).To(Equal(bosh.ComponentVersion{Name: "director", Version: "263.2.0"}))
+
+				Expect(stateStore.SetCall.Receives[0].State.BOSH.Manifest).To(Equal(currentManifest))
+				Expect(logger.PrintlnCall.Messages).To(ContainElement("The director is pinned to the versions it was rolled back to. Run `bbl upgrade-director --unpin` to upgrade it again."))
+			})
+
+			It("does not roll back the director when the rollback is not confirmed", func() {
+				stdin.Write([]byte("no\n"))
+
+				err := upgradeDirector.Execute([]string{"--rollback"}, state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(boshManager.RollbackDirectorCall.CallCount).To(Equal(0))
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+			})
+
+			It("saves the state of a failed create-env", func() {
+				failedState := storage.State{BOSH: storage.BOSH{PreviousManifest: currentManifest}}
+				boshManager.RollbackDirectorCall.Returns.Error = bosh.NewManagerCreateError(failedState, errors.New("failed to create env"))
+
+				err := upgradeDirector.Execute([]string{"--rollback", "--no-confirm"}, state)
+				Expect(err).To(MatchError("Roll back bosh director: failed to create env"))
+				Expect(stateStore.SetCall.Receives[0].State).To(Equal(failedState))
+			})
+		})
+
+		Context("failure cases", func() {
+			It("returns an error when the terraform outputs cannot be read", func() {
+				terraformManager.GetOutputsCall.Returns.Error = errors.New("failed to get outputs")
+
+				err := upgradeDirector.Execute([]string{}, state)
+				Expect(err).To(MatchError("failed to get outputs"))
+			})
+
+			It("returns an error when the target director cannot be interpolated", func() {
+				boshManager.InterpolateDirectorCall.Returns.Error = errors.New("failed to interpolate")
+
+				err := upgradeDirector.Execute([]string{}, state)
+				Expect(err).To(MatchError("Interpolate director: failed to interpolate"))
+			})
+
+			It("returns an error when the director cannot be upgraded", func() {
+				boshManager.UpgradeDirectorCall.Returns.Error = errors.New("failed to create env")
+
+				err := upgradeDirector.Execute([]string{"--no-confirm"}, state)
+				Expect(err).To(MatchError("Upgrade bosh director: failed to create env"))
+				Expect(stateStore.SetCall.CallCount).To(Equal(0))
+			})
+
+			It("saves the state of a failed create-env", func() {
+				failedState := storage.State{BOSH: storage.BOSH{PreviousManifest: currentManifest}}
+				boshManager.UpgradeDirectorCall.Returns.Error = bosh.NewManagerCreateError(failedState, errors.New("failed to create env"))
+
+				err := upgradeDirector.Execute([]string{"--no-confirm"}, state)
+				Expect(err).To(MatchError("Upgrade bosh director: failed to create env"))
+				Expect(stateStore.SetCall.Receives[0].State).To(Equal(failedState))
+			})
+
+			It("returns both errors when the state of a failed create-env cannot be saved", func() {
+				boshManager.UpgradeDirectorCall.Returns.Error = bosh.NewManagerCreateError(storage.State{}, errors.New("failed to create env"))
+				stateStore.SetCall.Returns = []fakes.SetCallReturn{{Error: errors.New("failed to save state")}}
+
+				err := upgradeDirector.Execute([]string{"--no-confirm"}, state)
+				Expect(err).To(MatchError("the following errors occurred:\nfailed to create env,\nfailed to save state"))
+			})
+		})
+	})
+})
@@ -43,12 +43,15 @@ Global Options:
   --state-dir            Directory containing bbl-state.json
   --debug                Prints debugging output
   --version              Prints version
+  --bosh-deployment-dir     Local bosh-deployment checkout to use instead of the vendored copy (Defaults to environment variable BBL_BOSH_DEPLOYMENT_DIR)
+  --jumpbox-deployment-dir  Local jumpbox-deployment checkout to use instead of the vendored copy (Defaults to environment variable BBL_JUMPBOX_DEPLOYMENT_DIR)
 
 Commands:
   help                    Prints usage
   version                 Prints version
   up                      Deploys BOSH director on an IAAS
   destroy                 Tears down BOSH director infrastructure
+  upgrade-director        Upgrades the BOSH director to pinned or bundled versions
   lbs                     Prints attached load balancer(s)
   create-lbs              Attaches load balancer(s)
   update-lbs              Updates load balancer(s)
@@ -66,6 +69,8 @@ Commands:
   latest-error            Prints the output from the latest call to terraform
   print-env               Prints BOSH friendly environment variables
   ssh-key                 Prints SSH private key
+  status                  Checks the health of the jumpbox, director, UAA and CredHub
+  doctor                  Checks that bbl can create an environment
 
   Use "bbl [command] --help" for more information about a command.
 `, "\n")))
@@ -84,6 +89,8 @@ Global Options:
   --state-dir            Directory containing bbl-state.json
   --debug                Prints debugging output
   --version              Prints version
+  --bosh-deployment-dir     Local bosh-deployment checkout to use instead of the vendored copy (Defaults to environment variable BBL_BOSH_DEPLOYMENT_DIR)
+  --jumpbox-deployment-dir  Local jumpbox-deployment checkout to use instead of the vendored copy (Defaults to environment variable BBL_JUMPBOX_DEPLOYMENT_DIR)
 
 [my-command command options]
   some message
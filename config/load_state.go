@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-bootloader/application"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
@@ -19,9 +23,19 @@ type globalFlags struct {
 	StateDir string `short:"s" long:"state-dir"`
 	IAAS     string `long:"iaas"                    env:"BBL_IAAS"`
 
+	Tags      []string `long:"tag"`
+	ClearTags bool     `long:"clear-tags"`
+
+	BOSHDeploymentDir    string `long:"bosh-deployment-dir"    env:"BBL_BOSH_DEPLOYMENT_DIR"`
+	JumpboxDeploymentDir string `long:"jumpbox-deployment-dir" env:"BBL_JUMPBOX_DEPLOYMENT_DIR"`
+
 	AWSAccessKeyID     string `long:"aws-access-key-id"       env:"BBL_AWS_ACCESS_KEY_ID"`
 	AWSSecretAccessKey string `long:"aws-secret-access-key"   env:"BBL_AWS_SECRET_ACCESS_KEY"`
 	AWSRegion          string `long:"aws-region"              env:"BBL_AWS_REGION"`
+	AWSNATMode         string `long:"aws-nat-mode"            env:"BBL_AWS_NAT_MODE"`
+	AWSSpotBidPrice    string `long:"aws-spot-bid-price"      env:"BBL_AWS_SPOT_BID_PRICE"`
+	AWSVPCLimit        int    `long:"aws-vpc-limit"           env:"BBL_AWS_VPC_LIMIT"`
+	AWSNATGatewayLimit int    `long:"aws-nat-gateway-limit"   env:"BBL_AWS_NAT_GATEWAY_LIMIT"`
 
 	AzureClientID       string `long:"azure-client-id"        env:"BBL_AZURE_CLIENT_ID"`
 	AzureClientSecret   string `long:"azure-client-secret"    env:"BBL_AZURE_CLIENT_SECRET"`
@@ -33,8 +47,16 @@ type globalFlags struct {
 	GCPProjectID         string `long:"gcp-project-id"          env:"BBL_GCP_PROJECT_ID"`
 	GCPZone              string `long:"gcp-zone"                env:"BBL_GCP_ZONE"`
 	GCPRegion            string `long:"gcp-region"              env:"BBL_GCP_REGION"`
+	GCPNetwork           string `long:"gcp-network"             env:"BBL_GCP_NETWORK"`
+	GCPSubnetwork        string `long:"gcp-subnetwork"          env:"BBL_GCP_SUBNETWORK"`
+	GCPNetworkProject    string `long:"gcp-network-project"     env:"BBL_GCP_NETWORK_PROJECT"`
 }
 
+var (
+	gcpLabelKey   = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+	gcpLabelValue = regexp.MustCompile(`^[a-z0-9_-]{0,63}$`)
+)
+
 func NewConfig(getState func(string) (storage.State, error)) Config {
 	return Config{
 		getState: getState,
@@ -87,6 +109,16 @@ func (c Config) Bootstrap(args []string) (application.Configuration, error) {
 		}
 	}
 
+	boshDeploymentDir, err := deploymentDir("bosh-deployment-dir", globalFlags.BOSHDeploymentDir)
+	if err != nil {
+		return application.Configuration{}, err
+	}
+
+	jumpboxDeploymentDir, err := deploymentDir("jumpbox-deployment-dir", globalFlags.JumpboxDeploymentDir)
+	if err != nil {
+		return application.Configuration{}, err
+	}
+
 	state, err := c.getState(globalFlags.StateDir)
 	if err != nil {
 		return application.Configuration{}, err
@@ -99,8 +131,10 @@ func (c Config) Bootstrap(args []string) (application.Configuration, error) {
 
 	return application.Configuration{
 		Global: application.GlobalConfiguration{
-			Debug:    globalFlags.Debug,
-			StateDir: globalFlags.StateDir,
+			Debug:                globalFlags.Debug,
+			StateDir:             globalFlags.StateDir,
+			BOSHDeploymentDir:    boshDeploymentDir,
+			JumpboxDeploymentDir: jumpboxDeploymentDir,
 		},
 		State:           state,
 		Command:         remainingArgs[0],
@@ -118,6 +152,21 @@ func updateIAASState(globalFlags globalFlags, state storage.State) (storage.Stat
 		state.IAAS = globalFlags.IAAS
 	}
 
+	if globalFlags.ClearTags {
+		if len(globalFlags.Tags) > 0 {
+			return storage.State{}, errors.New("--tag and --clear-tags cannot be used together.")
+		}
+		state.Tags = nil
+	} else {
+		tags, err := globalTags(globalFlags.Tags, os.Getenv("BBL_TAGS"), state.IAAS)
+		if err != nil {
+			return storage.State{}, err
+		}
+		if len(tags) > 0 {
+			state.Tags = tags
+		}
+	}
+
 	switch state.IAAS {
 	case "aws":
 		state, err := updateAWSState(globalFlags, state)
@@ -133,6 +182,60 @@ func updateIAASState(globalFlags globalFlags, state storage.State) (storage.Stat
 	return state, nil
 }
 
+// globalTags returns the tags given with --tag, or else the JSON object in
+// BBL_TAGS. JSON lets tag values contain any character.
+func globalTags(tagFlags []string, tagsEnv, iaas string) (map[string]string, error) {
+	tags := map[string]string{}
+	if len(tagFlags) > 0 {
+		for _, tagFlag := range tagFlags {
+			parts := strings.SplitN(tagFlag, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return nil, fmt.Errorf("Invalid tag %q, tags must be in the form key=value.", tagFlag)
+			}
+			tags[parts[0]] = parts[1]
+		}
+	} else if tagsEnv != "" {
+		err := json.Unmarshal([]byte(tagsEnv), &tags)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid BBL_TAGS, tags must be a JSON object such as {\"team\": \"core\"}: %s", err)
+		}
+	}
+
+	for key, value := range tags {
+		if key == "" {
+			return nil, errors.New("Invalid tag, tag keys cannot be empty.")
+		}
+
+		if iaas == "gcp" && (!gcpLabelKey.MatchString(key) || !gcpLabelValue.MatchString(value)) {
+			return nil, fmt.Errorf("Invalid tag \"%s=%s\", GCP labels may only contain lowercase letters, numbers, underscores and dashes, and keys must start with a letter.", key, value)
+		}
+	}
+
+	return tags, nil
+}
+
+func deploymentDir(flag, dir string) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Errorf("Invalid --%s: %s", flag, err)
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("Invalid --%s: %s is not a directory", flag, dir)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return absDir, nil
+}
+
 func updateAWSState(globalFlags globalFlags, state storage.State) (storage.State, error) {
 	if globalFlags.AWSAccessKeyID != "" {
 		state.AWS.AccessKeyID = globalFlags.AWSAccessKeyID
@@ -147,10 +250,46 @@ func updateAWSState(globalFlags globalFlags, state storage.State) (storage.State
 		}
 		state.AWS.Region = globalFlags.AWSRegion
 	}
+	if globalFlags.AWSNATMode != "" {
+		if !isValidAWSNATMode(globalFlags.AWSNATMode) {
+			return storage.State{}, fmt.Errorf("--aws-nat-mode must be one of [%s]", strings.Join(storage.AWSNATModes, ", "))
+		}
+		state.AWS.NATMode = globalFlags.AWSNATMode
+	} else if state.EnvID == "" && state.AWS.NATMode == "" {
+		state.AWS.NATMode = storage.AWSNATModeGateway
+	}
+	if globalFlags.AWSSpotBidPrice != "" {
+		price, err := strconv.ParseFloat(globalFlags.AWSSpotBidPrice, 64)
+		if err != nil || price <= 0 {
+			return storage.State{}, errors.New("--aws-spot-bid-price must be a price in US dollars per hour, for example 0.05")
+		}
+		state.AWS.SpotBidPrice = globalFlags.AWSSpotBidPrice
+	}
+	if globalFlags.AWSVPCLimit < 0 {
+		return storage.State{}, errors.New("--aws-vpc-limit must be a positive number of VPCs")
+	}
+	if globalFlags.AWSVPCLimit > 0 {
+		state.AWS.VPCLimit = globalFlags.AWSVPCLimit
+	}
+	if globalFlags.AWSNATGatewayLimit < 0 {
+		return storage.State{}, errors.New("--aws-nat-gateway-limit must be a positive number of NAT gateways")
+	}
+	if globalFlags.AWSNATGatewayLimit > 0 {
+		state.AWS.NATGatewayLimit = globalFlags.AWSNATGatewayLimit
+	}
 
 	return state, nil
 }
 
+func isValidAWSNATMode(natMode string) bool {
+	for _, mode := range storage.AWSNATModes {
+		if natMode == mode {
+			return true
+		}
+	}
+	return false
+}
+
 func updateGCPState(globalFlags globalFlags, state storage.State) (storage.State, error) {
 	if globalFlags.GCPServiceAccountKey != "" {
 		serviceAccountKey, err := parseServiceAccountKey(globalFlags.GCPServiceAccountKey)
@@ -176,6 +315,27 @@ func updateGCPState(globalFlags globalFlags, state storage.State) (storage.State
 		}
 		state.GCP.Region = globalFlags.GCPRegion
 	}
+	if globalFlags.GCPNetwork != "" {
+		if state.GCP.Network != "" && globalFlags.GCPNetwork != state.GCP.Network {
+			networkMismatch := fmt.Sprintf("The network cannot be changed for an existing environment. The current network is %s.", state.GCP.Network)
+			return storage.State{}, errors.New(networkMismatch)
+		}
+		state.GCP.Network = globalFlags.GCPNetwork
+	}
+	if globalFlags.GCPSubnetwork != "" {
+		if state.GCP.Subnetwork != "" && globalFlags.GCPSubnetwork != state.GCP.Subnetwork {
+			subnetworkMismatch := fmt.Sprintf("The subnetwork cannot be changed for an existing environment. The current subnetwork is %s.", state.GCP.Subnetwork)
+			return storage.State{}, errors.New(subnetworkMismatch)
+		}
+		state.GCP.Subnetwork = globalFlags.GCPSubnetwork
+	}
+	if globalFlags.GCPNetworkProject != "" {
+		if state.GCP.NetworkProject != "" && globalFlags.GCPNetworkProject != state.GCP.NetworkProject {
+			networkProjectMismatch := fmt.Sprintf("The network project cannot be changed for an existing environment. The current network project is %s.", state.GCP.NetworkProject)
+			return storage.State{}, errors.New(networkProjectMismatch)
+		}
+		state.GCP.NetworkProject = globalFlags.GCPNetworkProject
+	}
 
 	return state, nil
 }
@@ -234,6 +394,7 @@ func NeedsIAASConfig(command string) bool {
 		"delete-lbs": struct{}{},
 		"update-lbs": struct{}{},
 		"rotate":     struct{}{},
+		"doctor":     struct{}{},
 	}[command]
 	return ok
 }
@@ -264,6 +425,12 @@ func validateGCP(gcp storage.GCP) error {
 	if gcp.Region == "" {
 		return errors.New("GCP region must be provided")
 	}
+	if gcp.Network != "" && gcp.Subnetwork == "" {
+		return errors.New("GCP subnetwork must be provided when using an existing network")
+	}
+	if gcp.Network == "" && (gcp.Subnetwork != "" || gcp.NetworkProject != "") {
+		return errors.New("GCP network must be provided when using an existing subnetwork or network project")
+	}
 	return nil
 }
 
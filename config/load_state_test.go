@@ -2,8 +2,10 @@ package config_test
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-bootloader/application"
 	"github.com/cloudfoundry/bosh-bootloader/config"
@@ -133,6 +135,130 @@ var _ = Describe("LoadState", func() {
 					Expect(appConfig.Global.Debug).To(BeTrue())
 				})
 			})
+
+			Context("when tag flags are passed in", func() {
+				It("returns a state object containing the tags", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl", "up",
+						"--tag", "team=core",
+						"--tag", "cost-center=1234",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.Tags).To(Equal(map[string]string{
+						"team":        "core",
+						"cost-center": "1234",
+					}))
+				})
+
+				It("returns an error when a tag is not in the form key=value", func() {
+					_, err := c.Bootstrap([]string{"bbl", "up", "--tag", "team"})
+					Expect(err).To(MatchError(`Invalid tag "team", tags must be in the form key=value.`))
+				})
+
+				It("returns an error when a tag is not a valid gcp label", func() {
+					_, err := c.Bootstrap([]string{"bbl", "--iaas", "gcp", "up", "--tag", "Team=core"})
+					Expect(err).To(MatchError(`Invalid tag "Team=core", GCP labels may only contain lowercase letters, numbers, underscores and dashes, and keys must start with a letter.`))
+				})
+			})
+
+			Context("when deployment dirs are passed in", func() {
+				var (
+					boshDeploymentDir    string
+					jumpboxDeploymentDir string
+				)
+
+				BeforeEach(func() {
+					var err error
+					boshDeploymentDir, err = ioutil.TempDir("", "bosh-deployment")
+					Expect(err).NotTo(HaveOccurred())
+
+					jumpboxDeploymentDir, err = ioutil.TempDir("", "jumpbox-deployment")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns global flags with the deployment dirs", func() {
+					appConfig, err := c.Bootstrap([]string{
+						"bbl", "up",
+						"--bosh-deployment-dir", boshDeploymentDir,
+						"--jumpbox-deployment-dir", jumpboxDeploymentDir,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.Global.BOSHDeploymentDir).To(Equal(boshDeploymentDir))
+					Expect(appConfig.Global.JumpboxDeploymentDir).To(Equal(jumpboxDeploymentDir))
+				})
+
+				Context("when they are passed in through environment variables", func() {
+					BeforeEach(func() {
+						os.Setenv("BBL_BOSH_DEPLOYMENT_DIR", boshDeploymentDir)
+						os.Setenv("BBL_JUMPBOX_DEPLOYMENT_DIR", jumpboxDeploymentDir)
+					})
+
+					AfterEach(func() {
+						os.Unsetenv("BBL_BOSH_DEPLOYMENT_DIR")
+						os.Unsetenv("BBL_JUMPBOX_DEPLOYMENT_DIR")
+					})
+
+					It("returns global flags with the deployment dirs", func() {
+						appConfig, err := c.Bootstrap([]string{"bbl", "up"})
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(appConfig.Global.BOSHDeploymentDir).To(Equal(boshDeploymentDir))
+						Expect(appConfig.Global.JumpboxDeploymentDir).To(Equal(jumpboxDeploymentDir))
+					})
+				})
+
+				It("returns an error when a deployment dir does not exist", func() {
+					_, err := c.Bootstrap([]string{"bbl", "up", "--bosh-deployment-dir", "/some/missing/dir"})
+					Expect(err).To(MatchError("Invalid --bosh-deployment-dir: stat /some/missing/dir: no such file or directory"))
+				})
+
+				It("returns an error when a deployment dir is a file", func() {
+					jumpboxYML := filepath.Join(jumpboxDeploymentDir, "jumpbox.yml")
+					err := ioutil.WriteFile(jumpboxYML, []byte{}, os.ModePerm)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = c.Bootstrap([]string{"bbl", "up", "--jumpbox-deployment-dir", jumpboxYML})
+					Expect(err).To(MatchError(fmt.Sprintf("Invalid --jumpbox-deployment-dir: %s is not a directory", jumpboxYML)))
+				})
+			})
+
+			Context("when tags are passed in through environment variables", func() {
+				BeforeEach(func() {
+					os.Setenv("BBL_TAGS", `{"team": "core", "cost-center": "1234,5678"}`)
+				})
+
+				AfterEach(func() {
+					os.Unsetenv("BBL_TAGS")
+				})
+
+				It("returns a state object containing the tags", func() {
+					appConfig, err := c.Bootstrap([]string{"bbl", "up"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.Tags).To(Equal(map[string]string{
+						"team":        "core",
+						"cost-center": "1234,5678",
+					}))
+				})
+
+				It("prefers the tag flags", func() {
+					appConfig, err := c.Bootstrap([]string{"bbl", "up", "--tag", "team=platform"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.Tags).To(Equal(map[string]string{
+						"team": "platform",
+					}))
+				})
+
+				It("returns an error when the tags are not a JSON object", func() {
+					os.Setenv("BBL_TAGS", "team=core")
+
+					_, err := c.Bootstrap([]string{"bbl", "up"})
+					Expect(err).To(MatchError(ContainSubstring(`Invalid BBL_TAGS, tags must be a JSON object such as {"team": "core"}`)))
+				})
+			})
 		})
 
 		Describe("reading a previous state file", func() {
@@ -160,6 +286,37 @@ var _ = Describe("LoadState", func() {
 				Expect(appConfig.State.EnvID).To(Equal("some-env-id"))
 			})
 
+			Context("when the state has tags", func() {
+				BeforeEach(func() {
+					c = config.NewConfig(func(dir string) (storage.State, error) {
+						return storage.State{
+							IAAS:  "aws",
+							EnvID: "some-env-id",
+							Tags:  map[string]string{"team": "core"},
+						}, nil
+					})
+				})
+
+				It("keeps the tags", func() {
+					appConfig, err := c.Bootstrap([]string{"bbl", "up"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.Tags).To(Equal(map[string]string{"team": "core"}))
+				})
+
+				It("removes the tags with --clear-tags", func() {
+					appConfig, err := c.Bootstrap([]string{"bbl", "up", "--clear-tags"})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(appConfig.State.Tags).To(BeEmpty())
+				})
+
+				It("returns an error when --clear-tags is used with --tag", func() {
+					_, err := c.Bootstrap([]string{"bbl", "up", "--clear-tags", "--tag", "team=platform"})
+					Expect(err).To(MatchError("--tag and --clear-tags cannot be used together."))
+				})
+			})
+
 			It("uses the working directory", func() {
 				appConfig, err := c.Bootstrap([]string{
 					"bbl",
@@ -234,6 +391,10 @@ var _ = Describe("LoadState", func() {
 							"--aws-access-key-id", "some-access-key",
 							"--aws-secret-access-key", "some-secret-key",
 							"--aws-region", "some-region",
+							"--aws-nat-mode", "gateway-per-az",
+							"--aws-spot-bid-price", "0.05",
+							"--aws-vpc-limit", "10",
+							"--aws-nat-gateway-limit", "8",
 							"up",
 							"--name", "some-env-id",
 						}
@@ -249,6 +410,10 @@ var _ = Describe("LoadState", func() {
 						Expect(state.AWS.AccessKeyID).To(Equal("some-access-key"))
 						Expect(state.AWS.SecretAccessKey).To(Equal("some-secret-key"))
 						Expect(state.AWS.Region).To(Equal("some-region"))
+						Expect(state.AWS.NATMode).To(Equal("gateway-per-az"))
+						Expect(state.AWS.SpotBidPrice).To(Equal("0.05"))
+						Expect(state.AWS.VPCLimit).To(Equal(10))
+						Expect(state.AWS.NATGatewayLimit).To(Equal(8))
 					})
 
 					It("returns the remaining arguments", func() {
@@ -273,6 +438,7 @@ var _ = Describe("LoadState", func() {
 						os.Setenv("BBL_AWS_ACCESS_KEY_ID", "some-access-key-id")
 						os.Setenv("BBL_AWS_SECRET_ACCESS_KEY", "some-secret-key")
 						os.Setenv("BBL_AWS_REGION", "some-region")
+						os.Setenv("BBL_AWS_NAT_MODE", "instance")
 					})
 
 					AfterEach(func() {
@@ -280,6 +446,7 @@ var _ = Describe("LoadState", func() {
 						os.Unsetenv("BBL_AWS_ACCESS_KEY_ID")
 						os.Unsetenv("BBL_AWS_SECRET_ACCESS_KEY")
 						os.Unsetenv("BBL_AWS_REGION")
+						os.Unsetenv("BBL_AWS_NAT_MODE")
 					})
 
 					It("returns a state object containing configuration flags", func() {
@@ -291,6 +458,7 @@ var _ = Describe("LoadState", func() {
 						Expect(state.AWS.AccessKeyID).To(Equal("some-access-key-id"))
 						Expect(state.AWS.SecretAccessKey).To(Equal("some-secret-key"))
 						Expect(state.AWS.Region).To(Equal("some-region"))
+						Expect(state.AWS.NATMode).To(Equal("instance"))
 					})
 
 					It("returns the command", func() {
@@ -300,6 +468,19 @@ var _ = Describe("LoadState", func() {
 						Expect(appConfig.Command).To(Equal("up"))
 					})
 				})
+
+				Context("when no nat mode is passed in", func() {
+					It("uses a nat gateway", func() {
+						appConfig, err := c.Bootstrap([]string{
+							"bbl",
+							"--iaas", "aws",
+							"up",
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(appConfig.State.AWS.NATMode).To(Equal("gateway"))
+					})
+				})
 			})
 
 			Context("when a previous state exists", func() {
@@ -336,6 +517,30 @@ var _ = Describe("LoadState", func() {
 
 						Expect(appConfig.State.EnvID).To(Equal("some-env-id"))
 					})
+
+					It("keeps the nat instance of an environment created without a nat mode", func() {
+						appConfig, err := c.Bootstrap([]string{
+							"bbl",
+							"up",
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(appConfig.State.AWS.NATMode).To(BeEmpty())
+						Expect(appConfig.State.AWS.GetNATMode()).To(Equal("instance"))
+					})
+				})
+
+				Context("when a different nat mode is passed in", func() {
+					It("returns state with the new nat mode", func() {
+						appConfig, err := c.Bootstrap([]string{
+							"bbl",
+							"up",
+							"--aws-nat-mode", "gateway-per-az",
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(appConfig.State.AWS.NATMode).To(Equal("gateway-per-az"))
+					})
 				})
 
 				DescribeTable("when non-matching configuration is passed in",
@@ -348,6 +553,14 @@ var _ = Describe("LoadState", func() {
 						"The iaas type cannot be changed for an existing environment. The current iaas type is aws."),
 					Entry("returns an error for non-matching region", []string{"bbl", "create-lbs", "--aws-region", "some-other-region"},
 						"The region cannot be changed for an existing environment. The current region is some-region."),
+					Entry("returns an error for an unknown nat mode", []string{"bbl", "create-lbs", "--aws-nat-mode", "some-nat-mode"},
+						"--aws-nat-mode must be one of [gateway, gateway-per-az, instance]"),
+					Entry("returns an error for an invalid spot bid price", []string{"bbl", "create-lbs", "--aws-spot-bid-price", "cheap"},
+						"--aws-spot-bid-price must be a price in US dollars per hour, for example 0.05"),
+					Entry("returns an error for a negative vpc limit", []string{"bbl", "create-lbs", "--aws-vpc-limit", "-1"},
+						"--aws-vpc-limit must be a positive number of VPCs"),
+					Entry("returns an error for a negative nat gateway limit", []string{"bbl", "create-lbs", "--aws-nat-gateway-limit", "-1"},
+						"--aws-nat-gateway-limit must be a positive number of NAT gateways"),
 				)
 			})
 		})
@@ -380,6 +593,9 @@ var _ = Describe("LoadState", func() {
 							"--gcp-project-id", "some-project-id",
 							"--gcp-zone", "some-availability-zone",
 							"--gcp-region", "some-region",
+							"--gcp-network", "some-network",
+							"--gcp-subnetwork", "some-subnetwork",
+							"--gcp-network-project", "some-host-project-id",
 						}
 					})
 
@@ -393,6 +609,9 @@ var _ = Describe("LoadState", func() {
 						Expect(state.GCP.ServiceAccountKey).To(Equal(serviceAccountKey))
 						Expect(state.GCP.Zone).To(Equal("some-availability-zone"))
 						Expect(state.GCP.Region).To(Equal("some-region"))
+						Expect(state.GCP.Network).To(Equal("some-network"))
+						Expect(state.GCP.Subnetwork).To(Equal("some-subnetwork"))
+						Expect(state.GCP.NetworkProject).To(Equal("some-host-project-id"))
 					})
 
 					It("returns the command and its flags", func() {
@@ -492,6 +711,13 @@ var _ = Describe("LoadState", func() {
 						os.Setenv("BBL_GCP_PROJECT_ID", "some-project-id")
 						os.Setenv("BBL_GCP_ZONE", "some-zone")
 						os.Setenv("BBL_GCP_REGION", "some-region")
+						os.Setenv("BBL_GCP_NETWORK", "some-network")
+						os.Setenv("BBL_GCP_SUBNETWORK", "some-subnetwork")
+					})
+
+					AfterEach(func() {
+						os.Unsetenv("BBL_GCP_NETWORK")
+						os.Unsetenv("BBL_GCP_SUBNETWORK")
 					})
 
 					It("returns a state containing configuration", func() {
@@ -506,6 +732,8 @@ var _ = Describe("LoadState", func() {
 						Expect(state.GCP.ProjectID).To(Equal("some-project-id"))
 						Expect(state.GCP.Zone).To(Equal("some-zone"))
 						Expect(state.GCP.Region).To(Equal("some-region"))
+						Expect(state.GCP.Network).To(Equal("some-network"))
+						Expect(state.GCP.Subnetwork).To(Equal("some-subnetwork"))
 					})
 
 					It("returns the remaining arguments", func() {
@@ -533,6 +761,9 @@ var _ = Describe("LoadState", func() {
 								ProjectID:         "some-project-id",
 								Zone:              "some-zone",
 								Region:            "some-region",
+								Network:           "some-network",
+								Subnetwork:        "some-subnetwork",
+								NetworkProject:    "some-host-project-id",
 							},
 							EnvID: "some-env-id",
 						}, nil
@@ -569,6 +800,12 @@ var _ = Describe("LoadState", func() {
 						"The region cannot be changed for an existing environment. The current region is some-region."),
 					Entry("returns an error for non-matching zone", []string{"bbl", "create-lbs", "--gcp-zone", "some-other-zone"},
 						"The zone cannot be changed for an existing environment. The current zone is some-zone."),
+					Entry("returns an error for non-matching network", []string{"bbl", "create-lbs", "--gcp-network", "some-other-network"},
+						"The network cannot be changed for an existing environment. The current network is some-network."),
+					Entry("returns an error for non-matching subnetwork", []string{"bbl", "create-lbs", "--gcp-subnetwork", "some-other-subnetwork"},
+						"The subnetwork cannot be changed for an existing environment. The current subnetwork is some-subnetwork."),
+					Entry("returns an error for non-matching network project", []string{"bbl", "create-lbs", "--gcp-network-project", "some-other-project-id"},
+						"The network project cannot be changed for an existing environment. The current network project is some-host-project-id."),
 					// Entry("returns an error for non-matching project id", []string{"bbl", "create-lbs", "--gcp-project-id", "some-other-project-id"},
 					// 	"The project id cannot be changed for an existing environment. The current project id is some-project-id."),
 				)
@@ -818,6 +1055,32 @@ var _ = Describe("LoadState", func() {
 				},
 				"up",
 				"GCP zone must be provided"),
+			Entry("when GCP subnetwork is missing for an existing network",
+				storage.State{
+					IAAS: "gcp",
+					GCP: storage.GCP{
+						ProjectID:         "some-project-id",
+						ServiceAccountKey: "some-service-account-key",
+						Region:            "some-region",
+						Zone:              "some-availability-zone",
+						Network:           "some-network",
+					},
+				},
+				"up",
+				"GCP subnetwork must be provided when using an existing network"),
+			Entry("when GCP network is missing for an existing subnetwork",
+				storage.State{
+					IAAS: "gcp",
+					GCP: storage.GCP{
+						ProjectID:         "some-project-id",
+						ServiceAccountKey: "some-service-account-key",
+						Region:            "some-region",
+						Zone:              "some-availability-zone",
+						Subnetwork:        "some-subnetwork",
+					},
+				},
+				"up",
+				"GCP network must be provided when using an existing subnetwork or network project"),
 			Entry("when Azure client id is missing",
 				storage.State{
 					IAAS: "azure",
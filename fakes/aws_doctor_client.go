@@ -0,0 +1,68 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+
+type AWSDoctorClient struct {
+	RetrieveRegionsCall struct {
+		CallCount int
+		Returns   struct {
+			Regions []string
+			Error   error
+		}
+	}
+	RetrieveAvailabilityZonesCall struct {
+		CallCount int
+		Receives  struct {
+			Region string
+		}
+		Returns struct {
+			AZs   []string
+			Error error
+		}
+	}
+	CheckQuotasCall struct {
+		CallCount int
+		Receives  struct {
+			Region    string
+			Resources ec2.Resources
+			Limits    ec2.Limits
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	CheckInstanceTypesCall struct {
+		CallCount int
+		Receives  struct {
+			InstanceTypes []string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (r *AWSDoctorClient) RetrieveRegions() ([]string, error) {
+	r.RetrieveRegionsCall.CallCount++
+	return r.RetrieveRegionsCall.Returns.Regions, r.RetrieveRegionsCall.Returns.Error
+}
+
+func (r *AWSDoctorClient) RetrieveAvailabilityZones(region string) ([]string, error) {
+	r.RetrieveAvailabilityZonesCall.CallCount++
+	r.RetrieveAvailabilityZonesCall.Receives.Region = region
+	return r.RetrieveAvailabilityZonesCall.Returns.AZs, r.RetrieveAvailabilityZonesCall.Returns.Error
+}
+
+func (r *AWSDoctorClient) CheckQuotas(region string, resources ec2.Resources, limits ec2.Limits) error {
+	r.CheckQuotasCall.CallCount++
+	r.CheckQuotasCall.Receives.Region = region
+	r.CheckQuotasCall.Receives.Resources = resources
+	r.CheckQuotasCall.Receives.Limits = limits
+	return r.CheckQuotasCall.Returns.Error
+}
+
+func (r *AWSDoctorClient) CheckInstanceTypes(instanceTypes []string) error {
+	r.CheckInstanceTypesCall.CallCount++
+	r.CheckInstanceTypesCall.Receives.InstanceTypes = instanceTypes
+	return r.CheckInstanceTypesCall.Returns.Error
+}
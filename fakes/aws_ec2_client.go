@@ -44,6 +44,16 @@ type AWSEC2Client struct {
 		}
 	}
 
+	DescribeRegionsCall struct {
+		Receives struct {
+			Input *awsec2.DescribeRegionsInput
+		}
+		Returns struct {
+			Output *awsec2.DescribeRegionsOutput
+			Error  error
+		}
+	}
+
 	DeleteKeyPairCall struct {
 		Receives struct {
 			Input *awsec2.DeleteKeyPairInput
@@ -73,6 +83,47 @@ type AWSEC2Client struct {
 			Error  error
 		}
 	}
+
+	DescribeAccountAttributesCall struct {
+		Receives struct {
+			Input *awsec2.DescribeAccountAttributesInput
+		}
+		Returns struct {
+			Output *awsec2.DescribeAccountAttributesOutput
+			Error  error
+		}
+	}
+
+	DescribeAddressesCall struct {
+		Receives struct {
+			Input *awsec2.DescribeAddressesInput
+		}
+		Returns struct {
+			Output *awsec2.DescribeAddressesOutput
+			Error  error
+		}
+	}
+
+	DescribeNatGatewaysCall struct {
+		Stub     func(*awsec2.DescribeNatGatewaysInput) (*awsec2.DescribeNatGatewaysOutput, error)
+		Receives struct {
+			Input *awsec2.DescribeNatGatewaysInput
+		}
+		Returns struct {
+			Output *awsec2.DescribeNatGatewaysOutput
+			Error  error
+		}
+	}
+
+	DescribeSubnetsCall struct {
+		Receives struct {
+			Input *awsec2.DescribeSubnetsInput
+		}
+		Returns struct {
+			Output *awsec2.DescribeSubnetsOutput
+			Error  error
+		}
+	}
 }
 
 func (c *AWSEC2Client) ImportKeyPair(input *awsec2.ImportKeyPairInput) (*awsec2.ImportKeyPairOutput, error) {
@@ -99,6 +150,12 @@ func (c *AWSEC2Client) DescribeAvailabilityZones(input *awsec2.DescribeAvailabil
 	return c.DescribeAvailabilityZonesCall.Returns.Output, c.DescribeAvailabilityZonesCall.Returns.Error
 }
 
+func (c *AWSEC2Client) DescribeRegions(input *awsec2.DescribeRegionsInput) (*awsec2.DescribeRegionsOutput, error) {
+	c.DescribeRegionsCall.Receives.Input = input
+
+	return c.DescribeRegionsCall.Returns.Output, c.DescribeRegionsCall.Returns.Error
+}
+
 func (c *AWSEC2Client) DeleteKeyPair(input *awsec2.DeleteKeyPairInput) (*awsec2.DeleteKeyPairOutput, error) {
 	c.DeleteKeyPairCall.Receives.Input = input
 
@@ -116,3 +173,31 @@ func (c *AWSEC2Client) DescribeVpcs(input *awsec2.DescribeVpcsInput) (*awsec2.De
 
 	return c.DescribeVpcsCall.Returns.Output, c.DescribeVpcsCall.Returns.Error
 }
+
+func (c *AWSEC2Client) DescribeAccountAttributes(input *awsec2.DescribeAccountAttributesInput) (*awsec2.DescribeAccountAttributesOutput, error) {
+	c.DescribeAccountAttributesCall.Receives.Input = input
+
+	return c.DescribeAccountAttributesCall.Returns.Output, c.DescribeAccountAttributesCall.Returns.Error
+}
+
+func (c *AWSEC2Client) DescribeAddresses(input *awsec2.DescribeAddressesInput) (*awsec2.DescribeAddressesOutput, error) {
+	c.DescribeAddressesCall.Receives.Input = input
+
+	return c.DescribeAddressesCall.Returns.Output, c.DescribeAddressesCall.Returns.Error
+}
+
+func (c *AWSEC2Client) DescribeNatGateways(input *awsec2.DescribeNatGatewaysInput) (*awsec2.DescribeNatGatewaysOutput, error) {
+	c.DescribeNatGatewaysCall.Receives.Input = input
+
+	if c.DescribeNatGatewaysCall.Stub != nil {
+		return c.DescribeNatGatewaysCall.Stub(input)
+	}
+
+	return c.DescribeNatGatewaysCall.Returns.Output, c.DescribeNatGatewaysCall.Returns.Error
+}
+
+func (c *AWSEC2Client) DescribeSubnets(input *awsec2.DescribeSubnetsInput) (*awsec2.DescribeSubnetsOutput, error) {
+	c.DescribeSubnetsCall.Receives.Input = input
+
+	return c.DescribeSubnetsCall.Returns.Output, c.DescribeSubnetsCall.Returns.Error
+}
@@ -0,0 +1,44 @@
+package fakes
+
+import (
+	awselb "github.com/aws/aws-sdk-go/service/elb"
+)
+
+type AWSELBClient struct {
+	DescribeAccountLimitsCall struct {
+		Receives struct {
+			Input *awselb.DescribeAccountLimitsInput
+		}
+		Returns struct {
+			Output *awselb.DescribeAccountLimitsOutput
+			Error  error
+		}
+	}
+
+	DescribeLoadBalancersCall struct {
+		Stub     func(*awselb.DescribeLoadBalancersInput) (*awselb.DescribeLoadBalancersOutput, error)
+		Receives struct {
+			Input *awselb.DescribeLoadBalancersInput
+		}
+		Returns struct {
+			Output *awselb.DescribeLoadBalancersOutput
+			Error  error
+		}
+	}
+}
+
+func (c *AWSELBClient) DescribeAccountLimits(input *awselb.DescribeAccountLimitsInput) (*awselb.DescribeAccountLimitsOutput, error) {
+	c.DescribeAccountLimitsCall.Receives.Input = input
+
+	return c.DescribeAccountLimitsCall.Returns.Output, c.DescribeAccountLimitsCall.Returns.Error
+}
+
+func (c *AWSELBClient) DescribeLoadBalancers(input *awselb.DescribeLoadBalancersInput) (*awselb.DescribeLoadBalancersOutput, error) {
+	c.DescribeLoadBalancersCall.Receives.Input = input
+
+	if c.DescribeLoadBalancersCall.Stub != nil {
+		return c.DescribeLoadBalancersCall.Stub(input)
+	}
+
+	return c.DescribeLoadBalancersCall.Returns.Output, c.DescribeLoadBalancersCall.Returns.Error
+}
@@ -0,0 +1,19 @@
+package fakes
+
+type AWSPermissionChecker struct {
+	CheckPermissionsCall struct {
+		CallCount int
+		Receives  struct {
+			Features []string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (p *AWSPermissionChecker) CheckPermissions(features []string) error {
+	p.CheckPermissionsCall.CallCount++
+	p.CheckPermissionsCall.Receives.Features = features
+	return p.CheckPermissionsCall.Returns.Error
+}
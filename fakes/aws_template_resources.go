@@ -0,0 +1,30 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type AWSTemplateResources struct {
+	ResourcesCall struct {
+		CallCount int
+		Stub      func(storage.State) ec2.Resources
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Resources ec2.Resources
+		}
+	}
+}
+
+func (t *AWSTemplateResources) Resources(state storage.State) ec2.Resources {
+	t.ResourcesCall.CallCount++
+	t.ResourcesCall.Receives.State = state
+
+	if t.ResourcesCall.Stub != nil {
+		return t.ResourcesCall.Stub(state)
+	}
+
+	return t.ResourcesCall.Returns.Resources
+}
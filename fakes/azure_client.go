@@ -13,6 +13,19 @@ type AzureClient struct {
 			Error error
 		}
 	}
+	ValidateLocationCall struct {
+		CallCount int
+		Receives  struct {
+			SubscriptionID string
+			TenantID       string
+			ClientID       string
+			ClientSecret   string
+			Location       string
+		}
+		Returns struct {
+			Error error
+		}
+	}
 }
 
 func (a *AzureClient) ValidateCredentials(subscriptionID, tenantID, clientID, clientSecret string) error {
@@ -23,3 +36,13 @@ func (a *AzureClient) ValidateCredentials(subscriptionID, tenantID, clientID, cl
 	a.ValidateCredentialsCall.Receives.ClientSecret = clientSecret
 	return a.ValidateCredentialsCall.Returns.Error
 }
+
+func (a *AzureClient) ValidateLocation(subscriptionID, tenantID, clientID, clientSecret, location string) error {
+	a.ValidateLocationCall.CallCount++
+	a.ValidateLocationCall.Receives.SubscriptionID = subscriptionID
+	a.ValidateLocationCall.Receives.TenantID = tenantID
+	a.ValidateLocationCall.Receives.ClientID = clientID
+	a.ValidateLocationCall.Receives.ClientSecret = clientSecret
+	a.ValidateLocationCall.Receives.Location = location
+	return a.ValidateLocationCall.Returns.Error
+}
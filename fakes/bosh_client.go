@@ -1,21 +1,128 @@
 package fakes
 
 import (
+	"io"
+	"io/ioutil"
+
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"golang.org/x/net/proxy"
 )
 
+type DeleteDeploymentCallReceive struct {
+	Name  string
+	Force bool
+}
+
+type UploadRemoteReleaseCallReceive struct {
+	URL  string
+	SHA1 string
+}
+
 type BOSHClient struct {
-	UpdateCloudConfigCall struct {
+	UpdateConfigCall struct {
 		CallCount int
+		Stub      func(configType, name string, content []byte) error
 		Receives  struct {
-			Yaml []byte
+			Type    string
+			Name    string
+			Content []byte
 		}
 		Returns struct {
 			Error error
 		}
 	}
 
+	LatestConfigsCall struct {
+		CallCount int
+		Receives  struct {
+			Type string
+		}
+		Returns struct {
+			Configs []bosh.Config
+			Error   error
+		}
+	}
+
+	DeleteConfigCall struct {
+		CallCount int
+		Receives  struct {
+			Type string
+			Name string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	UploadStemcellCall struct {
+		CallCount int
+		Receives  struct {
+			Stemcell []byte
+			Size     int64
+		}
+		Returns struct {
+			TaskID int
+			Error  error
+		}
+	}
+
+	UploadRemoteStemcellCall struct {
+		CallCount int
+		Receives  struct {
+			URL string
+		}
+		Returns struct {
+			TaskID int
+			Error  error
+		}
+	}
+
+	ReleasesCall struct {
+		CallCount int
+		Returns   struct {
+			Releases []bosh.Release
+			Error    error
+		}
+	}
+
+	UploadRemoteReleaseCall struct {
+		CallCount int
+		Receives  []UploadRemoteReleaseCallReceive
+		Returns   struct {
+			TaskID int
+			Error  error
+		}
+	}
+
+	DeploymentsCall struct {
+		CallCount int
+		Returns   struct {
+			Deployments []bosh.Deployment
+			Error       error
+		}
+	}
+
+	DeleteDeploymentCall struct {
+		CallCount int
+		Receives  []DeleteDeploymentCallReceive
+		Returns   struct {
+			TaskID int
+			Error  error
+		}
+	}
+
+	WaitForTaskCall struct {
+		CallCount int
+		Receives  struct {
+			ID int
+		}
+		Returns struct {
+			Events []bosh.TaskEvent
+			Task   bosh.Task
+			Error  error
+		}
+	}
+
 	ConfigureHTTPClientCall struct {
 		CallCount int
 		Receives  struct {
@@ -32,10 +139,74 @@ type BOSHClient struct {
 	}
 }
 
-func (c *BOSHClient) UpdateCloudConfig(yaml []byte) error {
-	c.UpdateCloudConfigCall.CallCount++
-	c.UpdateCloudConfigCall.Receives.Yaml = yaml
-	return c.UpdateCloudConfigCall.Returns.Error
+func (c *BOSHClient) UpdateConfig(configType, name string, content []byte) error {
+	c.UpdateConfigCall.CallCount++
+	c.UpdateConfigCall.Receives.Type = configType
+	c.UpdateConfigCall.Receives.Name = name
+	c.UpdateConfigCall.Receives.Content = content
+	if c.UpdateConfigCall.Stub != nil {
+		return c.UpdateConfigCall.Stub(configType, name, content)
+	}
+	return c.UpdateConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) LatestConfigs(configType string) ([]bosh.Config, error) {
+	c.LatestConfigsCall.CallCount++
+	c.LatestConfigsCall.Receives.Type = configType
+	return c.LatestConfigsCall.Returns.Configs, c.LatestConfigsCall.Returns.Error
+}
+
+func (c *BOSHClient) DeleteConfig(configType, name string) error {
+	c.DeleteConfigCall.CallCount++
+	c.DeleteConfigCall.Receives.Type = configType
+	c.DeleteConfigCall.Receives.Name = name
+	return c.DeleteConfigCall.Returns.Error
+}
+
+func (c *BOSHClient) UploadStemcell(stemcell io.Reader, size int64) (int, error) {
+	c.UploadStemcellCall.CallCount++
+	c.UploadStemcellCall.Receives.Stemcell, _ = ioutil.ReadAll(stemcell)
+	c.UploadStemcellCall.Receives.Size = size
+	return c.UploadStemcellCall.Returns.TaskID, c.UploadStemcellCall.Returns.Error
+}
+
+func (c *BOSHClient) UploadRemoteStemcell(url string) (int, error) {
+	c.UploadRemoteStemcellCall.CallCount++
+	c.UploadRemoteStemcellCall.Receives.URL = url
+	return c.UploadRemoteStemcellCall.Returns.TaskID, c.UploadRemoteStemcellCall.Returns.Error
+}
+
+func (c *BOSHClient) Releases() ([]bosh.Release, error) {
+	c.ReleasesCall.CallCount++
+	return c.ReleasesCall.Returns.Releases, c.ReleasesCall.Returns.Error
+}
+
+func (c *BOSHClient) UploadRemoteRelease(url, sha1 string) (int, error) {
+	c.UploadRemoteReleaseCall.CallCount++
+	c.UploadRemoteReleaseCall.Receives = append(c.UploadRemoteReleaseCall.Receives, UploadRemoteReleaseCallReceive{URL: url, SHA1: sha1})
+	return c.UploadRemoteReleaseCall.Returns.TaskID, c.UploadRemoteReleaseCall.Returns.Error
+}
+
+func (c *BOSHClient) Deployments() ([]bosh.Deployment, error) {
+	c.DeploymentsCall.CallCount++
+	return c.DeploymentsCall.Returns.Deployments, c.DeploymentsCall.Returns.Error
+}
+
+func (c *BOSHClient) DeleteDeployment(name string, force bool) (int, error) {
+	c.DeleteDeploymentCall.CallCount++
+	c.DeleteDeploymentCall.Receives = append(c.DeleteDeploymentCall.Receives, DeleteDeploymentCallReceive{Name: name, Force: force})
+	return c.DeleteDeploymentCall.Returns.TaskID, c.DeleteDeploymentCall.Returns.Error
+}
+
+func (c *BOSHClient) WaitForTask(id int, events func(bosh.TaskEvent)) (bosh.Task, error) {
+	c.WaitForTaskCall.CallCount++
+	c.WaitForTaskCall.Receives.ID = id
+	if events != nil {
+		for _, event := range c.WaitForTaskCall.Returns.Events {
+			events(event)
+		}
+	}
+	return c.WaitForTaskCall.Returns.Task, c.WaitForTaskCall.Returns.Error
 }
 
 func (c *BOSHClient) ConfigureHTTPClient(socks5Client proxy.Dialer) {
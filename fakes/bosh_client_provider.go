@@ -3,6 +3,7 @@ package fakes
 import (
 	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"golang.org/x/net/proxy"
 )
 
 type BOSHClientProvider struct {
@@ -21,6 +22,18 @@ type BOSHClientProvider struct {
 			Error  error
 		}
 	}
+
+	DialerCall struct {
+		CallCount int
+
+		Receives struct {
+			Jumpbox storage.Jumpbox
+		}
+		Returns struct {
+			Dialer proxy.Dialer
+			Error  error
+		}
+	}
 }
 
 func (b *BOSHClientProvider) Client(jumpbox storage.Jumpbox, directorAddress, directorUsername, directorPassword, directorCACert string) (bosh.Client, error) {
@@ -32,3 +45,9 @@ func (b *BOSHClientProvider) Client(jumpbox storage.Jumpbox, directorAddress, di
 	b.ClientCall.Receives.DirectorCACert = directorCACert
 	return b.ClientCall.Returns.Client, b.ClientCall.Returns.Error
 }
+
+func (b *BOSHClientProvider) Dialer(jumpbox storage.Jumpbox) (proxy.Dialer, error) {
+	b.DialerCall.CallCount++
+	b.DialerCall.Receives.Jumpbox = jumpbox
+	return b.DialerCall.Returns.Dialer, b.DialerCall.Returns.Error
+}
@@ -25,6 +25,38 @@ type BOSHManager struct {
 			Error error
 		}
 	}
+	InterpolateDirectorCall struct {
+		CallCount int
+		Receives  struct {
+			State            storage.State
+			TerraformOutputs map[string]interface{}
+		}
+		Returns struct {
+			Manifest string
+			Error    error
+		}
+	}
+	UpgradeDirectorCall struct {
+		CallCount int
+		Receives  struct {
+			State            storage.State
+			TerraformOutputs map[string]interface{}
+		}
+		Returns struct {
+			State storage.State
+			Error error
+		}
+	}
+	RollbackDirectorCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			State storage.State
+			Error error
+		}
+	}
 	VersionCall struct {
 		CallCount int
 		Returns   struct {
@@ -59,7 +91,8 @@ type BOSHManager struct {
 			TerraformOutputs map[string]interface{}
 		}
 		Returns struct {
-			Vars string
+			Vars  string
+			Error error
 		}
 	}
 	GetJumpboxDeploymentVarsCall struct {
@@ -69,7 +102,8 @@ type BOSHManager struct {
 			TerraformOutputs map[string]interface{}
 		}
 		Returns struct {
-			Vars string
+			Vars  string
+			Error error
 		}
 	}
 }
@@ -88,6 +122,26 @@ func (b *BOSHManager) CreateDirector(state storage.State, terraformOutputs map[s
 	return b.CreateDirectorCall.Returns.State, b.CreateDirectorCall.Returns.Error
 }
 
+func (b *BOSHManager) InterpolateDirector(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
+	b.InterpolateDirectorCall.CallCount++
+	b.InterpolateDirectorCall.Receives.State = state
+	b.InterpolateDirectorCall.Receives.TerraformOutputs = terraformOutputs
+	return b.InterpolateDirectorCall.Returns.Manifest, b.InterpolateDirectorCall.Returns.Error
+}
+
+func (b *BOSHManager) UpgradeDirector(state storage.State, terraformOutputs map[string]interface{}) (storage.State, error) {
+	b.UpgradeDirectorCall.CallCount++
+	b.UpgradeDirectorCall.Receives.State = state
+	b.UpgradeDirectorCall.Receives.TerraformOutputs = terraformOutputs
+	return b.UpgradeDirectorCall.Returns.State, b.UpgradeDirectorCall.Returns.Error
+}
+
+func (b *BOSHManager) RollbackDirector(state storage.State) (storage.State, error) {
+	b.RollbackDirectorCall.CallCount++
+	b.RollbackDirectorCall.Receives.State = state
+	return b.RollbackDirectorCall.Returns.State, b.RollbackDirectorCall.Returns.Error
+}
+
 func (b *BOSHManager) DeleteDirector(state storage.State, terraformOutputs map[string]interface{}) error {
 	b.DeleteDirectorCall.CallCount++
 	b.DeleteDirectorCall.Receives.State = state
@@ -102,18 +156,18 @@ func (b *BOSHManager) DeleteJumpbox(state storage.State, terraformOutputs map[st
 	return b.DeleteJumpboxCall.Returns.Error
 }
 
-func (b *BOSHManager) GetDirectorDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) string {
+func (b *BOSHManager) GetDirectorDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
 	b.GetDirectorDeploymentVarsCall.CallCount++
 	b.GetDirectorDeploymentVarsCall.Receives.State = state
 	b.GetDirectorDeploymentVarsCall.Receives.TerraformOutputs = terraformOutputs
-	return b.GetDirectorDeploymentVarsCall.Returns.Vars
+	return b.GetDirectorDeploymentVarsCall.Returns.Vars, b.GetDirectorDeploymentVarsCall.Returns.Error
 }
 
-func (b *BOSHManager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) string {
+func (b *BOSHManager) GetJumpboxDeploymentVars(state storage.State, terraformOutputs map[string]interface{}) (string, error) {
 	b.GetJumpboxDeploymentVarsCall.CallCount++
 	b.GetJumpboxDeploymentVarsCall.Receives.State = state
 	b.GetJumpboxDeploymentVarsCall.Receives.TerraformOutputs = terraformOutputs
-	return b.GetJumpboxDeploymentVarsCall.Returns.Vars
+	return b.GetJumpboxDeploymentVarsCall.Returns.Vars, b.GetJumpboxDeploymentVarsCall.Returns.Error
 }
 
 func (b *BOSHManager) Version() (string, error) {
@@ -14,6 +14,15 @@ type CloudConfigManager struct {
 			Error error
 		}
 	}
+	ConfirmUpdateCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
 	GenerateCall struct {
 		CallCount int
 		Receives  struct {
@@ -24,6 +33,16 @@ type CloudConfigManager struct {
 			Error       error
 		}
 	}
+	DiffCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Diff  string
+			Error error
+		}
+	}
 }
 
 func (c *CloudConfigManager) Update(state storage.State) error {
@@ -37,3 +56,15 @@ func (c *CloudConfigManager) Generate(state storage.State) (string, error) {
 	c.GenerateCall.Receives.State = state
 	return c.GenerateCall.Returns.CloudConfig, c.GenerateCall.Returns.Error
 }
+
+func (c *CloudConfigManager) ConfirmUpdate(state storage.State) error {
+	c.ConfirmUpdateCall.CallCount++
+	c.ConfirmUpdateCall.Receives.State = state
+	return c.ConfirmUpdateCall.Returns.Error
+}
+
+func (c *CloudConfigManager) Diff(state storage.State) (string, error) {
+	c.DiffCall.CallCount++
+	c.DiffCall.Receives.State = state
+	return c.DiffCall.Returns.Diff, c.DiffCall.Returns.Error
+}
@@ -0,0 +1,23 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type DeploymentDeleter struct {
+	DeleteDeploymentsCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+			Force bool
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (d *DeploymentDeleter) DeleteDeployments(state storage.State, force bool) error {
+	d.DeleteDeploymentsCall.CallCount++
+	d.DeleteDeploymentsCall.Receives.State = state
+	d.DeleteDeploymentsCall.Receives.Force = force
+	return d.DeleteDeploymentsCall.Returns.Error
+}
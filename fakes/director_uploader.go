@@ -0,0 +1,39 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type DirectorUploader struct {
+	UploadStemcellCall struct {
+		CallCount int
+		Receives  struct {
+			State    storage.State
+			Stemcell string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	UpdateRuntimeConfigCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (d *DirectorUploader) UploadStemcell(state storage.State, stemcell string) error {
+	d.UploadStemcellCall.CallCount++
+	d.UploadStemcellCall.Receives.State = state
+	d.UploadStemcellCall.Receives.Stemcell = stemcell
+	return d.UploadStemcellCall.Returns.Error
+}
+
+func (d *DirectorUploader) UpdateRuntimeConfig(state storage.State) error {
+	d.UpdateRuntimeConfigCall.CallCount++
+	d.UpdateRuntimeConfigCall.Receives.State = state
+	return d.UpdateRuntimeConfigCall.Returns.Error
+}
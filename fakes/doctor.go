@@ -0,0 +1,23 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type Doctor struct {
+	CheckCall struct {
+		CallCount int
+		Receives  struct {
+			Current storage.State
+			Desired storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (d *Doctor) Check(current, desired storage.State) error {
+	d.CheckCall.CallCount++
+	d.CheckCall.Receives.Current = current
+	d.CheckCall.Receives.Desired = desired
+	return d.CheckCall.Returns.Error
+}
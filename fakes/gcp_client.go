@@ -1,6 +1,9 @@
 package fakes
 
-import compute "google.golang.org/api/compute/v1"
+import (
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+	compute "google.golang.org/api/compute/v1"
+)
 
 type GCPClient struct {
 	ProjectIDCall struct {
@@ -63,6 +66,32 @@ type GCPClient struct {
 			Error  error
 		}
 	}
+	GetSubnetworkCIDRCall struct {
+		CallCount int
+		Receives  struct {
+			Name      string
+			Region    string
+			ProjectID string
+		}
+		Returns struct {
+			CIDR  string
+			Error error
+		}
+	}
+	AddressesInUseCall struct {
+		CallCount int
+		Receives  struct {
+			IPs            []string
+			Subnetwork     string
+			Region         string
+			NetworkProject string
+			Zones          []string
+		}
+		Returns struct {
+			InUse []string
+			Error error
+		}
+	}
 	GetNetworksCall struct {
 		CallCount int
 		Receives  struct {
@@ -73,6 +102,26 @@ type GCPClient struct {
 			Error       error
 		}
 	}
+	CheckQuotasCall struct {
+		CallCount int
+		Receives  struct {
+			Region    string
+			Resources gcp.Resources
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	CheckMachineTypesCall struct {
+		CallCount int
+		Receives  struct {
+			Region       string
+			MachineTypes []string
+		}
+		Returns struct {
+			Error error
+		}
+	}
 }
 
 func (g *GCPClient) ProjectID() string {
@@ -114,8 +163,40 @@ func (g *GCPClient) GetRegion(region string) (*compute.Region, error) {
 	return g.GetRegionCall.Returns.Region, g.GetRegionCall.Returns.Error
 }
 
+func (g *GCPClient) GetSubnetworkCIDR(name, region, projectID string) (string, error) {
+	g.GetSubnetworkCIDRCall.CallCount++
+	g.GetSubnetworkCIDRCall.Receives.Name = name
+	g.GetSubnetworkCIDRCall.Receives.Region = region
+	g.GetSubnetworkCIDRCall.Receives.ProjectID = projectID
+	return g.GetSubnetworkCIDRCall.Returns.CIDR, g.GetSubnetworkCIDRCall.Returns.Error
+}
+
+func (g *GCPClient) AddressesInUse(ips []string, subnetwork, region, networkProject string, zones []string) ([]string, error) {
+	g.AddressesInUseCall.CallCount++
+	g.AddressesInUseCall.Receives.IPs = ips
+	g.AddressesInUseCall.Receives.Subnetwork = subnetwork
+	g.AddressesInUseCall.Receives.Region = region
+	g.AddressesInUseCall.Receives.NetworkProject = networkProject
+	g.AddressesInUseCall.Receives.Zones = zones
+	return g.AddressesInUseCall.Returns.InUse, g.AddressesInUseCall.Returns.Error
+}
+
 func (g *GCPClient) GetNetworks(name string) (*compute.NetworkList, error) {
 	g.GetNetworksCall.CallCount++
 	g.GetNetworksCall.Receives.Name = name
 	return g.GetNetworksCall.Returns.NetworkList, g.GetNetworksCall.Returns.Error
 }
+
+func (g *GCPClient) CheckQuotas(region string, resources gcp.Resources) error {
+	g.CheckQuotasCall.CallCount++
+	g.CheckQuotasCall.Receives.Region = region
+	g.CheckQuotasCall.Receives.Resources = resources
+	return g.CheckQuotasCall.Returns.Error
+}
+
+func (g *GCPClient) CheckMachineTypes(region string, machineTypes []string) error {
+	g.CheckMachineTypesCall.CallCount++
+	g.CheckMachineTypesCall.Receives.Region = region
+	g.CheckMachineTypesCall.Receives.MachineTypes = machineTypes
+	return g.CheckMachineTypesCall.Returns.Error
+}
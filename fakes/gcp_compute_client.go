@@ -3,6 +3,16 @@ package fakes
 import compute "google.golang.org/api/compute/v1"
 
 type GCPComputeClient struct {
+	GetProjectCall struct {
+		CallCount int
+		Receives  struct {
+			ProjectID string
+		}
+		Returns struct {
+			Project *compute.Project
+			Error   error
+		}
+	}
 	ListInstancesCall struct {
 		CallCount int
 		Receives  struct {
@@ -58,6 +68,47 @@ type GCPComputeClient struct {
 			Error       error
 		}
 	}
+	GetSubnetworkCall struct {
+		CallCount int
+		Receives  struct {
+			Name      string
+			Region    string
+			ProjectID string
+		}
+		Returns struct {
+			Subnetwork *compute.Subnetwork
+			Error      error
+		}
+	}
+	ListAddressesCall struct {
+		CallCount int
+		Receives  struct {
+			ProjectID string
+			Region    string
+		}
+		Returns struct {
+			Addresses []*compute.Address
+			Error     error
+		}
+	}
+	ListMachineTypesCall struct {
+		CallCount int
+		Stub      func(projectID, zone string) ([]string, error)
+		Receives  struct {
+			ProjectID string
+			Zone      string
+		}
+		Returns struct {
+			MachineTypes []string
+			Error        error
+		}
+	}
+}
+
+func (g *GCPComputeClient) GetProject(projectID string) (*compute.Project, error) {
+	g.GetProjectCall.CallCount++
+	g.GetProjectCall.Receives.ProjectID = projectID
+	return g.GetProjectCall.Returns.Project, g.GetProjectCall.Returns.Error
 }
 
 func (g *GCPComputeClient) ListInstances(projectID, zone string) (*compute.InstanceList, error) {
@@ -94,3 +145,30 @@ func (g *GCPComputeClient) GetNetworks(name, projectID string) (*compute.Network
 	g.GetNetworksCall.Receives.ProjectID = projectID
 	return g.GetNetworksCall.Returns.NetworkList, g.GetNetworksCall.Returns.Error
 }
+
+func (g *GCPComputeClient) GetSubnetwork(name, region, projectID string) (*compute.Subnetwork, error) {
+	g.GetSubnetworkCall.CallCount++
+	g.GetSubnetworkCall.Receives.Name = name
+	g.GetSubnetworkCall.Receives.Region = region
+	g.GetSubnetworkCall.Receives.ProjectID = projectID
+	return g.GetSubnetworkCall.Returns.Subnetwork, g.GetSubnetworkCall.Returns.Error
+}
+
+func (g *GCPComputeClient) ListAddresses(projectID, region string) ([]*compute.Address, error) {
+	g.ListAddressesCall.CallCount++
+	g.ListAddressesCall.Receives.ProjectID = projectID
+	g.ListAddressesCall.Receives.Region = region
+	return g.ListAddressesCall.Returns.Addresses, g.ListAddressesCall.Returns.Error
+}
+
+func (g *GCPComputeClient) ListMachineTypes(projectID, zone string) ([]string, error) {
+	g.ListMachineTypesCall.CallCount++
+	g.ListMachineTypesCall.Receives.ProjectID = projectID
+	g.ListMachineTypesCall.Receives.Zone = zone
+
+	if g.ListMachineTypesCall.Stub != nil {
+		return g.ListMachineTypesCall.Stub(projectID, zone)
+	}
+
+	return g.ListMachineTypesCall.Returns.MachineTypes, g.ListMachineTypesCall.Returns.Error
+}
@@ -0,0 +1,30 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type GCPTemplateResources struct {
+	ResourcesCall struct {
+		CallCount int
+		Stub      func(storage.State) gcp.Resources
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Resources gcp.Resources
+		}
+	}
+}
+
+func (t *GCPTemplateResources) Resources(state storage.State) gcp.Resources {
+	t.ResourcesCall.CallCount++
+	t.ResourcesCall.Receives.State = state
+
+	if t.ResourcesCall.Stub != nil {
+		return t.ResourcesCall.Stub(state)
+	}
+
+	return t.ResourcesCall.Returns.Resources
+}
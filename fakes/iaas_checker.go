@@ -0,0 +1,83 @@
+package fakes
+
+import "github.com/cloudfoundry/bosh-bootloader/storage"
+
+type IAASChecker struct {
+	CheckCredentialsCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	CheckRegionCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	CheckQuotasCall struct {
+		CallCount int
+		Receives  struct {
+			Current storage.State
+			Desired storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	CheckPermissionsCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+	CheckMachineTypesCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func (i *IAASChecker) CheckCredentials(state storage.State) error {
+	i.CheckCredentialsCall.CallCount++
+	i.CheckCredentialsCall.Receives.State = state
+	return i.CheckCredentialsCall.Returns.Error
+}
+
+func (i *IAASChecker) CheckRegion(state storage.State) error {
+	i.CheckRegionCall.CallCount++
+	i.CheckRegionCall.Receives.State = state
+	return i.CheckRegionCall.Returns.Error
+}
+
+func (i *IAASChecker) CheckQuotas(current, desired storage.State) error {
+	i.CheckQuotasCall.CallCount++
+	i.CheckQuotasCall.Receives.Current = current
+	i.CheckQuotasCall.Receives.Desired = desired
+	return i.CheckQuotasCall.Returns.Error
+}
+
+func (i *IAASChecker) CheckPermissions(state storage.State) error {
+	i.CheckPermissionsCall.CallCount++
+	i.CheckPermissionsCall.Receives.State = state
+	return i.CheckPermissionsCall.Returns.Error
+}
+
+func (i *IAASChecker) CheckMachineTypes(state storage.State) error {
+	i.CheckMachineTypesCall.CallCount++
+	i.CheckMachineTypesCall.Receives.State = state
+	return i.CheckMachineTypesCall.Returns.Error
+}
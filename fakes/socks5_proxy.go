@@ -3,6 +3,7 @@ package fakes
 type Socks5Proxy struct {
 	StartCall struct {
 		CallCount int
+		Stub      func(jumpboxPrivateKey, jumpboxExternalURL string) error
 		Receives  struct {
 			JumpboxPrivateKey  string
 			JumpboxExternalURL string
@@ -17,6 +18,12 @@ type Socks5Proxy struct {
 			Addr string
 		}
 	}
+	CheckPortCall struct {
+		CallCount int
+		Returns   struct {
+			Error error
+		}
+	}
 }
 
 func (s *Socks5Proxy) Start(jumpboxPrivateKey, jumpboxExternalURL string) error {
@@ -24,6 +31,10 @@ func (s *Socks5Proxy) Start(jumpboxPrivateKey, jumpboxExternalURL string) error
 	s.StartCall.Receives.JumpboxPrivateKey = jumpboxPrivateKey
 	s.StartCall.Receives.JumpboxExternalURL = jumpboxExternalURL
 
+	if s.StartCall.Stub != nil {
+		return s.StartCall.Stub(jumpboxPrivateKey, jumpboxExternalURL)
+	}
+
 	return s.StartCall.Returns.Error
 }
 
@@ -32,3 +43,9 @@ func (s *Socks5Proxy) Addr() string {
 
 	return s.AddrCall.Returns.Addr
 }
+
+func (s *Socks5Proxy) CheckPort() error {
+	s.CheckPortCall.CallCount++
+
+	return s.CheckPortCall.Returns.Error
+}
@@ -0,0 +1,24 @@
+package fakes
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+type StatusChecker struct {
+	CheckCall struct {
+		CallCount int
+		Receives  struct {
+			State storage.State
+		}
+		Returns struct {
+			Statuses []bosh.ComponentStatus
+		}
+	}
+}
+
+func (s *StatusChecker) Check(state storage.State) []bosh.ComponentStatus {
+	s.CheckCall.CallCount++
+	s.CheckCall.Receives.State = state
+	return s.CheckCall.Returns.Statuses
+}
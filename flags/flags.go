@@ -3,6 +3,7 @@ package flags
 import (
 	"flag"
 	"io/ioutil"
+	"strings"
 )
 
 type Flags struct {
@@ -30,6 +31,11 @@ func (f Flags) String(v *string, name string, value string) {
 	f.set.StringVar(v, name, value, "")
 }
 
+// StringSlice collects every occurrence of a repeated flag, in order.
+func (f Flags) StringSlice(v *[]string, name string) {
+	f.set.Var((*stringSlice)(v), name, "")
+}
+
 func (f Flags) Parse(args []string) error {
 	return f.set.Parse(args)
 }
@@ -37,3 +43,14 @@ func (f Flags) Parse(args []string) error {
 func (f Flags) Args() []string {
 	return f.set.Args()
 }
+
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
@@ -10,14 +10,18 @@ import (
 var _ = Describe("Flags", func() {
 	var (
 		f         flags.Flags
-		boolVal   bool
-		stringVal string
+		boolVal    bool
+		stringVal  string
+		stringVals []string
 	)
 
 	BeforeEach(func() {
 		f = flags.New("test")
 		f.Bool(&boolVal, "b", "bool", false)
 		f.String(&stringVal, "string", "")
+
+		stringVals = nil
+		f.StringSlice(&stringVals, "strings")
 	})
 
 	Describe("Parse", func() {
@@ -49,6 +53,14 @@ var _ = Describe("Flags", func() {
 				Expect(stringVal).To(Equal("string_value"))
 			})
 		})
+
+		Context("StringSlice flags", func() {
+			It("collects repeated flags in order", func() {
+				err := f.Parse([]string{"--strings", "first", "--strings", "second"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stringVals).To(Equal([]string{"first", "second"}))
+			})
+		})
 	})
 
 	Describe("Args", func() {
@@ -2,6 +2,7 @@ package gcp
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	compute "google.golang.org/api/compute/v1"
@@ -14,17 +15,25 @@ type Client struct {
 }
 
 type ComputeClient interface {
+	GetProject(projectID string) (*compute.Project, error)
 	ListInstances(projectID, zone string) (*compute.InstanceList, error)
 	GetZones(region, projectID string) ([]string, error)
 	GetZone(zone, projectID string) (*compute.Zone, error)
 	GetRegion(region, projectID string) (*compute.Region, error)
 	GetNetworks(name, projectID string) (*compute.NetworkList, error)
+	GetSubnetwork(name, region, projectID string) (*compute.Subnetwork, error)
+	ListAddresses(projectID, region string) ([]*compute.Address, error)
+	ListMachineTypes(projectID, zone string) ([]string, error)
 }
 
 func (c Client) ProjectID() string {
 	return c.projectID
 }
 
+func (c Client) GetProject() (*compute.Project, error) {
+	return c.computeClient.GetProject(c.projectID)
+}
+
 func (c Client) listInstances() (*compute.InstanceList, error) {
 	return c.computeClient.ListInstances(c.projectID, c.zone)
 }
@@ -45,6 +54,69 @@ func (c Client) GetNetworks(name string) (*compute.NetworkList, error) {
 	return c.computeClient.GetNetworks(name, c.projectID)
 }
 
+// GetSubnetworkCIDR returns the range of a subnetwork, which may live in a
+// Shared VPC host project.
+func (c Client) GetSubnetworkCIDR(name, region, projectID string) (string, error) {
+	if projectID == "" {
+		projectID = c.projectID
+	}
+
+	subnetwork, err := c.computeClient.GetSubnetwork(name, region, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	return subnetwork.IpCidrRange, nil
+}
+
+// AddressesInUse returns the ips that are reserved in the region of the
+// project of the subnetwork, which may be a Shared VPC host project, or that
+// an instance of the project in one of zones already has in the subnetwork.
+func (c Client) AddressesInUse(ips []string, subnetwork, region, networkProject string, zones []string) ([]string, error) {
+	if networkProject == "" {
+		networkProject = c.projectID
+	}
+
+	wanted := map[string]bool{}
+	for _, ip := range ips {
+		wanted[ip] = true
+	}
+
+	used := map[string]bool{}
+
+	addresses, err := c.computeClient.ListAddresses(networkProject, region)
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range addresses {
+		if wanted[address.Address] {
+			used[address.Address] = true
+		}
+	}
+
+	for _, zone := range zones {
+		instances, err := c.computeClient.ListInstances(c.projectID, zone)
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range instances.Items {
+			for _, networkInterface := range instance.NetworkInterfaces {
+				if wanted[networkInterface.NetworkIP] && path.Base(networkInterface.Subnetwork) == subnetwork {
+					used[networkInterface.NetworkIP] = true
+				}
+			}
+		}
+	}
+
+	var inUse []string
+	for _, ip := range ips {
+		if used[ip] {
+			inUse = append(inUse, ip)
+		}
+	}
+	return inUse, nil
+}
+
 // Methods added to conform to IAAS-agnostic interfaces
 
 func (c Client) CheckExists(networkName string) (bool, error) {
@@ -18,6 +18,101 @@ var _ = Describe("Client", func() {
 		client        gcp.Client
 	)
 
+	Describe("GetSubnetworkCIDR", func() {
+		BeforeEach(func() {
+			computeClient = &fakes.GCPComputeClient{}
+			computeClient.GetSubnetworkCall.Returns.Subnetwork = &compute.Subnetwork{IpCidrRange: "10.10.0.0/16"}
+			client = gcp.NewClientWithInjectedComputeClient(computeClient, "some-project-id", "some-zone")
+		})
+
+		It("returns the range of the subnetwork in the host project", func() {
+			cidr, err := client.GetSubnetworkCIDR("some-subnetwork", "some-region", "some-host-project")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidr).To(Equal("10.10.0.0/16"))
+
+			Expect(computeClient.GetSubnetworkCall.Receives.Name).To(Equal("some-subnetwork"))
+			Expect(computeClient.GetSubnetworkCall.Receives.Region).To(Equal("some-region"))
+			Expect(computeClient.GetSubnetworkCall.Receives.ProjectID).To(Equal("some-host-project"))
+		})
+
+		It("looks the subnetwork up in the project of the environment without a host project", func() {
+			_, err := client.GetSubnetworkCIDR("some-subnetwork", "some-region", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(computeClient.GetSubnetworkCall.Receives.ProjectID).To(Equal("some-project-id"))
+		})
+
+		It("returns an error when the subnetwork cannot be found", func() {
+			computeClient.GetSubnetworkCall.Returns.Error = errors.New("not found")
+
+			_, err := client.GetSubnetworkCIDR("some-subnetwork", "some-region", "")
+			Expect(err).To(MatchError("not found"))
+		})
+	})
+
+	Describe("AddressesInUse", func() {
+		BeforeEach(func() {
+			computeClient = &fakes.GCPComputeClient{}
+			computeClient.ListInstancesCall.Returns.InstanceList = &compute.InstanceList{}
+			client = gcp.NewClientWithInjectedComputeClient(computeClient, "some-project-id", "some-zone")
+		})
+
+		It("returns the ips that are reserved in the host project", func() {
+			computeClient.ListAddressesCall.Returns.Addresses = []*compute.Address{
+				{Address: "10.0.0.6"},
+				{Address: "10.0.0.7"},
+			}
+
+			inUse, err := client.AddressesInUse([]string{"10.0.0.5", "10.0.0.6"}, "some-subnetwork", "some-region", "some-host-project", []string{"zone-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inUse).To(Equal([]string{"10.0.0.6"}))
+
+			Expect(computeClient.ListAddressesCall.Receives.ProjectID).To(Equal("some-host-project"))
+			Expect(computeClient.ListAddressesCall.Receives.Region).To(Equal("some-region"))
+		})
+
+		It("returns the ips that instances already have in the subnetwork", func() {
+			computeClient.ListInstancesCall.Returns.InstanceList = &compute.InstanceList{
+				Items: []*compute.Instance{
+					{NetworkInterfaces: []*compute.NetworkInterface{{
+						NetworkIP:  "10.0.0.5",
+						Subnetwork: "https://www.googleapis.com/compute/v1/projects/some-host-project/regions/some-region/subnetworks/some-subnetwork",
+					}}},
+					{NetworkInterfaces: []*compute.NetworkInterface{{
+						NetworkIP:  "10.0.0.6",
+						Subnetwork: "https://www.googleapis.com/compute/v1/projects/some-host-project/regions/some-region/subnetworks/other-subnetwork",
+					}}},
+				},
+			}
+
+			inUse, err := client.AddressesInUse([]string{"10.0.0.5", "10.0.0.6"}, "some-subnetwork", "some-region", "some-host-project", []string{"zone-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inUse).To(Equal([]string{"10.0.0.5"}))
+
+			Expect(computeClient.ListInstancesCall.Receives.ProjectID).To(Equal("some-project-id"))
+			Expect(computeClient.ListInstancesCall.Receives.Zone).To(Equal("zone-1"))
+		})
+
+		It("lists the addresses of the project of the environment without a host project", func() {
+			_, err := client.AddressesInUse([]string{"10.0.0.5"}, "some-subnetwork", "some-region", "", []string{"zone-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(computeClient.ListAddressesCall.Receives.ProjectID).To(Equal("some-project-id"))
+		})
+
+		It("returns an error when the addresses cannot be listed", func() {
+			computeClient.ListAddressesCall.Returns.Error = errors.New("forbidden")
+
+			_, err := client.AddressesInUse([]string{"10.0.0.5"}, "some-subnetwork", "some-region", "", []string{"zone-1"})
+			Expect(err).To(MatchError("forbidden"))
+		})
+
+		It("returns an error when the instances cannot be listed", func() {
+			computeClient.ListInstancesCall.Returns.Error = errors.New("forbidden")
+
+			_, err := client.AddressesInUse([]string{"10.0.0.5"}, "some-subnetwork", "some-region", "", []string{"zone-1"})
+			Expect(err).To(MatchError("forbidden"))
+		})
+	})
+
 	Describe("ValidateSafeToDelete", func() {
 		BeforeEach(func() {
 			computeClient = &fakes.GCPComputeClient{}
@@ -11,6 +11,10 @@ type gcpComputeClient struct {
 	service *compute.Service
 }
 
+func (g gcpComputeClient) GetProject(projectID string) (*compute.Project, error) {
+	return g.service.Projects.Get(projectID).Do()
+}
+
 func (g gcpComputeClient) ListInstances(projectID, zone string) (*compute.InstanceList, error) {
 	return g.service.Instances.List(projectID, zone).Do()
 }
@@ -47,6 +51,48 @@ func (g gcpComputeClient) GetRegion(region, projectID string) (*compute.Region,
 	return g.service.Regions.Get(projectID, region).Do()
 }
 
+func (g gcpComputeClient) ListMachineTypes(projectID, zone string) ([]string, error) {
+	var machineTypes []string
+	call := g.service.MachineTypes.List(projectID, zone)
+	for {
+		list, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, machineType := range list.Items {
+			machineTypes = append(machineTypes, machineType.Name)
+		}
+
+		if list.NextPageToken == "" {
+			return machineTypes, nil
+		}
+		call.PageToken(list.NextPageToken)
+	}
+}
+
+func (g gcpComputeClient) GetSubnetwork(name, region, projectID string) (*compute.Subnetwork, error) {
+	return g.service.Subnetworks.Get(projectID, region, name).Do()
+}
+
+func (g gcpComputeClient) ListAddresses(projectID, region string) ([]*compute.Address, error) {
+	var addresses []*compute.Address
+	call := g.service.Addresses.List(projectID, region)
+	for {
+		list, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+
+		addresses = append(addresses, list.Items...)
+
+		if list.NextPageToken == "" {
+			return addresses, nil
+		}
+		call.PageToken(list.NextPageToken)
+	}
+}
+
 func (g gcpComputeClient) GetNetworks(name, projectID string) (*compute.NetworkList, error) {
 	networksListCall := g.service.Networks.List(projectID)
 	return networksListCall.Filter(fmt.Sprintf("name eq %s", name)).Do()
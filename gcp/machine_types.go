@@ -0,0 +1,49 @@
+package gcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckMachineTypes returns an error naming every machine type that is not
+// available in each zone of region.
+func (c Client) CheckMachineTypes(region string, machineTypes []string) error {
+	zones, err := c.GetZones(region)
+	if err != nil {
+		return fmt.Errorf("Get zones: %s", err)
+	}
+
+	var problems []string
+	for _, zone := range zones {
+		available, err := c.computeClient.ListMachineTypes(c.projectID, zone)
+		if err != nil {
+			return fmt.Errorf("List machine types in %s: %s", zone, err)
+		}
+
+		var missing []string
+		for _, machineType := range machineTypes {
+			if !contains(available, machineType) {
+				missing = append(missing, machineType)
+			}
+		}
+
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s", zone, strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Machine types are not available in every zone of %s:\n  %s", region, strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package gcp_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckMachineTypes", func() {
+	var (
+		computeClient *fakes.GCPComputeClient
+		client        gcp.Client
+	)
+
+	BeforeEach(func() {
+		computeClient = &fakes.GCPComputeClient{}
+		computeClient.GetZonesCall.Returns.Zones = []string{"us-west1-a", "us-west1-b"}
+		computeClient.ListMachineTypesCall.Stub = func(projectID, zone string) ([]string, error) {
+			if zone == "us-west1-a" {
+				return []string{"e2-medium", "n2-standard-2"}, nil
+			}
+			return []string{"e2-medium"}, nil
+		}
+		client = gcp.NewClientWithInjectedComputeClient(computeClient, "some-project-id", "some-zone")
+	})
+
+	It("checks the machine types are available in every zone of the region", func() {
+		err := client.CheckMachineTypes("us-west1", []string{"e2-medium"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(computeClient.GetZonesCall.Receives.Region).To(Equal("us-west1"))
+		Expect(computeClient.ListMachineTypesCall.CallCount).To(Equal(2))
+		Expect(computeClient.ListMachineTypesCall.Receives.ProjectID).To(Equal("some-project-id"))
+	})
+
+	It("returns an error naming the machine types missing from each zone", func() {
+		err := client.CheckMachineTypes("us-west1", []string{"e2-medium", "n2-standard-2", "n2-standard-4"})
+		Expect(err).To(MatchError("Machine types are not available in every zone of us-west1:\n" +
+			"  us-west1-a: n2-standard-4\n" +
+			"  us-west1-b: n2-standard-2, n2-standard-4"))
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when the zones cannot be retrieved", func() {
+			computeClient.GetZonesCall.Returns.Error = errors.New("not found")
+
+			err := client.CheckMachineTypes("us-west1", []string{"e2-medium"})
+			Expect(err).To(MatchError("Get zones: not found"))
+		})
+
+		It("returns an error when the machine types cannot be listed", func() {
+			computeClient.ListMachineTypesCall.Stub = nil
+			computeClient.ListMachineTypesCall.Returns.Error = errors.New("forbidden")
+
+			err := client.CheckMachineTypes("us-west1", []string{"e2-medium"})
+			Expect(err).To(MatchError("List machine types in us-west1-a: forbidden"))
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package gcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resources are the regional resources an environment will create that count
+// against the CPUS and STATIC_ADDRESSES quotas.
+type Resources struct {
+	CPUs            int
+	StaticAddresses int
+}
+
+// CheckQuotas returns an error naming every quota in region that does not
+// have room for resources.
+func (c Client) CheckQuotas(region string, resources Resources) error {
+	r, err := c.GetRegion(region)
+	if err != nil {
+		return fmt.Errorf("Get region: %s", err)
+	}
+
+	needed := map[string]int{
+		"CPUS":             resources.CPUs,
+		"STATIC_ADDRESSES": resources.StaticAddresses,
+	}
+
+	var problems []string
+	for _, quota := range r.Quotas {
+		need := needed[quota.Metric]
+		if need == 0 {
+			continue
+		}
+
+		if int(quota.Usage)+need > int(quota.Limit) {
+			problems = append(problems, fmt.Sprintf("%s: %d needed, %d of %d in use in %s", quota.Metric, need, int(quota.Usage), int(quota.Limit), region))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Not enough quota to create this environment, free up resources or request a quota increase:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+// Minus returns the resources in r that are not already in existing.
+func (r Resources) Minus(existing Resources) Resources {
+	return Resources{
+		CPUs:            remaining(r.CPUs, existing.CPUs),
+		StaticAddresses: remaining(r.StaticAddresses, existing.StaticAddresses),
+	}
+}
+
+func remaining(needed, existing int) int {
+	if needed < existing {
+		return 0
+	}
+	return needed - existing
+}
@@ -0,0 +1,53 @@
+package gcp_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-bootloader/fakes"
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+	compute "google.golang.org/api/compute/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckQuotas", func() {
+	var (
+		computeClient *fakes.GCPComputeClient
+		client        gcp.Client
+	)
+
+	BeforeEach(func() {
+		computeClient = &fakes.GCPComputeClient{}
+		computeClient.GetRegionCall.Returns.Region = &compute.Region{
+			Quotas: []*compute.Quota{
+				{Metric: "CPUS", Limit: 24, Usage: 20},
+				{Metric: "STATIC_ADDRESSES", Limit: 8, Usage: 4},
+				{Metric: "SSD_TOTAL_GB", Limit: 2048, Usage: 2048},
+			},
+		}
+		client = gcp.NewClientWithInjectedComputeClient(computeClient, "some-project-id", "some-zone")
+	})
+
+	It("checks the resources fit in the region quotas", func() {
+		err := client.CheckQuotas("us-west1", gcp.Resources{CPUs: 2, StaticAddresses: 4})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(computeClient.GetRegionCall.Receives.Region).To(Equal("us-west1"))
+		Expect(computeClient.GetRegionCall.Receives.ProjectID).To(Equal("some-project-id"))
+	})
+
+	It("returns an error naming each quota that is exceeded", func() {
+		err := client.CheckQuotas("us-west1", gcp.Resources{CPUs: 6, StaticAddresses: 5})
+		Expect(err).To(MatchError("Not enough quota to create this environment, free up resources or request a quota increase:\n" +
+			"  CPUS: 6 needed, 20 of 24 in use in us-west1\n" +
+			"  STATIC_ADDRESSES: 5 needed, 4 of 8 in use in us-west1"))
+	})
+
+	It("returns an error when the region cannot be retrieved", func() {
+		computeClient.GetRegionCall.Returns.Error = errors.New("not found")
+
+		err := client.CheckQuotas("us-west1", gcp.Resources{CPUs: 2})
+		Expect(err).To(MatchError("Get region: not found"))
+	})
+})
@@ -0,0 +1,13 @@
+package interpolate_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "interpolate")
+}
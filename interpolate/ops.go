@@ -0,0 +1,506 @@
+package interpolate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Op is a single operation from an ops file. It follows go-patch, which the
+// bosh cli uses: replace, remove and test operations are supported, and paths
+// may use the prev, next, before and after modifiers on array tokens.
+type Op struct {
+	Type   string      `yaml:"type"`
+	Path   string      `yaml:"path"`
+	Value  interface{} `yaml:"value,omitempty"`
+	Absent bool        `yaml:"absent,omitempty"`
+}
+
+type token struct {
+	key        string
+	index      int
+	matchKey   string
+	matchValue string
+	kind       tokenKind
+	modifiers  []string
+	optional   bool
+	marked     bool
+}
+
+type tokenKind int
+
+const (
+	keyToken tokenKind = iota
+	indexToken
+	afterLastIndexToken
+	matchingIndexToken
+)
+
+func ParseOps(contents []byte) ([]Op, error) {
+	var ops []Op
+	err := yaml.Unmarshal(contents, &ops)
+	if err != nil {
+		return nil, fmt.Errorf("parse ops file: %s", err)
+	}
+
+	for i, op := range ops {
+		switch op.Type {
+		case "replace", "remove":
+		case "test":
+			if op.Value == nil && !op.Absent {
+				return nil, fmt.Errorf("Operation [%d]: Missing value or absent", i)
+			}
+			if op.Value != nil && op.Absent {
+				return nil, fmt.Errorf("Operation [%d]: Cannot specify both value and absent", i)
+			}
+		default:
+			return nil, fmt.Errorf("Unknown operation [%d] with type '%s'", i, op.Type)
+		}
+
+		_, err := parsePath(op.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Operation [%d]: %s", i, err)
+		}
+	}
+
+	return ops, nil
+}
+
+// ApplyOps applies ops to doc in order and returns the resulting document.
+func ApplyOps(doc interface{}, ops []Op) (interface{}, error) {
+	for i, op := range ops {
+		tokens, err := parsePath(op.Path)
+		if err != nil {
+			return nil, fmt.Errorf("Operation [%d]: %s", i, err)
+		}
+
+		switch op.Type {
+		case "replace":
+			doc, err = replace(doc, tokens, op.Path, op.Value)
+		case "remove":
+			doc, err = remove(doc, tokens, op.Path)
+		case "test":
+			err = test(doc, tokens, op)
+		default:
+			err = fmt.Errorf("Unknown operation type '%s'", op.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Operation [%d] (%s %s): %s", i, op.Type, op.Path, err)
+		}
+	}
+
+	return doc, nil
+}
+
+// parsePath parses a go-patch style path such as /instance_groups/name=bosh/jobs/-.
+// Once a token is marked optional with a trailing ?, the rest of the path is
+// optional too. Index and matching tokens may end in :prev, :next, :before or
+// :after, and - is only an index as the last token.
+func parsePath(path string) ([]token, error) {
+	if path == "" || path == "/" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("Expected path '%s' to start with '/'", path)
+	}
+
+	var tokens []token
+	optional := false
+	parts := strings.Split(path[1:], "/")
+	for i, part := range parts {
+		pieces := strings.Split(part, ":")
+		part = strings.Replace(strings.Replace(pieces[0], "~1", "/", -1), "~0", "~", -1)
+
+		modifiers := pieces[1:]
+		for _, modifier := range modifiers {
+			switch modifier {
+			case "prev", "next", "before", "after":
+			default:
+				return nil, fmt.Errorf("Expected to find one of the following modifiers: 'prev', 'next', 'before', or 'after' but found '%s' in '%s'", modifier, path)
+			}
+		}
+
+		if part == "-" && i == len(parts)-1 {
+			if len(modifiers) > 0 {
+				return nil, fmt.Errorf("Expected not to find any modifiers with after last index token in '%s'", path)
+			}
+			tokens = append(tokens, token{kind: afterLastIndexToken, optional: optional})
+			continue
+		}
+
+		if index, err := strconv.Atoi(part); err == nil {
+			tokens = append(tokens, token{kind: indexToken, index: index, modifiers: modifiers, optional: optional})
+			continue
+		}
+
+		marked := strings.HasSuffix(part, "?")
+		if marked {
+			optional = true
+			part = strings.TrimSuffix(part, "?")
+		}
+
+		t := token{key: part, kind: keyToken, optional: optional, marked: marked}
+		if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+			t.kind = matchingIndexToken
+			t.matchKey = kv[0]
+			t.matchValue = kv[1]
+			t.modifiers = modifiers
+		} else if len(modifiers) > 0 {
+			return nil, fmt.Errorf("Expected not to find any modifiers with key token '%s' in '%s'", part, path)
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+func replace(doc interface{}, tokens []token, path string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	return replaceIn(doc, tokens, pathFor(tokens[:1]), path, value)
+}
+
+func replaceIn(obj interface{}, tokens []token, current, path string, value interface{}) (interface{}, error) {
+	t := tokens[0]
+	last := len(tokens) == 1
+
+	switch t.kind {
+	case keyToken:
+		m, ok := obj.(map[interface{}]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Expected to find a map at path '%s' but found '%T'", current, obj)
+		}
+
+		child, found := m[t.key]
+		if last {
+			if !found && !t.optional {
+				return nil, fmt.Errorf("Expected to find a map key '%s' for path '%s'", t.key, current)
+			}
+			m[t.key] = value
+			return m, nil
+		}
+
+		if !found {
+			if !t.optional {
+				return nil, fmt.Errorf("Expected to find a map key '%s' for path '%s'", t.key, current)
+			}
+			child = emptyFor(tokens[1])
+		}
+
+		child, err := replaceIn(child, tokens[1:], current+"/"+tokens[1].String(), path, value)
+		if err != nil {
+			return nil, err
+		}
+		m[t.key] = child
+		return m, nil
+
+	case indexToken, afterLastIndexToken, matchingIndexToken:
+		a, ok := obj.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Expected to find an array at path '%s' but found '%T'", current, obj)
+		}
+
+		index, insert, err := arrayIndex(a, t, current, last)
+		if err != nil {
+			return nil, err
+		}
+
+		if insert {
+			return append(append(a[:index:index], value), a[index:]...), nil
+		}
+
+		if index == len(a) {
+			if last {
+				return append(a, value), nil
+			}
+			var child interface{} = emptyFor(tokens[1])
+			if t.kind == matchingIndexToken {
+				child = map[interface{}]interface{}{t.matchKey: t.matchValue}
+			}
+			a = append(a, child)
+		}
+
+		if last {
+			a[index] = value
+			return a, nil
+		}
+
+		child, err := replaceIn(a[index], tokens[1:], current+"/"+tokens[1].String(), path, value)
+		if err != nil {
+			return nil, err
+		}
+		a[index] = child
+		return a, nil
+	}
+
+	return nil, fmt.Errorf("Unexpected token in path '%s'", path) // not tested
+}
+
+func remove(doc interface{}, tokens []token, path string) (interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("Cannot remove entire document")
+	}
+
+	result, _, err := removeIn(doc, tokens, pathFor(tokens[:1]))
+	return result, err
+}
+
+// removeIn returns the updated obj, and false when an optional part of the
+// path was missing and nothing was removed.
+func removeIn(obj interface{}, tokens []token, current string) (interface{}, bool, error) {
+	t := tokens[0]
+	last := len(tokens) == 1
+
+	switch t.kind {
+	case keyToken:
+		m, ok := obj.(map[interface{}]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("Expected to find a map at path '%s' but found '%T'", current, obj)
+		}
+
+		child, found := m[t.key]
+		if !found {
+			if t.optional {
+				return m, false, nil
+			}
+			return nil, false, fmt.Errorf("Expected to find a map key '%s' for path '%s'", t.key, current)
+		}
+
+		if last {
+			delete(m, t.key)
+			return m, true, nil
+		}
+
+		child, removed, err := removeIn(child, tokens[1:], current+"/"+tokens[1].String())
+		if err != nil || !removed {
+			return m, removed, err
+		}
+		m[t.key] = child
+		return m, true, nil
+
+	case indexToken, matchingIndexToken:
+		a, ok := obj.([]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("Expected to find an array at path '%s' but found '%T'", current, obj)
+		}
+
+		index, _, err := arrayIndex(a, t, current, false)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if index == len(a) {
+			return a, false, nil
+		}
+
+		if last {
+			return append(a[:index:index], a[index+1:]...), true, nil
+		}
+
+		child, removed, err := removeIn(a[index], tokens[1:], current+"/"+tokens[1].String())
+		if err != nil || !removed {
+			return a, removed, err
+		}
+		a[index] = child
+		return a, true, nil
+	}
+
+	return nil, false, fmt.Errorf("Expected not to find after last index token in path '%s' (not supported in remove operations)", current)
+}
+
+// arrayIndex resolves an array token to an index, applying its prev and next
+// modifiers. An index equal to len(a) means the element does not exist and
+// may be appended. insert is true when a before or after modifier asks for
+// the value to be inserted at index, which only the last token of a replace
+// may do.
+func arrayIndex(a []interface{}, t token, current string, insertable bool) (int, bool, error) {
+	var index int
+	switch t.kind {
+	case afterLastIndexToken:
+		return len(a), false, nil
+
+	case indexToken:
+		index = t.index
+
+	case matchingIndexToken:
+		var matches []int
+		for i, item := range a {
+			m, ok := item.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			if value, ok := m[t.matchKey].(string); ok && value == t.matchValue {
+				matches = append(matches, i)
+			}
+		}
+
+		switch {
+		case len(matches) == 0 && t.optional:
+			return len(a), false, nil
+		case len(matches) != 1:
+			return 0, false, fmt.Errorf("Expected to find exactly one matching array item for path '%s' but found %d", current, len(matches))
+		}
+		index = matches[0]
+	}
+
+	before, after := false, false
+	for _, modifier := range t.modifiers {
+		if before || after {
+			return 0, false, fmt.Errorf("Expected to not find any modifiers after 'before' or 'after' modifier for path '%s'", current)
+		}
+
+		switch modifier {
+		case "prev":
+			index--
+		case "next":
+			index++
+		case "before":
+			before = true
+		case "after":
+			after = true
+		}
+	}
+
+	if (before || after) && !insertable {
+		return 0, false, fmt.Errorf("Expected 'before' and 'after' modifiers only on the last token of a replace operation for path '%s'", current)
+	}
+
+	if index >= len(a) || -index-1 >= len(a) {
+		return 0, false, fmt.Errorf("Expected to find array index '%d' but found array of length '%d' for path '%s'", index, len(a), current)
+	}
+
+	if index < 0 {
+		index = len(a) + index
+	}
+	if after {
+		index++
+	}
+
+	return index, before || after, nil
+}
+
+// test checks that the value at tokens equals op.Value, or that nothing is
+// there when op.Absent is set.
+func test(doc interface{}, tokens []token, op Op) error {
+	value, found, err := find(doc, tokens, "")
+	if err != nil {
+		return err
+	}
+
+	if op.Absent {
+		if found {
+			return fmt.Errorf("Expected to not find '%s'", op.Path)
+		}
+		return nil
+	}
+
+	if !found {
+		return fmt.Errorf("Expected to find '%s'", op.Path)
+	}
+
+	if !reflect.DeepEqual(value, op.Value) {
+		return fmt.Errorf("Found value does not match expected value")
+	}
+
+	return nil
+}
+
+// find returns the value at tokens, and false when an optional part of the
+// path or the last token is missing.
+func find(obj interface{}, tokens []token, current string) (interface{}, bool, error) {
+	if len(tokens) == 0 {
+		return obj, true, nil
+	}
+
+	t := tokens[0]
+	last := len(tokens) == 1
+	current = current + "/" + t.String()
+
+	switch t.kind {
+	case keyToken:
+		m, ok := obj.(map[interface{}]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("Expected to find a map at path '%s' but found '%T'", current, obj)
+		}
+
+		child, found := m[t.key]
+		if !found {
+			if last || t.optional {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("Expected to find a map key '%s' for path '%s'", t.key, current)
+		}
+
+		return find(child, tokens[1:], current)
+
+	case indexToken, matchingIndexToken:
+		a, ok := obj.([]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("Expected to find an array at path '%s' but found '%T'", current, obj)
+		}
+
+		if last {
+			// a missing last item is absent rather than an error
+			if t.kind == indexToken && len(t.modifiers) == 0 && (t.index >= len(a) || -t.index-1 >= len(a)) {
+				return nil, false, nil
+			}
+			t.optional = true
+		}
+
+		index, _, err := arrayIndex(a, t, current, false)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if index == len(a) {
+			return nil, false, nil
+		}
+
+		return find(a[index], tokens[1:], current)
+	}
+
+	return nil, false, fmt.Errorf("Expected not to find after last index token in path '%s' (not supported in test operations)", current)
+}
+
+func emptyFor(next token) interface{} {
+	if next.kind == keyToken {
+		return map[interface{}]interface{}{}
+	}
+	return []interface{}{}
+}
+
+func pathFor(tokens []token) string {
+	var parts []string
+	for _, t := range tokens {
+		parts = append(parts, t.String())
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+func (t token) String() string {
+	var s string
+	switch t.kind {
+	case indexToken:
+		s = strconv.Itoa(t.index)
+	case afterLastIndexToken:
+		s = "-"
+	case matchingIndexToken:
+		s = t.matchKey + "=" + t.matchValue
+	default:
+		s = strings.Replace(strings.Replace(t.key, "~", "~0", -1), "/", "~1", -1)
+	}
+	if t.marked {
+		s += "?"
+	}
+	for _, modifier := range t.modifiers {
+		s += ":" + modifier
+	}
+	return s
+}
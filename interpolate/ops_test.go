@@ -0,0 +1,263 @@
+package interpolate_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/interpolate"
+	"github.com/pivotal-cf-experimental/gomegamatchers"
+	yaml "gopkg.in/yaml.v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApplyOps", func() {
+	var doc interface{}
+
+	BeforeEach(func() {
+		doc = nil
+		err := yaml.Unmarshal([]byte(`
+name: some-name
+instance_groups:
+- name: bosh
+  jobs:
+  - name: director
+  - name: health_monitor
+- name: jumpbox
+  jobs: []
+`), &doc)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	apply := func(ops string) (string, error) {
+		parsed, err := interpolate.ParseOps([]byte(ops))
+		if err != nil {
+			return "", err
+		}
+
+		result, err := interpolate.ApplyOps(doc, parsed)
+		if err != nil {
+			return "", err
+		}
+
+		contents, err := yaml.Marshal(result)
+		return string(contents), err
+	}
+
+	Describe("replace", func() {
+		It("replaces map keys and array items matched by name or index", func() {
+			result, err := apply(`
+- type: replace
+  path: /name
+  value: some-other-name
+- type: replace
+  path: /instance_groups/name=bosh/jobs/0/name
+  value: some-director
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ContainSubstring("name: some-other-name"))
+			Expect(result).To(ContainSubstring("- name: some-director"))
+			Expect(result).NotTo(ContainSubstring("name: director"))
+		})
+
+		It("appends to arrays with -", func() {
+			result, err := apply(`
+- type: replace
+  path: /instance_groups/name=jumpbox/jobs/-
+  value: {name: user_add}
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ContainSubstring("- name: user_add"))
+		})
+
+		It("creates the rest of the path after an optional token", func() {
+			result, err := apply(`
+- type: replace
+  path: /instance_groups/name=bosh/properties?/postgres/additional_databases/-
+  value: credhub
+- type: replace
+  path: /instance_groups/name=jumpbox/jobs/name=user_add?
+  value: {name: user_add, release: os-conf}
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ContainSubstring("properties:\n    postgres:\n      additional_databases:\n      - credhub\n"))
+			Expect(result).To(ContainSubstring("jobs:\n  - name: user_add\n    release: os-conf\n"))
+		})
+
+		It("inserts around array items with the before and after modifiers", func() {
+			result, err := apply(`
+- type: replace
+  path: /instance_groups/name=bosh/jobs/name=director:before
+  value: {name: some-first-job}
+- type: replace
+  path: /instance_groups/name=bosh/jobs/0:after
+  value: {name: some-second-job}
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ContainSubstring("jobs:\n  - name: some-first-job\n  - name: some-second-job\n  - name: director\n  - name: health_monitor\n"))
+		})
+
+		It("moves from a matched array item with the prev and next modifiers", func() {
+			result, err := apply(`
+- type: replace
+  path: /instance_groups/name=jumpbox:prev/name
+  value: some-bosh
+- type: replace
+  path: /instance_groups/name=some-bosh/jobs/name=director:next/name
+  value: some-health-monitor
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(ContainSubstring("  - name: some-health-monitor\n  name: some-bosh\n"))
+		})
+
+		It("only matches array items whose value is the same string", func() {
+			doc = []interface{}{
+				map[interface{}]interface{}{"name": 1},
+				map[interface{}]interface{}{"name": "1"},
+			}
+
+			result, err := apply(`
+- type: replace
+  path: /name=1/value?
+  value: some-value
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(gomegamatchers.MatchYAML("[{name: 1}, {name: '1', value: some-value}]"))
+		})
+
+		It("replaces the whole document at the root", func() {
+			result, err := apply(`
+- type: replace
+  path: /
+  value: {name: some-new-doc}
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(gomegamatchers.MatchYAML("name: some-new-doc"))
+		})
+	})
+
+	Describe("remove", func() {
+		It("removes map keys and matched array items", func() {
+			result, err := apply(`
+- type: remove
+  path: /instance_groups/name=jumpbox
+- type: remove
+  path: /instance_groups/name=bosh/jobs/name=health_monitor
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).NotTo(ContainSubstring("jumpbox"))
+			Expect(result).NotTo(ContainSubstring("health_monitor"))
+			Expect(result).To(ContainSubstring("director"))
+		})
+
+		It("does nothing when an optional path is missing", func() {
+			result, err := apply(`
+- type: remove
+  path: /releases?/name=os-conf
+`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).NotTo(ContainSubstring("releases"))
+		})
+	})
+
+	Describe("test", func() {
+		It("passes when the value or absence matches", func() {
+			_, err := apply(`
+- type: test
+  path: /instance_groups/name=bosh/jobs/0
+  value: {name: director}
+- type: test
+  path: /instance_groups/name=bosh/properties
+  absent: true
+- type: test
+  path: /instance_groups/name=some-group
+  absent: true
+`)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error when the value does not match", func() {
+			_, err := apply(`
+- type: test
+  path: /name
+  value: some-other-name
+`)
+			Expect(err).To(MatchError("Operation [0] (test /name): Found value does not match expected value"))
+		})
+
+		It("returns an error when an absent path is found", func() {
+			_, err := apply(`
+- type: test
+  path: /name
+  absent: true
+`)
+			Expect(err).To(MatchError("Operation [0] (test /name): Expected to not find '/name'"))
+		})
+	})
+
+	Context("failure cases", func() {
+		It("returns an error when a map key is missing", func() {
+			_, err := apply(`
+- type: replace
+  path: /instance_groups/name=bosh/some-key/some-other-key
+  value: some-value
+`)
+			Expect(err).To(MatchError("Operation [0] (replace /instance_groups/name=bosh/some-key/some-other-key): Expected to find a map key 'some-key' for path '/instance_groups/name=bosh/some-key'"))
+		})
+
+		It("returns an error when no array item matches", func() {
+			_, err := apply(`
+- type: remove
+  path: /instance_groups/name=some-group
+`)
+			Expect(err).To(MatchError("Operation [0] (remove /instance_groups/name=some-group): Expected to find exactly one matching array item for path '/instance_groups/name=some-group' but found 0"))
+		})
+
+		It("returns an error when an array index is out of range", func() {
+			_, err := apply(`
+- type: replace
+  path: /instance_groups/5/name
+  value: some-name
+`)
+			Expect(err).To(MatchError("Operation [0] (replace /instance_groups/5/name): Expected to find array index '5' but found array of length '2' for path '/instance_groups/5'"))
+		})
+
+		It("returns an error for unknown operation types", func() {
+			_, err := apply(`
+- type: some-type
+  path: /name
+`)
+			Expect(err).To(MatchError("Unknown operation [0] with type 'some-type'"))
+		})
+
+		It("returns an error for test operations without a value or absent", func() {
+			_, err := apply(`
+- type: test
+  path: /name
+`)
+			Expect(err).To(MatchError("Operation [0]: Missing value or absent"))
+		})
+
+		It("returns an error for unknown path modifiers", func() {
+			_, err := apply(`
+- type: remove
+  path: /instance_groups/0:some-modifier
+`)
+			Expect(err).To(MatchError("Operation [0]: Expected to find one of the following modifiers: 'prev', 'next', 'before', or 'after' but found 'some-modifier' in '/instance_groups/0:some-modifier'"))
+		})
+
+		It("returns an error for before and after modifiers in remove operations", func() {
+			_, err := apply(`
+- type: remove
+  path: /instance_groups/0:before
+`)
+			Expect(err).To(MatchError("Operation [0] (remove /instance_groups/0:before): Expected 'before' and 'after' modifiers only on the last token of a replace operation for path '/instance_groups/0:before'"))
+		})
+
+		It("returns an error for paths that do not start with a slash", func() {
+			_, err := apply(`
+- type: remove
+  path: name
+`)
+			Expect(err).To(MatchError("Operation [0]: Expected path 'name' to start with '/'"))
+		})
+	})
+})
@@ -0,0 +1,309 @@
+package interpolate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var variableRegexp = regexp.MustCompile(`\(\((!?[-/\.\w\pL]+)\)\)`)
+
+// Input mirrors the arguments to bosh interpolate. OpsFiles are applied in
+// order, VarsFile holds static variables and VarsStore holds previously
+// generated variables.
+type Input struct {
+	Manifest      []byte
+	OpsFiles      [][]byte
+	VarsFile      []byte
+	VarsStore     []byte
+	VarErrs       bool
+	VarErrsUnused bool
+}
+
+type Output struct {
+	Manifest  []byte
+	VarsStore []byte
+}
+
+// Interpolate applies the ops files to the manifest, then replaces its
+// ((variables)) with values from the vars file or the vars store. Variables
+// missing from both are generated from the manifest's variables section and
+// added to the vars store.
+func Interpolate(input Input) (Output, error) {
+	var doc interface{}
+	err := yaml.Unmarshal(input.Manifest, &doc)
+	if err != nil {
+		return Output{}, fmt.Errorf("parse manifest: %s", err)
+	}
+
+	for _, contents := range input.OpsFiles {
+		ops, err := ParseOps(contents)
+		if err != nil {
+			return Output{}, err
+		}
+
+		doc, err = ApplyOps(doc, ops)
+		if err != nil {
+			return Output{}, err
+		}
+	}
+
+	vars := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(input.VarsFile, &vars)
+	if err != nil {
+		return Output{}, fmt.Errorf("parse vars file: %s", err)
+	}
+
+	store := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(input.VarsStore, &store)
+	if err != nil {
+		return Output{}, fmt.Errorf("parse vars store: %s", err)
+	}
+
+	defs, err := variableDefinitions(doc)
+	if err != nil {
+		return Output{}, err
+	}
+
+	t := &tracker{
+		vars:       vars,
+		store:      store,
+		defs:       defs,
+		used:       map[string]bool{},
+		missing:    map[string]bool{},
+		generating: map[string]bool{},
+	}
+
+	doc, err = t.interpolate(doc)
+	if err != nil {
+		return Output{}, err
+	}
+
+	var errs []string
+	if input.VarErrs && len(t.missing) > 0 {
+		errs = append(errs, "Expected to find variables:"+nameList(t.missing))
+	}
+
+	if input.VarErrsUnused {
+		unused := map[string]bool{}
+		for name := range vars {
+			if !t.used[fmt.Sprintf("%v", name)] {
+				unused[fmt.Sprintf("%v", name)] = true
+			}
+		}
+		if len(unused) > 0 {
+			errs = append(errs, "Expected to use variables:"+nameList(unused))
+		}
+	}
+
+	if len(errs) > 0 {
+		return Output{}, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+
+	manifest, err := yaml.Marshal(doc)
+	if err != nil {
+		return Output{}, fmt.Errorf("marshal manifest: %s", err) // not tested
+	}
+
+	varsStore := input.VarsStore
+	if t.generated {
+		varsStore, err = yaml.Marshal(store)
+		if err != nil {
+			return Output{}, fmt.Errorf("marshal vars store: %s", err) // not tested
+		}
+	}
+
+	return Output{
+		Manifest:  manifest,
+		VarsStore: varsStore,
+	}, nil
+}
+
+type tracker struct {
+	vars       map[interface{}]interface{}
+	store      map[interface{}]interface{}
+	defs       map[string]variableDefinition
+	used       map[string]bool
+	missing    map[string]bool
+	generating map[string]bool
+	generated  bool
+}
+
+func (t *tracker) interpolate(obj interface{}) (interface{}, error) {
+	switch typed := obj.(type) {
+	case map[interface{}]interface{}:
+		result := map[interface{}]interface{}{}
+		for key, value := range typed {
+			key, err := t.interpolate(key)
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := t.interpolate(value)
+			if err != nil {
+				return nil, err
+			}
+
+			result[key] = value
+		}
+		return result, nil
+
+	case []interface{}:
+		result := make([]interface{}, 0, len(typed))
+		for _, value := range typed {
+			value, err := t.interpolate(value)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		return result, nil
+
+	case string:
+		return t.interpolateString(typed)
+	}
+
+	return obj, nil
+}
+
+func (t *tracker) interpolateString(s string) (interface{}, error) {
+	matches := variableRegexp.FindAllStringSubmatch(s, -1)
+	if len(matches) == 1 && matches[0][0] == s {
+		value, found, err := t.get(matches[0][1])
+		if err != nil || !found {
+			return s, err
+		}
+		return value, nil
+	}
+
+	for _, match := range matches {
+		value, found, err := t.get(match[1])
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		switch value.(type) {
+		case string, int, int64, uint64, float64:
+		default:
+			return nil, fmt.Errorf("Invalid type '%T' for value '%v' and variable '%s'. Acceptable types for partially interpolated variables are strings and integers.", value, value, match[1])
+		}
+
+		s = strings.Replace(s, match[0], fmt.Sprintf("%v", value), -1)
+	}
+
+	return s, nil
+}
+
+// get finds the value of a variable reference such as director_ssl.ca, where
+// everything after the first dot selects keys from the variable's value.
+func (t *tracker) get(reference string) (interface{}, bool, error) {
+	keys := strings.Split(strings.TrimPrefix(reference, "!"), ".")
+	name := keys[0]
+
+	value, found := t.vars[name]
+	if found {
+		t.used[name] = true
+	} else {
+		value, found = t.store[name]
+	}
+
+	if !found {
+		def, ok := t.defs[name]
+		if !ok {
+			t.missing[name] = true
+			return nil, false, nil
+		}
+
+		var err error
+		value, err = t.generate(def)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	for _, key := range keys[1:] {
+		m, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("Expected to find a map for variable '%s' but found '%T'", reference, value)
+		}
+
+		value, ok = m[key]
+		if !ok {
+			return nil, false, fmt.Errorf("Expected to find a map key '%s' for variable '%s'", key, reference)
+		}
+	}
+
+	return value, true, nil
+}
+
+func (t *tracker) generate(def variableDefinition) (interface{}, error) {
+	if t.generating[def.Name] {
+		return nil, fmt.Errorf("Variable '%s' depends on itself", def.Name)
+	}
+	t.generating[def.Name] = true
+	defer delete(t.generating, def.Name)
+
+	options, err := t.interpolate(def.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	optionsMap, _ := options.(map[interface{}]interface{})
+	value, err := generateValue(def, optionsMap, t.get)
+	if err != nil {
+		return nil, fmt.Errorf("Generating variable '%s': %s", def.Name, err)
+	}
+
+	t.store[def.Name] = value
+	t.generated = true
+
+	return value, nil
+}
+
+type variableDefinition struct {
+	Name    string      `yaml:"name"`
+	Type    string      `yaml:"type"`
+	Options interface{} `yaml:"options"`
+}
+
+func variableDefinitions(doc interface{}) (map[string]variableDefinition, error) {
+	defs := map[string]variableDefinition{}
+
+	m, ok := doc.(map[interface{}]interface{})
+	if !ok || m["variables"] == nil {
+		return defs, nil
+	}
+
+	contents, err := yaml.Marshal(m["variables"])
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	var list []variableDefinition
+	err = yaml.Unmarshal(contents, &list)
+	if err != nil {
+		return nil, fmt.Errorf("parse variables section: %s", err)
+	}
+
+	for _, def := range list {
+		defs[def.Name] = def
+	}
+
+	return defs, nil
+}
+
+func nameList(names map[string]bool) string {
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	return "\n    - " + strings.Join(sorted, "\n    - ")
+}
@@ -0,0 +1,232 @@
+package interpolate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	passwordLength = 20
+	passwordChars  = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	rsaKeyBits      = 2048
+	certificateDays = 365
+)
+
+func generateValue(def variableDefinition, options map[interface{}]interface{}, get func(string) (interface{}, bool, error)) (interface{}, error) {
+	switch def.Type {
+	case "password":
+		return generatePassword()
+	case "certificate":
+		return generateCertificate(options, get)
+	case "ssh":
+		return generateSSHKey()
+	case "rsa":
+		return generateRSAKey()
+	}
+
+	return nil, fmt.Errorf("Unsupported variable type '%s'", def.Type)
+}
+
+func generatePassword() (interface{}, error) {
+	max := big.NewInt(int64(len(passwordChars)))
+
+	password := make([]byte, passwordLength)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return nil, err // not tested
+		}
+		password[i] = passwordChars[n.Int64()]
+	}
+
+	return string(password), nil
+}
+
+func generateSSHKey() (interface{}, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	return map[interface{}]interface{}{
+		"private_key":            encodePrivateKey(privateKey),
+		"public_key":             strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		"public_key_fingerprint": ssh.FingerprintLegacyMD5(publicKey),
+	}, nil
+}
+
+func generateRSAKey() (interface{}, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	return map[interface{}]interface{}{
+		"private_key": encodePrivateKey(privateKey),
+		"public_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+	}, nil
+}
+
+// generateCertificate creates a certificate signed by the ca variable, or a
+// self-signed one when no ca is named, as the bosh cli does.
+func generateCertificate(options map[interface{}]interface{}, get func(string) (interface{}, bool, error)) (interface{}, error) {
+	isCA, _ := options["is_ca"].(bool)
+	caName, _ := options["ca"].(string)
+	commonName, _ := options["common_name"].(string)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Country:      []string{"USA"},
+			Organization: []string{"Cloud Foundry"},
+			CommonName:   commonName,
+		},
+		NotBefore:             now,
+		NotAfter:              now.Add(certificateDays * 24 * time.Hour),
+		BasicConstraintsValid: true,
+	}
+
+	if isCA {
+		template.IsCA = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	} else {
+		template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	}
+
+	for _, name := range stringList(options["alternative_names"]) {
+		if ip := net.ParseIP(name); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+
+	for _, usage := range stringList(options["extended_key_usage"]) {
+		switch usage {
+		case "server_auth":
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+		case "client_auth":
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageClientAuth)
+		case "code_signing":
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageCodeSigning)
+		case "email_protection":
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageEmailProtection)
+		case "timestamping":
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageTimeStamping)
+		default:
+			return nil, fmt.Errorf("Unsupported extended key usage '%s'", usage)
+		}
+	}
+
+	parent := template
+	var signer interface{} = privateKey
+	if caName != "" {
+		parent, signer, err = certificateAuthority(caName, get)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, template, parent, &privateKey.PublicKey, signer)
+	if err != nil {
+		return nil, err // not tested
+	}
+
+	certificatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}))
+	caPEM := certificatePEM
+	if caName != "" {
+		caPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: parent.Raw}))
+	}
+
+	return map[interface{}]interface{}{
+		"ca":          caPEM,
+		"certificate": certificatePEM,
+		"private_key": encodePrivateKey(privateKey),
+	}, nil
+}
+
+func certificateAuthority(name string, get func(string) (interface{}, bool, error)) (*x509.Certificate, interface{}, error) {
+	value, found, err := get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("Expected to find CA '%s'", name)
+	}
+
+	ca, _ := value.(map[interface{}]interface{})
+	certificatePEM, _ := ca["certificate"].(string)
+	privateKeyPEM, _ := ca["private_key"].(string)
+
+	certificateBlock, _ := pem.Decode([]byte(certificatePEM))
+	if certificateBlock == nil {
+		return nil, nil, fmt.Errorf("Expected CA '%s' to have a PEM encoded certificate", name)
+	}
+
+	certificate, err := x509.ParseCertificate(certificateBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse CA '%s' certificate: %s", name, err)
+	}
+
+	privateKeyBlock, _ := pem.Decode([]byte(privateKeyPEM))
+	if privateKeyBlock == nil {
+		return nil, nil, fmt.Errorf("Expected CA '%s' to have a PEM encoded private key", name)
+	}
+
+	privateKey, err := x509.ParsePKCS1PrivateKey(privateKeyBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse CA '%s' private key: %s", name, err)
+	}
+
+	return certificate, privateKey, nil
+}
+
+func encodePrivateKey(privateKey *rsa.PrivateKey) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	}))
+}
+
+func stringList(value interface{}) []string {
+	list, _ := value.([]interface{})
+
+	var strs []string
+	for _, item := range list {
+		strs = append(strs, fmt.Sprintf("%v", item))
+	}
+
+	return strs
+}
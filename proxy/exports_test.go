@@ -1,6 +1,9 @@
 package proxy
 
-import "net"
+import (
+	"net"
+	"time"
+)
 
 func SetNetListen(f func(net, laddr string) (net.Listener, error)) {
 	netListen = f
@@ -9,3 +12,11 @@ func SetNetListen(f func(net, laddr string) (net.Listener, error)) {
 func ResetNetListen() {
 	netListen = net.Listen
 }
+
+func SetSSHTimeout(timeout time.Duration) {
+	sshTimeout = timeout
+}
+
+func ResetSSHTimeout() {
+	sshTimeout = 30 * time.Second
+}
@@ -30,10 +30,11 @@ func (h HostKeyGetter) Get(key, serverURL string) (ssh.PublicKey, error) {
 			ssh.PublicKeys(signer),
 		},
 		HostKeyCallback: h.keyScanCallback,
+		Timeout:         sshTimeout,
 	}
 
 	go func() {
-		conn, err := ssh.Dial("tcp", serverURL, clientConfig)
+		conn, err := dialSSH(serverURL, clientConfig)
 		if err != nil {
 			h.publicKeyChannel <- nil
 			h.dialErrorChannel <- err
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	socks5 "github.com/armon/go-socks5"
 
@@ -11,7 +12,13 @@ import (
 	"golang.org/x/net/context"
 )
 
-var netListen = net.Listen
+var (
+	netListen = net.Listen
+
+	// sshTimeout bounds connecting and handshaking with the jumpbox, so an
+	// unreachable jumpbox fails instead of hanging.
+	sshTimeout = 30 * time.Second
+)
 
 type Socks5Proxy struct {
 	logger        logger
@@ -58,9 +65,10 @@ func (s *Socks5Proxy) Start(key, url string) error {
 			ssh.PublicKeys(signer),
 		},
 		HostKeyCallback: ssh.FixedHostKey(hostKey),
+		Timeout:         sshTimeout,
 	}
 
-	serverConn, err := ssh.Dial("tcp", url, clientConfig)
+	serverConn, err := dialSSH(url, clientConfig)
 	if err != nil {
 		return err
 	}
@@ -97,6 +105,55 @@ func (s *Socks5Proxy) Addr() string {
 	return fmt.Sprintf("127.0.0.1:%d", s.port)
 }
 
+// CheckPort returns an error when the proxy would not be able to listen on a
+// local port, either the one it was created with or any free one.
+func (s *Socks5Proxy) CheckPort() error {
+	if s.started {
+		return nil
+	}
+
+	if s.port == 0 {
+		_, err := openPort()
+		return err
+	}
+
+	l, err := netListen("tcp", s.Addr())
+	if err != nil {
+		return err
+	}
+
+	return l.Close()
+}
+
+// dialSSH is ssh.Dial with a deadline on the handshake as well as on
+// connecting, since ssh.ClientConfig.Timeout only covers the latter.
+func dialSSH(addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.SetDeadline(time.Now().Add(config.Timeout))
+	if err != nil {
+		conn.Close() // not tested
+		return nil, err
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	err = conn.SetDeadline(time.Time{})
+	if err != nil {
+		c.Close() // not tested
+		return nil, err
+	}
+
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
 func openPort() (int, error) {
 	l, err := netListen("tcp", "localhost:0")
 	if err != nil {
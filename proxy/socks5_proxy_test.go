@@ -132,6 +132,32 @@ var _ = Describe("Socks5Proxy", func() {
 				})
 			})
 
+			Context("when the jumpbox accepts the connection but never responds", func() {
+				var silentServer net.Listener
+
+				BeforeEach(func() {
+					var err error
+					silentServer, err = net.Listen("tcp", "127.0.0.1:0")
+					Expect(err).NotTo(HaveOccurred())
+
+					proxy.SetSSHTimeout(100 * time.Millisecond)
+				})
+
+				AfterEach(func() {
+					silentServer.Close()
+					proxy.ResetSSHTimeout()
+				})
+
+				It("times out instead of hanging", func() {
+					errs := make(chan error)
+					go func() {
+						errs <- socks5Proxy.Start(sshPrivateKey, silentServer.Addr().String())
+					}()
+
+					Eventually(errs, "5s").Should(Receive(MatchError(ContainSubstring("i/o timeout"))))
+				})
+			})
+
 			Context("when it cannot start a socks5 proxy server", func() {
 				var (
 					fakeServer net.Listener
@@ -187,4 +213,61 @@ var _ = Describe("Socks5Proxy", func() {
 			Expect(socks5Proxy.Addr()).To(Equal("127.0.0.1:9999"))
 		})
 	})
+
+	Describe("CheckPort", func() {
+		AfterEach(func() {
+			proxy.ResetNetListen()
+		})
+
+		Context("when the proxy uses any free port", func() {
+			It("checks that a local port can be opened", func() {
+				socks5Proxy := proxy.NewSocks5Proxy(&fakes.Logger{}, &fakes.HostKeyGetter{}, 0)
+
+				var laddr string
+				proxy.SetNetListen(func(network, address string) (net.Listener, error) {
+					laddr = address
+					return net.Listen(network, address)
+				})
+
+				err := socks5Proxy.CheckPort()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(laddr).To(Equal("localhost:0"))
+			})
+		})
+
+		Context("when the proxy uses a specific port", func() {
+			var listener net.Listener
+
+			BeforeEach(func() {
+				var err error
+				listener, err = net.Listen("tcp", "127.0.0.1:0")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				listener.Close()
+			})
+
+			It("returns an error when the port is in use", func() {
+				port := listener.Addr().(*net.TCPAddr).Port
+				socks5Proxy := proxy.NewSocks5Proxy(&fakes.Logger{}, &fakes.HostKeyGetter{}, port)
+
+				err := socks5Proxy.CheckPort()
+				Expect(err).To(MatchError(ContainSubstring("address already in use")))
+			})
+		})
+
+		Context("when netListen fails", func() {
+			It("returns an error", func() {
+				proxy.SetNetListen(func(string, string) (net.Listener, error) {
+					return nil, errors.New("failed to listen")
+				})
+
+				socks5Proxy := proxy.NewSocks5Proxy(&fakes.Logger{}, &fakes.HostKeyGetter{}, 0)
+
+				err := socks5Proxy.CheckPort()
+				Expect(err).To(MatchError("failed to listen"))
+			})
+		})
+	})
 })
@@ -1,7 +1,40 @@
 package storage
 
+const (
+	AWSNATModeGateway      = "gateway"
+	AWSNATModeGatewayPerAZ = "gateway-per-az"
+	AWSNATModeInstance     = "instance"
+)
+
+var AWSNATModes = []string{AWSNATModeGateway, AWSNATModeGatewayPerAZ, AWSNATModeInstance}
+
+// DefaultAWSSpotBidPrice is the most, in US dollars per hour, that the spot
+// vm_extension bids for an instance.
+const DefaultAWSSpotBidPrice = "0.10"
+
 type AWS struct {
 	AccessKeyID     string `json:"accessKeyId,omitempty"`
 	SecretAccessKey string `json:"secretAccessKey,omitempty"`
 	Region          string `json:"region"`
+	NATMode         string `json:"natMode,omitempty"`
+	SpotBidPrice    string `json:"spotBidPrice,omitempty"`
+	VPCLimit        int    `json:"vpcLimit,omitempty"`
+	NATGatewayLimit int    `json:"natGatewayLimit,omitempty"`
+}
+
+// GetNATMode defaults to the NAT instance that environments created before
+// the NAT mode was saved in the state were built with. New environments are
+// given a managed NAT gateway when their state is loaded.
+func (a AWS) GetNATMode() string {
+	if a.NATMode == "" {
+		return AWSNATModeInstance
+	}
+	return a.NATMode
+}
+
+func (a AWS) GetSpotBidPrice() string {
+	if a.SpotBidPrice == "" {
+		return DefaultAWSSpotBidPrice
+	}
+	return a.SpotBidPrice
 }
@@ -14,7 +14,49 @@ type BOSH struct {
 	Variables              string                 `json:"variables"`
 	State                  map[string]interface{} `json:"state"`
 	Manifest               string                 `json:"manifest"`
-	UserOpsFile            string                 `json:"userOpsFile"`
+	ManifestHash           string                 `json:"manifestHash,omitempty"`
+	UserOpsFiles           []OpsFile              `json:"userOpsFiles,omitempty"`
+	CloudConfigOpsFiles    []OpsFile              `json:"cloudConfigOpsFiles,omitempty"`
+	CloudConfigProfile     CloudConfigProfile     `json:"cloudConfigProfile,omitempty"`
+	PreemptibleCompilation bool                   `json:"preemptibleCompilation,omitempty"`
+	RuntimeConfig          RuntimeConfig          `json:"runtimeConfig,omitempty"`
+	DeploymentSource       DeploymentSource       `json:"deploymentSource,omitempty"`
+
+	// VersionsFile pins the releases and stemcell of the director, see
+	// bbl upgrade-director. PreviousManifest is the manifest the director was
+	// created from before its last upgrade.
+	VersionsFile     string `json:"versionsFile,omitempty"`
+	PreviousManifest string `json:"previousManifest,omitempty"`
+
+	// UserOpsFile is only read from state files written before multiple ops
+	// files were supported, GetState moves it into UserOpsFiles.
+	UserOpsFile string `json:"userOpsFile,omitempty"`
+}
+
+type OpsFile struct {
+	Name     string `json:"name"`
+	Contents string `json:"contents"`
+}
+
+// CloudConfigProfile sizes the vm_types of the cloud config. Contents are only
+// set for profiles read from a file, built-in profiles are stored by name.
+type CloudConfigProfile struct {
+	Name     string `json:"name,omitempty"`
+	Contents string `json:"contents,omitempty"`
+}
+
+// RuntimeConfig is the runtime config bbl applies to the director. Like cloud
+// config profiles, contents are only set for runtime configs read from a file.
+type RuntimeConfig struct {
+	Name     string `json:"name,omitempty"`
+	Contents string `json:"contents,omitempty"`
+}
+
+// DeploymentSource records where the bosh-deployment or jumpbox-deployment
+// manifests were read from, either "vendored" or a local directory.
+type DeploymentSource struct {
+	Source string `json:"source"`
+	SHA    string `json:"sha,omitempty"`
 }
 
 func (b BOSH) IsEmpty() bool {
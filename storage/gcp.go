@@ -6,6 +6,9 @@ type GCP struct {
 	Zone              string   `json:"zone"`
 	Region            string   `json:"region"`
 	Zones             []string `json:"zones"`
+	Network           string   `json:"network,omitempty"`
+	Subnetwork        string   `json:"subnetwork,omitempty"`
+	NetworkProject    string   `json:"networkProject,omitempty"`
 }
 
 func (g GCP) Empty() bool {
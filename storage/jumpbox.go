@@ -3,10 +3,13 @@ package storage
 import "reflect"
 
 type Jumpbox struct {
-	URL       string                 `json:"url"`
-	Variables string                 `json:"variables"`
-	Manifest  string                 `json:"manifest"`
-	State     map[string]interface{} `json:"state"`
+	URL              string                 `json:"url"`
+	Variables        string                 `json:"variables"`
+	Manifest         string                 `json:"manifest"`
+	ManifestHash     string                 `json:"manifestHash,omitempty"`
+	State            map[string]interface{} `json:"state"`
+	UserOpsFiles     []OpsFile              `json:"userOpsFiles,omitempty"`
+	DeploymentSource DeploymentSource       `json:"deploymentSource,omitempty"`
 }
 
 func (j Jumpbox) IsEmpty() bool {
@@ -0,0 +1,25 @@
+package storage
+
+const DefaultNetworkCIDR = "10.0.0.0/16"
+
+type Network struct {
+	CIDR    string         `json:"cidr,omitempty"`
+	Private bool           `json:"private,omitempty"`
+	Named   []NamedNetwork `json:"named,omitempty"`
+}
+
+// NamedNetwork is an additional network of the cloud config with subnets and
+// firewall rules of its own.
+type NamedNetwork struct {
+	Name string `json:"name"`
+	CIDR string `json:"cidr"`
+}
+
+// GetCIDR falls back to the network range used by environments that were
+// created before the network CIDR was configurable.
+func (n Network) GetCIDR() string {
+	if n.CIDR == "" {
+		return DefaultNetworkCIDR
+	}
+	return n.CIDR
+}
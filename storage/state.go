@@ -22,6 +22,8 @@ const (
 
 	OS_READ_WRITE_MODE = os.FileMode(0644)
 	StateFileName      = "bbl-state.json"
+
+	LegacyUserOpsFileName = "user-ops-file"
 )
 
 type logger interface {
@@ -37,20 +39,23 @@ type LB struct {
 }
 
 type State struct {
-	Version        int     `json:"version"`
-	IAAS           string  `json:"iaas"`
-	ID             string  `json:"id"`
-	NoDirector     bool    `json:"noDirector"`
-	AWS            AWS     `json:"aws,omitempty"`
-	Azure          Azure   `json:"azure,omitempty"`
-	GCP            GCP     `json:"gcp,omitempty"`
-	KeyPair        KeyPair `json:"keyPair,omitempty"`
-	Jumpbox        Jumpbox `json:"jumpbox,omitempty"`
-	BOSH           BOSH    `json:"bosh,omitempty"`
-	EnvID          string  `json:"envID"`
-	TFState        string  `json:"tfState"`
-	LB             LB      `json:"lb"`
-	LatestTFOutput string  `json:"latestTFOutput"`
+	Version        int               `json:"version"`
+	IAAS           string            `json:"iaas"`
+	ID             string            `json:"id"`
+	NoDirector     bool              `json:"noDirector"`
+	AWS            AWS               `json:"aws,omitempty"`
+	Azure          Azure             `json:"azure,omitempty"`
+	GCP            GCP               `json:"gcp,omitempty"`
+	KeyPair        KeyPair           `json:"keyPair,omitempty"`
+	Network        Network           `json:"network,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	Jumpbox        Jumpbox           `json:"jumpbox,omitempty"`
+	BOSH           BOSH              `json:"bosh,omitempty"`
+	EnvID          string            `json:"envID"`
+	TFState        string            `json:"tfState"`
+	TFFingerprint  string            `json:"tfFingerprint,omitempty"`
+	LB             LB                `json:"lb"`
+	LatestTFOutput string            `json:"latestTFOutput"`
 }
 
 type Store struct {
@@ -145,6 +150,11 @@ func GetState(dir string) (State, error) {
 		return state, fmt.Errorf("Existing bbl environment was created with a newer version of bbl. Please upgrade to a version of bbl compatible with schema version %d.\n", state.Version)
 	}
 
+	if state.BOSH.UserOpsFile != "" {
+		state.BOSH.UserOpsFiles = append([]OpsFile{{Name: LegacyUserOpsFileName, Contents: state.BOSH.UserOpsFile}}, state.BOSH.UserOpsFiles...)
+		state.BOSH.UserOpsFile = ""
+	}
+
 	return state, nil
 }
 
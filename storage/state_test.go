@@ -49,6 +49,7 @@ var _ = Describe("Store", func() {
 						AccessKeyID:     "some-aws-access-key-id",
 						SecretAccessKey: "some-aws-secret-access-key",
 						Region:          "some-region",
+						NATMode:         "gateway-per-az",
 					},
 					Azure: storage.Azure{
 						ClientID:       "client-id",
@@ -69,6 +70,16 @@ var _ = Describe("Store", func() {
 						PrivateKey: "some-private",
 						PublicKey:  "some-public",
 					},
+					Network: storage.Network{
+						CIDR:    "some-network-cidr",
+						Private: true,
+						Named: []storage.NamedNetwork{
+							{Name: "some-network", CIDR: "some-named-network-cidr"},
+						},
+					},
+					Tags: map[string]string{
+						"some-tag": "some-value",
+					},
 					LB: storage.LB{
 						Type:   "some-type",
 						Cert:   "some-cert",
@@ -83,6 +94,13 @@ var _ = Describe("Store", func() {
 						State: map[string]interface{}{
 							"key": "value",
 						},
+						UserOpsFiles: []storage.OpsFile{
+							{Name: "some-jumpbox-ops-file", Contents: "some-jumpbox-ops-file-contents"},
+						},
+						DeploymentSource: storage.DeploymentSource{
+							Source: "/some/jumpbox-deployment",
+							SHA:    "some-jumpbox-deployment-sha",
+						},
 					},
 					BOSH: storage.BOSH{
 						DirectorName:           "some-director-name",
@@ -95,9 +113,23 @@ var _ = Describe("Store", func() {
 						State: map[string]interface{}{
 							"key": "value",
 						},
-						Variables:   "some-vars",
-						Manifest:    "name: bosh",
-						UserOpsFile: "some-ops-file",
+						Variables: "some-vars",
+						Manifest:  "name: bosh",
+						UserOpsFiles: []storage.OpsFile{
+							{Name: "some-ops-file", Contents: "some-ops-file-contents"},
+						},
+						DeploymentSource: storage.DeploymentSource{
+							Source: "vendored",
+							SHA:    "some-bosh-deployment-sha",
+						},
+						CloudConfigProfile: storage.CloudConfigProfile{
+							Name: "dev",
+						},
+						RuntimeConfig: storage.RuntimeConfig{
+							Name: "bosh-dns",
+						},
+						VersionsFile:     "releases: []",
+						PreviousManifest: "name: old-bosh",
 						Credentials: map[string]string{
 							"mbusUsername":              "some-mbus-username",
 							"natsUsername":              "some-nats-username",
@@ -127,7 +159,8 @@ var _ = Describe("Store", func() {
 				"iaas": "aws",
 				"noDirector": false,
 				"aws": {
-					"region": "some-region"
+					"region": "some-region",
+					"natMode": "gateway-per-az"
 				},
 				"azure": {
 					"clientId": "client-id",
@@ -146,6 +179,19 @@ var _ = Describe("Store", func() {
 					"privateKey": "some-private",
 					"publicKey": "some-public"
 				},
+				"network": {
+					"cidr": "some-network-cidr",
+					"private": true,
+					"named": [
+						{
+							"name": "some-network",
+							"cidr": "some-named-network-cidr"
+						}
+					]
+				},
+				"tags": {
+					"some-tag": "some-value"
+				},
 				"lb": {
 					"type": "some-type",
 					"cert": "some-cert",
@@ -159,6 +205,13 @@ var _ = Describe("Store", func() {
 					"manifest": "name: jumpbox",
 					"state": {
 						"key": "value"
+					},
+					"userOpsFiles": [
+						{"name": "some-jumpbox-ops-file", "contents": "some-jumpbox-ops-file-contents"}
+					],
+					"deploymentSource": {
+						"source": "/some/jumpbox-deployment",
+						"sha": "some-jumpbox-deployment-sha"
 					}
 				},
 				"bosh":{
@@ -187,7 +240,21 @@ var _ = Describe("Store", func() {
 					},
 					"variables":   "some-vars",
 					"manifest": "name: bosh",
-					"userOpsFile": "some-ops-file",
+					"userOpsFiles": [
+						{"name": "some-ops-file", "contents": "some-ops-file-contents"}
+					],
+					"deploymentSource": {
+						"source": "vendored",
+						"sha": "some-bosh-deployment-sha"
+					},
+					"cloudConfigProfile": {
+						"name": "dev"
+					},
+					"runtimeConfig": {
+						"name": "bosh-dns"
+					},
+					"versionsFile": "releases: []",
+					"previousManifest": "name: old-bosh",
 					"state": {
 						"key": "value"
 					}
@@ -413,6 +480,28 @@ var _ = Describe("Store", func() {
 			})
 		})
 
+		Context("when there is a state file with a single user ops file", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`{
+					"version": 10,
+					"bosh": {
+						"userOpsFile": "some-ops-file-contents"
+					}
+				}`), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("moves it into the list of user ops files", func() {
+				state, err := storage.GetState(tempDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(state.BOSH.UserOpsFile).To(BeEmpty())
+				Expect(state.BOSH.UserOpsFiles).To(Equal([]storage.OpsFile{
+					{Name: "user-ops-file", Contents: "some-ops-file-contents"},
+				}))
+			})
+		})
+
 		Context("when there is a state file with a newer version than internal version", func() {
 			BeforeEach(func() {
 				err := ioutil.WriteFile(filepath.Join(tempDir, "bbl-state.json"), []byte(`{
@@ -1,8 +1,3 @@
-resource "aws_eip" "jumpbox_eip" {
-  depends_on = ["aws_internet_gateway.ig"]
-  vpc      = true
-}
-
 resource "tls_private_key" "bosh_vms" {
   algorithm = "RSA"
   rsa_bits = 4096
@@ -123,87 +118,6 @@ output "bosh_iam_instance_profile" {
   value = "${aws_iam_instance_profile.bosh.name}"
 }
 
-variable "nat_ami_map" {
-  type = "map"
-
-  default = {
-    ap-northeast-1 = "ami-10dfc877"
-    ap-northeast-2 = "ami-1a1bc474"
-    ap-south-1 = "ami-74c1861b"
-    ap-southeast-1 = "ami-36af2055"
-    ap-southeast-2 = "ami-1e91817d"
-    eu-central-1 = "ami-9ebe18f1"
-    eu-west-1 = "ami-3a849f5c"
-    eu-west-2 = "ami-21120445"
-    us-east-1 = "ami-d4c5efc2"
-    us-east-2 = "ami-f27b5a97"
-    us-gov-west-1 = "ami-c39610a2"
-    us-west-1 = "ami-b87f53d8"
-    us-west-2 = "ami-8bfce8f2"
-  }
-}
-
-resource "aws_security_group" "nat_security_group" {
-  description = "NAT"
-  vpc_id      = "${aws_vpc.vpc.id}"
-
-  ingress {
-    protocol    = "tcp"
-    from_port   = 0
-    to_port     = 65535
-    security_groups = ["${aws_security_group.internal_security_group.id}"]
-  }
-
-  ingress {
-    protocol    = "udp"
-    from_port   = 0
-    to_port     = 65535
-    security_groups = ["${aws_security_group.internal_security_group.id}"]
-  }
-
-  ingress {
-    protocol    = "icmp"
-    from_port   = -1
-    to_port     = -1
-    security_groups = ["${aws_security_group.internal_security_group.id}"]
-  }
-
-  egress {
-    from_port = 0
-    to_port = 0
-    protocol = "-1"
-    cidr_blocks = ["0.0.0.0/0"]
-  }
-
-  tags {
-    Name = "${var.env_id}-nat-security-group"
-  }
-}
-
-resource "aws_instance" "nat" {
-  private_ip             = "10.0.0.7"
-  instance_type          = "t2.medium"
-  subnet_id              = "${aws_subnet.bosh_subnet.id}"
-  source_dest_check      = false
-  ami                    = "${lookup(var.nat_ami_map, var.region)}"
-  vpc_security_group_ids = ["${aws_security_group.nat_security_group.id}"]
-
-  tags {
-    Name = "${var.env_id}-nat",
-    EnvID = "${var.env_id}"
-  }
-}
-
-resource "aws_eip" "nat_eip" {
-  depends_on = ["aws_internet_gateway.ig"]
-  instance = "${aws_instance.nat.id}"
-  vpc      = true
-}
-
-output "nat_eip" {
-  value = "${aws_eip.nat_eip.public_ip}"
-}
-
 variable "access_key" {
   type = "string"
 }
@@ -224,15 +138,15 @@ provider "aws" {
 
 resource "aws_default_security_group" "default_security_group" {
 	vpc_id = "${aws_vpc.vpc.id}"
+
+	tags = "${var.tags}"
 }
 
 resource "aws_security_group" "internal_security_group" {
   description = "Internal"
   vpc_id      = "${aws_vpc.vpc.id}"
 
-  tags {
-    Name = "${var.env_id}-internal-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-internal-security-group"))}"
 }
 
 resource "aws_security_group_rule" "internal_security_group_rule_tcp" {
@@ -292,9 +206,7 @@ resource "aws_security_group" "bosh_security_group" {
   description = "Bosh"
   vpc_id      = "${aws_vpc.vpc.id}"
 
-  tags {
-    Name = "${var.env_id}-bosh-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-bosh-security-group"))}"
 }
 
 output "bosh_security_group" {
@@ -368,9 +280,7 @@ resource "aws_security_group" "jumpbox" {
   description = "automatically created jumpbox by BBL"
   vpc_id      = "${aws_vpc.vpc.id}"
 
-  tags {
-    Name = "${var.env_id}-jumpbox-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-jumpbox-security-group"))}"
 }
 
 output "jumpbox_security_group" {
@@ -431,26 +341,21 @@ resource "aws_security_group_rule" "bosh_internal_security_rule_udp" {
   source_security_group_id = "${aws_security_group.bosh_security_group.id}"
 }
 
-variable "bosh_subnet_cidr" {
-  type    = "string"
-  default = "10.0.0.0/24"
-}
-
 variable "bosh_availability_zone" {
   type = "string"
 }
 
 resource "aws_subnet" "bosh_subnet" {
   vpc_id            = "${aws_vpc.vpc.id}"
-  cidr_block        = "${var.bosh_subnet_cidr}"
+  cidr_block        = "${cidrsubnet(var.vpc_cidr, 8, 0)}"
 
-  tags {
-    Name = "${var.env_id}-bosh-subnet"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-bosh-subnet"))}"
 }
 
 resource "aws_route_table" "bosh_route_table" {
   vpc_id = "${aws_vpc.vpc.id}"
+
+  tags = "${var.tags}"
 }
 
 resource "aws_route" "bosh_route_table" {
@@ -479,34 +384,16 @@ variable "availability_zones" {
 resource "aws_subnet" "internal_subnets" {
   count             = "${length(var.availability_zones)}"
   vpc_id            = "${aws_vpc.vpc.id}"
-  cidr_block        = "${cidrsubnet("10.0.0.0/16", 4, count.index+1)}"
+  cidr_block        = "${cidrsubnet(var.vpc_cidr, 4, count.index+1)}"
   availability_zone = "${element(var.availability_zones, count.index)}"
 
-  tags {
-    Name = "${var.env_id}-internal-subnet${count.index}"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-internal-subnet${count.index}"))}"
 
   lifecycle {
     ignore_changes = ["cidr_block", "availability_zone"]
   }
 }
 
-resource "aws_route_table" "internal_route_table" {
-  vpc_id = "${aws_vpc.vpc.id}"
-}
-
-resource "aws_route" "internal_route_table" {
-  destination_cidr_block = "0.0.0.0/0"
-  instance_id = "${aws_instance.nat.id}"
-  route_table_id = "${aws_route_table.internal_route_table.id}"
-}
-
-resource "aws_route_table_association" "route_internal_subnets" {
-  count          = "${length(var.availability_zones)}"
-  subnet_id      = "${element(aws_subnet.internal_subnets.*.id, count.index)}"
-  route_table_id = "${aws_route_table.internal_route_table.id}"
-}
-
 output "internal_az_subnet_id_mapping" {
 	value = "${
 	  zipmap("${aws_subnet.internal_subnets.*.availability_zone}", "${aws_subnet.internal_subnets.*.id}")
@@ -527,9 +414,13 @@ variable "short_env_id" {
   type = "string"
 }
 
+variable "tags" {
+  type    = "map"
+  default = {}
+}
+
 variable "vpc_cidr" {
   type = "string"
-  default = "10.0.0.0/16"
 }
 
 resource "aws_vpc" "vpc" {
@@ -537,13 +428,13 @@ resource "aws_vpc" "vpc" {
   instance_tenancy     = "default"
   enable_dns_hostnames = true
 
-  tags {
-    Name = "${var.env_id}-vpc"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-vpc"))}"
 }
 
 resource "aws_internet_gateway" "ig" {
   vpc_id = "${aws_vpc.vpc.id}"
+
+  tags = "${var.tags}"
 }
 
 output "vpc_id" {
@@ -559,6 +450,8 @@ resource "aws_flow_log" "bbl" {
 
 resource "aws_cloudwatch_log_group" "bbl" {
   name_prefix = "${var.short_env_id}-log-group"
+
+  tags = "${var.tags}"
 }
 
 resource "aws_iam_role" "flow_logs" {
@@ -607,8 +500,54 @@ EOF
 
 resource "aws_kms_key" "kms_key" {
   enable_key_rotation = true
+
+  tags = "${var.tags}"
 }
 
 output "kms_key_arn" {
   value = "${aws_kms_key.kms_key.arn}"
 }
+
+resource "aws_eip" "jumpbox_eip" {
+  depends_on = ["aws_internet_gateway.ig"]
+  vpc      = true
+
+  tags = "${var.tags}"
+}
+
+resource "aws_eip" "nat_eip" {
+  depends_on = ["aws_internet_gateway.ig"]
+  vpc        = true
+
+  tags = "${var.tags}"
+}
+
+resource "aws_nat_gateway" "nat" {
+  depends_on    = ["aws_internet_gateway.ig"]
+  allocation_id = "${aws_eip.nat_eip.id}"
+  subnet_id     = "${aws_subnet.bosh_subnet.id}"
+
+  tags = "${var.tags}"
+}
+
+output "nat_eip" {
+  value = "${aws_eip.nat_eip.public_ip}"
+}
+
+resource "aws_route_table" "internal_route_table" {
+  vpc_id = "${aws_vpc.vpc.id}"
+
+  tags = "${var.tags}"
+}
+
+resource "aws_route" "internal_route_table" {
+  destination_cidr_block = "0.0.0.0/0"
+  nat_gateway_id = "${aws_nat_gateway.nat.id}"
+  route_table_id = "${aws_route_table.internal_route_table.id}"
+}
+
+resource "aws_route_table_association" "route_internal_subnets" {
+  count          = "${length(var.availability_zones)}"
+  subnet_id      = "${element(aws_subnet.internal_subnets.*.id, count.index)}"
+  route_table_id = "${aws_route_table.internal_route_table.id}"
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type InputGenerator struct {
@@ -47,6 +48,15 @@ func (i InputGenerator) Generate(state storage.State) (map[string]string, error)
 		"region":                 state.AWS.Region,
 		"bosh_availability_zone": "",
 		"availability_zones":     string(zones),
+		"vpc_cidr":               state.Network.GetCIDR(),
+	}
+
+	for _, network := range state.Network.Named {
+		inputs[fmt.Sprintf("network_%s_cidr", network.Name)] = network.CIDR
+	}
+
+	if len(state.Tags) > 0 {
+		inputs["tags"] = terraform.MapVariable(state.Tags)
 	}
 
 	if state.LB.Type == "cf" || state.LB.Type == "concourse" {
@@ -62,6 +62,7 @@ var _ = Describe("InputGenerator", func() {
 				"access_key":             "some-access-key-id",
 				"secret_key":             "some-secret-access-key",
 				"region":                 "some-region",
+				"vpc_cidr":               "10.0.0.0/16",
 				"bosh_availability_zone": "",
 				"availability_zones":     `["z1","z2","z3"]`,
 			}))
@@ -102,6 +103,7 @@ var _ = Describe("InputGenerator", func() {
 				"access_key":                  "some-access-key-id",
 				"secret_key":                  "some-secret-access-key",
 				"region":                      "some-region",
+				"vpc_cidr":                    "10.0.0.0/16",
 				"bosh_availability_zone":      "",
 				"availability_zones":          `["z1","z2","z3"]`,
 				"ssl_certificate":             "some-cert",
@@ -127,6 +129,7 @@ var _ = Describe("InputGenerator", func() {
 					"access_key":                  "some-access-key-id",
 					"secret_key":                  "some-secret-access-key",
 					"region":                      "some-region",
+					"vpc_cidr":                    "10.0.0.0/16",
 					"bosh_availability_zone":      "",
 					"availability_zones":          `["z1","z2","z3"]`,
 					"ssl_certificate":             "some-cert",
@@ -171,6 +174,7 @@ var _ = Describe("InputGenerator", func() {
 				"access_key":                  "some-access-key-id",
 				"secret_key":                  "some-secret-access-key",
 				"region":                      "some-region",
+				"vpc_cidr":                    "10.0.0.0/16",
 				"bosh_availability_zone":      "",
 				"availability_zones":          `["z1","z2","z3"]`,
 				"ssl_certificate":             "some-cert",
@@ -180,6 +184,38 @@ var _ = Describe("InputGenerator", func() {
 		})
 	})
 
+	Context("when tags are provided", func() {
+		It("returns a map with the tags input", func() {
+			inputs, err := inputGenerator.Generate(storage.State{
+				EnvID: "some-env-id",
+				Tags: map[string]string{
+					"team": "core",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["tags"]).To(Equal(`{"team"="core"}`))
+		})
+	})
+
+	Context("when named networks are provided", func() {
+		It("returns a map with the cidr of each network", func() {
+			inputs, err := inputGenerator.Generate(storage.State{
+				EnvID: "some-env-id",
+				Network: storage.Network{
+					Named: []storage.NamedNetwork{
+						{Name: "services", CIDR: "10.0.128.0/20"},
+						{Name: "iso-seg", CIDR: "10.0.144.0/20"},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["network_services_cidr"]).To(Equal("10.0.128.0/20"))
+			Expect(inputs["network_iso-seg_cidr"]).To(Equal("10.0.144.0/20"))
+		})
+	})
+
 	Context("failure cases", func() {
 		Context("when the availability zone retriever fails", func() {
 			It("returns an error", func() {
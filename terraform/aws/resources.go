@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// Resources counts the limited resources that the template generated for
+// state creates.
+func (tg TemplateGenerator) Resources(state storage.State) ec2.Resources {
+	resources := ec2.Resources{VPCs: 1}
+
+	if !state.Network.Private {
+		resources.ElasticIPs++
+	}
+
+	switch state.AWS.GetNATMode() {
+	case storage.AWSNATModeInstance:
+		resources.ElasticIPs++
+	case storage.AWSNATModeGatewayPerAZ:
+		resources.NATGatewaysPerAZ++
+	default:
+		resources.ElasticIPs++
+		resources.NATGateways++
+	}
+
+	switch state.LB.Type {
+	case "cf":
+		resources.LoadBalancers += 3
+	case "concourse":
+		resources.LoadBalancers++
+	}
+
+	return resources
+}
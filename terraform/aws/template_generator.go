@@ -42,10 +42,21 @@ type TemplateData struct {
 	SSLCertificateNameProperty     string
 	IgnoreSSLCertificateProperties string
 	AWSNATAMIs                     map[string]string
+	JumpboxAddress                 string
+	BOSHRouteTableID               string
+	NATSubnet                      string
+	Private                        bool
+	Networks                       []storage.NamedNetwork
 }
 
 type templates struct {
 	base           string
+	jumpboxEIP     string
+	natInstance    string
+	natGateway     string
+	natGatewayAZ   string
+	natSubnet      string
+	namedNetworks  string
 	lbSubnet       string
 	cfLB           string
 	cfDNS          string
@@ -61,6 +72,41 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 	tmpls := readTemplates()
 	tmpl := tmpls.base
 
+	// Without public ips the jumpbox and director reach the internet through
+	// the nat, which then needs a public subnet of its own.
+	jumpboxAddress := "${aws_eip.jumpbox_eip.public_ip}"
+	boshRouteTableID := "${aws_route_table.bosh_route_table.id}"
+	natSubnet := "bosh_subnet"
+	if state.Network.Private {
+		jumpboxAddress = "${cidrhost(aws_subnet.bosh_subnet.cidr_block, 5)}"
+		boshRouteTableID = "${element(aws_route_table.internal_route_table.*.id, 0)}"
+		if state.AWS.GetNATMode() != storage.AWSNATModeGatewayPerAZ {
+			natSubnet = "nat_subnet"
+			tmpl = strings.Join([]string{tmpl, tmpls.natSubnet}, "\n")
+		}
+	} else {
+		tmpl = strings.Join([]string{tmpl, tmpls.jumpboxEIP}, "\n")
+	}
+
+	var ami map[string]string
+	switch state.AWS.GetNATMode() {
+	case storage.AWSNATModeInstance:
+		tmpl = strings.Join([]string{tmpl, tmpls.natInstance}, "\n")
+
+		err := json.Unmarshal([]byte(AMIs), &ami)
+		if err != nil {
+			panic(err)
+		}
+	case storage.AWSNATModeGatewayPerAZ:
+		tmpl = strings.Join([]string{tmpl, tmpls.natGatewayAZ}, "\n")
+	default:
+		tmpl = strings.Join([]string{tmpl, tmpls.natGateway}, "\n")
+	}
+
+	if len(state.Network.Named) > 0 {
+		tmpl = strings.Join([]string{tmpl, tmpls.namedNetworks}, "\n")
+	}
+
 	switch state.LB.Type {
 	case "concourse":
 		tmpl = strings.Join([]string{tmpl, tmpls.lbSubnet, tmpls.concourseLB, tmpls.sslCertificate}, "\n")
@@ -72,19 +118,18 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 		}
 	}
 
-	var ami map[string]string
-	err := json.Unmarshal([]byte(AMIs), &ami)
-	if err != nil {
-		panic(err)
-	}
-
 	templateData := TemplateData{
 		AWSNATAMIs:                   ami,
 		BOSHDescription:              "Bosh",
+		BOSHRouteTableID:             boshRouteTableID,
 		ConcourseDescription:         "Concourse",
 		ConcourseInternalDescription: "Concourse Internal",
 		InternalDescription:          "Internal",
+		JumpboxAddress:               jumpboxAddress,
+		Networks:                     state.Network.Named,
 		NATDescription:               "NAT",
+		NATSubnet:                    natSubnet,
+		Private:                      state.Network.Private,
 		RouterDescription:            "CF Router",
 		RouterInternalDescription:    "CF Router Internal",
 		SSHLBDescription:             "CF SSH",
@@ -95,7 +140,7 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 	}
 
 	t := template.New("descriptions")
-	t, err = t.Parse(tmpl)
+	t, err := t.Parse(tmpl)
 	if err != nil {
 		panic(err)
 	}
@@ -113,6 +158,12 @@ func (tg TemplateGenerator) Generate(state storage.State) string {
 func readTemplates() templates {
 	tmpls := templates{}
 	tmpls.base = string(MustAsset("templates/base.tf"))
+	tmpls.jumpboxEIP = string(MustAsset("templates/jumpbox_eip.tf"))
+	tmpls.natInstance = string(MustAsset("templates/nat_instance.tf"))
+	tmpls.natGateway = string(MustAsset("templates/nat_gateway.tf"))
+	tmpls.natGatewayAZ = string(MustAsset("templates/nat_gateway_per_az.tf"))
+	tmpls.natSubnet = string(MustAsset("templates/nat_subnet.tf"))
+	tmpls.namedNetworks = string(MustAsset("templates/named_networks.tf"))
 	tmpls.lbSubnet = string(MustAsset("templates/lb_subnet.tf"))
 	tmpls.concourseLB = string(MustAsset("templates/concourse_lb.tf"))
 	tmpls.sslCertificate = string(MustAsset("templates/ssl_certificate.tf"))
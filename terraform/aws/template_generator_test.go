@@ -2,7 +2,9 @@ package aws_test
 
 import (
 	"io/ioutil"
+	"regexp"
 
+	"github.com/cloudfoundry/bosh-bootloader/aws/ec2"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform/aws"
 
@@ -27,6 +29,9 @@ var _ = Describe("TemplateGenerator", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				template := templateGenerator.Generate(storage.State{
+					AWS: storage.AWS{
+						NATMode: "gateway",
+					},
 					LB: storage.LB{
 						Type:   lbType,
 						Domain: domain,
@@ -34,11 +39,151 @@ var _ = Describe("TemplateGenerator", func() {
 				})
 
 				Expect(template).To(Equal(string(expectedTemplate)))
+				expectSecurityGroupsDeclared(template)
 			},
 			Entry("when no lb type is provided", "fixtures/template_no_lb.tf", "", ""),
 			Entry("when a concourse lb type is provided", "fixtures/template_concourse_lb.tf", "concourse", ""),
 			Entry("when a cf lb type is provided", "fixtures/template_cf_lb.tf", "cf", ""),
 			Entry("when a cf lb type is provided with a system domain", "fixtures/template_cf_lb_with_domain.tf", "cf", "some-domain"),
 		)
+
+		DescribeTable("generates a terraform template for the nat mode",
+			func(fixtureFilename, natMode string) {
+				expectedTemplate, err := ioutil.ReadFile(fixtureFilename)
+				Expect(err).NotTo(HaveOccurred())
+
+				template := templateGenerator.Generate(storage.State{
+					AWS: storage.AWS{
+						NATMode: natMode,
+					},
+				})
+
+				Expect(template).To(Equal(string(expectedTemplate)))
+				expectSecurityGroupsDeclared(template)
+			},
+			Entry("when the nat mode is gateway", "fixtures/template_no_lb.tf", "gateway"),
+			Entry("when the nat mode is gateway-per-az", "fixtures/template_nat_gateway_per_az.tf", "gateway-per-az"),
+			Entry("when the nat mode is instance", "fixtures/template_nat_instance.tf", "instance"),
+			Entry("when the state has no nat mode", "fixtures/template_nat_instance.tf", ""),
+		)
+
+		Context("when the network is private", func() {
+			It("uses the internal address of the jumpbox instead of an elastic ip", func() {
+				expectedTemplate, err := ioutil.ReadFile("fixtures/template_private.tf")
+				Expect(err).NotTo(HaveOccurred())
+
+				template := templateGenerator.Generate(storage.State{
+					AWS: storage.AWS{
+						NATMode: "gateway",
+					},
+					Network: storage.Network{
+						Private: true,
+					},
+					LB: storage.LB{
+						Type:   "cf",
+						Domain: "some-domain",
+					},
+				})
+
+				Expect(template).To(Equal(string(expectedTemplate)))
+				expectSecurityGroupsDeclared(template)
+			})
+		})
+
+		Context("when the network is private and uses a nat instance", func() {
+			It("moves the nat instance into a public subnet that the jumpbox and director route through", func() {
+				template := templateGenerator.Generate(storage.State{
+					AWS: storage.AWS{
+						NATMode: "instance",
+					},
+					Network: storage.Network{
+						Private: true,
+					},
+				})
+
+				Expect(template).To(ContainSubstring(`resource "aws_subnet" "nat_subnet" {`))
+				Expect(template).To(ContainSubstring(`  subnet_id              = "${aws_subnet.nat_subnet.id}"`))
+				Expect(template).To(ContainSubstring(`  route_table_id = "${element(aws_route_table.internal_route_table.*.id, 0)}"`))
+				Expect(template).To(ContainSubstring(`security_groups = ["${aws_security_group.internal_security_group.id}", "${aws_security_group.bosh_security_group.id}", "${aws_security_group.jumpbox.id}"]`))
+				expectSecurityGroupsDeclared(template)
+			})
+		})
+
+		Context("when the network is private and uses a nat gateway per az", func() {
+			It("routes the bosh subnet through the nat gateways in the nat subnets", func() {
+				template := templateGenerator.Generate(storage.State{
+					AWS: storage.AWS{
+						NATMode: "gateway-per-az",
+					},
+					Network: storage.Network{
+						Private: true,
+					},
+				})
+
+				Expect(template).NotTo(ContainSubstring(`resource "aws_subnet" "nat_subnet" {`))
+				Expect(template).To(ContainSubstring(`  route_table_id = "${element(aws_route_table.internal_route_table.*.id, 0)}"`))
+			})
+		})
+
+		Context("when named networks are provided", func() {
+			It("creates subnets and a security group for each network that the nat instance accepts traffic from", func() {
+				expectedTemplate, err := ioutil.ReadFile("fixtures/template_named_networks.tf")
+				Expect(err).NotTo(HaveOccurred())
+
+				template := templateGenerator.Generate(storage.State{
+					AWS: storage.AWS{
+						NATMode: "instance",
+					},
+					Network: storage.Network{
+						Named: []storage.NamedNetwork{
+							{Name: "services", CIDR: "10.0.128.0/20"},
+							{Name: "iso-seg", CIDR: "10.0.144.0/20"},
+						},
+					},
+				})
+
+				Expect(template).To(Equal(string(expectedTemplate)))
+				expectSecurityGroupsDeclared(template)
+			})
+		})
+	})
+
+	Describe("Resources", func() {
+		DescribeTable("counts the limited resources the template creates",
+			func(natMode string, private bool, lbType string, expected ec2.Resources) {
+				resources := templateGenerator.Resources(storage.State{
+					AWS:     storage.AWS{NATMode: natMode},
+					Network: storage.Network{Private: private},
+					LB:      storage.LB{Type: lbType},
+				})
+
+				Expect(resources).To(Equal(expected))
+			},
+			Entry("when the nat mode is gateway", "gateway", false, "", ec2.Resources{VPCs: 1, ElasticIPs: 2, NATGateways: 1}),
+			Entry("when the nat mode is gateway-per-az", "gateway-per-az", false, "", ec2.Resources{VPCs: 1, ElasticIPs: 1, NATGatewaysPerAZ: 1}),
+			Entry("when the nat mode is instance", "instance", false, "", ec2.Resources{VPCs: 1, ElasticIPs: 2}),
+			Entry("when the state has no nat mode", "", false, "", ec2.Resources{VPCs: 1, ElasticIPs: 2}),
+			Entry("when the network is private", "gateway", true, "", ec2.Resources{VPCs: 1, ElasticIPs: 1, NATGateways: 1}),
+			Entry("when there are cf load balancers", "gateway", false, "cf", ec2.Resources{VPCs: 1, ElasticIPs: 2, NATGateways: 1, LoadBalancers: 3}),
+			Entry("when there is a concourse load balancer", "gateway", false, "concourse", ec2.Resources{VPCs: 1, ElasticIPs: 2, NATGateways: 1, LoadBalancers: 1}),
+		)
 	})
 })
+
+var (
+	securityGroupResource  = regexp.MustCompile(`resource "aws_security_group" "([^"]+)"`)
+	securityGroupReference = regexp.MustCompile(`\$\{aws_security_group\.([^.}]+)\.`)
+)
+
+// expectSecurityGroupsDeclared checks that every security group the template
+// refers to is a resource of the template, rather than an output.
+func expectSecurityGroupsDeclared(template string) {
+	declared := map[string]bool{}
+	for _, match := range securityGroupResource.FindAllStringSubmatch(template, -1) {
+		declared[match[1]] = true
+	}
+
+	for _, match := range securityGroupReference.FindAllStringSubmatch(template, -1) {
+		Expect(declared).To(HaveKey(match[1]), "aws_security_group.%s is not declared", match[1])
+	}
+}
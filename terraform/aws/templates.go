@@ -4,7 +4,13 @@
 // templates/cf_dns.tf
 // templates/cf_lb.tf
 // templates/concourse_lb.tf
+// templates/jumpbox_eip.tf
 // templates/lb_subnet.tf
+// templates/named_networks.tf
+// templates/nat_gateway.tf
+// templates/nat_gateway_per_az.tf
+// templates/nat_instance.tf
+// templates/nat_subnet.tf
 // templates/ssl_certificate.tf
 // DO NOT EDIT!
 
@@ -73,7 +79,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBaseTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x5b\x5f\x6f\xdb\x38\x12\x7f\x8e\x3f\x05\x21\xf4\xa1\xed\x39\x6a\x92\x6d\xb2\xbd\x60\xf3\x90\xb6\xb9\xbb\x1e\x7a\xdd\x22\x09\xf6\x1e\x8a\x42\xa0\x29\x5a\xe6\x45\x22\x05\x92\x72\xea\x1a\xfa\xee\x07\xfe\xd3\x7f\x59\xb2\x9b\x34\x09\x9a\xc5\xee\x26\xe4\x70\x66\xf8\xe3\x70\x66\x48\x0d\x39\x16\x2c\xe3\x08\x03\x0f\xde\x8a\x00\x93\xd4\x03\xde\xff\xb2\x24\x9d\xb1\x6f\xe6\xaf\xf5\x04\x80\x10\xa7\x98\x86\x22\x60\x14\x9c\x81\x2f\x9a\x92\x50\x89\x39\xc5\x32\x88\xa0\xc4\xb7\x70\xe5\x93\xc8\xfb\x3a\x01\x60\x99\x22\xa0\x7f\xce\x80\xe4\x19\x9e\xe4\x93\x49\x29\x42\xc6\x22\x48\x39\x59\x42\x89\x83\x1b\xbc\xf2\x80\x37\x63\x62\x11\x2c\x13\x61\xe4\xc0\x38\x62\x9c\xc8\x45\x02\xce\x80\x77\x79\x75\xee\x4d\x00\xe0\x02\x06\x33\x22\x05\x38\x03\xaf\x0f\xfe\x7e\x52\x67\xa8\x34\xb9\xc1\xab\x20\x85\x84\xb7\xb8\xa9\x0e\x0a\x13\xac\x98\x3d\x5b\x2f\x21\xf7\x31\x5d\x06\x24\xcc\x83\x82\x6e\x02\x40\x9a\xcd\x62\x82\x14\x17\x43\xd7\xd0\xd1\x77\xb4\x7e\x49\x18\xb0\x14\x53\x21\x16\xb9\xa7\xb4\x61\x99\x4c\x33\x59\x0a\x0f\x9c\x5c\xa3\xc5\x12\xc6\x99\x55\xa1\xaa\x6d\xc9\xd7\x91\xf7\x70\xab\xe1\xd5\x60\xd8\xaf\x6b\xd9\x18\xa4\x38\xc9\xd5\x44\x05\xa6\x82\x48\xb2\xc4\x95\xa5\x71\xd2\xf0\x37\xb5\x9a\x30\x0e\xdc\x8a\x37\xb4\xc6\x24\xf5\x2b\x56\xe1\xb0\x20\x69\x5d\x69\x47\x92\xf1\xd8\xb0\xd9\x82\xd1\xe9\xd1\x51\x8d\x57\x48\x38\x46\x92\xf1\x00\x86\x21\xc7\x42\x34\xf4\x5a\x48\x99\x8a\xd3\x57\xaf\x86\xd9\x1e\x1f\x1f\x1f\x7b\x6d\xb3\x21\x30\x09\x38\x8b\xb1\x35\x1b\xc3\x7e\x83\xb9\x68\x5a\x65\x2f\x50\x2e\x14\xc9\x2b\xf5\x47\x4c\xe6\x18\xad\x50\x8c\xed\x6c\x11\xc7\x0a\xf6\x19\x9e\x33\x8e\x83\x10\x0b\xc9\xd9\xca\xe1\x0d\x40\x3e\x51\x46\x2e\x44\x96\x60\xcd\x2f\x48\x59\x4c\x90\x22\xf8\xe3\x8f\x8b\x3f\xff\x31\x51\x4c\xbc\xbf\x30\x17\x84\x51\xef\x14\x78\x47\x07\x87\x47\xfb\x87\x07\xfb\x87\xbf\x7b\x53\xd5\x75\x25\xa1\xc4\x09\xa6\xd2\x3b\x05\x5f\xb4\x40\x23\x16\x00\xef\x1c\x49\x3b\x48\x48\x71\x7a\xae\x65\x5c\x2a\x95\xa7\x8e\xe2\x33\x27\x14\x91\x14\xc6\xde\x69\x31\x4c\xf1\xc4\x7c\x49\x10\x56\x23\x31\x3a\xf2\x61\x02\xbf\x33\x0a\x6f\x85\x8f\x58\xe2\x59\xb2\xbc\x60\x72\x31\x9f\x63\xa4\xc4\x7b\xe7\x71\xcc\x6e\x4b\xee\x57\x24\x54\xad\x66\x44\x3e\x01\xe0\xeb\x24\x9f\xa8\x39\x75\x02\x6f\xe6\xdd\x86\x1e\xf4\x80\x6f\xe9\x1d\xfc\xa0\x58\x80\x7b\x00\xf0\x4b\x89\x0d\x46\x47\x0a\x4a\x86\x08\x94\xf8\xdc\xda\xe1\xb4\xd1\x2f\x25\x44\x8b\xbf\x58\x9c\x25\xb8\xd9\xf7\x4e\x9b\x43\x77\xdf\x7b\x1c\x63\x89\xaf\x28\x4c\xc5\x82\xc9\xee\xde\xbe\x91\x02\x71\x32\x73\x0a\xe1\x96\x4a\x8e\xe0\x43\x02\xa3\x0d\xbd\x54\x48\x48\x51\x3f\xc1\x25\x8e\x08\xa3\xbd\xdd\x57\x18\x65\x9c\xc8\xd5\x3f\x39\xcb\xd2\x7e\x2a\x3b\xc1\x7e\x82\x6c\x46\x71\x7f\xb7\x81\xa0\xa3\x7b\x08\xf5\x3e\x64\x4d\xef\x35\x8c\x5a\x3c\x2f\x33\xda\x8b\xc9\x35\xe6\x09\xa1\x50\xf6\xa3\xa6\xd0\x12\x12\x73\x0d\x7a\x5b\x5d\x5e\xeb\x9e\xec\x01\xf0\x75\xaa\xfe\xdb\xb1\xa3\x54\xeb\xa5\xdd\x32\xaa\xfd\xa5\xdd\x54\xd3\xc9\xde\x5a\x77\x56\x4c\x75\x4f\x8b\x20\x30\x39\xfd\x0c\x85\xd0\x1b\x7e\x2b\xde\xc6\x7d\x3a\x5f\xa8\x03\x88\x0f\x39\xcd\xbd\xc9\xde\x06\x79\x38\x86\x42\x12\x14\x33\x18\xce\x60\x0c\x29\x22\x34\x3a\x7d\xb9\xf5\xac\xf6\x06\xfd\x44\xc5\x49\x06\x50\x6f\x34\xbd\x79\xab\x7e\x43\x91\x0c\xb9\x6c\xcb\x80\xd3\x32\x10\x95\x5e\xa8\x3a\xe9\x4e\x25\x88\x5d\xf2\x20\xe5\x6c\x4e\x1a\x11\xa3\x14\xdf\x06\xb2\x27\xaa\x77\xf3\xec\x88\xba\x5d\x84\x4d\xce\x4b\xc8\x09\x9c\xc5\x18\x78\x14\xca\x00\x26\x24\x48\xa0\x8d\xe1\x72\x95\x6a\x66\xaa\x61\xa2\xb3\xb8\x39\xcc\x62\x09\xce\x74\xef\x7a\xcd\x21\x8d\x30\x78\x76\x83\x57\x53\xf0\xcc\x88\x3e\x3d\x03\xfe\xf9\x7f\xaf\x3e\x9d\x5f\x9f\xff\xe7\x83\xc8\x73\x45\xa6\x08\xf2\x5c\x31\x5a\xaf\x0d\x59\xae\xf3\x89\xf5\x1a\xd3\x30\xcf\xf3\x36\x68\xc2\x7a\x86\x20\x52\xae\xc1\x33\xaa\x35\x1b\x4d\x5e\xa9\x36\x79\xaa\xac\xcb\xf0\xf7\x3f\x9d\x5f\xbf\x2f\x1b\x8d\xa0\x65\x8a\x02\x12\x9a\xdd\x54\x60\xb3\x4c\x91\xaf\xfe\x25\x61\xae\x27\x47\x68\xa4\x9c\xa1\xf5\xe7\x29\x67\x92\x21\x16\xdb\x21\x12\xa5\x66\x0f\xcd\x39\x53\xcb\xce\xa5\x6e\x3f\xd0\x6d\x92\xb9\x16\xd5\x76\x72\x7c\xfc\xdb\xb1\x6e\xaf\x2b\x2c\x74\xe6\x6b\x64\xd7\x7b\x7c\x93\x0a\xc3\xb8\xd5\x1e\xe6\x3a\x25\xce\x07\xf5\xcb\xc2\xc7\xad\x1f\x41\x49\xa7\x82\xfb\x87\x1d\x1a\xda\xc6\xbb\x55\x0f\x57\xb5\x2b\x95\x68\x62\xe4\xfe\x2e\xf4\x3f\x03\xde\xfe\xa1\x51\x1d\x91\x90\x07\xb3\x98\xa1\x1b\xa3\xcc\x81\xaf\xff\x79\x75\x50\x4a\x91\x30\x72\x32\x3e\x75\x65\x82\xfb\x14\xca\x7d\xa7\xe6\xbe\x31\x64\x3d\xb6\xed\x37\xec\xb6\x35\xc6\x6f\xac\xdd\x25\xe5\x24\x05\xd5\x9f\x33\xe0\x1d\x1a\x5d\x7e\xf7\xf4\x42\xd8\x0d\xaf\xb7\x6f\x95\x4a\x1e\xf9\x09\x0e\x49\xa6\xd3\x32\xa1\xc3\x66\xb1\x2f\x2a\x64\x16\x64\xdd\x6f\x4e\x04\xf6\x77\xbd\x55\x00\x30\x6a\xea\xe4\x34\x40\x0b\x8c\x6e\xdc\xc8\x39\x8c\x85\xca\x52\x61\x42\x40\xc7\x8f\x66\x1d\x33\x76\x93\xa5\xcf\x15\x26\x15\x7f\x33\x05\xaa\x81\xeb\x7c\xe1\x45\xb1\x67\xeb\xeb\x19\x90\x70\x83\x11\xb4\x3d\x84\x5d\xff\x91\xab\x62\x03\xee\x05\x5d\x7e\x78\xdf\x22\xe8\x59\x23\x73\xd8\x55\x92\x77\x39\xe8\xba\x75\xaa\xb8\x6b\xdb\xa2\x26\xe3\xc0\xee\x38\x0e\xbb\x58\x50\x13\xdc\x71\x4c\xb2\xfd\xcd\xb3\x56\xe9\xf0\x21\x42\x58\x88\xf2\x60\xe8\xfc\xbd\x90\x9c\xd0\xa8\x41\x2c\x30\xe2\x58\x8e\x24\x36\x2b\xd9\x4b\x98\x72\xb6\x24\x21\xe6\x1a\x46\x7b\x72\x2f\x74\x29\xd1\x2f\xdb\xec\xf9\xd3\x69\x50\x92\x94\x6d\x9a\xc4\xc8\x2d\xad\xad\xb4\xaa\xae\xd8\x6c\xe3\x59\x3b\xdc\xf4\x75\xac\x27\x7b\x36\x96\x74\x87\x91\xe1\x40\xd6\xe3\xab\xfa\xa2\xd9\x07\x4b\xbe\x5b\x48\x1b\xb4\x7b\xa7\xcd\x38\x97\xd4\xd8\x8e\x3c\xd3\x99\x4c\xcf\x8c\x74\x77\xa0\xe2\xa6\xd6\xa0\xb5\x95\xdb\xfe\x66\xbc\x53\x77\x46\xd5\xe3\x64\x6c\x24\xd2\x89\x5b\x25\x0c\x35\xc9\x6c\x4c\xaf\x06\xa4\x06\x89\x0a\x05\xd5\xd0\xd4\xe8\x76\x91\x54\xe0\x78\xde\xa3\x4b\xfb\x06\x6b\x47\x20\x55\x80\x7f\xac\x40\xda\xe4\xe3\x69\x00\xa9\x33\x91\xc7\x8a\xa4\x4b\x93\x36\x40\xa9\x93\xa3\x0d\x58\xea\xfe\x6a\xa6\xd2\xe8\xaf\xa7\x2d\x77\x81\x28\x54\xe7\xb3\x22\xca\xfd\x7c\x6c\xf1\x28\x68\x4d\x12\xb7\xbb\x8d\x1e\xfc\x6c\x58\x85\x3b\x20\x3e\x42\x3b\xbd\x7e\xf7\x79\x00\xcd\xa3\xa3\xcd\x70\xea\x7e\x9b\x48\xb6\x27\xd8\x37\x33\x7b\x43\x5b\x04\x5b\x97\x09\x6d\x8c\xaa\x3a\x33\x3a\xdb\x01\xaa\x5a\x46\x63\x0e\xde\x74\xc6\x32\x1a\x06\xca\x10\x5c\xc8\x76\x47\xe2\x8a\x01\x8c\xc8\x03\x4c\x5a\x3d\x2a\x07\x78\xfb\xe7\xd5\xbf\xee\x29\xfe\x2b\x2d\xfa\x62\x7f\xed\xc6\x61\x5b\x5c\x3b\x06\x8d\xca\x90\xdc\xce\xe8\x18\x5f\x24\x14\x3f\xb0\x33\x7a\xd5\xfa\x49\x09\xc5\xa8\x5d\xb1\xd1\xcb\x98\xf5\x6b\x19\x63\x3e\xde\xe9\x6c\x84\x56\x77\xc2\x48\x5f\x91\x3d\x49\x84\x4f\xde\x9c\xbc\x19\x48\x36\x0c\xc5\x43\xa1\x9c\x41\xf8\x44\xa1\x7d\xf3\xfa\xf5\x6f\x9b\xa1\xb5\x14\x0f\x69\xc0\xe5\x47\xbf\x94\x3c\x51\x9c\xf5\xf7\xc6\x01\x3f\x61\x49\x1e\x10\xe9\x27\x0a\xee\xd8\x93\xc8\xb6\x99\xc9\x50\x22\xf1\x63\x3e\x63\xe7\xc3\xdf\xfd\xc2\x7d\x77\x07\xbf\x47\x05\xf7\x9d\x1c\x68\x76\x44\xfe\xe9\x1d\x66\xca\x72\x9f\xce\x04\x16\x66\x92\x25\x50\x12\x04\xe3\x78\x65\xcb\x1b\x42\x60\x47\x80\xd9\x0a\xbc\x7d\xfb\xf1\xee\x12\x5a\xcb\x77\x28\xa7\x75\x95\x1e\xdb\xa6\xb5\xcd\xf3\xc7\x18\x33\x2b\x64\xed\x9c\xb5\xd6\xa4\xfe\x42\x99\xaa\x43\xee\x47\xf2\xd1\x87\xc0\xee\xb1\xe4\xa0\x0e\x3f\x97\x13\x3d\x21\x08\x1f\x4f\x0e\x54\x14\x85\x45\x65\x0d\xd9\x7d\x42\xf8\xf4\x02\x40\x2d\x9e\xb6\x03\xf3\x2f\xf4\x1d\x60\xdb\x2c\xe6\x4e\x6e\x49\x7a\x10\xff\x35\x3e\x18\xdc\x25\xe2\x8d\xbb\x3e\xfb\x7d\xbc\xbc\xea\x73\x13\xaf\x7c\xca\xac\xdd\xff\x1d\xba\x3d\x73\xf4\xba\x8b\x1f\x5c\x42\x12\xc3\x19\x89\x95\xe4\xef\x8c\xe2\xde\xaf\xa3\x8d\xa5\xd7\x7a\x78\x35\xad\x6c\xa6\x52\x49\x99\x1a\x4b\x5a\x4b\x9c\xaa\x5b\xbd\x46\x59\xf8\xc6\xca\x5c\xb7\xba\x39\x34\xca\x74\x7f\x2d\xe4\x2c\x93\x38\x90\x0a\x01\xa7\x7c\xad\xa9\x32\x83\x91\x1f\x53\xf5\xf0\x5e\x5e\x21\x16\x92\x50\xa8\xf2\xce\xa0\x32\xdf\xfa\xc5\x2c\x00\xf6\x23\x7c\x4d\x6c\xc7\x17\x7a\x87\x5c\x45\x4c\x6d\x48\xa5\xdd\x6f\xea\xb3\x49\x7d\xcb\x0a\xda\x9a\x50\xfd\x91\xdc\x33\x3d\x95\x95\x70\x71\xa6\x5e\xa2\x31\xa2\x34\xe3\x87\xd4\xad\xdf\xf8\x3a\xd9\x9d\xe5\x05\x7d\x1a\xf4\x70\xe9\xb1\xfd\x61\xa6\xad\x81\xad\x12\x86\x26\x81\xa8\x6f\xab\x98\x08\xb9\x69\x53\x95\xce\xae\x0a\x3c\x62\x19\x95\x4d\xaf\xf5\x6c\x1d\x63\x1a\xc9\x85\xae\x5d\x69\xcb\x7d\xd1\xba\x97\xdf\x69\x4f\xaa\x66\xa3\xcb\xf3\xd2\xa3\x1c\x9e\x78\x53\xf0\x7a\x6a\xf4\xf2\x09\x0d\xf1\xb7\xbf\x1d\x1a\x81\x2d\x45\x0c\x1b\x1c\xeb\x2a\xe5\x1e\x5d\x6b\x9c\x5e\x6c\x5d\x2c\xa0\xd5\x7b\xb6\xae\xf0\xb0\x15\x32\x1d\x05\xed\x24\xa2\x8c\xe3\x00\x2d\x20\x8d\xb0\xa9\xdf\x29\x67\xee\x4d\x3b\x56\xd0\x56\x53\x0d\x38\x94\x62\xe1\xee\xc8\xa9\xf4\xf3\x1b\xe9\x58\x8a\xb2\xab\xba\x67\x69\x57\xf4\x8c\xd9\xa3\x5d\xda\xec\xe8\x56\x46\x59\xf8\x58\xf3\xee\xf2\x48\xce\xd4\x2a\x5b\xb8\x29\xd3\x7f\xe9\x93\xb0\x65\x74\x77\x00\x45\xeb\x03\x20\xfc\x5e\x7a\xae\x20\x81\x69\xaa\x82\xaa\xae\xdb\x29\x7d\xcd\x64\x0f\x80\xef\x24\x4d\x60\xfa\xbc\xee\x79\x3a\xd4\xee\x70\x40\x53\x30\x38\x4a\xa9\xf7\x62\xb2\x37\xa8\xa3\x36\xa7\x07\xd3\xb2\x34\xe6\x8a\xb6\xa5\x6b\x35\xfb\x7e\x4c\x65\xd8\x82\x71\x19\x8c\x26\x57\xfb\xb3\x91\x55\x0d\xa6\x54\x87\x27\x1d\x96\xbf\x4c\x91\xa7\xd9\x59\x93\x6e\x39\xd4\x6a\x9e\xe3\xa4\xe6\xf5\x12\x49\x4c\x21\x45\x2b\x47\x6a\x45\x2b\x12\x4c\xb5\x55\x86\x54\x04\x0b\x26\x24\x85\x89\xf6\x5e\xba\x0e\x65\x8c\xb7\x54\x6a\xf5\x55\x76\xd6\xb3\x0d\xe5\x7c\xa2\x71\xae\xcb\x99\x92\xa1\xeb\x0c\xa2\x9b\xbd\xdd\x3c\x66\xb7\x41\xcc\x22\x95\x45\xcd\xec\x13\xab\x98\x45\x36\x71\x2e\x1f\x2f\x29\x5a\x14\xb3\x2c\xbc\x85\x12\x2d\x82\x82\xc4\x9f\xcd\x62\x57\x3b\x0e\x40\x51\x60\x0f\x39\xad\x46\xba\xa2\x88\xdd\x89\x13\xb6\x3a\xbe\x15\x1f\xfb\x82\xa3\xe4\x70\x3e\x27\xc8\x95\xb0\x9e\x01\xef\xf2\xe2\xdf\x17\xef\xae\x3b\xa6\xd4\xa5\x66\x75\x7a\x4a\xdb\x20\xe5\x78\x4e\xbe\x55\xca\x06\x2b\x26\x9b\xef\xc7\x2c\x72\x17\x85\x9b\x5e\x79\x15\xb3\xd9\xf0\xd4\x6b\x5f\x11\x29\x86\x62\xdf\x3c\x1e\xb8\xb7\xf7\x5a\xee\xbd\xd4\xf0\xcb\xaa\xe1\x77\x5b\xcb\x14\x95\x8a\x0f\xbd\xe0\xea\x7d\x28\x36\xee\xe5\x56\x05\x86\xed\x31\x2d\x9f\x71\xf5\x3c\x9b\x28\x2d\xce\xdd\x19\xdf\xef\x03\x2f\x25\xca\xbe\x08\xfa\xc8\x22\xfd\x92\xa9\xfa\x74\xa7\xde\x7d\x25\x39\x86\x49\xab\xff\x73\x26\x3f\xb2\xe8\x62\x89\x69\xfd\x15\x93\xee\x74\xcf\x98\x1c\xf7\x8d\x14\x46\x80\x70\x6b\xf6\x75\xd8\x36\xba\x9e\x09\x6d\x5a\xc1\x9b\xc4\xd6\x0b\x7b\xc5\x6f\xeb\xd2\x5b\xde\xe0\x55\xc0\x99\x84\xf6\xf2\xbf\x59\xb0\x6c\x87\x28\x77\xd1\xfd\xb4\xd5\xf4\xfb\xee\xff\xee\x49\xcd\xff\x03\x00\x00\xff\xff\x6b\x90\xe2\x47\x63\x3c\x00\x00")
+var _templatesBaseTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x5b\xdd\x6f\xdb\x38\x12\x7f\x8e\xfe\x0a\x82\xe8\xc3\xb6\xe7\xb8\x49\xb6\xe9\xf5\x82\xcd\x43\xbf\xee\xae\x8b\xe2\xb6\x48\x8a\x7d\x29\x02\x82\xa6\x68\x99\x57\x89\x14\x48\xca\x6d\x6a\xf8\x7f\x3f\x0c\x45\xea\x5b\xb6\xd3\x7c\xdf\x3a\xbb\x69\xc2\x19\xce\xc7\x8f\x33\xc3\xb1\x3c\xd1\xdc\xa8\x42\x33\x8e\xb0\x4d\x0d\xc9\xb5\x58\x52\xcb\xc9\x57\x7e\x89\x11\x9e\x29\xb3\x20\xcb\xcc\x60\xb4\x8a\x10\xa2\x69\xa2\xb4\xb0\x8b\x0c\x9d\x22\x7c\x76\xfe\x1a\x47\x08\x69\x43\xc9\x4c\x58\x83\x4e\xd1\x8b\x83\x7f\xbc\x8c\xd6\x51\x54\x0b\xa4\xdf\x0c\x08\x22\x39\x15\xba\x27\x0d\x08\x92\x66\x1c\x84\x3d\x59\x2d\xa9\x9e\x72\xb9\x24\x22\x5e\x93\x8a\x2f\x42\x28\x2f\x66\xa9\x60\x20\xa5\xe4\xeb\xd8\x38\x0d\xbc\xd3\x9a\x91\xa8\x9c\x4b\x63\x16\x6b\x0c\xd6\xa8\xc2\xe6\x85\xad\x95\x93\xa0\xb7\xf4\x69\x49\xd3\xc2\x9b\xd0\xb4\xb6\x96\x1b\xd8\x47\xa4\xb5\xf0\xea\x08\x1c\xb7\xb5\x5e\x24\x39\xcf\xd6\x00\xa4\xe1\xd2\x08\x2b\x96\xb0\xd7\xea\x82\x37\xb5\xf1\xef\x96\x6b\x49\x53\x22\xf2\x8e\x92\xd5\x6a\xfa\x7b\x91\xe5\x33\xf5\xfd\x75\x1c\x6b\x6e\xcc\xba\x6d\xe7\x7f\x4b\x22\x29\x74\x5a\xee\xdc\xbc\xf7\xe4\xe8\xa8\xb5\x3d\x16\x9a\x33\xab\x34\xa1\x25\x47\x47\xfb\xc2\xda\xdc\x9c\x3c\x7f\x3e\x28\xe9\xf8\xf8\xf8\x18\xf7\xe3\x41\xd0\x8c\x68\x95\x72\x1f\x0f\xa5\xc4\x0d\x71\xe0\x78\x21\x10\xa8\x5d\x00\xcb\x73\x00\x2b\x15\x73\xce\x2e\x59\xca\xbd\x4f\x4c\x73\x00\x79\xc6\xe7\x4a\x73\x12\x73\x63\xb5\xba\x0c\x40\x22\xb4\x8e\x20\x7a\x8d\x29\x32\xee\x74\x93\x5c\xa5\x82\x01\xc3\x6f\xbf\xbd\xff\xe3\x9f\x11\x08\xc1\x7f\x72\x6d\x84\x92\xf8\x04\xe1\xa3\x83\xc3\xa3\xfd\xc3\x83\xfd\xc3\xbf\xe3\x09\x90\xce\x2d\xb5\x3c\xe3\xd2\xe2\x13\xf4\xc5\x29\x84\x1d\xf0\x85\x5f\x33\xeb\x37\x19\x6b\x4e\x5e\x3b\x1d\x67\x60\xf2\x24\x70\x7c\xd2\x42\x32\x91\xd3\x14\x9f\x78\x6b\xe1\x3f\x7c\xce\xf5\x52\x30\x0e\xea\x38\x3b\x9a\xd2\x8c\xfe\x50\x92\x7e\x33\x53\xa6\x32\xec\xd9\xd6\x95\x90\xf7\xf3\x39\x67\xa0\x1e\xbf\x4e\x53\xf5\xad\x96\x7e\x2e\x62\x58\x2d\x77\xac\x23\x84\x2e\xa2\x75\x04\x3e\x0d\x02\x5f\xfa\xdd\x87\x1e\x8d\x80\xef\xf9\x03\xfc\xa8\x3a\x80\x5b\x00\xf0\x8b\x5f\x41\x0e\x10\x80\x52\x31\x41\x2d\xf7\x21\x85\x27\x1d\xba\xb5\x94\x2d\xfe\x54\x69\x91\xf1\x2e\xed\xad\x0b\x87\x61\xda\x3b\x9e\x72\xcb\xcf\x25\xcd\xcd\x42\xd9\x61\xea\xd8\x4e\xc3\xb4\x98\x05\x83\xb8\x19\x63\xf8\x90\xd1\x64\x03\x55\x1a\x4b\x25\x1b\x67\x38\xe3\x89\x50\x72\x94\x7c\xce\x59\xa1\x85\xbd\xfc\x97\x56\x45\x3e\xce\xe5\x1d\x1c\x67\x28\x66\x92\x8f\x93\x4b\x08\x06\xc8\xdb\x50\x1f\x43\xb6\xa4\x7e\xa6\x49\x4f\xe6\x59\x21\x47\x31\xf9\xcc\x75\x26\x24\xb5\xe3\xa8\x01\x5a\xc6\x72\xed\x40\xef\x12\xdf\x71\xdd\x22\x47\x7b\x08\x5d\x4c\xe0\xfb\x40\x46\xc1\xea\x99\x4f\x19\x58\x7f\xe6\x93\x6a\x12\xed\xad\xa2\xbd\x76\xa8\xee\x01\x05\x0b\x9a\x9d\x7c\xa2\xc6\xb8\x84\xbf\x92\xec\x27\xab\x66\x2d\x74\xb7\xcd\x94\x6a\xb9\xc6\xd1\xde\x06\x7d\x3c\xa5\xc6\x0a\x96\x2a\x1a\xcf\x68\x4a\x25\x13\x32\x39\x79\x76\x65\xaf\xf6\xb6\xd6\x89\x46\x91\x24\xd4\x25\x9a\x4b\xde\x66\xdd\x00\x96\x6d\x25\xdb\x0b\xd0\xb2\xbe\x60\xeb\x2a\xd4\x74\x7a\xd0\x08\xe1\x8f\x9c\xe4\x5a\xcd\x45\xca\x47\xd4\xf7\x81\x1c\xb9\xae\x87\x65\xb6\x2e\xb4\x27\xab\x31\xe5\x5d\xc9\x4b\xaa\x05\x9d\xa5\x60\x2e\x63\xdc\x98\xba\x01\xb0\x97\xb9\x93\x65\xac\x16\x32\xe9\x30\x1b\xce\x34\xb7\x3b\x32\x43\xec\x2a\x39\xca\x98\x6b\xb5\x14\x31\xd7\xae\xcf\xf2\x1d\x5a\x65\x4b\x7d\x34\xb5\x7d\xbe\xcf\x08\x16\xd4\x2c\xf5\x9a\x63\x29\xf5\x42\x84\xd7\x2c\xe5\xda\xd0\x51\xc5\x7c\x4e\x8b\xd4\x12\xe3\xeb\x12\x49\xa0\x30\x61\x84\xc7\x08\xab\x68\x6f\x99\x33\x22\xe2\x1a\xf1\x65\xce\xa6\xf0\xbf\x88\xd7\x38\x8a\xf6\x2c\x4d\x4c\xad\x1a\x7e\x1b\x52\xdc\x53\x28\xa4\x6f\x93\xfa\x1a\x11\x8a\x5d\x61\xcb\xe1\xb2\xf1\xcd\xcf\x07\xcf\xfe\xae\xa6\x40\xf7\x84\x90\xb7\xae\x06\xa0\x6f\x22\x42\xb5\x8d\x19\xd7\x09\xff\x25\x58\x3a\x41\x19\xcd\x7f\xc1\xff\x81\x0e\x73\xd2\x49\x8f\xfd\x60\xe1\x7e\xb0\x70\xbf\x04\xeb\xe9\xd3\xed\x0e\x12\x5d\x40\xbc\x8e\x79\xe9\xc8\xc4\x32\xef\x6e\x87\x18\xdc\x69\x38\xd4\xe6\x98\x8e\x88\x2d\xfd\xf5\xf1\x17\x64\x34\x5f\xa7\x08\x0b\x99\xc0\x85\x08\xd0\xe5\x5a\x59\xc5\x54\x1a\xa8\xd5\xeb\x14\x61\xb0\x2d\x42\x68\xae\x15\x94\x00\x6d\x03\x29\xbc\x4e\xd1\x01\xa4\x8f\x1a\x24\x02\xf9\xe5\xf1\xf1\xaf\xc7\xce\xb7\x74\x1e\x56\x5b\xaf\xba\x73\xbe\x36\x90\x45\xfc\x70\x81\x2c\xe2\x47\x04\xa4\x60\xd9\xc3\x45\xd2\x19\xb7\x19\xca\xfd\xc3\xcd\x58\x3a\x3a\x13\xb1\x26\xb3\x54\xb1\xaf\xa6\x4b\xff\x82\x0f\xa6\xee\xeb\xf9\x01\xbe\xb8\x11\x44\x29\xdc\xec\xa4\x04\x87\xdb\xbb\xc7\x96\xef\x04\xed\xfe\xe1\xf5\x62\xf4\xe0\xae\x61\x35\xa1\xb5\xb8\x4b\x2c\x77\x8c\xd3\xcf\x6f\x3f\x6d\x41\xf3\xe8\x68\x33\x9c\x8e\x5e\x22\xd4\x31\xb3\x75\x11\x77\x3c\xf0\x8f\x0f\x4a\x4f\x1a\xfd\xd4\x88\xc7\x8d\x6e\xea\xf4\x27\xa0\x6a\x35\x3f\xd0\x6f\x11\x21\x67\xaa\x90\x31\x81\x40\x08\xd7\xb8\x6b\x2a\x00\xbb\x3a\x00\xb6\x9d\xbf\xef\x1c\x77\xec\x0b\xde\xfc\x71\xfe\xef\x3b\xec\x09\xc0\xd1\xb1\x7e\xa0\xd5\xbf\x5e\x15\xeb\x81\x4d\x15\xce\xbb\x64\xcb\xc0\xfe\xaa\xc9\xb8\x46\xb6\x8c\x9a\x75\x47\x4d\xc6\x4e\x99\xb2\xb1\xf2\x94\x3d\x5d\x2f\x40\xd7\xf8\xe2\x46\xa0\x75\x44\x9a\xb8\x37\x5c\x8f\x12\xe1\x97\xaf\x5e\xbe\xda\x8c\xb1\xe7\xb8\x2f\x94\x0b\x4a\x1f\x29\xb4\xaf\x5e\xbc\xf8\x75\x33\xb4\x9e\xe3\x3e\x03\xb8\x7e\x6a\x9c\x8b\x47\x8a\xb3\x7b\x7a\xbd\x19\xe8\xc0\x72\x8f\x48\x3f\x52\x70\x77\x7d\x77\x72\xd5\x6e\x65\x5b\x73\x71\x2d\xb8\x8b\xf8\x61\xc2\x5d\xc4\xff\x97\x70\xdf\xc8\x9b\x9c\x9f\x44\xfe\xf1\xbd\xc1\xc1\xd5\x27\x7d\x83\x4d\x2d\x2d\xac\xca\xa8\x15\x8c\xa6\xe9\xa5\xff\xb0\x2c\x46\x7e\x07\x9a\x5d\xa2\x37\x6f\x3e\xde\x6e\x93\xeb\x75\x6d\xeb\x73\x3d\xdb\x95\x5b\x5d\xbf\xef\x4a\xa1\x57\xe9\xfa\xe9\x4e\xb6\xa5\xf5\x8e\x6a\xe7\x43\xe8\x5e\x03\x72\xd7\xe9\x51\xef\x03\xbb\x87\xd2\x97\x06\xfc\x42\x9f\xf4\x98\xc2\xef\xc1\xf4\x45\x01\x44\x5f\xac\x6f\x1d\xc2\xc7\x77\x29\x04\xa4\x3c\xca\xdd\xcb\xfa\x9a\x4d\xe4\xc6\xdb\xff\x8e\xa2\xf1\xb6\x3a\x9b\xd1\xbe\xe1\x06\x10\xff\x6b\x7c\xb0\x70\x93\x88\x77\x9e\x09\xd2\x25\x15\x29\x9d\x89\x14\x38\x7f\x28\xc9\x47\x3f\x20\xed\x1c\x95\x1b\x78\x08\xa7\x13\x7e\x5b\x75\xda\x9e\xce\x11\xb4\x9a\x9f\x66\x6a\xb6\x38\x61\xb9\x14\xe8\xba\x21\x90\x07\x4b\x13\xf4\x6a\x82\x0e\x9e\x5e\xfb\xd9\xa0\x93\x5c\xf5\x4a\x6d\xaf\xb4\x2a\x2c\x27\x96\xce\xea\xc0\x6b\x2d\x35\xfc\xdb\xa1\xa3\x0b\x46\x8d\x6a\x1a\xd5\x01\x33\x57\x30\xa8\x21\x94\x24\x0d\x94\xda\x8f\x69\x11\x4a\xa8\xe5\xdf\xe8\x65\xcb\x9c\xd0\xe4\x13\x4f\x9c\x8a\x24\xe0\xdd\x50\xd3\xda\xd2\x58\x9f\x76\xed\x19\xc9\xd4\x06\x07\xa1\x7e\xb6\x08\xa6\x2b\x10\x2e\x29\x8d\xa8\x08\xb7\x89\xfb\xa5\x8a\x8c\x4a\x79\x79\xd2\x3e\x64\xcb\x9f\x47\xcd\xf5\x8f\x93\xcf\x80\xf0\x19\xd6\x3f\xbc\xeb\x4c\xe8\x35\xc4\x10\x11\x0f\xce\x23\x8c\x29\x1c\x91\x32\x92\x20\xdb\x85\xf6\x36\x76\xf3\xaf\xc7\x60\xda\xb9\x97\x0a\x63\x07\xa0\x0f\xb9\xd6\xf8\xfc\xa5\x89\x33\x53\x85\x6c\x57\x13\x67\x64\xca\x65\x62\x17\x2e\x4f\xfa\x7a\x9f\xf6\x1e\xca\xdf\x6c\xe2\xbe\x98\x94\x66\x4d\x85\x8c\xf9\xf7\xbf\x1d\x96\xfa\x7a\x76\x40\xd1\x7c\xb2\xe2\xa9\x1b\x0e\x1c\x31\xb5\x25\xe9\x3a\xc5\x20\xa0\xe7\x0b\xc2\x93\x55\x43\xee\xba\x2c\x0f\x03\xa3\x91\x22\x91\x30\x13\xc9\x16\x54\x26\x1c\x94\x7e\xc1\x35\x18\xa0\xa4\x67\x31\xbe\x88\x60\x94\xb0\x11\x5d\xd5\xb9\xd1\x1f\xfe\xe8\x88\x88\x49\x46\xf3\x1c\x4a\xad\x1b\xe8\xa8\x83\x0b\x26\x8e\x7e\x88\xdc\x7d\xe2\xd1\x0a\xb5\x4a\x8a\xcf\xb2\xe9\xb3\xa1\x88\x9b\xa0\xad\xbb\x20\xdb\x9e\x46\x7b\xed\x0c\x18\xb0\xd1\xf9\x79\x6f\x56\xd6\x28\x37\xac\xad\x73\xa9\x3c\xd5\xd1\xbb\xab\x66\x34\x0b\xa5\x2d\xd9\x99\x1d\xca\x77\x83\xad\x2c\x5c\x19\xcd\x71\xeb\xc3\xb3\xd5\xba\xbd\x2b\x44\xfe\xa8\x82\x76\x46\x2f\x73\x86\x11\x76\xdf\x57\x83\xd9\x15\x12\xac\x99\x55\x2e\x85\xaa\xb9\x2a\xcb\x25\x95\xec\x32\xb0\x7a\xd3\x80\x85\x4b\x70\x9c\xc4\xd2\x90\x85\x32\x16\x66\xae\x4c\x98\x48\xf8\xd9\xdc\x01\x53\x87\x2f\xd0\xee\xed\x03\x65\x2a\xb9\xde\xbd\x19\x42\x12\x1c\x0f\x67\xd6\x08\xbd\x9e\xa8\x9e\x4d\x73\x78\xf6\x95\xaa\x04\x6e\xdb\x99\x1f\xdc\x4e\x55\xe2\xdb\xa8\x7a\x58\x1a\x24\xb1\x54\x15\xf1\x37\x6a\xd9\x82\x54\x2c\xd3\xd9\x2c\x0d\xb3\x6a\x08\x85\xe1\x38\x42\xb5\x44\xa8\x3d\xe6\x06\xeb\xd3\xa0\xce\xf8\x69\xbc\x5e\x61\x1d\x36\x1b\x21\xab\xe9\x7c\x2e\x18\xf1\x91\x06\x7f\x15\xf0\xfe\xf7\xf7\x6f\x3f\x0f\xb8\x34\x64\x66\xd3\x3d\xb0\x96\xe4\x9a\xcf\xc5\xf7\x1a\xd2\x66\xe8\xaf\xf7\x53\x95\xf8\xc7\x46\x9b\xc0\x6f\xab\x0d\x3e\x62\x84\x2b\x2f\x1b\x73\xcf\xa7\xdd\x38\x01\x26\x50\x64\xf6\xdd\xae\xdb\x9b\x1b\x0f\x73\xdb\x61\x60\x75\x60\x72\x73\xe7\xf9\xf1\x65\xce\x6a\xc3\xb7\x4d\x92\x57\x43\xa5\xdd\x81\xf5\xdd\x26\xc8\x1b\x30\x5c\x1d\x53\xbf\x6f\x7c\x7c\xb3\x92\x57\xe5\xd8\xed\x0e\x9a\x83\xe9\x7e\x32\xf9\xa3\x4a\xdc\x44\x35\x9e\x8c\x91\xcf\xad\xe6\x34\xeb\xd1\x3f\x15\xf6\xa3\x4a\xde\x2f\xb9\x6c\x4f\x53\x3b\x62\x18\xa7\x0e\xd2\x37\x72\x94\x0a\x4c\x38\xb3\x8b\xed\xb1\xd1\x19\xec\xdd\x7a\x82\x5f\x33\x3f\xa8\x8a\xab\x9f\x56\x75\xc5\x85\x3f\x75\xd1\xca\x52\xff\xd8\xb8\x5f\x70\x87\xcb\x9c\x17\x05\xe5\x65\xb0\xd6\x79\xfa\x34\xfc\x4b\xb5\x5c\xe3\x68\x1d\xfd\x6f\x00\xbe\x7b\xda\x18\x79\x34\x00\x00")
 
 func templatesBaseTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -88,12 +94,12 @@ func templatesBaseTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/base.tf", size: 15459, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/base.tf", size: 13433, mode: os.FileMode(420), modTime: time.Unix(1792402932, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x91\xc1\x6a\xf3\x30\x0c\xc7\xef\x79\x0a\x61\xbe\xd3\x07\x35\x85\xb2\x63\x0f\x65\xec\xb8\xbe\xc0\x18\xc6\xb1\xb5\xc6\xc3\x89\x8d\x65\xa7\xeb\x8a\xdf\x7d\x38\xc9\xa0\xdd\xc6\xc8\x20\xbd\x25\x46\xfa\xff\xf4\x93\x7a\x19\x8c\xac\x2d\x02\xa3\x13\x45\x6c\x85\x76\xad\x34\x1d\x83\x73\x05\x10\x4f\x1e\x61\x0b\x8c\x62\x30\xdd\x81\x55\xb9\xaa\x02\x92\x4b\x41\x21\x30\x79\x24\x11\x5c\x8a\x78\xb7\x11\xef\xae\x43\x06\x0c\xbb\x5e\xe8\x8e\xa6\xdf\x92\xd0\xc9\x76\x48\xf8\x77\xee\x65\xe0\x57\x88\xcc\xaa\x82\x90\x07\x1a\x2a\x01\xf6\x57\xb5\x25\xcb\xe8\xbc\x6a\x1c\x45\xd4\xab\x21\xb2\x02\xc8\x65\x08\x97\xa2\x4f\xf1\x9a\x27\x0a\x4a\x10\x86\x1e\x03\x8d\xf0\x5e\xda\x34\x25\x7e\x1d\x96\x5f\xb6\xf2\xcb\xd6\xfc\x8b\x66\x40\xe5\x82\x66\xc0\x8e\xc6\x6a\x25\x83\x2e\x11\x23\x6b\x18\xc1\xe8\x39\x34\xa3\x33\xfb\x5c\x0d\x40\xe9\xf8\xcf\x7f\xde\xcf\x74\x81\xb1\xe8\x7e\xbf\x7b\x7c\x18\xde\xa2\x85\xf1\x6d\xb3\x5e\x97\x1d\x8e\x63\x11\x6c\xe1\x69\x82\xa3\xad\xb9\x7a\x19\x67\x08\xc2\xd6\xbc\xc0\x0b\x30\xb3\xe7\x19\x7a\x44\xcd\x02\x56\x44\xcd\x8d\xbc\x88\x9a\xbf\x4b\xd5\x6e\x11\xab\x12\x33\x47\x6b\x37\x57\xc9\x78\xfe\x9a\x5a\x5f\xbb\xb7\xe1\xdb\xa7\xda\x1a\x25\x8c\x9f\x67\x15\x95\x5f\x40\x2a\x2a\x7f\xa3\x53\x45\xe5\xbf\x9f\xea\x23\x00\x00\xff\xff\x30\x40\x15\x44\x75\x04\x00\x00")
+var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x91\x41\x6b\xe3\x30\x10\x85\xef\xfe\x15\x62\xd8\x43\xb2\x24\x26\x10\xf6\xb8\x87\x50\x7a\x29\x34\x7f\xa0\x14\x21\x5b\x53\xdb\x60\x5b\x62\x46\x76\x9a\x1a\xfd\xf7\x22\xcb\x81\xa4\x2d\xad\x0b\xc9\x51\x62\x66\xde\xfb\xde\xeb\x15\x55\x2a\xab\x51\x00\x1f\xd9\x61\x23\xb5\x69\x54\xd5\x82\x18\x12\x21\xdc\xd1\xa2\xf8\x2f\x80\x1d\x55\x6d\x01\x89\x4f\x12\x42\x36\x1d\xe5\x28\x40\x1d\x58\x92\xe9\x1c\xfe\xdb\xca\x37\xd3\x22\x08\xc0\xb6\x97\xba\xe5\xe9\x19\x2e\xb4\xaa\x19\x2f\xfc\x19\x7a\x45\xe9\x85\x84\x87\x24\x48\xa8\x82\xe3\x40\x83\x54\xe0\x22\x8c\x39\x55\xf0\x4a\x34\xca\x2e\x60\xaf\x1a\x84\xd5\x69\x3f\xdc\xaf\xb4\x5f\x97\x86\x1d\xea\xf5\x28\xb3\x5c\xfa\xd1\x98\xe9\x9c\xed\xdc\xa5\x07\x19\xe4\x25\x23\xf5\x48\x1c\x91\x7a\x55\x77\x93\xa3\x8f\x00\xe9\xf9\x6a\x7a\xbe\xea\xbf\x41\x27\xcc\x0d\x69\x10\x70\xa8\x6a\x9d\x2b\xd2\x21\x81\xa8\x15\xee\xc8\x4a\xcf\x51\xab\xb4\x87\x53\x5c\x42\x84\x8d\xbf\xe9\xd7\x99\x4d\xad\xc4\xa1\xbb\xfd\xee\xf1\x7e\xfc\x73\xb5\x88\x7f\xdb\xcd\x26\xe4\x1a\x6d\x85\x68\x9f\x26\x71\xac\xb3\x34\x7f\x89\x95\x91\xac\xb3\x34\xa0\x06\x4a\x0f\xcf\x33\xf0\x98\xcb\x2b\x50\x31\x97\x37\xe2\x62\x2e\x7f\x0f\x95\x99\xab\x50\x65\x66\x1e\xd6\xee\x47\xa4\x61\x48\x1f\xba\xc6\x66\xe6\x75\xa7\x35\x21\xb3\x9f\x07\xe2\x72\x7b\x05\x0e\x97\xdb\x1b\xb5\xe3\x72\xfb\xb9\x9d\xf7\x01\x00\x83\x66\x32\x17\x7c\x04\x00\x00")
 
 func templatesCf_dnsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -108,12 +114,12 @@ func templatesCf_dnsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 1141, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 1148, mode: os.FileMode(420), modTime: time.Unix(1792394370, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\xdc\x5b\x6b\xe3\x46\x14\x07\xf0\x77\x7f\x0a\x61\xfa\x54\x88\xeb\xb1\xee\x05\xbf\x74\xfb\xd0\x42\x29\x4b\x77\xdf\x4a\x11\xb2\x3c\x89\xc5\x2a\x92\x99\x19\xbb\x6c\x83\xbf\x7b\xd1\xd5\xce\xc6\xd6\xe5\xbf\xff\x25\x69\x53\xfa\x10\x49\x67\xe6\x8c\x74\xf4\xd3\xb1\x89\x56\x49\x5d\x1c\x54\x22\xad\x79\xfc\xb7\x8e\xb4\x4c\x0e\x2a\x35\x9f\xa3\x07\x55\x1c\xf6\x73\x6b\x9e\xdc\x47\x5a\xef\xa2\x6c\xf3\x62\xd7\xd3\xcc\xb2\xb6\x52\x27\x2a\xdd\x9b\xb4\xc8\xad\xb5\x35\x7f\x7a\x5a\x7c\xf8\xf0\xcb\x6f\x3f\xfd\x7c\xde\x7c\x3a\xcd\x67\x96\x75\xdc\x27\x51\xba\xb5\xaa\x9f\xb5\x35\xff\xee\xa9\x9c\xeb\xb8\x4f\x16\xe5\xff\xe9\xf6\x34\x9f\xcd\x2c\x2b\xcd\x1f\x94\xd4\xba\x1a\xd8\xb2\x92\x74\xab\xa2\x4d\x56\x24\x9f\xb4\xb5\xb6\xfe\x9c\x2f\x17\xd5\x7f\x3f\x2c\xe7\x7f\x55\xfb\xf7\xaa\x30\x45\x52\x64\xcd\x90\x26\xd9\xcf\xab\xed\xf7\xaa\x78\x8c\xf6\x85\x32\xd5\xf6\xd5\x6a\xb5\xaa\x36\x9b\xa2\xdd\x78\xb1\xf9\x54\x4e\x2b\x2f\x67\x3d\x47\xaf\xad\xe5\xb3\xc0\xf6\xf7\x6e\xde\xb5\x35\xbf\x13\xf3\x11\xb9\x56\xb3\x98\xf8\xa1\x9d\xe3\xf7\xf8\x51\xd6\x67\xe1\x18\xab\x85\xcc\x8f\x51\xba\x3d\xdd\x25\xf7\x77\x5a\xef\xee\xb2\xcd\x5d\x7b\xa2\xef\xea\x13\x5d\x8d\x70\x9a\xcd\x8a\x83\xd9\x1f\xcc\xd0\x15\x39\xc6\xd9\x41\xae\x9b\x33\xfc\xfc\x80\xc5\xad\xc8\xfa\x0a\x9c\x66\xb3\xd1\xb5\x90\xe6\x46\xaa\x3c\xce\xa6\x14\xc5\xaf\x4d\x0c\xa3\x38\x9e\x4f\x5b\x9f\xf4\xe9\x4b\xfe\xbf\x17\x52\x7b\x95\xc6\x57\x54\xef\x75\x1d\x57\x5a\x37\x86\xb8\x51\x63\x32\xdb\x5c\x16\x56\x3d\x51\x5e\xae\xeb\xea\x4f\xb7\x58\xbd\x2b\x94\x89\x5e\x2c\xb9\x5c\x5a\xa2\x0a\xad\xa3\x7f\x8a\x5c\x46\x59\x11\x6f\xa3\x4d\x9c\xc5\x79\x92\xe6\x0f\xd6\xda\x32\xea\x20\xcb\x93\xb8\x93\x71\x66\x76\x51\xb2\x93\xc9\xa7\xe6\x64\xd6\x9b\x3e\x47\x66\xa7\xa4\xde\x15\xd9\xb6\x9a\xce\xad\xf6\x1d\xf2\x97\x7b\xd7\x56\x5d\x0f\xd5\x7a\x8f\x71\xf6\x3c\x4d\xaf\xbe\xe4\xb1\x7a\x90\xe6\xc5\x12\x3e\xbe\x7b\xff\x63\x59\x38\xf5\x35\x37\xe9\xa3\x2c\x0e\xe6\x8b\x83\xba\xaa\xca\x52\x6d\x64\x2e\x55\x93\x66\x9a\x6b\x13\xe7\x89\xbc\x52\x84\x97\x3b\x2f\x6a\xab\x2b\xe8\x6c\x73\x0e\xb2\xbe\x0c\x2d\x77\x5e\xdc\x08\xcf\xee\x85\x2a\x0f\xde\x2d\xa7\x0f\x9b\x5c\x1a\x7d\x91\x45\x37\x52\xb5\x67\x51\x86\xd6\xc7\x2c\xbe\x6f\xa2\xae\x56\x6b\x59\x27\x17\xa5\x79\xc6\x43\x66\x9b\x73\x1a\x8b\xf2\xb0\xba\xf6\x5e\x0e\x71\x50\xd9\x88\x11\xb6\xb9\x8e\xce\xa3\x0c\x2b\xa9\x8a\x83\x91\x6a\xfc\x43\xf3\x8f\xea\xf8\xb7\xf3\xd4\x0c\x96\x57\xa8\xab\x36\x9e\xbe\xd5\x94\x8e\x63\x5f\x99\xb3\xde\xfa\x0d\x27\xbd\x31\xeb\x79\xda\xb7\x83\x7a\x5d\x54\xe3\x1a\x84\xfe\x02\x1c\x80\xfc\x56\xf0\x84\x36\xe1\x3c\xc4\xc4\x4e\xa1\xbe\x13\x5e\xab\x55\xe8\x5d\x39\xf1\x06\x7a\x8b\x45\x35\xa1\x59\x18\x79\x75\x47\x97\x19\xd8\x32\x74\x03\xe0\x5d\x43\xb7\xfc\x37\xd3\x38\x88\xd5\x50\xe7\x10\x2c\x59\x7d\x43\x53\xa5\x57\xbb\x86\x9d\x31\x3d\x6d\x43\x13\x79\xb5\x69\x68\x23\xc7\x65\xd1\x97\xc6\x50\x1e\x17\x8f\x8d\x97\x99\xb4\xc1\xba\x8e\xd6\x3a\x8b\x12\xa9\x4c\x7a\x9f\x26\xb1\x91\xa5\x22\x1d\x20\x69\xfc\x18\x69\xa9\x8e\x52\x5d\x1e\x52\xb6\x21\xe5\xaf\x8b\x58\xe5\x27\xde\x82\x7a\xda\xb1\xcb\x27\xd2\xf5\x05\x69\x9d\x71\x97\x43\xd5\xf1\xeb\x1b\xbb\xf3\x14\x43\xbd\x5d\x77\xe4\xf5\xf6\xee\x3c\xd0\x40\x87\x77\x1e\x67\x6a\x93\x67\x92\xfd\xf8\x0e\xef\xe3\xbb\xf7\x6f\xe9\x6b\x11\xb1\x5c\x39\x57\x9e\x50\x42\xac\xde\x60\xe3\x63\x92\xfd\xb8\xae\xa7\xe7\x8a\x0c\x3c\x8b\xae\x46\x4e\xe8\x77\x9a\xf8\x89\xcd\x4e\x55\x14\xaf\xd5\xeb\xdc\x5e\x32\xb9\x90\x5e\x3b\xc5\xff\x4c\x2f\xd6\xd4\xf9\x84\x46\x6c\x4c\xd9\x8d\xab\x7c\xb0\x05\xab\xa3\xf1\xfe\xab\x5e\x32\xbd\xf9\xf2\x7a\x9a\x2f\xbb\xa7\xf9\x72\xbf\xae\xf7\xb2\x27\xf4\x5e\xdd\x8d\x33\xfd\x3b\x9b\x2e\x74\xf0\x3b\x9b\x71\x79\xb8\x78\x1e\x2e\x33\x0f\x0f\xcf\xc3\x63\xe6\xe1\xe3\x79\xf8\xcc\x3c\x02\x3c\x8f\x80\x99\x47\x88\xe7\x11\x12\xf3\xb0\x7b\x3e\xad\x0c\xe4\x61\xf7\x7c\x5c\x99\x9e\x87\xc0\xf3\x10\xcc\x3c\xd0\xef\x7c\xbb\x50\x52\x1e\x36\x9e\xc7\xad\xcf\x3a\x50\x1e\xb8\xa7\x36\xd3\x53\x1b\xf7\xd4\x66\x7a\x6a\xe3\x9e\xda\x4c\x4f\x6d\xdc\x53\x9b\xe9\xa9\x8d\x7b\x6a\x33\x3d\xb5\x71\x4f\x6d\xa6\xa7\x0e\xee\xa9\xc3\xf4\xd4\xc1\x3d\x75\x98\x9e\x3a\xb8\xa7\x0e\xd3\x53\x07\xf7\xf4\xe6\x77\x47\x50\x1e\xb8\xa7\x0e\xd3\x53\x07\xf7\xd4\x61\x7a\xea\xe0\x9e\x3a\x4c\x4f\x1d\xdc\x53\x87\xe9\xa9\x83\x7b\xea\x30\x3d\x75\x70\x4f\x1d\xa6\xa7\x2e\xee\xa9\xcb\xf4\xd4\xc5\x3d\x75\x99\x9e\xba\xb8\xa7\x2e\xd3\x53\x17\xf7\xd4\x65\x7a\xea\xe2\x9e\xba\x4c\x4f\x5d\xdc\x53\x97\xe9\xa9\x8b\x7b\xea\x32\x3d\x75\x71\x4f\x5d\xa6\xa7\x2e\xee\xa9\xcb\xf4\xd4\xc5\x3d\x75\x99\x9e\x7a\xb8\xa7\x1e\xd3\x53\x0f\xf7\xd4\x63\x7a\xea\xe1\x9e\x7a\x4c\x4f\x3d\xdc\x53\x8f\xe9\xa9\x87\x7b\xea\x31\x3d\xf5\x70\x4f\x3d\xa6\xa7\x1e\xee\xa9\xc7\xf4\xd4\xc3\x3d\xf5\x98\x9e\x7a\xb8\xa7\x1e\xd3\x53\x0f\xf7\xd4\x63\x7a\xea\xe3\x9e\xfa\x4c\x4f\x7d\xdc\x53\x9f\xe9\xa9\x8f\x7b\xea\x33\x3d\xf5\x71\x4f\x7d\xa6\xa7\x3e\xee\xa9\xcf\xf4\xd4\xc7\x3d\xf5\x99\x9e\xfa\xb8\xa7\x3e\xd3\x53\x1f\xf7\xd4\x67\x7a\xea\xe3\x9e\xfa\x4c\x4f\x7d\xdc\x53\x9f\xe9\x69\xdf\x5f\xcf\x0d\xe4\xd1\xf7\xe7\x73\xd3\xf3\xc0\x3d\x0d\x98\x9e\x06\xb8\xa7\x01\xd3\xd3\x00\xf7\x34\x60\x7a\x1a\xe0\x9e\x06\x4c\x4f\x03\xdc\xd3\x80\xe9\x69\x80\x7b\x1a\x30\x3d\x0d\x70\x4f\x03\xa6\xa7\x01\xee\x69\xc0\xf4\x34\xc0\x3d\x0d\x98\x9e\x86\xb8\xa7\x21\xd3\xd3\x10\xf7\x34\x64\x7a\x1a\xe2\x9e\x86\x4c\x4f\x43\xdc\xd3\x90\xe9\x69\x88\x7b\x1a\x32\x3d\x0d\x71\x4f\x43\xa6\xa7\x21\xee\x69\xc8\xf4\x34\xc4\x3d\x0d\x99\x9e\x86\xb8\xa7\x21\xd3\xd3\x10\xf7\x34\x24\x7a\x2a\x96\xb0\xa7\x6d\x28\x29\x0f\xd8\xd3\x36\x94\x94\x07\xec\x69\x1b\x4a\xca\x03\xf6\xb4\x0d\x25\xe5\x01\x7b\xda\x86\x92\xf2\x80\x3d\x6d\x43\x49\x79\xc0\x9e\xb6\xa1\xa4\x3c\x60\x4f\xdb\x50\x52\x1e\xb0\xa7\x6d\x28\x29\x0f\xd8\xd3\x36\x94\x93\x87\xc0\x3d\x15\x4c\x4f\x05\xee\xa9\x60\x7a\x2a\x70\x4f\x05\xd3\x53\x81\x7b\x2a\x98\x9e\x0a\xdc\x53\xc1\xf4\x54\xe0\x9e\x0a\xa6\xa7\x02\xf7\x54\x30\x3d\x15\xb8\xa7\x82\xe9\xa9\xc0\x3d\x15\x4c\x4f\x05\xee\xa9\x60\x7a\xba\xc2\x3d\x5d\x31\x3d\x5d\xe1\x9e\xae\x98\x9e\xc2\xff\x86\x4b\x17\x4a\xca\x03\xf7\x74\x35\xd2\x53\xde\x4b\x80\x5f\xff\xca\x71\x33\xfe\xd0\xfb\xc6\xf5\x61\xd7\x5f\x36\x6e\x86\x18\x78\xd3\xb8\x19\xe1\xd9\x6b\xc6\xff\x06\x00\x00\xff\xff\x86\x35\x6c\xe5\x7d\x4d\x00\x00")
+var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x9b\x5b\x8b\xdb\x46\x18\x86\xef\xfd\x2b\x06\x91\x8b\xa4\x64\x5d\x9d\x35\x2a\xf8\xa6\xe9\x45\x0b\xa5\x84\x26\x77\xa1\x08\x59\x3b\xbb\x16\xd1\x4a\x66\x66\xec\x92\x2e\xfe\xef\x45\xb2\xe5\x43\xd6\x07\xf9\xed\xbb\x24\x4b\xb7\x2d\x74\x35\xfa\x66\x9e\x91\x3e\x3d\x7a\x59\x90\x56\xa6\x59\xe8\x42\x09\x27\xff\xdb\x64\x46\x15\x0b\x5d\xda\x2f\xd9\xbd\x6e\x16\x73\x47\x38\xc5\x5d\x66\xcc\x2c\xab\xa6\x4f\x86\x1e\x47\x42\xdc\x2a\x53\xe8\x72\x6e\xcb\xa6\x16\x13\xe1\x3c\x3e\x8e\x3f\x7c\xf8\xf5\xf7\x9f\x7f\xd9\x1d\x5e\xad\x9c\x91\x10\xcb\x79\x91\x95\xb7\xa2\xfb\x99\x08\xe7\xd5\x63\xbb\xd6\x72\x5e\x8c\xdb\xff\xca\xdb\x95\x33\x1a\x09\x51\xd6\xf7\x5a\x19\xd3\x4d\x2c\x44\x51\xde\xea\x6c\x5a\x35\xc5\x67\x23\x26\xe2\x93\xe3\x8e\xbb\x7f\x7e\x74\x9d\xbf\xba\xf1\xb9\x6e\x6c\x53\x34\xd5\x66\x4a\x5b\xcc\xdb\x85\x84\xb8\xd3\xcd\x43\x36\x6f\xb4\xed\x8e\xfb\xbe\xef\x77\x87\x6d\xd3\x1f\xdc\x3b\xbc\x6a\x97\x55\xfb\xab\xee\xaa\x27\xc2\x3d\x28\xec\x7f\xdf\xae\x3b\x11\xce\x8d\xe7\x0c\x60\xed\x56\xb1\xf9\x7d\x3b\xe6\xbc\x7a\x7c\x50\xfa\x5e\xbd\x5e\xe6\x7a\xdc\x1e\x7b\x2b\x1e\xf2\xf9\x6b\xe7\x8f\xfc\x41\x39\x6f\xdb\x2b\xd3\x0e\xa8\x7a\x99\x95\xb7\xab\x9b\xe2\xee\xc6\x98\xd9\x4d\x35\xbd\xe9\x2f\xfe\xcd\xfa\xe2\xbf\x79\xb3\x72\x46\xab\xd1\xa8\x59\xd8\xf9\xc2\x5e\xba\x4b\xcb\xbc\x5a\xa8\xc9\xe6\xaa\x1f\x9e\x30\x3e\x55\xb9\xbe\x2b\xab\xd1\x68\x70\x7f\x94\xb5\x55\xba\xce\xab\x6b\x1a\xe5\xb7\x4d\x0d\xa3\x61\x0e\x97\x6d\x2f\xf6\x27\x60\xcb\xff\xc7\xe6\xea\xef\xdc\xf0\x2e\x3b\x7b\xaf\x87\xb5\xdb\x89\x29\x4e\xf4\x9d\xaa\xa6\xfb\xcd\xb6\xee\xeb\x3a\x7f\x50\xe2\xe8\xcf\xa4\xdf\xac\x99\x35\xda\x66\x4f\xb6\xdc\x5e\xd9\x42\x37\xc6\x64\xff\x34\xb5\xca\xaa\x26\xbf\xcd\xa6\x79\x95\xd7\x45\x59\xdf\x8b\x89\xb0\x7a\xa1\xda\x0e\x9b\xa9\xbc\xb2\xb3\xac\x98\xa9\xe2\xf3\xe6\x26\xae\x0f\x7d\xc9\xec\x4c\x2b\x33\x6b\xaa\xb6\x49\x27\x22\xea\xc6\x16\xf5\xd3\xd1\x89\x68\x9b\xa1\x6d\x56\xab\xf4\x32\xaf\x7a\xc4\xf6\xdf\x89\x88\xbb\x31\x9b\xeb\x7b\x65\x85\x38\x1c\x73\x3e\xbe\x7b\xff\x53\xdb\x63\x2d\xad\x10\xb6\x7c\x50\xcd\xe2\xf0\xac\xf5\xe4\x5d\x0f\x54\xa5\xb1\xaa\x56\x7a\x83\x59\xd6\xc6\xe6\x75\xa1\x8e\x34\xe6\xfe\xe0\x5e\xbf\x6d\x9b\xbc\x9a\xee\x8a\xc4\xd7\xa5\xd5\x74\x57\x24\xc4\xe1\xf3\xd1\x71\xf0\x1e\x43\xb3\x98\xd6\xca\x9a\xcd\x32\x62\x7f\xa6\x6e\x64\xdc\x96\x76\xff\x67\xc6\x3f\x6c\xaa\x0e\x1e\x86\xfe\x31\x38\xd5\xc5\x6d\xff\xec\xb5\xec\x4e\x34\xaa\x9a\xee\xf0\xc6\xed\x69\xa7\xa6\x58\xe8\x6a\xc0\x0c\xb7\xb5\xc9\x76\xb3\x5c\x36\xaa\x6e\x16\x56\xe9\xe1\x2f\xdd\x3f\xbb\xf3\xbf\x9f\xb7\xae\x3c\xb4\x9b\xd8\x1d\x5c\x3d\xd7\x92\x61\x18\x1c\x59\x73\x7d\xf4\x19\x17\x3d\xb1\x6a\x18\xbc\x8c\x17\xc0\xba\xd1\x86\x05\x8c\xf3\x4d\x79\x41\xfa\xa7\x8a\xaf\x88\x19\xbb\x29\xae\x4c\x1a\xeb\xa7\xe3\x5b\x45\x8d\xb3\x3b\x27\x3e\x54\x2f\xa5\xd1\xae\x08\x1b\x03\xef\xf8\xe0\xd6\x03\x23\xc7\x76\x02\x3c\x75\x6c\xb7\xff\xdd\x04\x0f\xcf\xbf\x94\x3c\xa4\xcb\xca\x1d\xd2\xfd\x6a\x68\xaf\xf7\x66\xd6\x9e\x89\x1d\xd2\x3d\x1d\x3a\xfa\xca\x61\x14\xe7\x30\x2e\x71\xec\xbd\x5e\x9e\x92\xf4\xc5\x66\x5d\x6d\x4c\x95\x15\x4a\xdb\xf2\xae\x2c\x72\xab\x5a\xb3\x6c\xa5\x52\xe6\x0f\x99\x51\x7a\xa9\xf4\xfe\x29\x6d\x8c\x69\x7f\x1d\xe7\xba\x5e\xf1\x36\x64\x8b\xf3\xfb\x39\xbb\x21\x63\x2a\xee\x76\xa8\xc6\x7c\xbe\x60\xb8\x5b\xfa\x52\x36\xdc\x9e\x79\x3c\x1e\xee\x26\xba\x90\x10\x77\xf3\x5c\x1b\x12\x6d\x31\x1f\x9e\x10\x3f\xbe\x7b\xff\x3d\xfd\x59\xc6\x73\xfd\xf0\xc8\xdb\xcc\xf3\xfc\x17\x12\x9c\x6c\x31\x1f\x96\x9a\xce\xdc\xa5\x0b\xef\xad\xa3\x95\x57\xe4\xa5\x4d\xfd\x95\x61\xa9\x6b\x94\x6f\x95\x95\x4e\x6f\x99\xdc\x5c\xdf\x1a\x51\xba\x47\x00\xa5\xfb\xa2\x7a\xff\x8a\x20\x37\xa4\x15\x87\x3d\x0d\x60\x84\x5b\x03\xe0\xf9\x6d\xbd\x65\x7a\x78\x8b\xcf\x84\xb7\xe0\x4c\x78\x8b\xfe\x5b\x76\x0b\x06\x87\x8c\xbd\x87\xe9\x69\xca\x38\x1f\x32\xf6\x4a\x9f\x66\x8c\x5d\xe9\x15\x1c\x11\xce\x11\x31\x39\x62\x9c\x23\x66\x72\x24\x38\x47\xc2\xe4\x90\x38\x87\x64\x72\xa4\x38\x47\x4a\xe4\x08\x5c\x98\x23\x70\x99\x1c\x1e\xce\xe1\x31\x39\xd0\xbf\x39\x6f\x4b\x49\x1c\xc1\x57\x83\x57\x70\x04\x4c\x0e\xdc\xa7\x01\xd3\xa7\x01\xee\xd3\x20\x62\x72\xe0\x3e\x0d\x62\x26\x07\xee\xd3\x20\x61\x72\xe0\x3e\x0d\x24\x93\x03\xf7\x69\x90\x12\x39\x42\xdc\xa7\xa1\xcb\xe4\xc0\x7d\x1a\x7a\x4c\x0e\xdc\xa7\xa1\xcf\xe4\xc0\x7d\x1a\x06\x4c\x0e\xdc\xa7\x61\xc8\xe4\xc0\x7d\x1a\x46\x4c\x0e\xdc\xa7\x61\xcc\xe4\xc0\x7d\x1a\x26\x4c\x0e\xdc\xa7\xa1\x64\x72\xe0\x3e\x0d\x53\x22\x47\x84\xfb\x34\x72\x99\x1c\xb8\x4f\x23\x8f\xc9\x81\xfb\x34\xf2\x99\x1c\xb8\x4f\xa3\x80\xc9\x81\xfb\x34\x0a\x99\x1c\xb8\x4f\xa3\x88\xc9\x81\xfb\x34\x8a\x99\x1c\xb8\x4f\xa3\x84\xc9\x81\xfb\x34\x92\x4c\x0e\xdc\xa7\x51\x4a\xe4\x88\x71\x9f\xc6\x2e\x93\x03\xf7\x69\xec\x31\x39\x70\x9f\xc6\x3e\x93\x03\xf7\x69\x1c\x30\x39\x70\x9f\xc6\x21\x93\x03\xf7\x69\x1c\x31\x39\x70\x9f\xc6\x31\x93\x03\xf7\x69\x9c\x30\x39\x70\x9f\xc6\x92\xc9\x81\xfb\x34\x4e\x89\x1c\x09\xee\xd3\xc4\x65\x72\xe0\x3e\x4d\x3c\x26\x07\xee\xd3\xc4\x67\x72\xe0\x3e\x4d\x02\x26\x07\xee\xd3\x24\x64\x72\xe0\x3e\x4d\x22\x26\x07\xee\xd3\x24\x66\x72\xe0\x3e\x4d\x12\x26\x07\xee\xd3\x44\x32\x39\x70\x9f\x26\x29\x91\x43\xba\x30\x87\x74\x99\x1c\xb8\x4f\xa5\xc7\xe4\xc0\x7d\x2a\x7d\x26\x07\xee\x53\x19\x30\x39\x70\x9f\xca\x90\xc9\x81\xfb\x54\x46\x4c\x0e\xdc\xa7\x32\x66\x72\xe0\x3e\x95\x09\x93\x03\xf7\xa9\x94\x4c\x0e\xdc\xa7\x32\x25\x72\xa4\xb8\x4f\x53\x97\xc9\x81\xfb\x34\xf5\x98\x1c\xb8\x4f\x53\x9f\xc9\x81\xfb\x34\x0d\x98\x1c\xb8\x4f\xd3\x90\xc9\x81\xfb\x34\x8d\x98\x1c\xb8\x4f\xd3\x98\xc9\x81\xfb\x34\x4d\x98\x1c\xb8\x4f\x53\xc9\xe4\xc0\x7d\x9a\xa6\x3c\x0e\xcf\x85\x7d\xda\x97\x92\x38\x60\x9f\xf6\xa5\x24\x0e\xd8\xa7\x7d\x29\x89\x03\xf6\x69\x5f\x4a\xe2\x80\x7d\xda\x97\x92\x38\x60\x9f\xf6\xa5\x24\x0e\xd8\xa7\x7d\x29\x89\x03\xf6\x69\x5f\x4a\xe2\x80\x7d\xda\x97\x92\x38\x60\x9f\xf6\xa5\x1c\x0e\x0f\xf7\xa9\xe7\x32\x39\x70\x9f\x7a\x1e\x93\x03\xf7\xa9\xe7\x33\x39\x70\x9f\x7a\x01\x93\x03\xf7\xa9\x17\x32\x39\x70\x9f\x7a\x11\x93\x03\xf7\xa9\x17\x33\x39\x70\x9f\x7a\x09\x93\x03\xf7\xa9\x27\x99\x1c\xb8\x4f\xbd\x94\xc8\xe1\xe3\x3e\xf5\x5d\x26\x07\xee\x53\xdf\x63\x72\xe0\x3e\xf5\x7d\x26\x07\xee\x53\x3f\x18\xc6\xc1\xfb\x30\xf0\xf9\x3e\x59\xde\xac\x7b\xe9\x7b\xe5\xf5\x69\xc7\x3f\x56\xde\x4c\x71\xe1\x4b\xe5\xcd\x0c\x07\x9f\x29\xff\x3b\x00\x00\xb8\x6a\xbb\x3d\x4e\x00\x00")
 
 func templatesCf_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -128,12 +134,12 @@ func templatesCf_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 19837, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 20029, mode: os.FileMode(420), modTime: time.Unix(1792393815, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x55\xc1\x6e\x9c\x30\x10\xbd\xf3\x15\x96\xd5\x53\xa5\xa5\x9b\x4d\x2a\x55\x95\x38\xa5\x97\x5e\xaa\x1e\x7a\xab\x2a\xcb\x98\xc9\x62\xc5\x6b\xa3\xb1\x4d\x95\xae\xf8\xf7\x0a\x1b\x58\x08\x6c\xc2\x56\x89\x1a\x56\x7b\x60\xc6\x33\xef\xcd\xf8\xcd\x80\x60\x8d\x47\x01\x84\xf2\xdf\x96\x59\x10\x1e\xa5\x7b\x60\x7b\x34\xbe\xa2\x84\x0a\xa3\x85\xf1\x68\x81\xa9\x7c\xe6\x3d\x26\x84\x14\x60\x05\xca\xca\x49\xa3\x49\x46\xe8\xf1\x98\xde\xf6\x21\x5f\x4e\xae\xa6\xa1\x09\x21\x75\x25\x98\x2c\x48\x78\x32\x42\xdf\x1d\x5b\xc8\xba\x12\x69\xfb\x97\x45\x43\x93\x84\x10\xa9\xf7\x08\xd6\x86\xe4\x84\x08\x59\x20\xcb\x95\x11\xf7\x96\x64\xe4\x27\xdd\xa6\xe1\xf7\x61\x4b\x7f\x05\x7f\x85\xc6\x19\x61\x54\x97\xd2\x89\x8a\x06\xfb\x1d\x9a\x03\xab\x0c\xba\x60\xff\xb4\x0d\x46\x67\x7a\xd3\x60\x6c\x5e\x0b\x72\xb7\xdb\xed\x16\x40\x3b\xf3\xab\xc1\xde\xdc\x5c\x2f\xa0\x46\x6b\x00\x85\x31\xe6\x29\x36\x23\xd3\x16\xf5\xef\x03\x6a\x46\xe8\xe6\x8a\xae\x60\x1a\x50\x1c\xdf\xf7\x18\xdf\xf8\x01\xe2\x6d\xd7\x1c\x53\xd0\x35\x93\x45\xb3\x19\x74\xb5\x51\xf9\xa6\xd7\xd5\x26\xea\x2a\x24\x69\x92\xe4\x12\x69\x4a\xed\x00\x35\x57\x97\x6a\xf4\x6b\x17\xf7\x12\x5a\x9d\x42\xc7\xde\xc4\xb8\xa9\x27\x7d\x62\xa8\x42\xea\xcb\xa4\x7d\x46\xdc\xe7\xe4\xfd\x5f\x58\xae\x98\x86\xb7\x28\xcc\x5e\x55\xe7\x14\x6a\xbc\xab\xbc\xbb\x44\x8a\x35\x57\x1e\xb2\x15\x0d\x3f\x93\x25\x4a\x6f\x36\x1c\xa0\xf2\x47\x13\x11\xe1\x74\x5b\xe3\xe2\x33\x14\x6e\x4b\x83\x8e\x2d\x95\xdf\x96\x29\xd0\x58\xcb\xfe\x18\x0d\x4c\x19\x5e\xb0\x9c\x2b\xae\x85\xd4\x7b\x92\x11\x87\x1e\xda\x9e\x96\xc0\x95\x2b\x99\x28\x41\xdc\x77\xbd\x8d\xa6\x07\xe6\x4a\x04\x5b\x1a\x55\xc4\xeb\x0e\x3e\xaf\xe7\xde\x8c\x5c\xc5\x6b\x0d\x65\xd7\x5c\x4d\xa9\x5e\x77\x1a\xe0\xb8\x07\x37\xab\xe3\xc7\xed\xf7\xcf\xad\xde\xa3\x08\x9c\x3c\x80\xf1\xee\xd1\xa1\x8f\xbd\x00\x94\xb4\x0e\x34\x60\x47\x54\x6a\xeb\xb8\x16\xb0\x30\x3b\x63\xe7\x48\x6c\x83\xc2\x55\x7e\x0a\x22\xe3\x6f\x4a\x74\x8d\xe6\x62\x32\x1a\xeb\x58\x8c\x67\x66\x4e\xe3\x19\x1e\xe3\xe0\x39\x95\x7f\xe1\xf2\x44\x4b\x9e\xe7\xd2\x7f\x93\x96\xa9\x58\xab\x62\xac\xb5\x8a\x09\x40\x27\xef\xa4\xe0\x0e\xda\xed\x3b\x2c\x5e\xc9\x0f\xcc\x02\xd6\x80\xe3\x23\xa9\xca\xc3\x6b\xca\x51\x37\x43\x3d\x2f\xba\xe0\xac\xcf\x35\x38\x3b\xaa\x66\x48\x16\x3c\x2d\x85\xee\x4c\xfa\xbe\x8b\x3a\xb7\x18\xda\x61\x1c\x6d\x81\x53\x75\xa0\xf2\x09\x99\xb4\x3d\x19\xc7\x7c\x31\x91\x47\xb5\x2e\x4f\xa1\x2d\x1b\x72\xfd\x0d\x00\x00\xff\xff\xfb\x9d\x40\x3e\xea\x09\x00\x00")
+var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x55\xb1\x6e\xdb\x30\x10\xdd\xf5\x15\x07\x22\x43\x52\xc4\xaa\xe3\xa4\x40\x50\x40\x53\xba\x74\x29\x3a\x74\x0b\x0a\x82\xa2\x2e\x36\x11\x8a\x14\x8e\x94\x8a\xd4\xd0\xbf\x17\x94\x45\x5b\x8a\xe5\xc4\x2e\x12\x14\x95\xed\x41\xf7\x78\xf7\x1e\xc9\x77\x67\x42\x67\x6b\x92\x08\x4c\xfc\x72\xdc\xa1\xac\x49\xf9\x27\xbe\x24\x5b\x57\x0c\x98\xb4\x46\xda\x9a\x1c\x72\x9d\xef\xa1\xeb\x04\xa0\x40\x27\x49\x55\x5e\x59\x03\x19\xb0\xf5\x3a\xbd\x8b\x29\x5f\x76\x50\xdb\xb2\x04\xa0\xa9\x24\x57\x05\x74\x4f\x06\xec\x6c\x1d\x28\x9b\x4a\xa6\xe1\xa7\x8a\x96\x25\x09\x80\x32\x4b\x42\xe7\xba\xe2\x00\x52\x15\xc4\x73\x6d\xe5\xa3\x83\x0c\xee\xd9\x3c\xed\x3e\x1f\xe7\xec\x67\x87\x57\x64\xbd\x95\x56\xf7\x25\xbd\xac\x02\x11\xc0\x03\xd9\x92\x57\x96\x7c\x17\xbf\x9d\x77\x41\x6f\x63\x68\x1b\x6c\xdf\x8b\x72\xb1\x58\x2c\x26\x48\xfb\xf0\xbb\xd1\xde\xdc\x5c\x4f\xb0\x6e\xa2\x1d\x29\x0e\x39\x77\xb9\x19\x8c\x8f\x28\xbe\x6f\x59\x33\x60\xb3\x2b\x76\x84\xd2\x8e\xc5\x8b\x65\xc0\xd8\xd9\xba\x44\x5a\xe2\x79\x23\x28\x0d\xb1\x4b\x28\x45\x75\xce\xbe\x89\x12\xd9\x65\x70\x40\x00\xd0\x34\x5c\x15\xed\x6c\xeb\xb5\x99\xce\x67\xd1\x6b\xb3\x8d\xd7\x2e\x2e\x5a\x96\xb4\x49\x72\x8a\x5d\x95\xf1\x48\x46\xe8\x53\x7d\xfb\xb5\xcf\x7b\x0b\xff\x8e\xa9\xc3\x99\xdc\xf7\x79\x63\x24\x1d\x29\x7f\x86\x85\xd2\xa7\x98\xe0\x76\x7e\xc0\xf0\x87\x2c\xff\x4f\x54\x1e\xd1\x21\xff\x8b\x59\xa3\xd3\x0e\xb9\xd6\xd6\xbe\xaa\xfd\x29\xf6\x6c\x84\xae\x31\x3b\xe2\x12\x0e\x54\xe9\x6e\x63\xbf\x61\x50\xe7\xcf\xba\x64\x43\x67\x44\x89\x30\xf9\x64\x71\xe3\x6e\x65\xc9\xf3\xa9\xed\x07\x0b\x4a\xb2\xce\xf1\xdf\xd6\x20\xd7\x56\x14\x3c\x17\x5a\x18\xa9\xcc\x12\x32\xf0\x54\x63\x38\xe7\x15\x0a\xed\x57\x5c\xae\x50\x3e\xf6\x77\xba\x09\x3d\x71\xbf\x22\x74\x2b\xab\x43\x73\x65\x10\xee\x1f\xa0\x36\xfb\x68\x06\x57\xc1\xc2\xc1\xbf\x1e\xa9\x11\x3a\xca\x0c\xdf\x0c\xae\x7b\x5f\x08\x5a\xa2\x07\x18\x83\xec\xc7\xdd\xf7\xcf\xa1\x35\x82\x5e\x00\xaf\x4a\xb4\xf5\x78\x55\x06\x9f\xa2\x29\xb4\x72\x1e\x0d\x52\x2f\x54\x19\xe7\x85\x91\x38\xd1\x4f\x43\x70\x60\xc0\xad\xeb\x75\xbe\x4b\x8a\x34\x7d\xa2\xce\x77\x29\x00\xe3\x76\x39\x4e\xc5\xb0\x8f\xf6\x65\xbc\xa2\x63\x98\xbc\x2f\xe5\x6f\xb4\xbc\x70\x24\xaf\x6b\x89\xff\x5d\xd3\x52\x9c\xd3\xe1\x58\x00\x9c\xd3\x5c\x22\x79\xf5\xa0\xa4\xf0\x18\x26\xf2\x76\x18\x2b\x51\x72\x87\xd4\x20\x0d\x97\xa4\x3a\xef\x5e\x53\x41\xa6\xdd\xee\xe7\x4d\x87\x9e\xab\x73\x83\xde\x45\xbd\xc3\x62\x1d\x12\x24\xf4\x6b\xd2\x0f\x7d\xd6\x68\xf4\xc4\xa1\xf3\xc2\xc0\x08\x4d\x3a\x98\x0e\xbb\x5d\xa3\xce\x47\x22\xd3\xb0\xf2\x85\x42\x35\xe9\xe3\xea\x14\xc6\x71\x23\x4a\x6c\x59\xd2\x26\x7f\x06\x00\x33\xf3\xdc\x96\x2a\x0a\x00\x00")
 
 func templatesConcourse_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -148,12 +154,32 @@ func templatesConcourse_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 2538, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 2602, mode: os.FileMode(420), modTime: time.Unix(1792393815, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesLb_subnetTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x93\x51\x6e\x9c\x30\x10\x86\xdf\x39\xc5\x68\x94\x87\xb4\xdd\xb8\xdb\xa8\xaf\x7b\x85\x5e\xa0\x8a\x2c\x63\x4f\xc9\xa8\x8e\xbd\xc2\x86\x74\x8b\xb8\x7b\x85\x4d\x0a\x16\x6c\xbb\x52\x40\x48\xc8\xf6\x7c\xf3\x8f\xe7\x9f\x96\x82\xef\x5a\x4d\x80\xea\x35\xc8\xd0\xd5\x8e\x22\x02\xda\x7a\xfe\x0f\x08\x43\x05\xa0\x7d\xe7\x22\xac\x9f\x13\xe0\xdd\x60\xc9\x35\xf1\xf9\xbe\x57\xad\x50\xbd\x62\xab\x6a\xb6\x1c\x2f\xf2\xb7\x77\x14\x3e\x8c\x58\x01\xf4\x67\x2d\xd9\x6c\x22\xa7\x6c\xfd\x59\x8b\xe9\x63\x93\x4e\x6a\x36\xad\xac\xad\xd7\x3f\x8b\x93\xd3\x72\xd6\x72\x8f\x5f\x8e\x22\xbd\x9f\x1f\x8f\x78\x80\xaf\x87\xac\x4b\xb0\x33\xf4\xeb\xd3\x63\x4e\xb8\x11\x92\x31\x64\xe9\x85\x5c\xbc\xa2\xb5\x20\x4d\x9c\x0a\x20\xaa\x26\xa4\xe2\x01\xbe\xa9\x97\x19\x33\x85\x93\xeb\x25\x9b\xf1\xc1\xd6\x0f\x59\xd8\xdd\xb0\x8a\x4e\x22\xc6\x09\x60\xf9\x07\xe9\x8b\xb6\x34\x53\xb8\x71\xbe\x25\xa9\x9f\x95\x6b\x28\xc0\x09\xbe\xe3\x52\x33\x1e\x00\x37\xba\xf0\x29\xb1\xc6\xaa\x2a\xfb\xd4\xfa\x2e\x92\x8c\xaa\xb6\x94\x9b\x55\x2c\x0c\xcb\xb5\xef\xdf\xf5\x3e\xef\x0a\xc9\x50\x88\xec\x54\x64\xef\xe4\xaa\x45\x27\xc0\xb7\x66\x1c\xa7\x8a\x1b\x15\xe9\x55\x5d\x8a\xa4\xec\x22\xb5\x8e\xa2\x9c\x37\x05\x37\x6f\xcd\x5e\xa5\x29\x42\x56\xeb\xa2\x54\xf3\x2f\xe9\x33\x48\x85\xe0\x35\x27\xa9\x08\x98\x77\xfe\xe3\xe4\x5b\x6d\x9c\x19\x7f\x9d\x5c\x58\x6a\x99\x1c\xb1\x64\x13\x1f\x05\x9b\x8d\xad\xde\x55\xb8\xef\xe2\xb9\x8b\xab\xe1\x94\x6c\xe6\xaa\x7a\x65\x3b\x4a\x8e\xca\xb4\x7d\x39\x23\x3e\xed\x73\xb6\x55\xdf\x8e\xdd\xc4\x5e\xcd\x92\x26\xf9\x76\xf0\x62\xb6\x4c\xfc\x13\x00\x00\xff\xff\x29\xc2\x4a\x36\xab\x04\x00\x00")
+var _templatesJumpbox_eipTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x7a\x00\x85\xff\x72\x65\x73\x6f\x75\x72\x63\x65\x20\x22\x61\x77\x73\x5f\x65\x69\x70\x22\x20\x22\x6a\x75\x6d\x70\x62\x6f\x78\x5f\x65\x69\x70\x22\x20\x7b\x0a\x20\x20\x64\x65\x70\x65\x6e\x64\x73\x5f\x6f\x6e\x20\x3d\x20\x5b\x22\x61\x77\x73\x5f\x69\x6e\x74\x65\x72\x6e\x65\x74\x5f\x67\x61\x74\x65\x77\x61\x79\x2e\x69\x67\x22\x5d\x0a\x20\x20\x76\x70\x63\x20\x20\x20\x20\x20\x20\x3d\x20\x74\x72\x75\x65\x0a\x0a\x20\x20\x74\x61\x67\x73\x20\x3d\x20\x22\x24\x7b\x76\x61\x72\x2e\x74\x61\x67\x73\x7d\x22\x0a\x7d\x0a\x03\x00\xa6\xc2\x3e\x79\x7a\x00\x00\x00")
+
+func templatesJumpbox_eipTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesJumpbox_eipTf,
+		"templates/jumpbox_eip.tf",
+	)
+}
+
+func templatesJumpbox_eipTf() (*asset, error) {
+	bytes, err := templatesJumpbox_eipTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/jumpbox_eip.tf", size: 122, mode: os.FileMode(420), modTime: time.Unix(1792394292, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesLb_subnetTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x53\x51\x8e\x9b\x30\x10\xfd\xe7\x14\xa3\x51\x3e\x92\x36\xeb\x46\x55\x7f\xf7\x0a\xbd\x40\x15\x59\xc6\x4c\xd9\x51\x1d\x3b\xc2\x86\x6d\x8a\x7c\xf7\xca\x38\x5b\xb0\x58\xda\xad\x16\x84\xb0\xc6\x9e\xf7\xde\x78\xde\x74\xe4\x5d\xdf\x69\x02\x54\xcf\x5e\xfa\xbe\xb6\x14\x10\xd0\xd4\xf7\xb5\x47\x18\x2b\x00\xed\x7a\x1b\x60\xf9\x3c\x02\xee\x46\x43\xb6\x0d\x4f\xfb\x41\x75\x42\x0d\x8a\x8d\xaa\xd9\x70\xb8\xc9\x5f\xce\x92\x3f\x44\xac\x00\x86\xab\x96\xdc\xac\x32\x13\xdb\x70\xd5\x22\x7d\xdc\x4c\x27\x35\x37\x9d\xac\x8d\xd3\x3f\x8a\x93\x29\x9c\xb5\xec\x17\xcb\x44\x99\xa0\x53\xe8\x08\x5f\x8e\x70\x3a\x4c\xbf\x49\xa8\x60\xdb\xd0\xcf\x8f\x9f\xb3\x82\x95\xb2\x8c\x4b\x86\x2e\x64\xc3\x86\xf8\x02\x29\xe1\x54\x00\x41\xb5\x3e\xe7\x5e\xa8\x6b\x69\xca\x4c\xb1\x23\x5c\xd4\x75\x8f\x5f\xd5\x85\xf0\x98\xb6\xd3\x06\xd9\x41\x72\x13\x1f\x4c\xfd\x90\x25\xef\xc6\x05\x62\xc4\xc3\x1d\xd4\xf0\x77\xd2\x37\x6d\x68\xba\x67\x00\x6e\xad\xeb\x48\xea\x27\x65\x5b\x4a\x74\xdf\x70\xbe\x98\x04\xbf\xd2\x8a\xe7\x0a\x20\x56\xb1\xaa\xca\x66\x76\xae\x0f\x24\x83\xaa\x0d\xe5\x8e\x16\x81\x71\xee\xcd\xeb\x0d\x29\x0a\x7e\x29\x35\xe2\x06\xcf\x06\x43\x43\x3e\xb0\x55\x81\x9d\x95\x8b\xfe\x3e\x02\x9e\xc4\xf4\x7e\x3a\xa5\x16\xb5\x2a\xd0\xb3\xba\x15\x62\xd8\x06\xea\x2c\x05\x79\xdf\x14\xdc\xbe\x38\x65\x41\x53\xa4\x2c\xe2\xa2\x54\x93\x33\xff\x76\x45\x52\x79\xef\x34\x4f\x52\x11\x30\x43\xfd\x63\x0c\xde\x3a\x03\xd9\x00\x7f\xc6\xa0\xb0\xdf\x3c\x76\x62\x66\x13\x1f\x04\x37\x2b\x0b\xbe\xab\x70\xd7\x87\x6b\x1f\x16\x93\x2d\xb9\xb9\x57\x35\x28\xd3\xd3\xe4\xb4\xdd\xb8\x2d\x27\xe2\xf9\x75\x9c\x75\xd5\x6f\x87\x5d\xe5\x6e\xb2\x24\xf7\xfc\x07\xf0\x6c\xb6\x88\xe7\x2a\x56\xbf\x07\x00\x41\xd8\x69\xe1\xe8\x04\x00\x00")
 
 func templatesLb_subnetTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -168,12 +194,112 @@ func templatesLb_subnetTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/lb_subnet.tf", size: 1195, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/lb_subnet.tf", size: 1256, mode: os.FileMode(420), modTime: time.Unix(1792393815, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNamed_networksTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x98\x4b\x4f\xeb\x38\x14\xc7\xd7\xe4\x53\x58\x56\x17\x74\xd4\x66\x0a\x88\xd9\x65\x35\x7b\x34\x8b\xd9\x5d\x5d\x59\x6e\x7c\x08\xbe\x24\xb6\xe5\x47\x78\x44\xf9\xee\x57\xb6\x09\x4d\x9a\xb4\x94\x5b\x84\x0a\x46\x6c\xfc\x8f\x8f\xff\xe7\xe7\x93\xc7\x69\xd3\x68\x2a\x0a\x40\xe9\x0d\xd8\x07\xa9\xef\x4d\xdb\x26\x35\xd5\x9c\xae\x4b\x40\x58\xc4\x49\xd2\x34\xe9\x0d\xad\xa0\x6d\x49\xce\x99\xc6\xa8\x49\x10\xb2\x4f\x0a\x50\x86\xb0\xb1\x9a\x8b\x02\x27\x6d\x92\x68\x30\xd2\xe9\x1c\x10\xa6\x0f\x86\x18\xc8\x9d\xe6\xf6\x89\x14\x5a\x3a\x85\x27\xa2\xc5\x40\x0c\x4c\xae\xb9\xb2\x5c\x0a\x1f\x6f\xa3\x26\x08\xd5\x2a\x27\x9c\xa1\x30\x32\x84\x67\x8d\x0f\x5c\xab\x3c\xf5\xff\x9c\xb5\x38\xf1\x4e\x68\x61\xfc\xca\x59\x53\x81\x2e\xe0\xbc\xa6\x3a\xb5\xb4\x30\x0b\x54\x51\x75\x8e\xbd\x73\xbc\xf0\xb2\x17\x40\xd4\x84\xb3\x76\xf9\xba\xcd\xb2\xf3\xb9\x8c\x3e\xe7\xf3\xf6\xcd\x64\x88\x76\x25\x4c\x65\x14\x04\x62\x73\x15\x53\xdb\x5a\xd5\x65\xd2\xcb\x65\x78\x45\x3a\x0a\x18\x93\x7c\xa1\xdd\xad\xee\x8f\x0c\x61\x2e\x0a\x0d\xc6\xe0\x04\x21\xa5\xa5\x95\xb9\x2c\x3b\xf5\x75\x64\x08\x7b\x57\x09\x42\xb7\x5a\x56\x44\x49\x6d\x3b\xa9\x1b\x19\x5a\x79\x98\x72\x52\xf4\xf2\x3f\xd7\xd7\x57\xd7\x21\xab\xf2\xb6\x9b\x1d\x8c\x0c\x59\xed\xe0\x48\x78\x8e\x9d\x22\x3c\xc7\xbe\x04\x3c\x9e\x57\xa7\x48\x2f\xd8\xda\x8f\x6f\x79\xb1\x9f\x5f\xd0\xfd\xd3\x87\xac\x4b\x99\xdf\x9b\x6d\xfd\x07\x5e\xa5\xe1\xef\xef\x15\xfe\x79\x24\x45\x5a\x96\xf2\x81\x70\x61\x41\x0b\xb0\x9f\xc9\x13\x0e\xc2\xb9\xbc\x38\xae\x16\x57\x9f\x87\xd2\x98\xbb\x13\xac\xc7\xff\xff\xfd\xef\x0d\x82\x97\x97\xfb\x11\x06\x3d\x52\xd9\x06\xc2\xd9\xce\x9c\x7e\xb9\x4a\xad\xe5\x63\xcc\xe4\x38\xb0\x6b\x69\xee\xbe\xfd\x8b\xe6\xbd\x7c\x03\x94\xad\xb9\x8f\x62\xfd\xdd\xdf\x4b\x9f\xcd\x7a\x62\x7d\x90\x7a\xf0\xff\xbc\xbc\x77\x9a\xfb\xe2\x05\xbe\xa3\x94\x3e\x0e\xb9\x63\xa7\x89\xdc\xb1\xd3\x43\x2e\x9d\x55\xce\x4e\x3d\x35\x86\x21\xe2\x23\xba\xa6\xa5\x83\xec\xc8\x63\x75\xeb\xf0\x41\x32\xb5\x65\x90\x4c\xdc\x2b\x97\x4e\x0c\x29\x84\xec\x4a\x10\x85\xbd\x0b\xfd\x11\xad\x29\x2f\xe9\x9a\x97\xde\xc4\xb3\x14\x60\xe6\xa3\x86\x6b\x5f\xdb\xd5\xff\x80\x18\x5c\xe9\xa7\xa3\x97\xb0\xcf\xd8\xa8\xbf\x60\x81\xae\x16\xd1\x64\xca\x05\x83\xc7\xb8\xf7\xc8\x93\x3f\xf8\x59\x03\x25\x54\x20\xec\x0e\xdb\xa3\x38\x1f\xd0\x10\x06\xfb\xb3\xa6\x17\xb8\xdd\xd1\x16\x6a\xe9\x2c\x10\xeb\xbb\x65\x42\x8d\x91\x39\xa7\xbe\x8f\xc5\x08\x47\xe5\x7d\xe7\x74\xe8\x21\xc5\x18\xaf\xe7\x34\xa0\xb4\x29\x93\x74\xe7\xe6\xe9\x5f\x29\x67\x13\x07\xd0\xcf\x86\xb3\x71\xdc\x9e\x9e\xc6\xaf\x63\x5a\x0e\x26\x27\xe3\xee\xbd\x53\xe8\xf3\x8b\x29\xc2\x19\xa9\xa8\x52\xfe\xb7\x04\xd4\x24\x67\xe1\x76\x89\x16\x92\x33\x84\x9e\xb9\x0a\xcd\xfc\xac\x39\x30\xc1\x11\xbb\x36\x1e\xf9\xc1\x7c\x5a\x3c\x4f\xce\x0e\xb7\xef\xcb\xfa\x94\x12\xd8\xdc\x9e\x9b\x44\x9a\x06\x04\x6b\xdb\xe4\xf7\x00\x69\x4f\x44\x6a\xf5\x11\x00\x00")
+
+func templatesNamed_networksTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNamed_networksTf,
+		"templates/named_networks.tf",
+	)
+}
+
+func templatesNamed_networksTf() (*asset, error) {
+	bytes, err := templatesNamed_networksTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/named_networks.tf", size: 4597, mode: os.FileMode(420), modTime: time.Unix(1792399054, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNat_gatewayTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x92\x41\x8e\xd5\x30\x0c\x86\xf7\x39\x85\x15\xb1\x98\x41\x28\xcc\x05\xde\x82\x0b\xb0\x81\x1d\x42\x91\x9b\x58\x0f\x8b\x90\x44\x8d\xd3\x61\xa8\x7a\x77\x94\xa6\x33\x6a\x87\xea\xcd\x86\x56\x95\x1a\x2b\xf6\xff\xfb\xb3\x47\x2a\xa9\x8e\x8e\x40\xe3\x63\xb1\xc4\x59\x83\x8e\x28\xfd\x6f\x56\x00\x9e\x32\x45\x5f\x6c\x8a\x70\x81\x6f\xeb\x2d\x8e\x42\x63\x24\xb1\x57\x14\x7a\xc4\x27\xc3\x57\xfd\x5d\x01\x4c\xd9\xc1\xf6\x5c\x40\xc6\x4a\x4a\x01\x08\x5e\x0b\x5c\x40\xbf\x9b\x27\x1c\x4d\x3b\x2d\x5a\x2d\x4a\x1d\x85\x23\xbe\x54\xeb\x06\xfe\x11\x07\x78\x53\x1f\x43\x48\x0e\x85\x53\xb4\xec\xbb\xe6\xd6\x94\xd9\x5a\x32\xec\x17\xad\x00\x4a\x1d\x5a\x3e\xfb\xcd\xec\x76\xb3\x87\xcd\x3c\x9b\xcf\x9f\xbe\x7e\x59\x0f\xcb\xd2\x73\x6e\x74\x92\xaa\xe4\x2a\xaf\xb0\x4d\x18\x2a\x9d\x7b\xc8\x75\x08\xec\x2c\xe7\x33\x10\x63\xaa\x42\x56\x70\x08\xa4\x41\x77\xd2\x18\x8e\xe1\x46\x66\xca\xee\xd0\xe4\x94\x9d\x69\xdf\x5b\x66\x4f\xd4\x6e\xea\x78\x2a\xc2\xb1\x43\x75\xec\x47\x3b\x84\xe4\x7e\xb6\xca\x0f\x66\x7d\x3f\x3e\x34\xa0\xbb\xf9\x1d\x6c\xed\xe2\x6d\x06\xcf\xfc\x77\x32\x87\xeb\xbb\xb8\x39\xf3\xd4\xf3\x6f\x41\xb3\x58\x4a\x72\xbc\x1a\xd6\xa0\x7b\xc1\x97\x52\x7d\xc0\xa5\x23\x74\xa9\x46\x79\x5e\xd8\x6d\x0b\x02\xc5\xab\xfc\xb8\x6b\xcc\x70\x42\x0e\x38\x70\x60\x79\xb2\x7f\x52\xa4\x72\x7f\xb2\x3b\x3d\x8d\x02\xfd\xa2\x28\x77\xbb\x25\x7a\xad\x69\xde\x1b\xf6\x1f\xba\xa8\xe1\xe8\xe9\xf7\xfd\x7f\x41\xf1\x77\x00\x2a\xa2\x06\x3a\xc1\x03\x00\x00")
+
+func templatesNat_gatewayTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNat_gatewayTf,
+		"templates/nat_gateway.tf",
+	)
+}
+
+func templatesNat_gatewayTf() (*asset, error) {
+	bytes, err := templatesNat_gatewayTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/nat_gateway.tf", size: 961, mode: os.FileMode(420), modTime: time.Unix(1792402932, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNat_gateway_per_azTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\xd1\x8e\x9b\x3a\x10\x7d\xe7\x2b\x46\x56\x1e\x92\x7b\xb3\x6e\x1e\xfa\xd0\x97\xfd\x85\xfe\x40\xb5\xb2\x8c\x99\x92\x51\x1d\x1b\x61\xc3\x36\x8d\xf8\xf7\xca\x86\x12\x13\x48\x96\x5d\x2d\x51\x14\x32\xd8\xc7\x67\xce\x39\x4c\x8d\xce\x36\xb5\x42\x60\xf2\xd5\x09\xd7\xe4\x06\x3d\x03\x66\xa4\x1f\xfe\x38\x06\x97\x0c\x40\xd9\xc6\x78\x48\xaf\x67\x60\x9b\x8b\x46\x53\xfa\xe3\xb6\x95\x35\x97\xad\x24\x2d\x73\xd2\xe4\xcf\xe2\x8f\x35\xe8\x76\x1d\xcb\x00\xda\x4a\x09\x2a\x66\x3b\xc3\x71\x6d\xa5\x78\xf8\x52\x11\x57\x2a\x2a\x6a\x91\x6b\xab\x7e\x4d\x56\x86\x72\xcf\x65\x9b\xdc\x86\x23\x03\x74\x28\xed\xe1\xeb\x1e\x0e\xbb\xf8\x13\x89\x72\x32\x05\xfe\xfe\xff\x5b\xcf\x60\xc6\xac\xc7\x45\x8d\x27\x34\xfe\x0e\xf9\x09\x52\xc0\xc9\x00\xbc\x2c\x5d\xbf\xf7\x84\x75\x89\x71\x67\xa8\xed\xe1\x24\xab\x2d\xfb\x2e\x4f\xc8\xf6\xe1\x71\x78\x80\xa6\x15\x54\x74\x4f\x46\xfa\xa7\x9e\xf3\xe6\x92\x40\x76\x6c\x37\xa0\x6a\xfa\x89\xea\xac\x34\x46\xa1\x01\xa8\x34\xb6\x46\xa1\x8e\xd2\x94\x18\xce\xfb\xc1\xae\xca\x04\xfc\x19\x59\xf6\x92\x01\x74\x59\x97\x65\x53\x3b\x6b\xdb\x78\x14\x5e\xe6\x1a\x85\x74\xce\x2a\x92\x9e\xac\x61\xc0\xfa\x27\x6f\xb9\xbc\xd6\xe2\x1e\x63\x74\x79\xa2\xee\x35\x56\x3c\x39\x8e\xff\xc7\xa9\x98\x49\x0c\x90\x32\xa6\xe2\x9a\x94\xa4\xce\x73\xeb\x8e\x93\x42\x8c\xcf\xac\x79\xa4\x6a\x08\x72\xbc\xbb\x69\x6f\x6d\x6b\x05\x56\x68\x0a\x27\xac\x89\x46\x04\x2e\x64\x3c\xd6\x06\xbd\x28\xa5\xc7\x57\x79\xe6\x54\x46\x03\xda\x4a\x5d\x85\xf3\x75\x83\xd3\xc8\xfc\x0b\xcb\x12\x57\x23\x47\xb4\x9e\xf3\x92\x1d\x1f\xa0\x0c\xf0\x26\x6b\xa9\xb5\x55\x31\x16\xa3\xe0\xa9\x75\x48\x15\x1f\x34\xbc\xe7\xd9\xd4\xfd\x0f\x9b\xff\x40\x2c\xdb\xf8\xaa\xf1\xa3\x99\x43\x58\x5b\xa9\x1b\x8c\xfd\x6d\x2e\x73\xaa\x55\x93\x6b\x52\x82\xaa\x8e\xbd\x3c\x7c\x33\x18\xb0\xde\x51\xa9\xa7\xe5\xc4\x81\x77\xce\xba\xe5\x01\xb7\x3a\x0d\x91\xc5\x1a\x5a\xb7\xd7\xfa\x84\x38\x4f\xa6\x37\x3d\x19\xba\xcf\xc0\x0e\x3c\x7e\xbe\x1c\xc2\xb2\x24\x95\xe3\xbb\xbd\xe4\x70\xb2\x2e\x64\xe5\x5e\x4e\x92\x2e\x1e\xc2\x25\xeb\xf8\x92\x04\xcb\xf8\xef\x1e\x7e\x23\xf4\x90\xca\x45\x65\x3f\x79\x02\xde\x9e\xb9\x52\xaa\x4f\x93\xe8\xef\x00\x3a\xa7\x6e\x47\xee\x07\x00\x00")
+
+func templatesNat_gateway_per_azTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNat_gateway_per_azTf,
+		"templates/nat_gateway_per_az.tf",
+	)
+}
+
+func templatesNat_gateway_per_azTf() (*asset, error) {
+	bytes, err := templatesNat_gateway_per_azTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/nat_gateway_per_az.tf", size: 2030, mode: os.FileMode(420), modTime: time.Unix(1792393815, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNat_instanceTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x56\x4d\x6f\xe3\x36\x10\xbd\xfb\x57\x0c\x88\x1c\xe2\xc2\x66\xb3\x2d\xd2\x02\x05\x7c\x30\xb0\x3d\xec\xa1\x41\x81\x0d\xd0\xc3\x22\x20\x68\x72\xa2\xb0\x96\x48\x82\xa4\x94\x4d\x05\xfe\xf7\x82\xa4\x65\xc9\x8a\x9d\x3d\x34\xb7\xae\x82\x00\xd6\x23\x39\x6f\xe6\x71\x3e\xd4\x71\xa7\xf8\xae\x46\x20\x9a\x07\xc6\x1b\xc5\x1a\x6e\x09\xf4\x0b\x80\xf0\x62\x11\x36\x40\x12\xb0\x58\x00\x48\x7c\xe4\x6d\x1d\x60\x93\x57\xfb\xde\x71\x5d\x21\x5c\xed\xf1\x65\x05\x57\x1d\xaf\x5b\x84\xdf\x36\x40\xb7\x7f\x7d\xbe\xdb\xde\x6f\xff\xf8\xe4\x63\x04\xe8\xfb\xb4\x21\xc6\x64\xa8\xef\xcb\xb6\x18\x49\x36\x80\x5a\xc6\x18\x17\x71\xb1\x70\xe8\x4d\xeb\x04\x02\xe1\xcf\x9e\x79\x14\xad\x53\xe1\x85\x55\xce\xb4\x96\x14\xd7\xe6\x60\xf2\x41\xa2\x17\x4e\xd9\xa0\x8c\x2e\xf6\xe9\xdd\xf6\xfe\xe3\x08\x16\xa2\xce\x0a\xa6\x24\xe4\x67\x03\xe4\xaa\x4f\x1c\x9d\x15\x34\xfd\x2b\x19\x73\x70\x4a\x57\x0e\xbd\xcf\xa1\x01\x58\x67\x82\x11\xa6\x3e\x1c\x09\xc2\x26\x43\x00\x8f\xce\x34\xcc\x1a\x17\x32\x7e\x93\xb1\x60\x06\x24\x61\xbf\xdc\xde\xfe\x7c\x9b\xf1\x53\x87\x3d\x6c\xe0\xcb\x81\xfb\x74\x85\x2a\x1d\xd0\x69\x5e\xcf\x42\xcc\xae\x0d\x2a\xd3\x3b\x0c\xcf\xc6\xed\x7d\x8c\x2b\x38\x6f\x46\x97\x1d\x2c\xa9\xc0\x1b\x8c\xf1\x60\x20\xab\xdc\xf7\xea\x11\xe8\x9f\x4e\x75\x3c\xe0\x65\x1b\x3b\xe3\x9f\xe6\x58\xb2\x72\x69\xff\xdf\x6d\x63\x77\xe6\xeb\x94\xe9\x61\x01\x10\xbf\xa9\x68\x2b\xbf\x2b\xfa\xbe\x8a\x2a\xd1\x9c\x95\x74\xfd\xe1\x8c\xa6\x07\xf0\xff\x2e\x28\x4e\xf5\x1c\x65\x9b\xe7\xe1\xf0\x7e\x54\x7c\x03\x64\xfd\xa1\x88\x2d\x94\x74\x6c\x57\x1b\xb1\x2f\xf2\xdd\xd0\xfc\xf7\xe3\x0d\x39\xb2\x04\x5e\xa5\x35\x72\xd5\x37\xe8\x2a\xbc\xee\xb8\xa3\x09\x5b\x41\xc3\xed\x35\x49\xa9\x55\xd2\x21\x2d\xa0\xee\x98\x92\x71\xad\x79\x58\x0f\x91\xac\x73\x24\x64\xb9\x8c\xe4\x75\xb3\x54\xda\x07\xae\x05\x96\x36\x59\xfa\xa2\x2d\x22\x32\x65\x61\xfa\x64\x1f\x92\xc3\x4f\xc6\x87\xeb\x74\xd8\xb7\x3b\x8d\x81\xa6\xfb\xd8\xde\x7f\xce\x2f\x31\xd2\x31\xa6\x15\xfc\x9a\x48\x53\x31\x17\x16\x96\x87\xc2\x60\x30\x45\x15\x7e\xa2\x0d\x4a\xd5\x36\x69\x5b\xb1\x77\xec\xb6\x00\xb3\xae\x7b\x9e\x2f\x5d\x4d\x3a\x9c\x47\x00\x93\xe8\x03\x13\x4f\x28\xf6\xc3\xe1\x47\x5e\x7b\x5c\x00\xf0\x46\x0d\x16\xa7\x4f\xb6\x5e\x1b\xb3\x6f\x6d\xd6\x76\x32\xc8\x56\x90\x00\x87\x95\x32\x7a\x79\x1c\x06\xa7\x19\xc2\x94\x7c\x23\xf3\x5f\x8f\x9e\x9c\xf4\x0f\xff\xe5\x62\xd3\x6d\xff\xae\xbb\x4f\x1f\x5f\xad\x5e\xb8\x63\x54\xc3\x14\xcc\xbf\xd2\x15\x4b\xb4\xa8\xa5\x67\x79\xf2\x7d\x39\x64\x42\xea\x7a\x18\x58\xc5\x03\x3e\xf3\x17\xaa\x2a\xf2\x30\xb9\xbb\xf1\x1a\x06\x84\x6a\x1e\x06\xf5\x3b\x2b\x06\x39\x83\x6b\xf1\x34\xbe\x21\xb2\x92\x80\xa6\x0d\xb6\x0d\x33\x87\xf2\x64\x1f\x29\x50\x59\x7a\x58\xa7\xb6\xdd\xd5\x4a\x30\x65\xcf\xc5\xe6\x4c\x1b\x90\x85\xf4\x0d\x42\x80\x1c\x1b\xcd\x09\xdc\x8f\x53\xfc\xe2\x00\xbf\xe4\xec\x19\xb6\x37\x79\x52\xfa\x29\xcd\xd3\xe7\x03\x1b\x0b\x21\x59\x1e\x4b\x7b\x5a\x10\x4a\xbe\xad\xeb\x84\xe0\x64\xef\x04\x1f\xdb\xeb\x09\x28\xbf\x21\x17\xe3\xde\x1b\xa1\xb2\xab\x04\x48\x39\x7b\x34\x55\x4a\xcd\x97\x86\x20\x4c\xab\xc3\xbc\x62\x50\x57\xe1\x29\x57\x0c\xef\xb8\xaa\xf9\x4e\xd5\xa9\x6d\xfe\x63\x34\xfa\x65\x3c\x53\xce\xd9\x77\xac\xb1\x41\x7d\xd2\x3e\xe6\x9c\xf4\x07\xaa\xe4\xaa\x90\x52\xa5\x25\x7e\x5d\xbe\x8b\x14\xff\x0e\x00\x83\x43\x91\xa3\xac\x0a\x00\x00")
+
+func templatesNat_instanceTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNat_instanceTf,
+		"templates/nat_instance.tf",
+	)
+}
+
+func templatesNat_instanceTf() (*asset, error) {
+	bytes, err := templatesNat_instanceTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/nat_instance.tf", size: 2732, mode: os.FileMode(420), modTime: time.Unix(1792406372, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNat_subnetTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\x50\x6d\x6a\xc4\x20\x10\xfd\xef\x29\x86\xa1\x3f\x76\x21\x1b\x5a\xe8\xdf\x5e\xa1\x57\x90\x89\x0e\xdb\xa1\x89\x06\x35\x29\xed\xe2\xdd\x8b\x71\xbb\x71\xb7\x82\x38\xbe\xf9\x78\xf3\x5e\xe0\xe8\x97\x60\x18\x90\xbe\xa2\x8e\xcb\xe0\x38\x21\xa0\xa3\x74\xfb\x5c\x14\xc0\x3a\x1b\x2d\x16\x9a\xf3\x06\xf8\x74\x29\x3d\xeb\x6c\xfa\x72\xc5\x66\x54\x00\x46\x6c\xd0\xc3\xe8\xcd\xe7\x5d\x65\x81\xeb\xc0\x43\x13\xae\x14\x4a\xaf\x2e\x50\x07\xaf\x1d\x3c\x1f\xb7\xe7\xe5\xb8\xcd\xa2\x95\x64\xa4\x41\x46\x49\xdf\xfa\xc7\x3b\xde\x59\xeb\x80\x7e\xf0\xf1\xe3\xba\x68\xff\xaf\x3a\xa3\x52\x00\x89\xce\xb1\xf6\x4d\x1c\xce\xbc\x71\x16\xac\x83\x89\xe6\x03\xbe\xd3\xc4\xd8\x95\x74\x49\xb0\x5b\xb5\xd8\x7c\x72\x94\x4e\x57\xfd\xc7\xb2\x4b\x56\xea\xde\xa9\xe0\x97\xc4\x3a\xd1\x30\xb2\xa6\x18\xbd\x11\x4a\xe2\x1d\x02\xd6\xcc\xa3\x81\x35\xbe\x79\xf8\xa8\x63\x2f\xff\xf3\xb1\x25\x10\xbb\x0b\x6f\xf0\xaa\xbe\x05\xc4\x66\x54\x59\xfd\x0e\x00\xcb\x25\x9f\xef\xd5\x01\x00\x00")
+
+func templatesNat_subnetTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNat_subnetTf,
+		"templates/nat_subnet.tf",
+	)
+}
+
+func templatesNat_subnetTf() (*asset, error) {
+	bytes, err := templatesNat_subnetTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/nat_subnet.tf", size: 469, mode: os.FileMode(420), modTime: time.Unix(1792402932, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesSsl_certificateTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\x41\x6e\xc3\x20\x14\x44\xf7\x9c\x62\x84\xba\xee\x0d\x72\x16\x84\xf1\xb8\xf9\x2a\x31\xd1\x87\xd0\xa2\x88\xbb\x57\xc6\x1b\xa7\x92\x37\x61\x83\x04\xf3\x46\x6f\xaa\x57\xf1\x53\x24\x6c\xce\xd1\x05\x6a\x91\x45\x82\x2f\xb4\x78\x1a\xa0\xb4\x3b\x71\x81\xcd\x45\x65\xfd\xb2\xa6\x1b\x73\x4a\xb8\x70\xf5\xb2\xbe\xc1\xdd\x55\xea\x76\x7f\xb3\x9d\xd2\xca\x9c\x1e\x1a\x08\xeb\x7f\xb2\x13\x7f\x73\x99\x5a\xa9\xaf\xca\x36\x4e\xe3\x61\xaf\x59\xfd\x6d\x2b\xe7\x22\xbf\x5b\xdb\xc7\xb3\x7a\xfd\xcc\xd7\xa4\xc5\x71\xad\x4e\xe6\x6e\x8d\x01\x8e\x2a\x53\x9a\x1b\x0e\xe1\x57\xd3\x6e\xff\xc5\xc7\xe2\xd3\xf8\xfe\x3d\xa0\xc3\x44\xec\xe7\x14\x3a\x44\x77\xbf\x28\x0b\x43\x0b\x91\x63\x14\x10\x94\x43\x95\x4b\x52\xba\x99\xb9\x68\x6a\xb8\xa0\xe8\x83\x06\xe8\xa6\x9b\xbf\x00\x00\x00\xff\xff\x4f\x95\x65\x5c\xd6\x01\x00\x00")
+var _templatesSsl_certificateTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\x31\x6e\xc4\x20\x14\x44\x7b\x4e\x31\x42\xa9\x73\x83\x3d\x0b\xc2\x78\x9c\xfd\x0a\x6b\xac\x0f\x4b\x82\x56\xdc\x3d\xb2\xdd\x90\x48\x6e\x42\x09\xef\x8d\x66\xa8\x5e\xc5\x4f\x91\xb0\x39\x47\x17\xa8\x45\x16\x09\xbe\xd0\xe2\x65\x80\xd2\x36\xe2\x06\x9b\x8b\xca\xfa\x61\x4d\x37\xe6\xd2\x70\xe1\xee\x65\xfd\x87\xb7\xa9\xd4\xdd\xff\x64\xbb\xb4\x95\x39\x3d\x35\x10\xd6\x7f\x65\x27\xfe\xe1\x32\xb5\x52\xc7\x20\x0b\x1b\xa7\xe3\xe2\x8c\x59\xfd\x83\x6e\x53\x2e\xf2\xbd\xa7\xbd\xbd\xaa\xd7\xf7\x7c\x4f\x5a\x1c\xd7\xea\x64\xee\xd6\x18\x60\xac\x32\xa5\xb9\x61\x80\x7f\x37\xed\xf6\x0f\x7e\x2c\xbe\xc4\xcf\x0f\x39\xa4\x61\x22\xce\x73\x29\x0d\xe8\xd9\x2f\xca\xc2\xd0\x42\xe4\x31\x0a\x08\xca\xfd\x7d\xe2\x92\x94\x6e\x66\x2e\x9a\x1a\x6e\x28\xfa\xa4\x01\xba\xe9\xe6\x67\x00\x4f\x95\x65\x5c\xd6\x01\x00\x00")
 
 func templatesSsl_certificateTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -188,7 +314,7 @@ func templatesSsl_certificateTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/ssl_certificate.tf", size: 470, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/ssl_certificate.tf", size: 470, mode: os.FileMode(420), modTime: time.Unix(1792393815, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -249,7 +375,13 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/cf_dns.tf": templatesCf_dnsTf,
 	"templates/cf_lb.tf": templatesCf_lbTf,
 	"templates/concourse_lb.tf": templatesConcourse_lbTf,
+	"templates/jumpbox_eip.tf": templatesJumpbox_eipTf,
 	"templates/lb_subnet.tf": templatesLb_subnetTf,
+	"templates/named_networks.tf": templatesNamed_networksTf,
+	"templates/nat_gateway.tf": templatesNat_gatewayTf,
+	"templates/nat_gateway_per_az.tf": templatesNat_gateway_per_azTf,
+	"templates/nat_instance.tf": templatesNat_instanceTf,
+	"templates/nat_subnet.tf": templatesNat_subnetTf,
 	"templates/ssl_certificate.tf": templatesSsl_certificateTf,
 }
 
@@ -298,7 +430,13 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"cf_dns.tf": &bintree{templatesCf_dnsTf, map[string]*bintree{}},
 		"cf_lb.tf": &bintree{templatesCf_lbTf, map[string]*bintree{}},
 		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
+		"jumpbox_eip.tf": &bintree{templatesJumpbox_eipTf, map[string]*bintree{}},
 		"lb_subnet.tf": &bintree{templatesLb_subnetTf, map[string]*bintree{}},
+		"named_networks.tf": &bintree{templatesNamed_networksTf, map[string]*bintree{}},
+		"nat_gateway.tf": &bintree{templatesNat_gatewayTf, map[string]*bintree{}},
+		"nat_gateway_per_az.tf": &bintree{templatesNat_gateway_per_azTf, map[string]*bintree{}},
+		"nat_instance.tf": &bintree{templatesNat_instanceTf, map[string]*bintree{}},
+		"nat_subnet.tf": &bintree{templatesNat_subnetTf, map[string]*bintree{}},
 		"ssl_certificate.tf": &bintree{templatesSsl_certificateTf, map[string]*bintree{}},
 	}},
 }}
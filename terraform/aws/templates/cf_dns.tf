@@ -5,9 +5,7 @@ variable "system_domain" {
 resource "aws_route53_zone" "env_dns_zone" {
   name = "${var.system_domain}"
 
-  tags {
-    Name = "${var.env_id}-hosted-zone"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-hosted-zone"))}"
 }
 
 output "env_dns_zone_name_servers" {
@@ -38,7 +36,7 @@ resource "aws_route53_record" "bosh" {
   type    = "A"
   ttl     = 300
 
-  records = ["${aws_eip.jumpbox_eip.public_ip}"]
+  records = ["{{.JumpboxAddress}}"]
 }
 
 resource "aws_route53_record" "tcp" {
@@ -16,9 +16,7 @@ resource "aws_security_group" "cf_ssh_lb_security_group" {
     cidr_blocks = ["0.0.0.0/0"]
   }
 
-  tags {
-    Name = "${var.env_id}-cf-ssh-lb-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-cf-ssh-lb-security-group"))}"
 }
 
 output "cf_ssh_lb_security_group" {
@@ -43,9 +41,7 @@ resource "aws_security_group" "cf_ssh_lb_internal_security_group" {
     cidr_blocks = ["0.0.0.0/0"]
   }
 
-  tags {
-    Name = "${var.env_id}-cf-ssh-lb-internal-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-cf-ssh-lb-internal-security-group"))}"
 }
 
 output "cf_ssh_lb_internal_security_group" {
@@ -73,6 +69,8 @@ resource "aws_elb" "cf_ssh_lb" {
 
   security_groups = ["${aws_security_group.cf_ssh_lb_security_group.id}"]
   subnets         = ["${aws_subnet.lb_subnets.*.id}"]
+
+  tags = "${var.tags}"
 }
 
 output "cf_ssh_lb_name" {
@@ -115,9 +113,7 @@ resource "aws_security_group" "cf_router_lb_security_group" {
     cidr_blocks = ["0.0.0.0/0"]
   }
 
-  tags {
-    Name = "${var.env_id}-cf-router-lb-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-cf-router-lb-security-group"))}"
 }
 
 output "cf_router_lb_security_group" {
@@ -142,9 +138,7 @@ resource "aws_security_group" "cf_router_lb_internal_security_group" {
     cidr_blocks = ["0.0.0.0/0"]
   }
 
-  tags {
-    Name = "${var.env_id}-cf-router-lb-internal-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-cf-router-lb-internal-security-group"))}"
 }
 
 output "cf_router_lb_internal_security_group" {
@@ -188,6 +182,8 @@ resource "aws_elb" "cf_router_lb" {
 
   security_groups = ["${aws_security_group.cf_router_lb_security_group.id}"]
   subnets         = ["${aws_subnet.lb_subnets.*.id}"]
+
+  tags = "${var.tags}"
 }
 
 output "cf_router_lb_name" {
@@ -216,9 +212,7 @@ resource "aws_security_group" "cf_tcp_lb_security_group" {
     cidr_blocks = ["0.0.0.0/0"]
   }
 
-  tags {
-    Name = "${var.env_id}-cf-tcp-lb-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-cf-tcp-lb-security-group"))}"
 }
 
 output "cf_tcp_lb_security_group" {
@@ -250,9 +244,7 @@ resource "aws_security_group" "cf_tcp_lb_internal_security_group" {
     cidr_blocks = ["0.0.0.0/0"]
   }
 
-  tags {
-    Name = "${var.env_id}-cf-tcp-lb-internal-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-cf-tcp-lb-internal-security-group"))}"
 }
 
 output "cf_tcp_lb_internal_security_group" {
@@ -973,6 +965,8 @@ resource "aws_elb" "cf_tcp_lb" {
 
   security_groups = ["${aws_security_group.cf_tcp_lb_security_group.id}"]
   subnets         = ["${aws_subnet.lb_subnets.*.id}"]
+
+  tags = "${var.tags}"
 }
 
 output "cf_tcp_lb_name" {
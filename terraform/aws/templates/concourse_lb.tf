@@ -30,9 +30,7 @@ resource "aws_security_group" "concourse_lb_security_group" {
     cidr_blocks = ["0.0.0.0/0"]
   }
 
-  tags {
-    Name = "${var.env_id}-concourse-lb-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-concourse-lb-security-group"))}"
 }
 
 resource "aws_security_group" "concourse_lb_internal_security_group" {
@@ -60,9 +58,7 @@ resource "aws_security_group" "concourse_lb_internal_security_group" {
     cidr_blocks = ["0.0.0.0/0"]
   }
 
-  tags {
-    Name = "${var.env_id}-concourse-lb-internal-security-group"
-  }
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-concourse-lb-internal-security-group"))}"
 }
 
 output "concourse_lb_internal_security_group" {
@@ -105,6 +101,8 @@ resource "aws_elb" "concourse_lb" {
 
   security_groups = ["${aws_security_group.concourse_lb_security_group.id}"]
   subnets         = ["${aws_subnet.lb_subnets.*.id}"]
+
+  tags = "${var.tags}"
 }
 
 output "concourse_lb_name" {
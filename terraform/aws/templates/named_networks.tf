@@ -0,0 +1,124 @@
+{{range .Networks}}
+variable "network_{{.Name}}_cidr" {
+  type = "string"
+}
+
+resource "aws_security_group" "network_{{.Name}}" {
+  description = "{{.Name}}"
+  vpc_id      = "${aws_vpc.vpc.id}"
+
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-{{.Name}}-security-group"))}"
+}
+
+resource "aws_security_group_rule" "network_{{.Name}}_rule_tcp" {
+  security_group_id        = "${aws_security_group.network_{{.Name}}.id}"
+  type                     = "ingress"
+  protocol                 = "tcp"
+  from_port                = 0
+  to_port                  = 65535
+  self                     = true
+}
+
+resource "aws_security_group_rule" "network_{{.Name}}_rule_udp" {
+  security_group_id        = "${aws_security_group.network_{{.Name}}.id}"
+  type                     = "ingress"
+  protocol                 = "udp"
+  from_port                = 0
+  to_port                  = 65535
+  self                     = true
+}
+
+resource "aws_security_group_rule" "network_{{.Name}}_rule_icmp" {
+  security_group_id        = "${aws_security_group.network_{{.Name}}.id}"
+  type                     = "ingress"
+  protocol                 = "icmp"
+  from_port                = -1
+  to_port                  = -1
+  cidr_blocks              = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "network_{{.Name}}_rule_allow_internet" {
+  security_group_id        = "${aws_security_group.network_{{.Name}}.id}"
+  type                     = "egress"
+  protocol                 = "-1"
+  from_port                = 0
+  to_port                  = 0
+  cidr_blocks              = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "network_{{.Name}}_rule_ssh" {
+  security_group_id        = "${aws_security_group.network_{{.Name}}.id}"
+  type                     = "ingress"
+  protocol                 = "TCP"
+  from_port                = 22
+  to_port                  = 22
+  source_security_group_id = "${aws_security_group.jumpbox.id}"
+}
+
+resource "aws_security_group_rule" "network_{{.Name}}_rule_bosh_tcp" {
+  security_group_id        = "${aws_security_group.network_{{.Name}}.id}"
+  type                     = "ingress"
+  protocol                 = "tcp"
+  from_port                = 0
+  to_port                  = 65535
+  source_security_group_id = "${aws_security_group.bosh_security_group.id}"
+}
+
+resource "aws_security_group_rule" "network_{{.Name}}_rule_bosh_udp" {
+  security_group_id        = "${aws_security_group.network_{{.Name}}.id}"
+  type                     = "ingress"
+  protocol                 = "udp"
+  from_port                = 0
+  to_port                  = 65535
+  source_security_group_id = "${aws_security_group.bosh_security_group.id}"
+}
+
+resource "aws_security_group_rule" "bosh_security_group_rule_{{.Name}}_tcp" {
+  security_group_id        = "${aws_security_group.bosh_security_group.id}"
+  type                     = "ingress"
+  protocol                 = "tcp"
+  from_port                = 0
+  to_port                  = 65535
+  source_security_group_id = "${aws_security_group.network_{{.Name}}.id}"
+}
+
+resource "aws_security_group_rule" "bosh_security_group_rule_{{.Name}}_udp" {
+  security_group_id        = "${aws_security_group.bosh_security_group.id}"
+  type                     = "ingress"
+  protocol                 = "udp"
+  from_port                = 0
+  to_port                  = 65535
+  source_security_group_id = "${aws_security_group.network_{{.Name}}.id}"
+}
+
+output "network_{{.Name}}_security_group" {
+  value="${aws_security_group.network_{{.Name}}.id}"
+}
+
+resource "aws_subnet" "network_{{.Name}}_subnets" {
+  count             = "${length(var.availability_zones)}"
+  vpc_id            = "${aws_vpc.vpc.id}"
+  cidr_block        = "${cidrsubnet(var.network_{{.Name}}_cidr, 3, count.index)}"
+  availability_zone = "${element(var.availability_zones, count.index)}"
+
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-{{.Name}}-subnet${count.index}"))}"
+}
+
+resource "aws_route_table_association" "route_network_{{.Name}}_subnets" {
+  count          = "${length(var.availability_zones)}"
+  subnet_id      = "${element(aws_subnet.network_{{.Name}}_subnets.*.id, count.index)}"
+  route_table_id = "${element(aws_route_table.internal_route_table.*.id, count.index)}"
+}
+
+output "network_{{.Name}}_az_subnet_id_mapping" {
+	value = "${
+	  zipmap("${aws_subnet.network_{{.Name}}_subnets.*.availability_zone}", "${aws_subnet.network_{{.Name}}_subnets.*.id}")
+	}"
+}
+
+output "network_{{.Name}}_az_subnet_cidr_mapping" {
+	value = "${
+	  zipmap("${aws_subnet.network_{{.Name}}_subnets.*.availability_zone}", "${aws_subnet.network_{{.Name}}_subnets.*.cidr_block}")
+	}"
+}
+{{end}}
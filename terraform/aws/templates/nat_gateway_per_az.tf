@@ -0,0 +1,59 @@
+resource "aws_subnet" "nat_subnets" {
+  count             = "${length(var.availability_zones)}"
+  vpc_id            = "${aws_vpc.vpc.id}"
+  cidr_block        = "${cidrsubnet(cidrsubnet(var.vpc_cidr, 4, 0), 4, count.index+8)}"
+  availability_zone = "${element(var.availability_zones, count.index)}"
+
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-nat-subnet${count.index}"))}"
+
+  lifecycle {
+    ignore_changes = ["cidr_block", "availability_zone"]
+  }
+}
+
+resource "aws_route_table_association" "route_nat_subnets" {
+  count          = "${length(var.availability_zones)}"
+  subnet_id      = "${element(aws_subnet.nat_subnets.*.id, count.index)}"
+  route_table_id = "${aws_route_table.bosh_route_table.id}"
+}
+
+resource "aws_eip" "nat_eip" {
+  count      = "${length(var.availability_zones)}"
+  depends_on = ["aws_internet_gateway.ig"]
+  vpc        = true
+
+  tags = "${var.tags}"
+}
+
+resource "aws_nat_gateway" "nat" {
+  count         = "${length(var.availability_zones)}"
+  depends_on    = ["aws_internet_gateway.ig"]
+  allocation_id = "${element(aws_eip.nat_eip.*.id, count.index)}"
+  subnet_id     = "${element(aws_subnet.nat_subnets.*.id, count.index)}"
+
+  tags = "${var.tags}"
+}
+
+output "nat_eips" {
+  value = ["${aws_eip.nat_eip.*.public_ip}"]
+}
+
+resource "aws_route_table" "internal_route_table" {
+  count  = "${length(var.availability_zones)}"
+  vpc_id = "${aws_vpc.vpc.id}"
+
+  tags = "${var.tags}"
+}
+
+resource "aws_route" "internal_route_table" {
+  count                  = "${length(var.availability_zones)}"
+  destination_cidr_block = "0.0.0.0/0"
+  nat_gateway_id         = "${element(aws_nat_gateway.nat.*.id, count.index)}"
+  route_table_id         = "${element(aws_route_table.internal_route_table.*.id, count.index)}"
+}
+
+resource "aws_route_table_association" "route_internal_subnets" {
+  count          = "${length(var.availability_zones)}"
+  subnet_id      = "${element(aws_subnet.internal_subnets.*.id, count.index)}"
+  route_table_id = "${element(aws_route_table.internal_route_table.*.id, count.index)}"
+}
@@ -0,0 +1,83 @@
+variable "nat_ami_map" {
+  type = "map"
+
+  default = {
+  {{range $key, $value := .AWSNATAMIs}}  {{$key}} = "{{$value}}"
+  {{end}}}
+}
+
+resource "aws_security_group" "nat_security_group" {
+  description = "{{.NATDescription}}"
+  vpc_id      = "${aws_vpc.vpc.id}"
+
+  ingress {
+    protocol    = "tcp"
+    from_port   = 0
+    to_port     = 65535
+    security_groups = ["${aws_security_group.internal_security_group.id}"{{range .Networks}}, "${aws_security_group.network_{{.Name}}.id}"{{end}}{{if .Private}}, "${aws_security_group.bosh_security_group.id}", "${aws_security_group.jumpbox.id}"{{end}}]
+  }
+
+  ingress {
+    protocol    = "udp"
+    from_port   = 0
+    to_port     = 65535
+    security_groups = ["${aws_security_group.internal_security_group.id}"{{range .Networks}}, "${aws_security_group.network_{{.Name}}.id}"{{end}}{{if .Private}}, "${aws_security_group.bosh_security_group.id}", "${aws_security_group.jumpbox.id}"{{end}}]
+  }
+
+  ingress {
+    protocol    = "icmp"
+    from_port   = -1
+    to_port     = -1
+    security_groups = ["${aws_security_group.internal_security_group.id}"{{range .Networks}}, "${aws_security_group.network_{{.Name}}.id}"{{end}}{{if .Private}}, "${aws_security_group.bosh_security_group.id}", "${aws_security_group.jumpbox.id}"{{end}}]
+  }
+
+  egress {
+    from_port = 0
+    to_port = 0
+    protocol = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-nat-security-group"))}"
+}
+
+resource "aws_instance" "nat" {
+  private_ip             = "${cidrhost(aws_subnet.{{.NATSubnet}}.cidr_block, 7)}"
+  instance_type          = "t2.medium"
+  subnet_id              = "${aws_subnet.{{.NATSubnet}}.id}"
+  source_dest_check      = false
+  ami                    = "${lookup(var.nat_ami_map, var.region)}"
+  vpc_security_group_ids = ["${aws_security_group.nat_security_group.id}"]
+
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-nat", "EnvID", "${var.env_id}"))}"
+}
+
+resource "aws_eip" "nat_eip" {
+  depends_on = ["aws_internet_gateway.ig"]
+  instance = "${aws_instance.nat.id}"
+  vpc      = true
+
+  tags = "${var.tags}"
+}
+
+output "nat_eip" {
+  value = "${aws_eip.nat_eip.public_ip}"
+}
+
+resource "aws_route_table" "internal_route_table" {
+  vpc_id = "${aws_vpc.vpc.id}"
+
+  tags = "${var.tags}"
+}
+
+resource "aws_route" "internal_route_table" {
+  destination_cidr_block = "0.0.0.0/0"
+  instance_id = "${aws_instance.nat.id}"
+  route_table_id = "${aws_route_table.internal_route_table.id}"
+}
+
+resource "aws_route_table_association" "route_internal_subnets" {
+  count          = "${length(var.availability_zones)}"
+  subnet_id      = "${element(aws_subnet.internal_subnets.*.id, count.index)}"
+  route_table_id = "${aws_route_table.internal_route_table.id}"
+}
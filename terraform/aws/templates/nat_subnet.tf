@@ -0,0 +1,12 @@
+resource "aws_subnet" "nat_subnet" {
+  vpc_id            = "${aws_vpc.vpc.id}"
+  cidr_block        = "${cidrsubnet(cidrsubnet(var.vpc_cidr, 4, 0), 4, 1)}"
+  availability_zone = "${aws_subnet.bosh_subnet.availability_zone}"
+
+  tags = "${merge(var.tags, map("Name", "${var.env_id}-nat-subnet"))}"
+}
+
+resource "aws_route_table_association" "route_nat_subnet" {
+  subnet_id      = "${aws_subnet.nat_subnet.id}"
+  route_table_id = "${aws_route_table.bosh_route_table.id}"
+}
@@ -10,6 +10,15 @@ variable "simple_env_id" {
 	type = "string"
 }
 
+variable "network_cidr" {
+	type = "string"
+}
+
+variable "tags" {
+	type    = "map"
+	default = {}
+}
+
 variable "subscription_id" {
 	type = "string"
 }
@@ -37,32 +46,21 @@ resource "azurerm_resource_group" "bosh" {
   name     = "${var.env_id}-bosh"
   location = "${var.location}"
 
-  tags {
-    environment = "${var.env_id}"
-  }
-}
-
-resource "azurerm_public_ip" "bosh" {
-  name                         = "${var.env_id}-bosh"
-  location                     = "${var.location}"
-  resource_group_name          = "${azurerm_resource_group.bosh.name}"
-  public_ip_address_allocation = "static"
-
-  tags {
-    environment = "${var.env_id}"
-  }
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
 }
 
 resource "azurerm_virtual_network" "bosh" {
   name                = "${var.env_id}-bosh-vn"
-  address_space       = ["10.0.0.0/16"]
+  address_space       = ["${var.network_cidr}"]
   location            = "${var.location}"
   resource_group_name = "${azurerm_resource_group.bosh.name}"
+
+  tags = "${var.tags}"
 }
 
 resource "azurerm_subnet" "bosh" {
   name                 = "${var.env_id}-bosh-sn"
-  address_prefix       = "10.0.0.0/16"
+  address_prefix       = "${var.network_cidr}"
   resource_group_name  = "${azurerm_resource_group.bosh.name}"
   virtual_network_name = "${azurerm_virtual_network.bosh.name}"
 }
@@ -74,9 +72,7 @@ resource "azurerm_storage_account" "bosh" {
   location     = "westus"
   account_type = "Standard_GRS"
 
-  tags {
-    environment = "${var.env_id}"
-  }
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
 }
 
 resource "azurerm_storage_container" "bosh" {
@@ -98,9 +94,7 @@ resource "azurerm_network_security_group" "bosh" {
   location            = "${var.location}"
   resource_group_name = "${azurerm_resource_group.bosh.name}"
 
-  tags {
-    environment = "${var.env_id}"
-  }
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
 }
 
 resource "azurerm_network_security_group" "cf" {
@@ -108,9 +102,7 @@ resource "azurerm_network_security_group" "cf" {
   location            = "${var.location}"
   resource_group_name = "${azurerm_resource_group.bosh.name}"
 
-  tags {
-    environment = "${var.env_id}"
-  }
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
 }
 
 resource "azurerm_network_security_rule" "ssh" {
@@ -217,6 +209,15 @@ output "bosh_default_security_group" {
     value = "${azurerm_network_security_group.bosh.name}"
 }
 
+resource "azurerm_public_ip" "bosh" {
+  name                         = "${var.env_id}-bosh"
+  location                     = "${var.location}"
+  resource_group_name          = "${azurerm_resource_group.bosh.name}"
+  public_ip_address_allocation = "static"
+
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
+}
+
 output "external_ip" {
     value = "${azurerm_public_ip.bosh.ip_address}"
 }
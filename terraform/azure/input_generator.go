@@ -3,7 +3,9 @@ package azure
 import (
 	"strings"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 type InputGenerator struct {
@@ -26,6 +28,19 @@ func (i InputGenerator) Generate(state storage.State) (map[string]string, error)
 		"tenant_id":       state.Azure.TenantID,
 		"client_id":       state.Azure.ClientID,
 		"client_secret":   state.Azure.ClientSecret,
+		"network_cidr":    state.Network.GetCIDR(),
+	}
+
+	if state.Network.Private {
+		networkPlan, err := bosh.NewNetworkPlan(state.Network.GetCIDR())
+		if err != nil {
+			return map[string]string{}, err
+		}
+		input["director_internal_ip"] = networkPlan.DirectorIP()
+	}
+
+	if len(state.Tags) > 0 {
+		input["tags"] = terraform.MapVariable(state.Tags)
 	}
 
 	return input, nil
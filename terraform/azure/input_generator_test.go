@@ -37,6 +37,7 @@ var _ = Describe("InputGenerator", func() {
 		Expect(inputs).To(Equal(map[string]string{
 			"simple_env_id":   "envid",
 			"env_id":          state.EnvID,
+			"network_cidr":    "10.0.0.0/16",
 			"location":        state.Azure.Location,
 			"subscription_id": state.Azure.SubscriptionID,
 			"tenant_id":       state.Azure.TenantID,
@@ -54,6 +55,7 @@ var _ = Describe("InputGenerator", func() {
 			Expect(inputs).To(Equal(map[string]string{
 				"simple_env_id":   "superlongenvironment",
 				"env_id":          state.EnvID,
+				"network_cidr":    "10.0.0.0/16",
 				"location":        state.Azure.Location,
 				"subscription_id": state.Azure.SubscriptionID,
 				"tenant_id":       state.Azure.TenantID,
@@ -62,4 +64,30 @@ var _ = Describe("InputGenerator", func() {
 			}))
 		})
 	})
+
+	Context("given tags", func() {
+		It("returns a map with the tags input", func() {
+			state.Tags = map[string]string{"team": "core"}
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["tags"]).To(Equal(`{"team"="core"}`))
+		})
+	})
+
+	Context("given a private network", func() {
+		It("returns a map with the internal ip of the director", func() {
+			state.Network = storage.Network{CIDR: "172.16.0.0/16", Private: true}
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["director_internal_ip"]).To(Equal("172.16.0.6"))
+		})
+
+		It("returns an error when the network cidr cannot be planned", func() {
+			state.Network = storage.Network{CIDR: "172.16.0.0/24", Private: true}
+			_, err := inputGenerator.Generate(state)
+			Expect(err).To(MatchError(`"172.16.0.0/24" is too small, the network must be at least a /20`))
+		})
+	})
 })
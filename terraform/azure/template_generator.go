@@ -13,6 +13,8 @@ type templates struct {
 	storage              string
 	networkSecurityGroup string
 	output               string
+	publicIP             string
+	privateIP            string
 }
 
 type TemplateGenerator struct{}
@@ -23,7 +25,13 @@ func NewTemplateGenerator() TemplateGenerator {
 
 func (t TemplateGenerator) Generate(state storage.State) string {
 	tmpls := readTemplates()
-	return strings.Join([]string{tmpls.vars, tmpls.resourceGroup, tmpls.network, tmpls.storage, tmpls.networkSecurityGroup, tmpls.output}, "\n")
+
+	ip := tmpls.publicIP
+	if state.Network.Private {
+		ip = tmpls.privateIP
+	}
+
+	return strings.Join([]string{tmpls.vars, tmpls.resourceGroup, tmpls.network, tmpls.storage, tmpls.networkSecurityGroup, tmpls.output, ip}, "\n")
 }
 
 func readTemplates() templates {
@@ -34,6 +42,8 @@ func readTemplates() templates {
 	tmpls.storage = string(MustAsset("templates/storage.tf"))
 	tmpls.networkSecurityGroup = string(MustAsset("templates/network_security_group.tf"))
 	tmpls.output = string(MustAsset("templates/output.tf"))
+	tmpls.publicIP = string(MustAsset("templates/public_ip.tf"))
+	tmpls.privateIP = string(MustAsset("templates/private_ip.tf"))
 
 	return tmpls
 }
@@ -34,5 +34,20 @@ var _ = Describe("TemplateGenerator", func() {
 			})
 			Expect(template).To(Equal(string(expectedTemplate)))
 		})
+
+		Context("when the network is private", func() {
+			It("uses the internal address of the director instead of a public ip", func() {
+				expectedTemplate, err := ioutil.ReadFile("fixtures/azure_template_private.tf")
+				Expect(err).NotTo(HaveOccurred())
+
+				template := templateGenerator.Generate(storage.State{
+					EnvID: "azure-environment",
+					Network: storage.Network{
+						Private: true,
+					},
+				})
+				Expect(template).To(Equal(string(expectedTemplate)))
+			})
+		})
 	})
 })
@@ -3,6 +3,8 @@
 // templates/network.tf
 // templates/network_security_group.tf
 // templates/output.tf
+// templates/private_ip.tf
+// templates/public_ip.tf
 // templates/resource_group.tf
 // templates/storage.tf
 // templates/vars.tf
@@ -73,7 +75,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesNetworkTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\xcb\x4e\xc5\x20\x10\x40\xf7\x7c\xc5\x64\xe2\xb6\x68\x37\xee\xfc\x12\x63\x08\x6d\x47\x25\xb6\xd0\x0c\x0f\x8d\x0d\xff\x6e\x68\x52\xb5\xdc\x36\xf7\xc2\x92\xc3\xe4\x9c\x0c\x93\x77\x91\x7b\x02\xd4\xdf\x91\x89\x27\x95\x0c\x87\xa8\x47\x65\x29\x7c\x3a\xfe\x40\xc0\xce\xf9\x77\x84\x45\x00\x58\x3d\x11\x54\xe7\x09\xf0\x6e\x49\x9a\x25\xd9\xa4\xcc\x90\x9b\x82\x37\xc9\xa2\x00\xd0\xc3\xc0\xe4\xbd\xf2\xb3\xee\xe9\x97\x7f\xc6\xf6\x41\xae\xf7\xbe\x7d\xc4\x17\x01\x30\xba\x5e\x07\xe3\xec\xe1\xdc\xed\x31\x97\x89\x9b\xaf\x7a\x63\x17\x67\xb5\x0a\xad\xe4\xa6\xbf\x07\x64\x91\x91\x85\xca\x28\xb2\x10\x97\xb9\x3e\x76\x96\xc2\xd5\xca\x93\x4c\xbf\xcb\x9c\x99\x5e\xcd\xd7\xdf\x87\xff\x99\x27\xee\x37\xcb\x03\x54\x8b\x39\x68\xaf\x88\x2a\xfe\x27\x00\x00\xff\xff\x03\x56\xce\x9c\xeb\x01\x00\x00")
+var _templatesNetworkTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\x51\x6a\xc3\x30\x10\x44\xff\xf7\x14\xc3\xd2\xdf\xf8\x06\x39\x49\x29\x42\xb1\xb7\xa9\x68\x22\x99\x5d\xc9\x2d\x0d\xba\x7b\x51\x88\x29\x56\x13\x5a\xff\x19\xcd\x8c\x66\x9e\x54\x2c\x15\x1d\x05\xec\xbf\x8a\x8a\x9e\xdd\x12\x34\x17\x7f\x72\x51\xf2\x47\xd2\x77\x06\x1f\x92\xbd\x31\x2e\x04\x44\x7f\x16\x74\xdf\x1e\xfc\x74\x59\xbc\x0e\x12\x17\x17\xa6\xba\x6b\xf2\xdd\x12\x99\x00\x3f\x4d\x2a\x66\xce\x66\x3f\xae\xc6\x3d\x9e\x6f\x86\xdb\x0d\x6e\x0c\x93\x56\x7e\x21\xe0\x94\x46\x9f\x43\x8a\x77\xf3\xd7\xc3\xda\x92\xd7\xde\xee\xa8\xa9\xcc\xee\x5a\xec\xaa\x5c\x67\x6c\x05\x43\x2b\x35\x34\x55\x65\x22\x20\xfb\xa3\xfd\x24\xb7\xbf\xca\x54\x89\x7e\xe3\xb0\x72\x88\x92\xff\xa4\xf0\x00\x83\x6d\x30\xcc\x2a\xaf\xe1\xb3\x37\x6c\x31\x3c\xd8\xf6\xef\x71\x40\xf7\x80\x77\xd8\x74\x8a\x8d\xbf\xd2\xf7\x00\x57\xd3\xc1\x09\x13\x02\x00\x00")
 
 func templatesNetworkTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -88,12 +90,12 @@ func templatesNetworkTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/network.tf", size: 491, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/network.tf", size: 531, mode: os.FileMode(420), modTime: time.Unix(1792393823, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesNetwork_security_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x95\xcd\x8e\x9b\x30\x10\x80\xef\x7e\x8a\x91\xd5\xd3\x4a\x89\xb6\x6c\x58\xed\x65\x0f\x3d\xf6\xde\x3b\xf2\x9a\x81\xb5\x4a\x3c\x68\x6c\x92\xb6\x11\xef\x5e\x99\x9f\x28\x50\xd2\x94\xa8\xad\x44\x14\xae\x7c\xf3\xff\x49\x66\x74\x54\xb1\x46\x90\xea\x47\xc5\xc8\xdb\xc4\xa2\xdf\x13\x7f\x4d\x1c\xea\x8a\x8d\xff\x9e\xe4\x4c\x55\x29\x41\xbe\x91\x7b\x97\x70\x10\x00\x56\x6d\x11\x46\xdf\x2b\xc8\x0f\x87\x9d\xe2\x35\xda\x5d\x62\xd2\x7a\xd5\xe0\x02\xa0\x20\xad\xbc\x21\x3b\x09\xf7\x3f\xeb\x40\xf6\xbd\xb4\x15\x93\xa6\x4a\x43\xf6\xad\x0d\x81\x75\xa8\xb0\x0e\x54\x2d\x85\x00\xf0\x2a\x77\x4d\x7b\x00\x68\x77\x86\xc9\x6e\xd1\xfa\x5f\x1a\x0b\x95\x6a\x51\x0b\x31\x63\x74\x9d\xcd\x18\x5c\x67\x4b\x1f\x9b\xab\x02\x25\x48\xf7\xbb\x7b\x9f\x9b\xde\xb5\x57\x2f\xd9\x50\xc8\x35\x19\x12\x3d\x3e\x0a\x80\xd4\x30\xea\xf1\x8a\x8e\x59\x3f\xdb\x37\xaa\x6c\x1a\x72\x29\xad\xd1\xb9\x73\xe5\x3f\x15\x05\xed\xdb\x92\xe4\x49\x53\x31\x8d\x7d\xd1\x65\x80\xba\x4d\x96\xc4\x3e\x61\x65\x73\x1c\x40\x0f\x01\x49\xd1\x79\x63\x9b\xfb\x8c\xb9\x57\x90\x51\x74\x92\x46\xa5\x29\xa3\x73\x49\xc9\x98\x99\x6f\xe7\xd3\x8c\xb8\x1e\x99\xba\xfc\x60\xb1\x7f\x60\x00\xc0\xb4\xb5\x13\x1e\x4d\x83\x83\x6c\xb3\xfc\x08\x81\x2b\x95\xa3\xf5\xf3\x35\x39\x89\xbd\x6c\xcb\xc7\xc5\xda\xf2\xfc\xf2\xfc\x72\xf7\xe5\xd4\x97\xf6\x8e\xc4\x57\x2a\x73\x0c\xbf\x6c\x4d\xb4\x58\x6b\xa2\x38\x8e\xe3\xbb\x36\x9d\x36\xa9\x75\xf3\x65\x09\x41\x97\x15\x79\xfa\xef\x8a\x3c\xfc\x15\x41\xe2\xa7\xbb\x1d\x9d\x1d\x3a\x5b\xbd\x7b\x5f\xfe\x33\x45\x96\xfb\xf6\x6c\x36\x37\x67\x89\xce\xae\x75\xa4\xa0\x7c\xbe\x21\x5d\xdc\x2d\x3f\x35\x9b\x1b\xb7\xe4\x67\x00\x00\x00\xff\xff\x3b\xed\x87\x70\xe0\x0e\x00\x00")
+var _templatesNetwork_security_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x95\xb1\x6e\xdb\x30\x10\x86\x77\x3e\xc5\xe1\xd0\x21\x09\x62\x23\x75\xac\x20\x8b\x86\x8e\xdd\xbb\x0b\x0c\x75\x52\x84\x4a\x3c\xe1\x48\x39\x6d\x03\xbd\x7b\x41\xdb\x0c\x24\xc1\xad\xab\xa0\x2d\x60\xc3\x1c\xcd\xef\x8e\xa7\x5f\x9f\x45\x21\xc7\x9d\x18\x02\xd4\x3f\x3a\x21\x69\x32\x4b\xfe\x85\xe5\x6b\xe6\xc8\x74\x52\xf9\xef\x59\x29\xdc\xb5\x08\xf8\xc4\xee\x19\xe1\x55\x01\x58\xdd\x10\x4c\x56\x0a\xf8\xe1\x75\xa3\x65\x49\x76\x93\x55\x79\xbf\xd8\xe2\x0a\xa0\x66\xa3\x7d\xc5\xf6\x20\x1c\x37\x7b\x54\x00\x71\x96\xdd\x89\xd9\xf6\x94\x2d\x19\x47\x1b\x03\xcb\x70\xc2\x32\x50\x3d\x2a\x05\xe0\x75\xe9\x76\x7c\x43\x52\xd2\x55\x18\x26\xfc\x76\x0b\x8d\x6e\xaf\x90\xec\xa6\x12\xb6\x0d\x59\x8f\xb7\x93\x61\xf1\xfa\xba\x47\xd5\x2b\x35\x23\x0e\x53\xcc\x08\xc3\x14\xe7\x18\x85\x74\x35\x21\xa0\xfb\x9d\x17\xd3\xc7\x8c\x89\x84\x22\x05\xd0\x4a\xc5\x21\xd6\xc8\x0d\x56\x0a\xab\xbb\x3b\x05\x90\x57\x42\x66\x1a\xdb\x5b\xd7\xcf\xf6\x89\x3b\x9b\x87\xd0\xb4\x31\xe4\x5c\xdc\x1b\xad\x14\xf0\x53\x5d\xf3\x4b\xc0\x5a\x61\xcf\x86\xeb\xb8\x37\x58\x29\xe0\x17\xd3\x06\x68\x9f\x6e\xcb\xe2\x33\xd1\xb6\x1c\x3c\x57\x0a\x78\x13\x90\x9c\x9c\xaf\xec\xf6\x9d\x4d\xb9\x14\x70\xb5\x1a\xb4\xd1\x79\x2e\xe4\x5c\xd6\x0a\x15\xd5\xb7\x5f\xb7\x99\x70\x11\x19\xbf\xec\x6c\x14\xf3\x9f\x5a\x01\x70\xd8\xe4\x03\x6e\x1d\x06\x47\xdd\x66\xf9\x11\x0a\x17\xba\x0c\xb6\xcd\xd6\x64\x50\x7b\xdc\x96\x8f\x27\x6b\xcb\xc3\xe3\xc3\xe3\xc5\x97\xa1\x2f\xbb\x7f\x3d\xcb\x3b\x95\x79\x2b\x3f\x6e\xcd\xea\x74\xbf\x31\x49\x92\x24\x17\x6d\xf6\xda\xe4\xd6\xcd\x97\x25\x14\x1d\x57\xe4\xfe\xbf\x2b\x72\xf3\x57\x04\x49\xee\x2f\x76\xec\xed\x30\xc5\xe2\xd9\xfb\xf6\x9f\x29\x72\xba\x77\xcf\x7a\x7d\x76\x96\x98\xe2\xbd\x8e\xd4\x5c\xce\x37\x64\x5f\x77\xce\x57\xcd\xfa\xcc\x2d\xf9\x39\x00\x9e\x98\x21\x69\x08\x0f\x00\x00")
 
 func templatesNetwork_security_groupTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -108,12 +110,12 @@ func templatesNetwork_security_groupTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/network_security_group.tf", size: 3808, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/network_security_group.tf", size: 3848, mode: os.FileMode(420), modTime: time.Unix(1792393823, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesOutputTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd1\x4d\x6a\x03\x31\x0c\x05\xe0\x75\xe7\x14\x66\xe8\x3a\x81\xc2\x6c\x02\x3d\x8b\xd0\x78\xd4\xc4\xd4\xb1\x8c\x2c\xa5\x3f\x21\x77\x2f\x26\x9d\x82\x17\xf5\x64\xed\xf7\x3e\x3f\x10\x9b\x66\x53\x37\xce\x5c\x4e\x90\x48\x3f\x58\xde\x21\xe1\x99\x46\x77\x1d\x9c\x73\xee\x82\xd1\xc8\xbd\xba\xf1\xf9\x8a\xdf\x26\x24\x67\xb8\x04\x51\xc3\xb8\xc6\x77\xb5\xbb\xab\x9d\xdb\x38\xdc\x86\xa1\x21\x8b\xcd\x89\x74\x4b\xbc\xa7\xba\x90\x50\x61\x13\x4f\x70\x14\xb6\xbc\x05\xb6\xe9\xfe\x42\x65\xc1\x23\x01\x7a\xcf\x96\xb6\xa7\xb6\xf1\x2e\xbd\xd0\x1b\x5a\x54\x28\xe4\x4d\x82\x7e\xdd\xd7\x74\xf0\xf5\x00\x6d\xe1\xbf\x3f\xe8\x53\x49\x12\x46\x08\x3d\x33\xdb\x1c\x83\x87\xf0\xcb\x84\x0c\xb8\x2c\x42\xa5\xb4\xd8\x12\x84\xbc\xb2\xac\xaf\x55\x7c\xfa\xe3\x4e\xaa\xb9\x1c\xf6\xfb\x47\xd8\xc3\xcb\x34\x4d\x53\xc5\x7f\x02\x00\x00\xff\xff\x5c\x02\xb8\xbf\x5d\x02\x00\x00")
+var _templatesOutputTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x91\x41\xae\x83\x30\x0c\x05\xf7\x39\x85\x15\xfd\x35\x37\xf8\x67\x89\x4c\xea\x52\x54\x88\x91\x63\x53\xb5\x88\xbb\x57\x08\xb1\x48\x17\xc9\x7e\xde\x78\x24\xb3\xe9\x62\x0a\xbe\xe7\xfc\x08\x89\xf4\xc5\xf2\x0c\x09\x67\xf2\xb0\x39\x00\x80\x15\x27\x23\xf8\x07\xff\xb7\xe1\xc7\x84\x64\x0e\xeb\x28\x6a\x38\x5d\x78\x77\x6c\xbb\x63\xb3\x7b\xb7\x3b\x57\x28\xb3\xf5\x89\xb4\x65\x3c\xa9\xaa\x48\x28\xb3\x49\xa4\x30\x08\xdb\xd2\x12\x96\x74\xbd\x50\x59\x70\xa0\x80\x31\xb2\xa5\x76\x6a\x89\x57\xd5\x37\xba\xa3\x4d\x1a\x32\x45\x93\x51\xdf\x67\x4d\x45\x7e\x3d\xa0\x1c\xfc\xdc\xf8\x0e\x00\xd8\x36\x7c\xc4\xb3\x01\x00\x00")
 
 func templatesOutputTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -128,12 +130,52 @@ func templatesOutputTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/output.tf", size: 605, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/output.tf", size: 435, mode: os.FileMode(420), modTime: time.Unix(1792394370, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesResource_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x90\x41\x6a\xc5\x30\x0c\x44\xf7\x3e\xc5\x60\xba\x6d\x6e\xd0\xb3\x18\xc5\x11\xa9\x21\xb1\x83\x6c\x67\xd1\xe0\xbb\x17\x1b\xd2\x26\x24\xff\x7f\xf8\x5a\x8a\xd1\x68\xe6\x09\xc7\x90\xc5\x32\x34\xfd\x64\x61\x99\xcd\xbe\x31\xa3\x84\xbc\x68\xe8\x3e\xc4\x6f\x8d\x4d\x01\x9e\x66\x46\x9d\x2f\xe8\x8f\x6d\x25\xe9\xd8\xaf\xc6\x0d\xe5\xb3\x69\x14\x30\x05\x4b\xc9\x05\xff\xaf\xd8\x37\x45\x2b\x05\x24\x1a\x63\xb3\x02\xd8\xaf\x4e\x82\x9f\xd9\xa7\x8b\x5f\xb5\x2a\xaa\x28\x75\x8d\xb7\xe4\x7e\x72\xd6\xb8\x07\xc9\xee\xe6\x75\xda\xa7\x57\x87\x06\xc0\x99\x8e\x39\xff\x6d\x27\xf7\x1c\xbb\xfa\xb3\xab\xf2\x66\xf3\xd7\xc2\xd0\x30\x08\xc7\x68\x68\x3a\xb2\x8b\x89\x92\xb3\xef\x20\xfb\x0d\x00\x00\xff\xff\xe9\x3c\x7f\x17\xd1\x01\x00\x00")
+var _templatesPrivate_ipTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\xcd\xc1\x0a\xc2\x30\x0c\x80\xe1\xb3\x79\x8a\x10\x3c\x3b\x10\x7a\x19\xf8\x2c\x23\xba\xa0\x81\xd2\x95\x34\x2d\xca\xe8\xbb\x0b\x3b\x0c\x06\xe2\xfd\xe7\xfb\x1b\x9b\xf2\x3d\x0a\xd2\xac\x26\x0f\x5f\x6c\xd2\xe4\x62\x89\xe3\xa4\x99\x70\x85\x93\x7f\xb2\xe0\x0d\xa9\xb8\x69\x7a\x12\x74\x80\xa5\x7a\xae\x8e\x24\xef\x43\x8a\x88\xd8\x38\xd6\x2d\x3f\xaf\x8d\xed\xf2\x4b\xed\x07\x63\x2f\x78\x9e\x4d\x4a\xd9\x9e\xbb\xf2\x72\xcf\x65\x1c\x86\x3f\xda\x78\x0d\x21\x04\x82\x0e\xdf\x01\x00\x0b\x3b\x0e\xf5\xcd\x00\x00\x00")
+
+func templatesPrivate_ipTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesPrivate_ipTf,
+		"templates/private_ip.tf",
+	)
+}
+
+func templatesPrivate_ipTf() (*asset, error) {
+	bytes, err := templatesPrivate_ipTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/private_ip.tf", size: 205, mode: os.FileMode(420), modTime: time.Unix(1792402905, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesPublic_ipTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x91\x41\x6b\xc3\x30\x0c\x85\xcf\xf3\xaf\x10\x62\x87\x16\xba\x14\x06\xb9\x14\xfa\x5b\x8c\x9a\x88\xd4\x90\xd8\x46\x96\xc3\x58\xc9\x7f\x1f\x4e\x71\x46\xc6\x06\xf3\xd1\x7e\x7a\xef\xd3\xb3\x70\x0a\x59\x3a\x06\xa4\xcf\x2c\x2c\x93\x8d\xf9\x36\xba\xce\xba\x88\x80\xb7\x90\xee\x08\x0f\x03\xe0\x69\x62\xf8\xeb\x5c\x01\x5f\x1f\x33\x49\xc3\x7e\xb6\xae\x5f\xde\xd6\x39\x03\x30\x86\x8e\xd4\x05\x5f\x85\xbf\x4f\x55\xd5\x82\x06\xa0\x02\xd9\x41\x42\x8e\x76\x9f\xbb\x06\x55\xd0\xbd\xb2\x29\x99\x4d\x91\xaf\x36\xdb\x16\x96\xfa\x5e\x38\x25\x4b\xe3\x46\x73\x05\x4c\x4a\xea\x3a\x34\x06\x40\x69\x48\x4f\x98\x89\x65\xe0\x43\x41\x2a\x77\x27\x98\x28\x1e\x90\xfd\xec\x24\xf8\x89\xbd\xe2\xe9\xc7\xa2\x78\x3c\x2e\x68\x16\x63\x42\xd6\x98\x15\x90\x3f\x94\xc5\xd3\xb8\xd6\x57\x7a\x03\x98\x69\xcc\xbc\x27\xdf\xe0\x9e\xd0\xdf\x90\x7b\xb3\xde\x09\x77\x1a\xa4\xbe\x96\x9f\x78\xd9\xec\xee\xaa\x31\x5d\xce\xe7\xff\xd8\x5e\xde\xdb\xb6\x6d\xd1\x2c\xe6\x6b\x00\x3b\x78\x4f\xc1\xf0\x01\x00\x00")
+
+func templatesPublic_ipTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesPublic_ipTf,
+		"templates/public_ip.tf",
+	)
+}
+
+func templatesPublic_ipTf() (*asset, error) {
+	bytes, err := templatesPublic_ipTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/public_ip.tf", size: 496, mode: os.FileMode(420), modTime: time.Unix(1792394316, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesResource_groupTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\xcd\x41\x0a\xc2\x40\x0c\x85\xe1\x7d\x4e\xf1\x08\x2e\x5a\xa8\xde\xc0\xb3\x94\x58\x43\x2d\x38\x49\x49\xdb\x59\x58\xe6\xee\x32\x4a\x11\xcc\xf2\xcf\x07\x2f\x74\xf1\x2d\x06\x05\xcb\x6b\x0b\x8d\xd4\x1f\xa5\x1f\xc3\xb7\x99\xc1\x37\x5f\x1e\x8c\x9d\x00\x93\xa4\xa8\x77\x05\x9f\xf6\x2c\x71\x51\xcb\xfd\x74\x2f\xe7\x8f\x21\xe0\xe9\x83\xac\x93\xdb\x4f\x1c\xa5\x30\x11\xb0\xca\xb8\x7c\x7f\x49\x63\xd4\xa6\x8a\xda\x3a\x24\x99\x1b\x56\xcb\x53\xb8\x25\xb5\x95\xbb\xbf\x0d\x6e\xdb\xc2\x54\xe8\x3d\x00\x25\xc2\x44\xdf\xb2\x00\x00\x00")
 
 func templatesResource_groupTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -148,12 +190,12 @@ func templatesResource_groupTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/resource_group.tf", size: 465, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/resource_group.tf", size: 178, mode: os.FileMode(420), modTime: time.Unix(1792394370, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesStorageTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x91\x41\x6e\x83\x40\x0c\x45\xf7\x73\x0a\x6b\xd4\x75\x6e\xd0\x75\xf7\xcd\x01\x46\x66\xb0\xe8\x48\x60\x23\xdb\x50\xb5\x11\x77\xaf\x18\x05\xda\x20\x45\xc9\xb2\x6c\xf9\xfe\x7e\xcf\xa3\x64\x32\x69\x26\x88\xf8\x3d\x29\xe9\x90\xcc\x45\xb1\xa3\x84\x39\xcb\xc4\x1e\x21\x36\x62\x1f\x11\x2e\x01\x80\x71\x20\x38\x7c\xaf\x10\x5f\x2e\x33\xea\xc9\xca\x30\xf6\x94\x88\xe7\x54\xda\x25\x06\x80\xad\x3c\x75\x2a\xd3\x98\xea\x74\x8d\x6f\xbb\x6e\x03\xa7\x75\xd1\x69\x4d\x2d\x31\x04\x80\x5e\x32\x7a\x11\xde\xd6\x7c\x92\xf9\x64\x6b\xf1\x95\x2d\xf9\xd7\x58\x1b\xcf\x8e\xdc\xa2\xb6\xe9\xed\xfd\x5c\x47\x1d\x3b\xab\xc4\x00\xc4\x73\x51\xe1\x81\xd8\x7f\x59\xff\x40\x2e\x61\x09\xe1\xfe\x19\xb2\xb0\x63\x61\xd2\x87\x87\xa8\x8c\x35\x72\x47\x1d\x9e\x96\x07\x38\xbc\xc2\xb5\xe0\x66\xfe\x10\x39\x14\xec\xdc\xeb\x7f\x32\xdb\x6f\x35\x6a\x99\xd1\x29\x3e\xaf\x6d\x4e\x43\xa6\xbe\x7f\xa0\xbe\xc7\xfe\xb5\x7e\xd3\x4b\xb3\xba\xff\x04\x00\x00\xff\xff\xcf\x0a\x89\xf7\xf9\x02\x00\x00")
+var _templatesStorageTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x51\xcf\x6a\xf3\x30\x0c\xbf\xfb\x29\x84\xe8\xa1\x85\x92\x37\xf8\xce\xdf\x7d\x7d\x00\xa3\x38\x22\x33\xc4\x76\x90\x95\x8c\x2d\xe4\xdd\x87\x43\x1d\x68\xa0\x74\xbb\xcd\x47\xeb\xa7\xdf\x3f\x09\xe7\x34\x89\x63\x40\xfa\x9a\x84\x25\xd8\xac\x49\xa8\x67\x4b\xce\xa5\x29\x2a\x02\xb6\x29\xbf\x23\x2c\x06\x20\x52\x60\x38\xbc\x7f\x80\xa7\x65\x26\x69\xb2\x0f\xe3\xc0\x96\xe3\x6c\x7d\xb7\xa2\x01\xa8\xe4\xb6\x97\x34\x8d\x76\xdb\xde\xe0\x55\xeb\x11\xd0\x14\xa1\xa6\xa0\x56\x34\x06\x60\x48\x8e\xd4\xa7\x58\x65\x3e\x38\xeb\x94\x0b\xf1\xdd\x9b\xd5\xcf\x91\xcb\xe4\xa6\x14\x3b\x92\xce\xfe\x7f\xbb\x6d\xab\x4a\x7d\x2e\x83\xd3\x12\x58\x7a\x3e\x17\x7f\xe5\xef\x0a\x81\xc6\x33\x72\x9c\xbd\xa4\x18\x38\x2a\x5e\xab\xff\x6a\xfc\x72\x59\xd1\xac\xc6\x3c\xaf\xc6\xa5\xa8\xe4\x23\xcb\xcb\x72\xa0\x98\x28\xb1\x9e\xd5\x01\x3f\x2e\x04\xe0\x70\x99\x3b\xc1\xc3\xfe\x01\x72\x20\xd8\x7d\x97\xe3\x72\xce\x7b\x7f\xa3\xf8\x99\x94\x7f\x11\x3b\x2b\x07\xc7\xc3\xf0\x22\xfa\x0e\xfb\xd3\xf1\xdb\x21\xb5\x68\x56\xf3\x3d\x00\xa4\x21\x9d\xad\x0d\x03\x00\x00")
 
 func templatesStorageTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -168,12 +210,12 @@ func templatesStorageTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/storage.tf", size: 761, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/storage.tf", size: 781, mode: os.FileMode(420), modTime: time.Unix(1792393823, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcf\xd1\xaa\xc2\x30\x0c\x06\xe0\xeb\xd3\xa7\x08\xe5\x5c\xfb\x06\x3e\xcb\xc8\xba\x20\x81\xae\x2b\x69\x56\xd0\xb1\x77\x97\x3a\xa9\x32\x15\x6b\x6e\xff\x2f\xe1\x4f\x46\x61\xec\x3d\x81\xa5\x90\x3b\x1e\x2c\x2c\xe6\x4f\xcf\x91\xe0\x08\x36\xa9\x70\x38\x59\xb3\x1a\xf3\x70\x7e\x72\xa8\x3c\x85\xef\x32\xf1\x18\x3d\x75\xad\x87\xd3\xdc\x27\x27\x1c\xcb\xf1\xa6\x05\xa5\x80\x41\x9b\xa8\xf3\x4c\xbf\xd1\x44\x4e\x48\x3f\xf1\x28\x53\xe6\x81\x04\x2c\x5e\x66\x21\x19\x0b\x04\xd8\xbd\x00\x65\xeb\x7f\xc9\x28\x87\x5d\xb2\x5a\x03\x50\xfb\xc3\x7d\xaa\xae\xc9\xcd\xd5\xf2\x2f\xae\x26\xcf\x6e\x6b\xfe\xce\x6d\xc9\x5a\x3e\xb8\x06\x00\x00\xff\xff\xb7\xcd\x6b\x24\xf8\x01\x00\x00")
+var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcf\x51\x4e\xc3\x30\x0c\xc6\xf1\xe7\xf9\x14\x96\xc5\x33\x37\xd8\x59\x2a\x2f\x35\x93\x45\x9a\x46\x8e\x1b\x04\x55\xee\x8e\x3a\x50\x06\x03\xb4\xd2\xd7\xef\xd7\xe8\xef\xca\xa6\x7c\x8a\x82\x24\xa9\x0e\x3a\x12\xae\x70\xf0\xd7\x2c\x78\x44\x2a\x6e\x9a\xce\x04\x0d\xe0\xea\xe2\x1c\xd8\x75\x4e\xf7\x65\xd1\x29\x47\x19\xf6\x3e\x9c\xc4\x5f\x66\x7b\x1e\x82\x8e\x76\x5f\x3b\x9f\xcb\x55\x21\x6e\xbd\x13\x67\x82\xc3\x28\x4f\xbc\x44\xc7\x23\xae\xed\xfb\x3f\x65\x39\x95\x60\x9a\xb7\xfc\x5d\x49\x2e\x89\x93\xef\xa2\x21\xaa\xfc\x8f\x16\x09\x26\xfe\x17\xcf\x36\x57\x1d\xc5\x90\xf8\x6d\x31\xb1\x69\x83\x88\x37\x27\x5c\xce\x7e\x58\x2b\xdb\xe3\xcd\xd2\x08\x10\x7b\x3f\x7e\x7e\x5d\xf7\xe5\xe2\x7a\xfc\x0f\xd7\x97\xaf\xee\xa3\xfc\x37\x57\x24\x98\x78\x23\x68\xf0\x3e\x00\x4c\x36\x59\x11\x5a\x02\x00\x00")
 
 func templatesVarsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -188,7 +230,7 @@ func templatesVarsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vars.tf", size: 504, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/vars.tf", size: 602, mode: os.FileMode(420), modTime: time.Unix(1792393826, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -248,6 +290,8 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/network.tf": templatesNetworkTf,
 	"templates/network_security_group.tf": templatesNetwork_security_groupTf,
 	"templates/output.tf": templatesOutputTf,
+	"templates/private_ip.tf": templatesPrivate_ipTf,
+	"templates/public_ip.tf": templatesPublic_ipTf,
 	"templates/resource_group.tf": templatesResource_groupTf,
 	"templates/storage.tf": templatesStorageTf,
 	"templates/vars.tf": templatesVarsTf,
@@ -297,6 +341,8 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"network.tf": &bintree{templatesNetworkTf, map[string]*bintree{}},
 		"network_security_group.tf": &bintree{templatesNetwork_security_groupTf, map[string]*bintree{}},
 		"output.tf": &bintree{templatesOutputTf, map[string]*bintree{}},
+		"private_ip.tf": &bintree{templatesPrivate_ipTf, map[string]*bintree{}},
+		"public_ip.tf": &bintree{templatesPublic_ipTf, map[string]*bintree{}},
 		"resource_group.tf": &bintree{templatesResource_groupTf, map[string]*bintree{}},
 		"storage.tf": &bintree{templatesStorageTf, map[string]*bintree{}},
 		"vars.tf": &bintree{templatesVarsTf, map[string]*bintree{}},
@@ -3,9 +3,7 @@ resource "azurerm_network_security_group" "bosh" {
   location            = "${var.location}"
   resource_group_name = "${azurerm_resource_group.bosh.name}"
 
-  tags {
-    environment = "${var.env_id}"
-  }
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
 }
 
 resource "azurerm_network_security_group" "cf" {
@@ -13,9 +11,7 @@ resource "azurerm_network_security_group" "cf" {
   location            = "${var.location}"
   resource_group_name = "${azurerm_resource_group.bosh.name}"
 
-  tags {
-    environment = "${var.env_id}"
-  }
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
 }
 
 resource "azurerm_network_security_rule" "ssh" {
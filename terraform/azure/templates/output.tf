@@ -17,11 +17,3 @@ output "bosh_storage_account_name" {
 output "bosh_default_security_group" {
     value = "${azurerm_network_security_group.bosh.name}"
 }
-
-output "external_ip" {
-    value = "${azurerm_public_ip.bosh.ip_address}"
-}
-
-output "director_address" {
-	value = "https://${azurerm_public_ip.bosh.ip_address}:25555"
-}
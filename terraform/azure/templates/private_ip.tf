@@ -0,0 +1,11 @@
+variable "director_internal_ip" {
+	type = "string"
+}
+
+output "external_ip" {
+    value = "${var.director_internal_ip}"
+}
+
+output "director_address" {
+	value = "https://${var.director_internal_ip}:25555"
+}
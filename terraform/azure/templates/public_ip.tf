@@ -0,0 +1,16 @@
+resource "azurerm_public_ip" "bosh" {
+  name                         = "${var.env_id}-bosh"
+  location                     = "${var.location}"
+  resource_group_name          = "${azurerm_resource_group.bosh.name}"
+  public_ip_address_allocation = "static"
+
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
+}
+
+output "external_ip" {
+    value = "${azurerm_public_ip.bosh.ip_address}"
+}
+
+output "director_address" {
+	value = "https://${azurerm_public_ip.bosh.ip_address}:25555"
+}
@@ -5,9 +5,7 @@ resource "azurerm_storage_account" "bosh" {
   location     = "westus"
   account_type = "Standard_GRS"
 
-  tags {
-    environment = "${var.env_id}"
-  }
+  tags = "${merge(var.tags, map("environment", "${var.env_id}"))}"
 }
 
 resource "azurerm_storage_container" "bosh" {
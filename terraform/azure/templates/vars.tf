@@ -10,6 +10,15 @@ variable "simple_env_id" {
 	type = "string"
 }
 
+variable "network_cidr" {
+	type = "string"
+}
+
+variable "tags" {
+	type    = "map"
+	default = {}
+}
+
 variable "subscription_id" {
 	type = "string"
 }
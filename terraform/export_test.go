@@ -3,6 +3,8 @@ package terraform
 import (
 	"io/ioutil"
 	"os"
+
+	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
 func SetTempDir(f func(dir, prefix string) (string, error)) {
@@ -28,3 +30,7 @@ func SetReadFile(f func(filename string) ([]byte, error)) {
 func ResetReadFile() {
 	readFile = ioutil.ReadFile
 }
+
+func Fingerprint(template string, inputs map[string]string, lb storage.LB) string {
+	return fingerprint(template, inputs, lb)
+}
@@ -0,0 +1,333 @@
+variable "project_id" {
+	type = "string"
+}
+
+variable "region" {
+	type = "string"
+}
+
+variable "zone" {
+	type = "string"
+}
+
+variable "env_id" {
+	type = "string"
+}
+
+variable "credentials" {
+	type = "string"
+}
+
+variable "network_cidr" {
+	type = "string"
+}
+
+provider "google" {
+	credentials = "${file("${var.credentials}")}"
+	project = "${var.project_id}"
+	region = "${var.region}"
+}
+
+output "network_name" {
+    value = "${google_compute_network.bbl-network.name}"
+}
+
+output "subnetwork_name" {
+    value = "${google_compute_subnetwork.bbl-subnet.name}"
+}
+
+resource "google_compute_network" "bbl-network" {
+  name		 = "${var.env_id}-network"
+}
+
+resource "google_compute_subnetwork" "bbl-subnet" {
+  name			= "${var.env_id}-subnet"
+  ip_cidr_range = "${var.network_cidr}"
+  network		= "${google_compute_network.bbl-network.self_link}"
+}
+
+output "bosh_open_tag_name" {
+    value = "${google_compute_firewall.bosh-open.name}"
+}
+
+output "bosh_director_tag_name" {
+	value = "${google_compute_firewall.bosh-director.name}"
+}
+
+output "jumpbox_tag_name" {
+	value = "${var.env_id}-jumpbox"
+}
+
+output "internal_tag_name" {
+    value = "${google_compute_firewall.internal.name}"
+}
+
+resource "google_compute_firewall" "external" {
+  name    = "${var.env_id}-external"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_ranges = ["0.0.0.0/0"]
+
+  allow {
+    ports = ["22", "6868", "25555"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-bosh-open"]
+}
+
+resource "google_compute_firewall" "bosh-open" {
+  name    = "${var.env_id}-bosh-open"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-bosh-open"]
+
+  allow {
+    ports = ["22", "6868", "8443", "25555"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-bosh-director"]
+}
+
+resource "google_compute_firewall" "bosh-director" {
+  name    = "${var.env_id}-bosh-director"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-bosh-director"]
+
+  allow {
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-internal"]
+}
+
+resource "google_compute_firewall" "internal-to-director" {
+  name    = "${var.env_id}-internal-to-director"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-internal"]
+
+  allow {
+    ports = ["4222", "25250", "25777"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-bosh-director"]
+}
+
+resource "google_compute_firewall" "jumpbox-to-all" {
+  name    = "${var.env_id}-jumpbox-to-all"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-jumpbox"]
+
+  allow {
+    ports = ["22"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-internal", "${var.env_id}-bosh-director"]
+}
+
+resource "google_compute_firewall" "internal" {
+  name    = "${var.env_id}-internal"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-internal"]
+
+  allow {
+    protocol = "icmp"
+  }
+
+  allow {
+    protocol = "tcp"
+  }
+
+  allow {
+    protocol = "udp"
+  }
+
+  target_tags = ["${var.env_id}-internal"]
+}
+
+resource "google_compute_address" "jumpbox-ip" {
+  name = "${var.env_id}-jumpbox-ip"
+}
+
+output "jumpbox_url" {
+    value = "${google_compute_address.jumpbox-ip.address}:22"
+}
+
+output "external_ip" {
+    value = "${google_compute_address.jumpbox-ip.address}"
+}
+
+output "director_address" {
+	value = "https://${google_compute_address.bosh-external-ip.address}:25555"
+}
+
+resource "google_compute_address" "bosh-external-ip" {
+  name = "${var.env_id}-bosh-external-ip"
+}
+
+variable "network_services_cidr" {
+	type = "string"
+}
+
+output "network_services_subnetwork_name" {
+    value = "${google_compute_subnetwork.network-services.name}"
+}
+
+output "network_services_tag_name" {
+    value = "${google_compute_firewall.network-services.name}"
+}
+
+resource "google_compute_subnetwork" "network-services" {
+  name          = "${var.env_id}-services-subnet"
+  ip_cidr_range = "${var.network_services_cidr}"
+  network       = "${google_compute_network.bbl-network.name}"
+}
+
+resource "google_compute_firewall" "network-services" {
+  name    = "${var.env_id}-services"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-services"]
+
+  allow {
+    protocol = "icmp"
+  }
+
+  allow {
+    protocol = "tcp"
+  }
+
+  allow {
+    protocol = "udp"
+  }
+
+  target_tags = ["${var.env_id}-services"]
+}
+
+resource "google_compute_firewall" "network-services-to-director" {
+  name    = "${var.env_id}-services-to-director"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-services"]
+
+  allow {
+    ports = ["4222", "25250", "25777"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-bosh-director"]
+}
+
+resource "google_compute_firewall" "director-to-network-services" {
+  name    = "${var.env_id}-director-to-services"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-bosh-director"]
+
+  allow {
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-services"]
+}
+
+resource "google_compute_firewall" "jumpbox-to-network-services" {
+  name    = "${var.env_id}-jumpbox-to-services"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-jumpbox"]
+
+  allow {
+    ports = ["22"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-services"]
+}
+
+variable "network_iso-seg_cidr" {
+	type = "string"
+}
+
+output "network_iso-seg_subnetwork_name" {
+    value = "${google_compute_subnetwork.network-iso-seg.name}"
+}
+
+output "network_iso-seg_tag_name" {
+    value = "${google_compute_firewall.network-iso-seg.name}"
+}
+
+resource "google_compute_subnetwork" "network-iso-seg" {
+  name          = "${var.env_id}-iso-seg-subnet"
+  ip_cidr_range = "${var.network_iso-seg_cidr}"
+  network       = "${google_compute_network.bbl-network.name}"
+}
+
+resource "google_compute_firewall" "network-iso-seg" {
+  name    = "${var.env_id}-iso-seg"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-iso-seg"]
+
+  allow {
+    protocol = "icmp"
+  }
+
+  allow {
+    protocol = "tcp"
+  }
+
+  allow {
+    protocol = "udp"
+  }
+
+  target_tags = ["${var.env_id}-iso-seg"]
+}
+
+resource "google_compute_firewall" "network-iso-seg-to-director" {
+  name    = "${var.env_id}-iso-seg-to-director"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-iso-seg"]
+
+  allow {
+    ports = ["4222", "25250", "25777"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-bosh-director"]
+}
+
+resource "google_compute_firewall" "director-to-network-iso-seg" {
+  name    = "${var.env_id}-director-to-iso-seg"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-bosh-director"]
+
+  allow {
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-iso-seg"]
+}
+
+resource "google_compute_firewall" "jumpbox-to-network-iso-seg" {
+  name    = "${var.env_id}-jumpbox-to-iso-seg"
+  network = "${google_compute_network.bbl-network.name}"
+
+  source_tags = ["${var.env_id}-jumpbox"]
+
+  allow {
+    ports = ["22"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-iso-seg"]
+}
@@ -18,6 +18,10 @@ variable "credentials" {
 	type = "string"
 }
 
+variable "network_cidr" {
+	type = "string"
+}
+
 provider "google" {
 	credentials = "${file("${var.credentials}")}"
 	project = "${var.project_id}"
@@ -32,6 +36,16 @@ output "subnetwork_name" {
     value = "${google_compute_subnetwork.bbl-subnet.name}"
 }
 
+resource "google_compute_network" "bbl-network" {
+  name		 = "${var.env_id}-network"
+}
+
+resource "google_compute_subnetwork" "bbl-subnet" {
+  name			= "${var.env_id}-subnet"
+  ip_cidr_range = "${var.network_cidr}"
+  network		= "${google_compute_network.bbl-network.self_link}"
+}
+
 output "bosh_open_tag_name" {
     value = "${google_compute_firewall.bosh-open.name}"
 }
@@ -48,24 +62,6 @@ output "internal_tag_name" {
     value = "${google_compute_firewall.internal.name}"
 }
 
-output "director_address" {
-	value = "https://${google_compute_address.bosh-external-ip.address}:25555"
-}
-
-resource "google_compute_network" "bbl-network" {
-  name		 = "${var.env_id}-network"
-}
-
-resource "google_compute_subnetwork" "bbl-subnet" {
-  name			= "${var.env_id}-subnet"
-  ip_cidr_range = "10.0.0.0/16"
-  network		= "${google_compute_network.bbl-network.self_link}"
-}
-
-resource "google_compute_address" "bosh-external-ip" {
-  name = "${var.env_id}-bosh-external-ip"
-}
-
 resource "google_compute_firewall" "external" {
   name    = "${var.env_id}-external"
   network = "${google_compute_network.bbl-network.name}"
@@ -167,3 +163,11 @@ output "jumpbox_url" {
 output "external_ip" {
     value = "${google_compute_address.jumpbox-ip.address}"
 }
+
+output "director_address" {
+	value = "https://${google_compute_address.bosh-external-ip.address}:25555"
+}
+
+resource "google_compute_address" "bosh-external-ip" {
+  name = "${var.env_id}-bosh-external-ip"
+}
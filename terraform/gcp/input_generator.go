@@ -1,11 +1,14 @@
 package gcp
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/cloudfoundry/bosh-bootloader/bosh"
 	"github.com/cloudfoundry/bosh-bootloader/storage"
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
 )
 
 var tempDir func(dir, prefix string) (string, error) = ioutil.TempDir
@@ -37,6 +40,33 @@ func (i InputGenerator) Generate(state storage.State) (map[string]string, error)
 		"zone":          state.GCP.Zone,
 		"credentials":   credentialsPath,
 		"system_domain": state.LB.Domain,
+		"network_cidr":  state.Network.GetCIDR(),
+	}
+
+	if state.Network.Private {
+		networkPlan, err := bosh.PlanNetwork(state)
+		if err != nil {
+			return map[string]string{}, err
+		}
+		input["jumpbox_internal_ip"] = networkPlan.JumpboxIP()
+		input["director_internal_ip"] = networkPlan.DirectorIP()
+	}
+
+	if state.GCP.Network != "" {
+		input["network"] = state.GCP.Network
+		input["subnetwork"] = state.GCP.Subnetwork
+		input["network_project"] = state.GCP.NetworkProject
+		if state.GCP.NetworkProject == "" {
+			input["network_project"] = state.GCP.ProjectID
+		}
+	}
+
+	for _, network := range state.Network.Named {
+		input[fmt.Sprintf("network_%s_cidr", network.Name)] = network.CIDR
+	}
+
+	if len(state.Tags) > 0 {
+		input["labels"] = terraform.MapVariable(state.Tags)
 	}
 
 	if state.LB.Cert != "" && state.LB.Key != "" {
@@ -65,6 +65,7 @@ var _ = Describe("InputGenerator", func() {
 			"zone":          state.GCP.Zone,
 			"credentials":   filepath.Join(tempDir, "credentials.json"),
 			"system_domain": state.LB.Domain,
+			"network_cidr":  "10.0.0.0/16",
 		}))
 
 		credentials, err := ioutil.ReadFile(inputs["credentials"])
@@ -91,6 +92,7 @@ var _ = Describe("InputGenerator", func() {
 				"ssl_certificate":             filepath.Join(tempDir, "cert"),
 				"ssl_certificate_private_key": filepath.Join(tempDir, "key"),
 				"system_domain":               state.LB.Domain,
+				"network_cidr":                "10.0.0.0/16",
 			}))
 
 			sslCertificate, err := ioutil.ReadFile(inputs["ssl_certificate"])
@@ -103,6 +105,86 @@ var _ = Describe("InputGenerator", func() {
 		})
 	})
 
+	Context("when tags are provided", func() {
+		BeforeEach(func() {
+			state.Tags = map[string]string{"team": "core"}
+		})
+
+		It("returns a map containing the labels variable", func() {
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["labels"]).To(Equal(`{"team"="core"}`))
+		})
+	})
+
+	Context("when the network is private", func() {
+		BeforeEach(func() {
+			state.Network = storage.Network{CIDR: "172.16.0.0/16", Private: true}
+		})
+
+		It("returns a map containing the internal ips of the jumpbox and director", func() {
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["jumpbox_internal_ip"]).To(Equal("172.16.0.5"))
+			Expect(inputs["director_internal_ip"]).To(Equal("172.16.0.6"))
+		})
+	})
+
+	Context("when named networks are provided", func() {
+		BeforeEach(func() {
+			state.Network.Named = []storage.NamedNetwork{
+				{Name: "services", CIDR: "10.1.0.0/20"},
+			}
+		})
+
+		It("returns a map containing the cidr of each network", func() {
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs["network_services_cidr"]).To(Equal("10.1.0.0/20"))
+		})
+	})
+
+	Context("when an existing network is provided", func() {
+		BeforeEach(func() {
+			state.GCP.Network = "some-network"
+			state.GCP.Subnetwork = "some-subnetwork"
+		})
+
+		It("returns a map containing the network variables", func() {
+			inputs, err := inputGenerator.Generate(state)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(inputs).To(Equal(map[string]string{
+				"env_id":          state.EnvID,
+				"project_id":      state.GCP.ProjectID,
+				"region":          state.GCP.Region,
+				"zone":            state.GCP.Zone,
+				"credentials":     filepath.Join(tempDir, "credentials.json"),
+				"system_domain":   state.LB.Domain,
+				"network_cidr":    "10.0.0.0/16",
+				"network":         "some-network",
+				"subnetwork":      "some-subnetwork",
+				"network_project": "some-project-id",
+			}))
+		})
+
+		Context("when the network belongs to a shared vpc host project", func() {
+			BeforeEach(func() {
+				state.GCP.NetworkProject = "some-host-project-id"
+			})
+
+			It("uses the host project for the network", func() {
+				inputs, err := inputGenerator.Generate(state)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inputs["network_project"]).To(Equal("some-host-project-id"))
+			})
+		})
+	})
+
 	Context("failure cases", func() {
 		It("returns an error if temp dir cannot be created", func() {
 			gcp.SetTempDir(func(dir, prefix string) (string, error) {
@@ -0,0 +1,30 @@
+package gcp
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/gcp"
+	"github.com/cloudfoundry/bosh-bootloader/storage"
+)
+
+// Resources counts the regional resources that the template generated for
+// state creates, along with the CPUs of the jumpbox and director, which are
+// both n1-standard-1 vms.
+func (t TemplateGenerator) Resources(state storage.State) gcp.Resources {
+	var resources gcp.Resources
+
+	if !state.NoDirector {
+		resources.CPUs += 2
+	}
+
+	if !state.Network.Private {
+		resources.StaticAddresses += 2
+	}
+
+	switch state.LB.Type {
+	case "concourse":
+		resources.StaticAddresses++
+	case "cf":
+		resources.StaticAddresses += 3
+	}
+
+	return resources
+}
@@ -1,23 +1,56 @@
 package gcp
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
+	"text/template"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 )
 
 type templates struct {
-	vars         string
-	jumpbox      string
-	boshDirector string
-	cfLB         string
-	cfDNS        string
-	concourseLB  string
+	vars            string
+	network         string
+	existingNetwork string
+	labels          string
+	jumpbox         string
+	jumpboxPrivate  string
+	boshDirector    string
+	cfLB            string
+	cfDNS           string
+	concourseLB     string
+	namedNetworks   string
+}
+
+// templateData decides which parts of the templates are rendered.
+type templateData struct {
+	ExistingNetwork bool
+	Labels          bool
+	Private         bool
+	Networks        []storage.NamedNetwork
 }
 
 type TemplateGenerator struct{}
 
+// partials are shared by the templates. Every resource in the network points
+// at either the network bbl creates or the user provided one, which may live
+// in a separate Shared VPC host project. Labels are only added to the
+// resources that support them when tags are provided, since older google
+// providers do not support them.
+const partials = `{{define "network"}}
+{{- if .ExistingNetwork}}project = "${var.network_project}"
+  network = "${var.network}"
+{{- else}}network = "${google_compute_network.bbl-network.name}"
+{{- end}}
+{{- end}}
+{{- define "labels"}}
+{{- if .Labels}}
+
+  labels = "${var.labels}"
+{{- end}}
+{{- end}}`
+
 func NewTemplateGenerator() TemplateGenerator {
 	return TemplateGenerator{}
 }
@@ -25,7 +58,21 @@ func NewTemplateGenerator() TemplateGenerator {
 func (t TemplateGenerator) Generate(state storage.State) string {
 	tmpls := readTemplates()
 
-	template := strings.Join([]string{tmpls.vars, tmpls.boshDirector, tmpls.jumpbox}, "\n")
+	network := tmpls.network
+	if state.GCP.Network != "" {
+		network = tmpls.existingNetwork
+	}
+
+	jumpbox := tmpls.jumpbox
+	if state.Network.Private {
+		jumpbox = tmpls.jumpboxPrivate
+	}
+
+	template := strings.Join([]string{tmpls.vars, network, tmpls.boshDirector, jumpbox}, "\n")
+
+	if len(state.Network.Named) > 0 {
+		template = strings.Join([]string{template, tmpls.namedNetworks}, "\n")
+	}
 
 	switch state.LB.Type {
 	case "concourse":
@@ -41,7 +88,30 @@ func (t TemplateGenerator) Generate(state storage.State) string {
 		}
 	}
 
-	return template
+	if len(state.Tags) > 0 {
+		template = strings.Join([]string{template, tmpls.labels}, "\n")
+	}
+
+	return render(template, templateData{
+		ExistingNetwork: state.GCP.Network != "",
+		Labels:          len(state.Tags) > 0,
+		Private:         state.Network.Private,
+		Networks:        state.Network.Named,
+	})
+}
+
+func render(tf string, data templateData) string {
+	tmpl := template.Must(template.New("gcp").Parse(partials))
+	tmpl = template.Must(tmpl.Parse(tf))
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, data)
+	if err != nil {
+		// this should never happen since the templates are built into bbl
+		panic(fmt.Sprintf("gcp template generator: render template: %s", err))
+	}
+
+	return buf.String()
 }
 
 func (t TemplateGenerator) GenerateBackendService(zoneList []string) string {
@@ -89,7 +159,12 @@ func (t TemplateGenerator) GenerateInstanceGroups(zoneList []string) string {
 func readTemplates() templates {
 	tmpls := templates{}
 	tmpls.vars = string(MustAsset("templates/vars.tf"))
+	tmpls.network = string(MustAsset("templates/network.tf"))
+	tmpls.existingNetwork = string(MustAsset("templates/existing_network.tf"))
+	tmpls.namedNetworks = string(MustAsset("templates/named_networks.tf"))
+	tmpls.labels = string(MustAsset("templates/labels.tf"))
 	tmpls.jumpbox = string(MustAsset("templates/jumpbox.tf"))
+	tmpls.jumpboxPrivate = string(MustAsset("templates/jumpbox_private.tf"))
 	tmpls.boshDirector = string(MustAsset("templates/bosh_director.tf"))
 	tmpls.cfLB = string(MustAsset("templates/cf_lb.tf"))
 	tmpls.cfDNS = string(MustAsset("templates/cf_dns.tf"))
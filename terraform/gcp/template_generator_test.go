@@ -6,6 +6,8 @@ import (
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/cloudfoundry/bosh-bootloader/terraform/gcp"
 
+	gcpclient "github.com/cloudfoundry/bosh-bootloader/gcp"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -45,6 +47,124 @@ var _ = Describe("TemplateGenerator", func() {
 			Entry("when a cf lb type is provided", "fixtures/gcp_template_cf_lb.tf", "some-region", "cf", ""),
 			Entry("when a cf lb type is provided with a domain", "fixtures/gcp_template_cf_lb_dns.tf", "some-region", "cf", "some-domain"),
 		)
+
+		Context("when an existing network is provided", func() {
+			It("uses the provided network and subnetwork instead of creating them", func() {
+				expectedTemplate, err := ioutil.ReadFile("fixtures/gcp_template_existing_network.tf")
+				Expect(err).NotTo(HaveOccurred())
+
+				template := templateGenerator.Generate(storage.State{
+					GCP: storage.GCP{
+						Region:  "some-region",
+						Zones:   zones,
+						Network: "some-network",
+					},
+					LB: storage.LB{
+						Type: "cf",
+					},
+				})
+				Expect(template).To(Equal(string(expectedTemplate)))
+			})
+		})
+
+		Context("when the network is private", func() {
+			It("uses the internal addresses of the jumpbox and director instead of external ips", func() {
+				expectedTemplate, err := ioutil.ReadFile("fixtures/gcp_template_private.tf")
+				Expect(err).NotTo(HaveOccurred())
+
+				template := templateGenerator.Generate(storage.State{
+					GCP: storage.GCP{
+						Region: "some-region",
+						Zones:  zones,
+					},
+					Network: storage.Network{
+						Private: true,
+					},
+				})
+				Expect(template).To(Equal(string(expectedTemplate)))
+			})
+		})
+
+		Context("when named networks are provided", func() {
+			It("adds a subnetwork and firewall rules for each network", func() {
+				expectedTemplate, err := ioutil.ReadFile("fixtures/gcp_template_named_networks.tf")
+				Expect(err).NotTo(HaveOccurred())
+
+				template := templateGenerator.Generate(storage.State{
+					GCP: storage.GCP{
+						Region: "some-region",
+						Zones:  zones,
+					},
+					Network: storage.Network{
+						Named: []storage.NamedNetwork{
+							{Name: "services", CIDR: "10.1.0.0/20"},
+							{Name: "iso-seg", CIDR: "10.2.0.0/20"},
+						},
+					},
+				})
+				Expect(template).To(Equal(string(expectedTemplate)))
+			})
+
+			It("creates the subnetworks in an existing network", func() {
+				template := templateGenerator.Generate(storage.State{
+					GCP: storage.GCP{
+						Region:  "some-region",
+						Zones:   zones,
+						Network: "some-network",
+					},
+					Network: storage.Network{
+						Named: []storage.NamedNetwork{
+							{Name: "services", CIDR: "10.1.0.0/20"},
+						},
+					},
+				})
+				Expect(template).To(ContainSubstring(`  ip_cidr_range = "${var.network_services_cidr}"
+  project       = "${var.network_project}"
+  network       = "${var.network}"`))
+				Expect(template).NotTo(ContainSubstring("google_compute_network.bbl-network"))
+			})
+		})
+
+		Context("when tags are provided", func() {
+			It("labels the addresses and forwarding rules", func() {
+				expectedTemplate, err := ioutil.ReadFile("fixtures/gcp_template_labels.tf")
+				Expect(err).NotTo(HaveOccurred())
+
+				template := templateGenerator.Generate(storage.State{
+					GCP: storage.GCP{
+						Region: "some-region",
+						Zones:  zones,
+					},
+					LB: storage.LB{
+						Type: "cf",
+					},
+					Tags: map[string]string{
+						"team": "core",
+					},
+				})
+				Expect(template).To(Equal(string(expectedTemplate)))
+			})
+
+			It("labels the dns zone", func() {
+				template := templateGenerator.Generate(storage.State{
+					GCP: storage.GCP{
+						Region: "some-region",
+						Zones:  zones,
+					},
+					LB: storage.LB{
+						Type:   "cf",
+						Domain: "some-domain",
+					},
+					Tags: map[string]string{
+						"team": "core",
+					},
+				})
+				Expect(template).To(ContainSubstring(`  description = "DNS zone for the ${var.env_id} environment"
+
+  labels = "${var.labels}"
+}`))
+			})
+		})
 	})
 
 	Describe("GenerateBackendService", func() {
@@ -74,4 +194,23 @@ var _ = Describe("TemplateGenerator", func() {
 			Expect(template).To(Equal(string(expectedTemplate)))
 		})
 	})
+
+	Describe("Resources", func() {
+		DescribeTable("counts the cpus and static addresses the environment creates",
+			func(lbType string, private, noDirector bool, expected gcpclient.Resources) {
+				resources := templateGenerator.Resources(storage.State{
+					NoDirector: noDirector,
+					Network:    storage.Network{Private: private},
+					LB:         storage.LB{Type: lbType},
+				})
+
+				Expect(resources).To(Equal(expected))
+			},
+			Entry("without load balancers", "", false, false, gcpclient.Resources{CPUs: 2, StaticAddresses: 2}),
+			Entry("with cf load balancers", "cf", false, false, gcpclient.Resources{CPUs: 2, StaticAddresses: 5}),
+			Entry("with a concourse load balancer", "concourse", false, false, gcpclient.Resources{CPUs: 2, StaticAddresses: 3}),
+			Entry("when the network is private", "", true, false, gcpclient.Resources{CPUs: 2}),
+			Entry("when there is no director", "", false, true, gcpclient.Resources{StaticAddresses: 2}),
+		)
+	})
 })
@@ -4,7 +4,12 @@
 // templates/cf_dns.tf
 // templates/cf_lb.tf
 // templates/concourse_lb.tf
+// templates/existing_network.tf
 // templates/jumpbox.tf
+// templates/jumpbox_private.tf
+// templates/labels.tf
+// templates/named_networks.tf
+// templates/network.tf
 // templates/vars.tf
 // DO NOT EDIT!
 
@@ -73,7 +78,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesBosh_directorTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x95\xd1\x6e\xa3\x30\x10\x45\x9f\xc3\x57\x8c\xac\x7d\x2c\x24\x65\x43\x12\x55\xea\x97\xac\x22\x64\xc0\xa5\x6c\x1d\x6c\x19\x93\x54\xaa\xf8\xf7\x95\xb1\x0d\x24\x10\xe4\x64\x1b\x55\x7d\x28\x81\x3b\x97\x99\xc3\x1d\x60\xb5\xe4\xb5\x04\x54\x12\x79\x62\xe2\x23\x2e\xf1\x81\x20\xf8\xf2\x00\x00\x8e\x98\xd6\x04\x5e\x01\xfd\xfa\xca\x19\xcb\x29\x89\x53\x76\xe0\xb5\x24\xb1\x51\x07\x49\x42\x7d\x7b\xac\x2a\x1b\xe4\x35\x9e\x67\x3d\xab\x3a\xb9\xcd\xb6\x2f\x68\x9d\xf5\xcf\x09\xe3\x84\x55\xef\x31\xe3\xa4\x8c\x25\xce\x1d\xbd\xdf\x0a\x41\x4e\x98\xd2\x40\x15\xfb\xaa\xf8\x9a\x71\x56\x08\x92\x4a\x26\xce\xcc\x17\xae\xce\xb6\x7a\xc2\xfd\x6f\x7d\xe0\x09\xfb\xbc\xea\x7b\xc4\x22\x20\xe5\x31\x2e\xb2\xc6\x37\xda\xb3\xfa\xa2\x94\x44\x94\x98\xde\x33\xb5\xad\x9d\x68\xab\x9b\x17\x67\x99\x20\x55\x75\xde\xd6\xbb\x94\xbc\x7a\x59\x2e\x47\xd6\x46\xad\xa7\x26\x9f\xda\xde\x2f\x78\x60\x2e\x34\x2f\x61\x14\x45\x51\x7b\x2b\x41\x2a\x56\x8b\x94\x00\x9a\x0e\x12\x02\x34\x88\x92\x1e\x4b\x35\xba\x58\x8c\xc9\x58\xd1\xac\x6f\x9f\x24\x63\xad\x4f\x0c\x9d\x17\x23\x67\xa3\xf1\x00\x0a\x1e\xa7\x45\x26\x62\x81\xcb\xbc\xa5\xf0\xbc\x0a\xda\xbf\xe5\xf3\x46\x5d\x37\xde\xc6\xc3\x61\x39\x2a\x42\xdf\x62\x5a\x94\x1f\xcd\x7c\xdf\xdd\x23\x40\x97\x58\xfb\xd6\xc7\x48\x46\xda\xd9\x7b\xd8\x4c\x20\x40\xb6\x66\x60\x0e\x30\xf6\xef\x64\xfd\xe8\xb7\xbe\x16\x3c\x00\xdd\x8e\x66\x5a\xc1\x2b\xfc\x41\x16\xea\x0a\xed\x95\x00\x53\xca\x4e\x26\xd2\x9c\x09\xa9\x45\x61\x88\x9e\x00\x6d\x76\x9b\x9d\xfa\xaf\x33\xb5\xd7\x1a\xc1\x24\x4b\x19\x55\xbd\xc8\x94\xab\xee\x1a\xe5\x23\xb1\xc8\x89\x54\x5b\xa2\x1d\x26\x60\xa9\xfd\x47\x7b\x57\x4c\x7d\xc9\x3c\xa7\x5e\xf7\x1d\xa0\x1c\xfa\x77\x84\xb6\x5b\xaf\x7f\x7f\x23\x3c\xfb\xc2\xb8\x11\x60\x57\xe6\x00\xb1\xd3\x3e\x1a\xe4\x60\x96\x4b\x98\x77\x01\xb2\xef\x59\x77\x36\xb6\xc2\x97\xcc\x15\xd1\x64\xc9\x03\x49\x0d\x86\xba\x9a\xb8\x75\xa8\x33\x17\x46\x61\xb4\xd2\x07\xdb\xed\xf6\x27\xc2\x66\x3e\x9d\x0a\x4e\x7b\x62\x16\xe5\x85\xf8\x81\x10\xed\x17\x7d\x7e\x6b\xff\x87\x57\xf7\x98\x9e\xe6\x77\xea\xe6\x68\x3a\xc6\xf1\x87\x22\x38\x60\x55\xa4\x87\x1e\x96\xcb\x32\x5f\xd3\xd4\xd9\x5d\x0b\xff\x2f\x00\x00\xff\xff\x60\x07\x9c\x5d\x4e\x0b\x00\x00")
+var _templatesBosh_directorTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x94\xc1\x6e\x9b\x40\x10\x86\xcf\xdd\xa7\x18\x8d\x7a\x8c\xa9\x45\x4d\xec\x4b\x9e\xa4\xb2\xd0\x06\x4f\x29\xed\xc2\xa0\xf5\x60\x47\x42\xbc\x7b\xb5\x5e\xc0\xa1\x89\xc9\x26\x44\xaa\x7c\x30\x42\xff\xff\xef\xcc\xc7\xcc\x72\x23\x75\x23\x80\x8f\x7c\xfc\x95\x72\x4d\x55\x2a\x3a\x4f\x2b\x5d\x12\x42\xab\x00\x00\x4e\xda\x34\x04\x0f\x80\x5f\xdb\x9c\x39\x37\x94\x66\x5c\xd6\x8d\x50\xfa\xb3\xb0\x74\xd6\xc6\x44\xce\xbc\x72\xe6\xc8\x19\x3b\x54\x9d\x52\x93\xe0\x43\x61\x29\x13\xb6\x93\xf0\x2f\xa1\xc9\x83\xfb\x95\xf4\xdf\x4d\x59\x3f\xf2\xd3\xcd\xdc\x93\xb6\x11\x55\xa7\xb4\x38\x74\xab\x5e\x3b\xf1\x17\x95\x90\xad\xb4\xf9\x48\xd7\x83\xf7\x59\x59\x96\x8e\xdc\xd8\x8c\x00\x6f\x98\x10\x90\x9e\xbc\xcd\xf3\x75\x5e\x47\xf9\x45\xb5\xa3\x4c\x01\xb4\xad\x50\x59\x1b\x2d\x04\x58\x91\x9c\xd9\xfe\x41\x88\xba\x4e\x29\x00\x7f\x60\x6a\x75\x95\xd3\x11\x1e\xe0\x07\xae\xa3\xcb\xef\xdb\x1a\xf7\x4e\xa0\x8d\xe1\x73\xdf\x55\xcd\x56\xbc\x28\x8e\xf1\x0e\xf0\x7e\x77\xbf\x73\xff\x71\x92\x24\x09\xee\xbd\xc6\xb2\x70\xc6\xc6\x35\x2f\x59\x8d\x0a\xe0\x72\x90\x68\x9b\x93\x38\x50\x3e\x61\x5a\xee\x38\x02\xb8\x0f\x05\x71\xb5\xcc\x93\xb8\xea\xc2\x50\x04\x54\x18\x88\x65\xb7\xd9\x7c\xff\x44\x3c\xc3\x1c\xbf\x13\xd1\x68\x0b\xc0\x34\x6a\x97\xa3\x1a\xa3\x5e\xe2\xfa\x10\x82\x61\x5d\xc2\xbb\x1f\x1c\x2b\xe1\x50\x08\xaf\x5a\x16\xb1\x18\x12\xe7\xa6\x66\x13\xfb\x75\x8a\x93\x38\x59\xfb\x87\xed\x76\xfb\x3f\x06\xa6\xbf\xe3\x5c\xfb\x97\x17\xb3\xb0\xfe\x11\x2f\xc2\xd4\x67\xbd\xb1\x5b\x4b\x88\x8c\x1f\xe2\x0e\x3e\x87\xd5\x18\x18\x36\x52\xcb\x6e\x9f\x99\x31\x7a\x46\xa3\xc8\xca\x2b\x8e\x5b\x22\xc9\xde\xd6\x34\x87\x77\x63\xdd\xab\x4e\xfd\x1d\x00\x5f\x97\x39\x29\x0e\x08\x00\x00")
 
 func templatesBosh_directorTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -88,12 +93,12 @@ func templatesBosh_directorTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/bosh_director.tf", size: 2894, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/bosh_director.tf", size: 2062, mode: os.FileMode(420), modTime: time.Unix(1792402024, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x96\xcf\x8a\xdb\x30\x10\x87\xef\x7e\x8a\x41\xf4\x54\xb0\x59\xe8\x39\x87\x42\xcf\xbd\xf4\x58\x16\xa3\x58\x13\x47\x20\x6b\xc4\xcc\xd8\xd9\x74\xf1\xbb\x17\x39\x71\x36\x69\x6b\xba\x3e\x04\x72\xd8\x5c\xf2\x47\x3f\xcd\x7c\xf3\x29\x18\x0d\x96\xbd\xdd\x06\x04\x23\x47\x51\xec\x6a\x47\x9d\xf5\xd1\xc0\x6b\x01\xa0\xc7\x84\xb0\x01\x23\xca\x3e\xb6\xa6\x18\x8b\x82\x51\xa8\xe7\x06\xc1\xb4\x44\x6d\xc0\xda\x45\xa9\x3b\x1b\x6d\x8b\xae\xfe\x45\x11\x0d\x18\x8c\xc3\xf4\xf3\xe9\x6b\x2e\x14\x6d\x87\x70\x7e\x6d\xc0\x7c\x7a\x1d\x2c\x57\x39\xe6\xdd\x58\x4e\xb1\x02\x20\x6f\x99\x83\x97\xd0\x0d\xd5\x58\x4d\x39\x94\x86\x7d\x52\x4f\x31\xe7\xbe\x7d\xff\x01\xb9\x04\xec\x88\x41\xf7\x08\x37\xd5\x01\xe3\xe0\x99\x62\x87\x51\xa7\x01\xa8\xd7\xd4\xeb\x1f\xe3\x4e\xb8\x82\x3c\x20\xcb\x89\x78\xb0\xa1\xc7\x13\xc6\xc2\xa0\xd5\xf5\x98\x55\x06\x9f\x2b\x8c\xcb\xa6\x18\x1b\x62\x57\x0b\xaa\x01\x73\xf0\xc1\x35\x96\x5d\xe9\xa2\xfc\xe5\x69\x03\xe6\x73\xf5\xce\xe6\xb3\xb9\xf1\xa4\x27\x61\x74\x52\x4f\x76\x7e\xce\xcd\x1b\xea\x52\xaf\x58\xb7\x81\xb6\x36\xd4\xd6\x39\x46\x91\xaa\xd9\x95\xe7\x8f\xe6\x79\x3e\xf0\x4b\xff\xaf\xb9\x9c\x6a\x78\x3b\xb9\x2f\x4f\x4f\x45\x01\x70\x4d\xb2\xd2\xd1\x68\x72\x01\x66\x67\xd5\xca\x04\x78\xd9\xfc\x5f\xc4\xea\xfc\x3e\x9a\xe7\xf7\x09\xde\x92\xec\x97\xe4\xe6\xb5\x3b\xf8\x9d\x51\xa7\xd6\xf8\xa2\xc8\xd1\x86\xd2\xa7\xc7\xd1\xbb\x44\xb8\xda\x6e\xb3\x2b\x45\xf6\x65\x62\x7a\x39\xfe\xcb\xb0\xdc\x55\xf0\x4d\xf7\x87\x93\x7b\x4d\xb7\x5a\xac\x36\x69\xe9\x5f\xab\x4d\xba\xaf\xd3\xdc\x9b\xa9\x57\xe4\x87\x94\xfa\x86\xb7\xda\xaa\xa3\x94\x02\xf2\x92\xd9\xf3\xf2\x7d\xed\x1e\x1e\xe8\x31\x7b\x83\xb5\xda\x66\xa0\xb6\x65\x6c\xad\xd2\xa2\xd1\xab\xc8\x87\xd5\x95\x37\x82\x83\x2c\x5f\x0a\x0e\xf2\xa1\xb3\x18\x8b\xdf\x01\x00\x00\xff\xff\xc6\x89\x38\x3c\xba\x0a\x00\x00")
+var _templatesCf_dnsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\xd6\xc1\xab\x9b\x4e\x10\x07\xf0\xbb\x7f\xc5\xb0\xe4\xf4\x40\x79\xf0\x3b\xe7\xf0\x83\x9e\x7b\xe9\xb1\x3c\x64\xe3\x4e\x8c\xb0\xee\x2e\x33\xa3\xbe\x54\xf6\x7f\x2f\x6b\x34\x24\x6d\x85\x78\x08\x04\xda\x5c\x92\xe8\x64\xe6\x3b\x1f\x96\x60\xaf\xa9\xd1\x07\x8b\xa0\xf8\xcc\x82\x6d\x69\x7c\xab\x1b\xa7\x60\xcc\x00\xe4\x1c\x10\xf6\xa0\x58\xa8\x71\xb5\xca\x62\x96\x11\xb2\xef\xa8\x42\x50\xb5\xf7\xb5\xc5\xd2\x38\x2e\x5b\xed\x74\x8d\xa6\xfc\xe1\x1d\x2a\x50\xe8\xfa\xe9\xf2\xe5\x6b\x6a\xe4\x74\x8b\x30\xbf\xf6\xa0\x76\x63\xaf\xa9\x48\x65\x8d\x89\xf9\x54\x96\x01\xa4\x9f\x2c\x85\xd7\xa2\xbb\x54\xb1\x98\xea\x90\x2b\x6a\x82\x34\xde\xa5\x70\x5f\xbe\x7e\x83\xd4\x02\x8e\x9e\x40\x4e\x08\x77\xdd\x01\x5d\xdf\x90\x77\x2d\x3a\x51\xd9\x38\xe6\x20\xd8\x06\xab\x05\x41\x59\x7d\x40\xcb\x0a\x8a\x18\xd3\x6a\xbe\x93\xd0\xc9\x2f\x10\xd3\x22\x8c\xd4\x23\xf1\x65\x97\x5e\xdb\x6e\x52\xd9\x8d\x2b\x04\xc5\x2d\x40\x91\x56\x5a\x3a\xc4\x75\x43\xc2\xca\x93\x29\x19\x45\x81\x1a\x1a\x6b\x2a\x4d\x26\x37\x8e\x7f\x13\xdc\x83\x7a\x2b\x1e\x1c\xbe\x98\xc6\x0b\x5c\x40\x67\xb8\x9c\xdc\xbe\x2f\xc3\x2b\xdf\x86\x4e\xb0\xac\xad\x3f\x68\x5b\x6a\x63\x08\x99\x8b\xea\x98\xcf\x1f\xd5\xc7\x72\x14\xae\xf3\xff\x4f\xed\x44\xec\x7c\x05\xf6\xf0\xdf\xfb\x7b\x96\x01\xdc\x26\xd9\x68\x14\x55\x6a\x40\x64\xb4\x68\x9e\x02\xee\xc6\x47\x23\x16\xf3\x7b\x54\x1f\x8f\x01\x1f\x3c\x9f\xd6\x70\xd3\xbd\x27\xf8\x2e\x51\x53\xfb\x1c\x3f\x05\xc9\x69\x9b\x37\xe1\x75\x78\xd7\x12\x6e\xd6\xad\x8e\x39\xf3\x29\x0f\xe4\x3f\xcf\x7f\x12\xe6\xa7\x02\xdf\x4d\x7f\x39\xdc\xdb\x74\x9b\x61\xa5\x0a\x6b\xa7\x56\xaa\xf0\x5c\xd3\x34\x9b\x7c\x27\x48\xaf\x77\x62\xef\xe2\x6d\x56\x35\x3e\x04\x8b\xb4\x26\x3b\xdf\x7e\xae\xee\xc0\x2f\xa9\x3a\x6c\xff\x6b\xb5\xbe\xae\x09\x6b\x2d\x7e\x55\xf4\xa6\xe4\x9f\xea\x63\xaa\xd7\x27\x82\x81\xd7\x54\xdf\x8a\x81\xff\x72\xce\x0b\xe7\xcf\x01\x00\xad\x52\x8e\xfb\xd4\x0a\x00\x00")
 
 func templatesCf_dnsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -108,12 +113,12 @@ func templatesCf_dnsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 2746, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/cf_dns.tf", size: 2772, mode: os.FileMode(420), modTime: time.Unix(1792402764, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x58\x4f\x6f\xe3\xb6\x13\x3d\xff\xfc\x29\x08\xe2\x77\x68\x81\x95\x63\x3b\xe9\xd6\x3d\xec\xa9\xe8\x75\xdb\x43\x6f\x8b\x80\xa0\xa8\x91\x4d\x98\x11\x59\x92\xb2\xd7\x08\xf2\xdd\x0b\x92\x52\x2c\x59\x12\x25\x39\x59\x14\xd9\x3d\x58\x11\x67\xde\x50\x6f\xfe\xe8\x89\x47\xaa\x39\x4d\x05\x20\x6c\x8c\x20\x0c\xb4\xe5\x39\x67\xd4\x02\x46\xcf\x0b\x84\xec\x59\x01\xfa\x82\xb0\xb1\x9a\x17\x3b\xbc\x78\x59\x2c\x06\x3d\x88\xd2\xfc\xe8\x7e\x0f\x70\x1e\xf4\x96\xa5\x55\xa5\x45\x58\xcb\xd2\x82\x26\x29\x65\x07\x28\x32\x62\x40\x1f\x39\xab\x82\x1e\xa9\x28\xbd\xdf\xff\x9f\x77\x52\xee\x04\x10\x26\x9f\x54\x69\xe1\xda\x7c\x19\x50\x12\x91\x26\xd5\x4a\x52\xaf\x14\xf4\x09\x5e\xfa\x22\x8a\x94\x70\x15\xe2\xc4\x22\xed\x84\x4c\xa9\x20\x34\xcb\x34\x18\xb3\x64\x79\x52\x5f\x56\xbf\x6d\x70\x63\xf6\x44\x69\xf9\xfd\x3c\x15\xbf\x01\x6c\xcc\x3e\xf1\xbe\xfd\xd0\x96\x29\x32\x6f\xef\x0d\x6c\xcb\x54\x12\x9c\xfb\xc1\x4f\xe6\x06\xd0\xd3\x15\x09\x1a\x8c\x2c\x35\x03\x84\xaf\x7c\x72\xae\xe1\x44\x85\xc0\x08\xd7\x97\x09\xcb\x43\x2c\x97\x20\x14\xfe\xf9\x70\x47\xaa\x97\x50\x1c\x09\xcf\x5e\x12\x96\x27\x52\x41\x81\x17\x08\x65\xa0\xa0\xc8\x0c\x91\x05\xfa\x82\xbe\x5d\x07\x28\xc0\x9e\xa4\x3e\x2c\xd3\x54\x24\xd5\x35\x7e\x74\xe0\xe1\xfa\x15\x7c\xdc\xad\x2e\x98\x05\x42\x54\x08\x79\xaa\xf8\x50\x5a\x5a\xc9\xa4\x70\x30\x96\x29\x1c\x6e\x4a\x6d\x4d\xc0\xfe\x86\xb7\x2b\xfc\x09\xe1\x87\x87\x7b\x1f\xf8\xc5\x01\x04\x36\x88\xa6\xc5\x0e\x8c\x37\x5a\x2d\xfd\xff\xbb\x15\x7e\x74\x06\x96\xea\x1d\x58\x62\xe9\x2e\x2c\xbf\xb9\xce\x1f\xa3\x69\x68\xd7\x32\x46\xf8\x52\xcd\x8d\x5c\xf4\x64\x21\x9e\xdd\x0a\x36\x97\xfa\x44\x75\xc6\x8b\x1d\xd1\xa5\x80\x00\xbf\xb7\x56\x25\x97\x95\x24\xac\x4c\xc8\xbb\x73\x74\x2c\x73\x55\xef\xf7\xe6\xf6\xac\x79\x46\x43\x65\x50\xa5\xc1\x85\x0c\xcd\xbb\xac\x77\x2e\xd2\xaa\x23\x0d\x88\x9c\x08\x5e\x1c\x3c\x9e\x4b\x7c\x48\xab\xc3\xdb\xae\xde\xc6\x8f\xb9\x99\x20\xf3\x1f\x30\x64\xda\x14\x99\x69\x1c\xb9\xbe\x88\x92\xd4\xc9\x41\xa3\x7e\xea\x08\x1d\x5e\xba\xc4\x78\xfb\x60\xec\x87\x86\x61\x9a\x2b\xcb\xfd\xd4\xc0\x1a\xa8\x10\x67\x44\x91\x90\x34\x43\x29\x15\xb4\x60\xa0\x51\x5a\x5a\x24\xb8\xb1\x90\x21\x6a\x10\x2d\x90\x03\x41\xaf\x20\xa5\x16\xe4\x89\xaa\x41\x6e\xaa\xf5\x16\x21\xa5\x16\x89\xbb\xd7\xa4\x64\xe2\xd3\x9b\xeb\xc7\x37\x91\xe7\x1f\x26\xc1\xf4\xb3\x50\x3b\xcc\xa1\xc2\xf4\x73\xf1\x66\x42\x10\xba\x12\x0e\x03\x43\xf0\xca\xca\xe1\xba\x3f\x9b\x58\xf1\xb9\xd7\x51\x34\xb8\x82\xb8\x10\x4a\x94\x86\x9c\x7f\xef\x70\xd9\x53\x45\xa5\x01\xed\x18\x39\xf2\x0c\x32\xf7\x08\xa8\xd2\x3b\xe8\x00\x67\x74\xe7\xef\x34\xa2\x21\x45\xb9\xf6\x0d\x71\x51\x45\x21\x4c\xce\x05\xfc\xe4\x62\x45\xf4\xd3\xcf\x7e\x07\x4d\xb8\xa8\x6b\x30\x17\x3c\x07\x76\x66\x02\xd0\xf3\xe2\x7f\x4c\x83\xc3\x4a\x21\x97\x1a\x48\x06\xc6\x6a\xe9\x36\x60\x75\x09\xfe\x45\x15\x63\xae\x4a\xe5\x55\x31\x56\xc9\x8c\xbf\x33\xaa\x09\xee\xf9\xcb\x69\x29\x6c\xfd\x12\x7b\xa3\xa0\x9b\xda\x52\x7b\xa0\xc2\xee\x09\xdb\x03\x3b\x84\xfd\xab\x32\x15\x9c\x25\x61\x21\xa9\x16\x7a\x3b\x6a\x60\xe4\x06\x00\xff\x4c\x7e\x4e\x35\x43\x38\xaa\xc3\xd0\xeb\x22\x6d\x57\xdb\x95\x5b\xd5\xf0\x4f\x09\xc6\x12\x45\xed\xbe\x11\xe7\x2e\xe0\xe0\xd1\x6c\x74\x82\xbe\xcf\x73\xd5\xd3\x7a\x60\xe3\xe3\xfb\x9e\x28\xfd\x5c\x4d\xc4\xf6\xd8\x5b\x44\x4d\x87\x8f\x21\x03\x83\x10\xdc\xae\x62\x3a\x70\x7d\xbf\x5a\x6e\xd6\x6b\xaf\x05\x37\x1b\x67\x7f\xff\xcb\x72\xfd\x5b\xb8\xb1\xfe\xec\x5d\x9b\xe2\x10\xbd\xa3\x3c\xec\x7e\xaa\x54\x91\x94\x94\x62\xec\xc3\xab\x61\xda\xfe\x64\xb9\x7c\x67\x0d\x96\x42\x4b\x77\xbe\x7a\x8e\x4c\x91\x8b\xdd\x8c\x32\xeb\x03\x1f\xae\xb1\x57\xeb\x8f\xf3\xb1\xb1\xd9\x6c\x36\x97\xfa\x1a\xfd\x8c\x18\xc9\x5a\xfc\xed\xd9\xaa\x8e\x1b\x53\xe7\x9a\x00\x8c\xe1\xb2\x20\x34\xcf\x79\xc1\xad\x7f\x07\x7e\xfd\xf3\xeb\x1f\x23\x79\xed\x13\xcd\xc3\xe9\x1d\xdb\x47\x4b\xe8\xce\x2b\xf0\x41\x75\xeb\x60\x7c\x3e\x82\x16\x6f\x26\xef\xef\xdf\xff\xba\x52\xe8\xef\x77\x06\x70\x7b\xd3\x36\xce\x02\x26\x74\x6d\xbb\xb3\x2e\xbe\x93\x5a\xab\x61\xfe\x11\xda\x6a\xbd\xda\x3c\x24\xf7\x9b\x5f\x3f\x6f\x6f\x6f\xae\x0e\xbb\xf1\xee\x6a\x0d\xc5\x5e\x76\xc7\x78\xbd\x41\x31\x44\xb2\x18\x15\x40\xed\x74\x0e\x69\x86\x37\x2a\x86\xce\xbc\xb9\x89\x95\xe8\xc4\x71\x02\xae\x41\x8a\x4f\xac\xaf\x86\x6e\x76\x3b\x0c\xf6\xe6\xf8\xd3\x02\xa1\x78\x9e\x7b\x07\x59\x2c\x0f\xe3\xfc\xcf\x1c\x65\x8d\x4d\x47\x67\x59\xa3\x09\xde\x63\xa2\x4d\x38\x79\xbc\x7d\x94\x9d\xcc\x6c\xe1\x71\x1a\x39\xeb\x72\x06\xf3\xea\x73\x12\xe2\xec\x7a\x9c\x58\x8a\x3d\xe2\x7f\xd2\xdc\xe9\xad\xc7\x93\xa9\x8e\x95\x26\x55\xe3\xab\xf5\xfc\x5a\x3c\x99\x78\x0d\xfa\xe3\xa2\x77\x28\xbe\xe9\x27\xd4\x11\x3a\x66\xb1\xf1\x03\xc8\xd8\xae\x7e\x04\x17\xff\x06\x00\x00\xff\xff\xcb\x4b\x2e\xce\xf1\x19\x00\x00")
+var _templatesCf_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\xc1\x6e\xe3\x36\x10\x3d\xd7\x5f\x31\x20\x7a\x68\x81\x95\xd7\x71\xb6\x5b\xf7\x90\x53\xb1\xd7\x6d\x0f\xbd\x2d\x16\x02\x45\x8d\x6c\xd6\x8c\xc8\x92\x94\x15\x23\xd0\xbf\x17\x14\xa9\x58\x96\x69\x59\x76\xb2\xed\x65\xe3\x83\x1d\x92\x33\x8f\xf3\xe6\x71\x34\xd4\x8e\x6a\x4e\x33\x81\x40\x8c\x11\x29\x43\x6d\x79\xc1\x19\xb5\x48\xe0\x79\x06\x60\xf7\x0a\xe1\x01\x88\xb1\x9a\x97\x6b\x32\x6b\x66\xb3\xb3\x16\xa9\xd2\x7c\xe7\xbe\xb7\xb8\x3f\x6b\x2d\x2b\xab\x2a\x0b\x44\xcb\xca\xa2\x4e\x33\xca\xb6\x58\xe6\xa9\x41\xbd\xe3\x2c\x80\xee\xa8\xa8\x5a\xd4\x1f\x9f\xd7\x52\xae\x05\xa6\x4c\x3e\xaa\xca\xe2\x70\xf9\xdc\x7b\x49\x44\x96\x84\x99\xa4\x9b\x29\xe9\x23\x36\x31\x44\x91\xa5\x5c\x79\x9c\x31\xa4\xb5\x90\x19\x15\x29\xcd\x73\x8d\xc6\xcc\x59\x91\x74\x3f\xc3\xf7\xb1\x73\x63\x36\xa9\xd2\xf2\x69\x3f\xd5\x7f\xe7\x8d\x15\x89\x31\x9b\xa4\xb5\x8d\xbb\xb6\x4c\xa5\xd7\xed\xbd\xe7\xdb\x32\x95\x78\xe3\xb8\xf3\xda\xdc\xe0\xb4\x1e\x90\xa0\xd1\xc8\x4a\x33\x04\x32\xb0\x29\xb8\xc6\x9a\x0a\x41\x80\x74\x3f\x13\x56\x78\x2c\x97\x20\x87\x08\xe0\x33\xbd\xa3\x7a\x8e\xe5\x2e\xe5\x79\x93\xb0\x22\x91\x0a\x4b\x32\x7b\x7e\x4e\x80\x17\x30\xff\xf4\xc4\x8d\xe5\xe5\xfa\x33\xda\x5a\xea\x6d\xd3\xcc\x00\x94\x96\x7f\x23\xb3\x47\x0e\x4a\x3f\x9f\x86\xb9\x86\xcc\x00\xc2\x58\x6c\x5d\xe3\x11\x50\x18\x6c\x5d\xe6\xa8\xb0\xcc\x4d\x2a\x4b\x78\x80\x2f\xc3\x68\x82\xd1\x3c\xcb\x44\x12\x7e\x93\xaf\x11\x80\xcb\x66\x9d\x3a\x5b\xf0\x32\x6f\x9a\xd9\x0c\x80\x0a\x21\xeb\x90\x06\xa5\xa5\x95\x4c\x0a\x47\x8d\x65\xca\x85\x01\xa0\xa4\xb6\xc6\x87\xf1\x85\xac\x16\xe4\x1d\x90\x0f\x1f\xee\xdb\x2d\xb4\x0e\x7c\x12\x52\x4d\xcb\x35\x9a\x36\x80\xc5\xbc\xfd\xbc\x5f\x90\xaf\x6e\x81\xa5\x7a\x8d\x36\xb5\x74\xed\xa7\x5f\x7d\xbc\xbe\x8e\x66\xff\xf8\x08\x11\x20\x87\x43\xd4\x93\x40\x24\xf9\x9e\x18\x8b\x8f\x4a\x50\x8b\x40\x04\xcd\x50\x18\x02\xf3\xa6\x99\x02\x58\x48\x5d\x53\x9d\xf3\x72\x9d\xea\x4a\xa0\x07\xde\x58\xab\x92\xc3\x4c\xe2\x67\x26\x08\xd1\x19\x3a\xfe\xb9\xea\x22\x89\x66\x79\x4a\xbd\xe8\x32\x70\xc0\x1a\x38\x09\x09\x72\x90\x4e\xc2\x4f\xfb\x79\xb7\x73\x91\x85\x12\x61\x50\x14\xa9\xe0\xe5\xb6\xd5\xb6\x93\x84\x4f\xb8\xf3\xb7\x5a\x7c\x2b\xe6\xcc\xcd\xd4\x99\xff\x81\x3b\x73\x4c\x9e\x99\xc6\x9e\x3b\x4b\x37\xd2\xd7\xc3\xf6\xd0\x3d\xcd\x75\xd8\x27\x8c\x9d\x52\xd6\xae\xf7\xf6\x6d\x31\x32\x4c\x73\x65\x79\x5b\x8d\x88\x46\x2a\xc4\x1e\x28\x08\x49\x73\xc8\xa8\xa0\x25\x43\x0d\x59\x65\x41\x70\x63\x31\x07\x6a\x80\x96\xe0\x9c\xc0\x8b\x93\x4a\x8b\xf4\x91\xaa\xb3\xac\x85\xf9\x23\xaa\x2a\x2d\x12\x37\xd6\x27\x6b\x62\xf4\x66\x18\xbe\x19\x89\xff\x3c\x09\x26\xce\x42\x67\x70\x0d\x15\x26\xce\xc5\xab\x09\x01\x18\x74\x3f\x67\x4a\xea\x60\x95\xf3\xeb\xfe\xed\xfb\x1a\xaf\xa2\x03\x07\x5e\x59\x6e\xe0\x40\x68\xaa\x34\x16\xfc\xe9\x84\xcb\x88\x8a\x2a\x83\xda\x31\xb2\xe3\x39\xe6\x2e\x04\x08\x4d\x1b\x6c\x71\x0f\xef\xdb\x91\x1e\x1a\x28\xca\xb5\x73\xd3\x6b\xed\x3c\x4c\xc1\x05\xfe\xe4\xb0\x46\x9a\xc0\x9f\x5b\x9e\xfa\xee\x46\x4d\xfd\x72\xc1\x0b\x64\x7b\x26\x10\x9e\x67\x3f\x30\x8d\xce\x57\x86\x85\xd4\x98\xe6\x68\xac\x96\x7b\x78\x00\xab\x2b\x6c\x1f\x7b\x63\xcc\x85\x54\x0e\xc4\x18\x92\xd9\x93\xe3\x89\x04\xc3\x72\xe2\x9e\x99\x39\x16\xb4\x12\xb6\x7b\x24\x46\x35\x33\xfd\xb1\xd9\x57\xd0\xd8\xd6\x37\x48\x85\xdd\xa4\x6c\x83\x6c\xeb\xf7\xaf\xaa\x4c\x70\x96\xf8\x89\x24\x4c\xbc\x84\xd0\x29\x3a\xfc\xc5\x22\xf2\x0e\xda\x98\x1c\x17\x47\x10\x8e\x6a\x5f\x0e\x3b\x0f\xdd\xdf\x03\xac\x16\xab\x85\x9b\xd5\xf8\x4f\x85\xc6\xa6\x8a\xda\xcd\x61\x96\xbc\xf7\x7e\xc8\xc5\x6c\x9c\x80\xbe\x4d\x5c\x5d\x1d\xef\x96\x0c\x36\x7e\x79\xdf\x13\xfb\x57\x56\x8c\xef\x31\x46\xf9\x91\xc1\xf7\x5e\x76\xa4\x97\xf5\xdd\xec\x6a\x31\xd6\xcc\xde\xdd\x2f\xe6\xcb\xbb\xbb\xb6\xa1\x5d\x2e\xdd\xfa\xfb\x5f\xe6\x77\xbf\xf9\x81\xbb\x8f\xad\x69\xbf\xc3\x85\x37\xec\x71\x4f\xaf\x79\x01\x49\x49\x29\x2e\x5d\x5a\x7b\x4b\x8f\xaf\x7b\x81\xb9\x31\x05\x86\x06\xc8\x0b\xf0\xc5\xb2\xa7\xbe\x98\xee\x0e\xeb\x6e\xec\x67\x8e\x75\x1f\x83\x3d\x2f\xfa\x97\xd5\xdf\xaf\x70\x17\xae\x70\xcb\xe5\x72\x79\x10\xfc\xc5\xcb\xd9\x05\x19\x8d\x77\x11\x3d\xe3\x9b\xb5\xe4\x4e\x25\x1a\xc3\x65\x99\xd2\xa2\xe0\x25\xb7\xee\x51\x4c\x3e\xff\xf1\xf9\xd3\x85\x32\x1a\xbb\x56\xc4\x36\x30\x45\x56\x83\xab\xc0\x75\x27\xee\x6c\xff\xef\xdc\xb4\xf9\xf0\xb7\x95\x7e\xf2\xfe\xfa\xfd\xcf\xc1\x1d\x26\x8a\x19\x26\x8f\xf1\xc2\x60\x73\xe1\x20\x46\x5e\xf5\xdc\x5e\x5f\x7a\xaf\x7c\x26\x14\x98\xe3\xa3\x7e\xb0\x3d\xc9\x4a\x2c\x29\xbd\xe5\xdf\xcf\xf9\xd9\x73\x7e\xb7\x58\x7e\x48\xee\x97\xbf\x7e\x5c\xdd\x7e\xda\x0f\x4c\x4f\x3a\xee\x41\x77\x23\x49\x9d\x92\xce\xeb\x1f\x1c\x67\x9a\xbc\xe8\x0e\x82\xae\xba\xcf\xa5\x0d\x9d\x6f\xf3\x5e\xd9\xe4\xf5\x98\x7e\x05\x5f\xa3\xc5\xd1\xf5\xdc\x3d\x52\xda\x94\xb7\xe2\x39\xcd\xfb\x09\x83\xd1\xec\xbf\x9b\x01\x8c\x2b\x20\xfa\x2a\x27\x1a\xd9\x64\xfe\xaf\xac\xba\x07\xe3\xf1\xb2\xdb\x3b\x1e\x6f\x51\x7c\x7b\xb0\x61\xb4\xb9\xa0\xe6\xae\xfa\xd6\xe6\x15\x55\xb7\x36\x21\x35\xa3\x59\x09\x3b\xf2\x3a\xab\x2f\xbc\x06\x4d\x6a\x73\xeb\x39\x3c\x8a\x63\x32\xd6\xd5\x1a\x9e\x28\xdf\xc8\x1d\x6f\x52\x15\x8b\x6a\xb8\x36\xe1\xbd\xe2\x24\x05\xbf\xac\xbe\x5e\xbf\xb5\x19\xd7\x6d\xfb\xbe\xf0\x0d\x04\x5b\x9b\xa9\x42\xbd\x89\xa8\xab\x78\xfa\x06\x34\xad\x16\xff\x2d\x4b\xff\x0e\x00\x50\x51\x32\x71\xd1\x1c\x00\x00")
 
 func templatesCf_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -128,12 +133,12 @@ func templatesCf_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 6641, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/cf_lb.tf", size: 7377, mode: os.FileMode(420), modTime: time.Unix(1792402764, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x93\x3f\x8f\xdc\x20\x10\xc5\xeb\xf0\x29\x46\xa3\x94\xc1\x85\x73\x6d\xaa\x28\xed\x25\x45\xba\xe8\x84\x58\x7b\xec\x45\xc7\x31\x08\xf0\x5a\xd1\xc9\xdf\x3d\xc2\x66\xbd\xbe\xdc\x9f\x5d\x69\xb5\x95\x47\xe3\xc7\x63\xf8\x3d\xe0\x21\xf9\x21\x01\x36\xec\x1a\x1e\x42\x24\x95\x74\xe8\x29\x29\xcf\x6c\x11\x9e\xc5\xa7\x83\xb6\x03\xc1\x37\xc0\xcf\xcf\x3d\x73\x6f\x49\x35\xfc\xe4\x87\xf4\x42\x59\x2d\xb5\x9c\x6b\xa7\x9f\x68\x42\x31\x09\xf1\xda\xdd\xee\x94\xf1\xd9\x17\x00\xe0\x7d\x6b\xdd\xb6\x81\x62\xac\xd6\x85\xf2\xd8\x29\xdf\xc5\x3f\x50\xe4\x21\x34\x04\xf8\xdf\xfa\xce\x04\x1a\xb5\xb5\x08\x78\x2c\xe5\xea\xb5\x6c\x9f\xa7\xcc\x43\xcc\xdb\x1f\x74\xa8\xc8\x1d\x94\x69\xa7\x93\x4e\xb2\x27\x87\x59\x4a\x69\xe4\xf0\xf8\xe6\xa4\xe5\x5f\xb5\xdb\x59\x79\xac\x0b\x00\x01\xa0\xad\xe5\xb1\x9c\xd6\x07\x4e\xdc\xb0\xcd\x36\xa9\xf1\xb8\x34\x39\xa4\xb8\x8c\xf1\x07\xef\xee\xbe\xe2\x17\xc0\xba\xae\x6b\x7c\x10\x00\x53\xb6\x28\x94\x93\xee\xe3\x2c\x3a\x1d\xe3\xe1\x43\x04\x05\x14\x6e\xe8\xcb\xb5\xb7\x02\x78\xff\xf4\x1f\x03\x7e\x71\x4b\x70\x93\xfe\x85\xde\x02\x20\x52\x8c\x86\x9d\xd2\x5d\x67\x9c\x49\x7f\xb3\xfe\xfe\xe7\xfd\x8f\x33\xc9\x72\x18\x75\x68\x8d\xeb\x55\x18\x2c\x21\x60\x8c\x7b\x79\xea\xca\xa5\xbb\x4d\xf8\x4c\xca\x31\xee\x71\xe5\xbc\x51\x5f\x78\xdb\x23\xd9\x4e\x59\xe3\x1e\xa7\xec\x92\xf3\x54\x41\xbb\x9e\x66\x97\x39\x4a\x01\x60\xbc\xda\xc6\xff\xfb\xfb\xaf\xd2\x2d\x89\xbc\xbd\xe5\xd5\xaf\xe0\x15\xab\x7d\x4a\x3e\x5e\x45\x6b\x76\xb8\x19\xaf\xfc\x02\x6e\x8c\xeb\x5f\x00\x00\x00\xff\xff\x5c\xc2\x73\xd4\xf2\x04\x00\x00")
+var _templatesConcourse_lbTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x53\xc1\x8e\xd3\x30\x10\x3d\xe3\xaf\x18\x8d\x38\x92\x1c\xca\x5e\xf7\x84\xb8\x2e\x1c\xb8\xa1\x95\xe5\x4d\x27\xa9\xb5\xae\xc7\xf2\x38\x8d\x50\x94\x7f\x47\x4e\xd2\x24\x50\x5a\x2a\x10\xe2\x14\x6b\xf4\xe6\xcd\xbc\xf7\x26\xdc\xa6\xd0\x26\xc0\x8a\x7d\xc5\x6d\x14\xd2\xc9\xc4\x86\x92\x0e\xcc\x0e\xa1\x57\x6f\x4e\xc6\xb5\x04\x8f\x80\x6f\xfb\x86\xb9\x71\xa4\x2b\x3e\x86\x36\xfd\x80\x2c\xa7\x77\x91\xbb\x4a\x6f\x8e\x34\xa0\x1a\x94\xba\x64\x77\x2f\xda\x86\xcc\x0b\x00\x70\x9d\xda\xec\xf7\x91\x44\xca\xa5\xb1\x38\x57\xe6\xef\xc4\x1f\x49\xb8\x8d\x15\x01\xfe\xd4\x5f\xdb\x48\x9d\x71\x0e\x01\xcf\xcf\x62\xe1\x9a\xc6\xe7\x2d\xf3\x12\xe3\xf8\x93\x89\x25\xf9\x93\xb6\xfb\x61\xc5\x15\x1c\xc8\xa3\x02\xe8\xfb\x44\xc7\xe0\x4c\x22\x40\x4f\xa9\xe3\xf8\x8a\x50\x0e\x83\x52\x00\xc6\x39\xee\x66\x3d\x21\x72\xe2\x8a\x5d\x76\x2b\x55\x21\xb7\x02\x04\x8e\x49\xf2\xe3\x11\xbe\xe2\xc3\xc3\x7b\x7c\x07\xb8\xdb\xed\x76\xf8\xac\x00\x46\x8a\xd9\xc7\x64\x1a\x19\x41\xeb\xa2\xcf\x37\x45\xce\x56\x20\xe0\x85\x4d\x1b\x89\xd7\xf5\xa1\xea\xfb\x02\x56\x69\xce\xbc\x90\x93\x49\xd9\xad\xb9\x9b\xdc\x11\x70\x93\xfc\x9d\x53\x15\x80\x90\x88\x65\xaf\x4d\x5d\x5b\x6f\xd3\xb7\x8c\x7f\xfa\xf4\xf4\xf1\x37\xa9\x72\xec\x4c\xdc\x5b\xdf\xe8\xd8\x3a\x42\x40\x91\x43\xb1\x56\x8b\xa9\xba\x2c\x91\x5d\xbf\x9d\xb0\xc8\x01\x97\x04\x36\xe8\x3b\x2f\x5d\xc8\xd5\xda\x59\xff\x3a\x64\x96\x9c\xb4\x8e\xc6\x37\x34\xb2\x8c\x21\x2b\x00\x1b\xf4\xf6\x30\xbe\x7c\xf8\x9c\xc1\x36\x9c\x8f\xfc\xd7\x23\xef\xf9\x03\xfe\x2c\xbe\x4b\x17\x0f\x29\x05\xf9\x2b\x1f\x47\x86\x7f\xe6\x64\xfe\x6b\xfe\x9b\x91\xdf\x07\x00\x1a\x44\xf0\x09\x22\x05\x00\x00")
 
 func templatesConcourse_lbTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -148,12 +153,32 @@ func templatesConcourse_lbTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 1266, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/concourse_lb.tf", size: 1314, mode: os.FileMode(420), modTime: time.Unix(1792402764, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesJumpboxTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xce\xcd\x0a\xc3\x20\x0c\xc0\xf1\xbb\x4f\x11\x64\xd7\x7a\xe8\x71\xb0\x67\x11\x57\x43\xe9\x50\x23\xd1\x48\xa1\xf8\xee\x63\xac\xfb\x82\x9d\x7a\x0d\xf9\xff\x12\xc6\x42\xc2\x13\x82\x9e\x89\xe6\x80\x76\xa2\x98\xa5\xa2\x75\xde\x33\x96\xa2\x41\xdf\x24\xe6\x2b\xad\xc3\x92\x35\x6c\x0a\x20\xb9\x88\x70\x01\x7d\xda\x9a\x63\x83\xa9\xd9\xc5\xf7\xe1\x6b\x4b\x75\xa5\x48\x6a\x96\xfa\x8e\xad\x70\x78\xd6\x00\xcd\x05\xd9\x81\xff\x37\xcd\xc7\x32\xfb\xa8\x9f\xc7\xf1\xc7\xc5\xb5\x22\x27\x17\xec\xeb\xab\x83\xee\x03\xbd\x07\x00\x00\xff\xff\xa3\x5f\xd0\xdf\x04\x01\x00\x00")
+var _templatesExisting_networkTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\x41\x0a\xc2\x40\x0c\x46\xe1\xb5\x39\xc5\x4f\x70\xed\x0d\x3c\x8b\xa4\x12\xa4\x5a\x67\x86\x34\x33\x22\x65\xee\x2e\x4a\x21\xb8\x50\xba\xff\x1e\xbc\x26\x36\xca\x30\x29\x38\xa9\x3f\xb2\xdd\x18\x0b\xed\xfc\x59\x14\x47\xf0\xec\x36\xa6\x0b\x53\x27\x0a\x38\xd7\x61\xb3\x5d\xe1\xa9\x58\xbe\xea\xd9\x7f\x05\xb9\x7a\xa9\x1e\x3c\xc9\x5d\xdf\x16\x00\x9a\x4c\xf5\xe3\xf7\x4b\x13\x3b\xac\xa4\x7f\x75\xb1\xf4\x2f\x0d\xd5\x99\x3a\xbd\x06\x00\xed\x66\xf7\xd3\xfb\x00\x00\x00")
+
+func templatesExisting_networkTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesExisting_networkTf,
+		"templates/existing_network.tf",
+	)
+}
+
+func templatesExisting_networkTf() (*asset, error) {
+	bytes, err := templatesExisting_networkTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/existing_network.tf", size: 251, mode: os.FileMode(420), modTime: time.Unix(1792392979, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesJumpboxTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\xd1\xdd\x6a\xc3\x30\x0c\x05\xe0\xeb\xf9\x29\x84\xd8\x6d\x52\x28\xe4\xa6\xb0\x67\x31\x4e\x22\x5a\x0f\x3b\x32\xb2\x1c\x0a\xc6\xef\x3e\xb6\xb5\x5d\xbb\x9f\x32\xe8\xad\x91\xbf\x73\xe0\x08\x65\x2e\x32\x11\xe0\x9e\x79\x1f\xc8\x4e\x1c\x53\x51\xb2\x6e\x9e\x85\x72\x46\xc0\xd7\x12\xd3\xc8\xc7\xce\x27\x84\x6a\x00\x16\x17\x09\x5e\x00\x9f\xeb\xea\xa4\xa7\x65\xb5\x7e\x6e\xdd\xd5\x95\xa9\xb5\x03\xa5\x98\x82\x53\x02\x0c\x6e\xa4\x90\x11\xfa\xd6\x4c\x33\x86\x8b\xa6\xa2\x17\xd6\x16\x09\x9f\x2e\xc0\xea\x42\x39\xd1\xbf\xb7\xe9\xbf\x52\xfa\xd3\x53\xdb\x6d\xb7\x78\xed\xd2\x51\x49\x16\x17\xac\x4f\x0f\xb9\x37\xe8\xec\x85\x26\x65\x39\x7f\x78\x97\x9f\x2e\xec\x41\x35\xe5\xdd\x66\xf3\x27\x3f\x72\x3e\x74\xe7\x62\xb7\xe5\x87\x61\x18\x3e\xa2\xfe\xb1\xc4\x77\xe6\xde\x1e\x3f\x6e\xef\xae\xf2\x36\x00\xfa\xa6\xfc\x81\x07\x02\x00\x00")
 
 func templatesJumpboxTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -168,12 +193,92 @@ func templatesJumpboxTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/jumpbox.tf", size: 260, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/jumpbox.tf", size: 519, mode: os.FileMode(420), modTime: time.Unix(1792402764, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesJumpbox_privateTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\xc1\x0a\x83\x30\x10\x44\xcf\xee\x57\x2c\xa1\xe7\x0a\x42\x2e\x42\xbf\x45\xd6\xba\xb4\x29\xa9\x86\xcd\x46\x2c\x92\x7f\x2f\xb6\xa0\x08\x1e\xbc\xcf\xbc\x79\x33\x92\x38\x6a\x3d\xa3\x79\xa5\x77\x68\x87\xa9\x71\xbd\xb2\xf4\xe4\x1b\x17\x0c\xce\x50\xe8\x27\x30\xde\xd0\x44\x15\xd7\x3f\x0c\x64\x80\xad\xd4\x39\xe1\xbb\x0e\x72\xa6\x35\x24\x0d\x49\xb7\xa1\x24\x7e\x89\x22\x22\x8e\xe4\xd3\x2f\x7e\x99\x47\x92\xeb\x81\x4a\xae\xab\x6a\x47\xe1\x69\x37\x78\x92\xb2\x43\xac\xf2\xd4\x75\xc2\x31\x2e\x36\xc5\x0a\x79\xaa\x86\x58\x97\xe5\x5f\xe9\xe8\x68\xae\x2b\x6b\xad\x35\x90\xe1\x3b\x00\xce\xbe\x0d\x3b\x47\x01\x00\x00")
+
+func templatesJumpbox_privateTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesJumpbox_privateTf,
+		"templates/jumpbox_private.tf",
+	)
+}
+
+func templatesJumpbox_privateTf() (*asset, error) {
+	bytes, err := templatesJumpbox_privateTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/jumpbox_private.tf", size: 327, mode: os.FileMode(420), modTime: time.Unix(1792402905, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesLabelsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x25\x00\xda\xff\x76\x61\x72\x69\x61\x62\x6c\x65\x20\x22\x6c\x61\x62\x65\x6c\x73\x22\x20\x7b\x0a\x20\x20\x74\x79\x70\x65\x20\x3d\x20\x22\x6d\x61\x70\x22\x0a\x7d\x0a\x03\x00\x4c\x51\x41\x75\x25\x00\x00\x00")
+
+func templatesLabelsTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesLabelsTf,
+		"templates/labels.tf",
+	)
+}
+
+func templatesLabelsTf() (*asset, error) {
+	bytes, err := templatesLabelsTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/labels.tf", size: 37, mode: os.FileMode(420), modTime: time.Unix(1792393919, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNamed_networksTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x53\xc1\x8e\x9b\x30\x10\x3d\x97\xaf\x18\x59\x39\x2e\xa8\x42\x5d\x45\xaa\x94\x63\xaf\xab\xde\xab\x15\x32\x30\x4b\xbd\x35\xd8\x32\x43\xb2\x95\x35\xff\x5e\x19\x9c\x90\x66\x37\x24\x4d\x77\x73\x89\x31\xf3\xde\xbc\x79\xf3\xf0\xde\xc9\xae\x41\x58\xa9\x3b\x58\x75\x48\x3b\xe3\x7e\xc1\xd7\x0d\x64\x0f\xd3\xb9\x67\xf6\x5e\x3d\xc1\x4a\x31\x27\xde\x63\x57\x33\x6f\xa5\x53\xb2\xd4\x08\x22\x02\x0a\xef\xb3\x07\xd9\x22\x73\x51\xa9\xda\x09\xf0\xc9\x27\xfa\x6d\x11\x36\x20\x7a\x72\xaa\x6b\x44\xc2\x49\x62\x06\xb2\x03\xbd\x85\xea\x87\x72\x7f\xd9\xc9\x16\x03\x01\x00\xc0\x56\xea\x61\x24\x59\xf9\xc6\x98\x46\x63\x51\x99\xd6\x0e\x84\x47\x80\x2c\xfe\xa7\x07\xb6\x2c\x50\xf0\x85\x8e\x24\x9b\x2b\x5b\x3d\x29\x87\x3b\xa9\xf5\x62\x23\x87\xbd\x19\x5c\x85\x20\xce\x0a\x15\x20\x5e\x31\x4c\x83\x06\x1d\x70\xf8\x8d\xe3\x6e\xa5\xcb\xb0\xdb\x16\xaa\xe6\xb9\x3a\x9d\xa6\x16\x09\x80\xb2\xa3\xd3\xc5\xb4\xbc\x03\xe4\xf5\x9c\xa1\x8a\x45\xe2\x7d\x0a\x61\x8b\xd9\xb7\x17\xd5\x93\xea\x9a\xb8\x5e\xe6\x04\xc0\x3a\xf3\x8c\x15\x9d\xf6\xdf\x93\xc5\xd7\x1c\xfa\xc6\xbb\x33\xa5\xb1\x11\xea\x1e\x99\xdf\xae\x3e\xb1\x27\x56\x64\x65\xa9\xd3\xfd\x39\xba\x1a\x24\x8f\x71\x9b\xc5\x7f\x77\x6a\x2b\x29\x70\x8f\xaa\xc7\x87\x42\xd9\x22\x92\xca\xaa\xc2\xbe\x87\x0d\x90\x1b\xf0\x08\xbf\xb4\x9f\xfd\x76\x2f\x6f\xe7\xfc\x5e\x82\x31\xde\x13\xb6\x56\x4b\x9a\xbf\x0a\x01\xab\x49\xe9\x14\x8d\x82\x64\x13\xc4\xfd\x38\x4b\xf3\x18\x8a\xa5\xd6\x66\x17\x43\x69\x9d\x21\x53\x19\x1d\xac\x53\x55\x6b\x43\x23\x5e\x2a\xa2\xea\x72\xcd\x50\xcf\x35\x24\x5d\x83\x74\x8d\xb2\x5b\x3d\x4c\xc9\xa4\xb5\x72\x58\x91\x71\xd7\xfa\xf9\x17\xe6\xc3\xbc\x35\x8e\xa6\xa1\xbf\xe4\x79\x2e\xee\x40\xe4\xf7\xf9\xfd\xe7\xe9\xb0\x5e\xaf\xc5\xe3\xa2\xbb\xcb\xce\x95\xa6\xff\x39\x8f\x70\xb5\x7b\x7b\x44\x30\xe0\x5f\xd3\x78\x8c\x7d\xa7\x64\x9e\x4e\x71\x65\xf0\x96\xad\xb9\x21\x54\xcf\x43\x6b\x4b\xf3\x72\x8b\x2b\x47\xd0\x77\x32\x25\x32\x2e\x05\x2a\xcf\xff\x2b\x3c\xb3\xd0\xe0\x90\xf7\xd8\xd5\x90\x32\x27\x7f\x06\x00\xa4\x48\x99\x77\xab\x07\x00\x00")
+
+func templatesNamed_networksTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNamed_networksTf,
+		"templates/named_networks.tf",
+	)
+}
+
+func templatesNamed_networksTf() (*asset, error) {
+	bytes, err := templatesNamed_networksTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/named_networks.tf", size: 1963, mode: os.FileMode(420), modTime: time.Unix(1792404858, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesNetworkTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\x5d\x6e\xc3\x20\x10\x84\x9f\xbd\xa7\x18\xa1\xbe\xc6\x37\xc8\x1d\x7a\x03\x84\xed\x8d\x85\xe2\x00\xe2\xc7\x7d\xb0\xf6\xee\x95\x0d\x69\x2c\xb5\xaa\xda\x37\x76\x99\xf9\x98\xc1\x97\x1c\x4a\x86\x72\x9c\x3f\x7c\xbc\x6b\x67\x1e\xac\xb0\x11\x00\xac\x66\x29\x8c\x2b\xd4\xdb\x36\x7b\x3f\x2f\xac\x47\xff\x08\x25\xb3\x6e\xea\x7e\x18\x96\xcb\xf3\xbc\x3b\x45\x91\x10\x3d\x99\xa9\x0c\xff\xc3\xbe\x0c\x07\xb9\x8e\x27\x70\xe4\xe4\x4b\x1c\x19\xea\xe7\x3c\x0a\xea\x94\xa8\xbe\xb7\xbb\xbb\xae\xb6\x58\x4d\xec\xd9\xad\xda\x4e\xf2\x25\xfa\x95\xfb\x0a\xd4\xd0\x75\x71\x26\x77\xdf\xc8\x4d\x43\x80\x0d\x7a\xb4\x53\xd4\xd1\xb8\xb9\x35\xde\x23\x34\xe4\x71\x27\x8a\x80\xb6\x68\xac\x3f\xfc\x75\xe2\xe5\xa6\x17\xeb\xee\xa2\x68\xdb\x2e\xb0\x37\xf4\xef\xd1\xae\x26\xb3\x08\x11\x10\xea\xa0\x6d\xd0\x0d\x67\xc6\x91\x53\xc2\x15\x39\x16\x3e\x4c\xec\x26\x11\x12\xfa\x1c\x00\xf5\x1e\x24\x88\x03\x02\x00\x00")
+
+func templatesNetworkTfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesNetworkTf,
+		"templates/network.tf",
+	)
+}
+
+func templatesNetworkTf() (*asset, error) {
+	bytes, err := templatesNetworkTfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/network.tf", size: 515, mode: os.FileMode(420), modTime: time.Unix(1792402905, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\x41\x0a\xc3\x20\x10\x05\xd0\x75\x3c\x85\x0c\x59\xb4\x9b\xde\xa0\x67\x29\x36\x99\xca\x14\x99\x91\x89\x08\xad\x78\xf7\x22\x16\xcc\xa6\xa4\x4b\xf9\x4f\x3e\xf3\xb3\x53\x72\xf7\x80\x16\xa2\xca\x13\x97\x74\xa3\x15\x6c\x31\x53\x7a\x45\xb4\x57\x0b\x5b\x52\x62\x0f\xa6\x1a\x33\xac\xa2\x27\xe1\x63\xf7\x16\xc6\x63\x85\x9c\xff\x6a\x5d\x14\x57\xe4\x44\x2e\x6c\xbf\x70\x54\xc9\xb4\xa2\x5a\xf0\x22\x3e\xf4\xf2\xdd\xbf\xc6\xe7\xf2\xa0\x80\x27\x98\x4b\x76\x7a\xd9\x85\x15\xce\x15\xcc\xf4\x1d\xa2\xd3\x46\xc6\x32\x2d\xee\xb7\x8f\xb4\xbf\x6b\xab\xff\x04\x00\x00\xff\xff\x39\xda\x2a\x22\x4d\x01\x00\x00")
+var _templatesVarsTf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\x41\xaa\xc3\x30\x0c\x04\xd0\x75\x74\x0a\x23\xb2\xf8\x7f\xd3\x1b\xf4\x2c\xc1\x8d\x55\xa3\xd6\x48\x41\x31\x2e\x6d\xf0\xdd\x4b\x48\x21\xd9\x14\x77\x3d\x0f\x66\x98\xe2\x8d\xfd\x25\x91\xc3\xc9\xf4\x46\x63\x1e\x38\xa0\x5b\xa0\xcb\xcf\x89\xdc\xd9\xe1\x9c\x8d\x25\x22\x54\x80\xdd\x1a\x45\x56\x69\xbb\x97\x0a\xb5\x15\x49\xf9\xa9\x75\x34\x0a\x24\x99\x7d\x9a\xdb\x58\x28\x3f\xd4\xee\xc3\xc8\xc1\xbe\xe9\xc9\xb4\x70\x20\x73\x18\x55\x63\xda\xa6\x1e\x5a\xd6\x25\xfd\x72\xe5\x44\x7f\xd8\x2f\xc5\xdb\xe9\x10\x56\xfc\xaf\x08\xdd\xe7\xb6\x8d\xae\x64\xff\x71\x8d\xb7\xa7\xf6\xd4\x28\xb2\x4a\x45\xa8\xf0\x1e\x00\x29\x9f\x6f\xd5\x7b\x01\x00\x00")
 
 func templatesVarsTfBytes() ([]byte, error) {
 	return bindataRead(
@@ -188,7 +293,7 @@ func templatesVarsTf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/vars.tf", size: 333, mode: os.FileMode(420), modTime: time.Unix(1506547663, 0)}
+	info := bindataFileInfo{name: "templates/vars.tf", size: 379, mode: os.FileMode(420), modTime: time.Unix(1792394370, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -249,7 +354,12 @@ var _bindata = map[string]func() (*asset, error){
 	"templates/cf_dns.tf": templatesCf_dnsTf,
 	"templates/cf_lb.tf": templatesCf_lbTf,
 	"templates/concourse_lb.tf": templatesConcourse_lbTf,
+	"templates/existing_network.tf": templatesExisting_networkTf,
 	"templates/jumpbox.tf": templatesJumpboxTf,
+	"templates/jumpbox_private.tf": templatesJumpbox_privateTf,
+	"templates/labels.tf": templatesLabelsTf,
+	"templates/named_networks.tf": templatesNamed_networksTf,
+	"templates/network.tf": templatesNetworkTf,
 	"templates/vars.tf": templatesVarsTf,
 }
 
@@ -298,7 +408,12 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"cf_dns.tf": &bintree{templatesCf_dnsTf, map[string]*bintree{}},
 		"cf_lb.tf": &bintree{templatesCf_lbTf, map[string]*bintree{}},
 		"concourse_lb.tf": &bintree{templatesConcourse_lbTf, map[string]*bintree{}},
+		"existing_network.tf": &bintree{templatesExisting_networkTf, map[string]*bintree{}},
 		"jumpbox.tf": &bintree{templatesJumpboxTf, map[string]*bintree{}},
+		"jumpbox_private.tf": &bintree{templatesJumpbox_privateTf, map[string]*bintree{}},
+		"labels.tf": &bintree{templatesLabelsTf, map[string]*bintree{}},
+		"named_networks.tf": &bintree{templatesNamed_networksTf, map[string]*bintree{}},
+		"network.tf": &bintree{templatesNetworkTf, map[string]*bintree{}},
 		"vars.tf": &bintree{templatesVarsTf, map[string]*bintree{}},
 	}},
 }}
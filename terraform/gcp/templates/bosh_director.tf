@@ -1,11 +1,3 @@
-output "network_name" {
-    value = "${google_compute_network.bbl-network.name}"
-}
-
-output "subnetwork_name" {
-    value = "${google_compute_subnetwork.bbl-subnet.name}"
-}
-
 output "bosh_open_tag_name" {
     value = "${google_compute_firewall.bosh-open.name}"
 }
@@ -22,27 +14,9 @@ output "internal_tag_name" {
     value = "${google_compute_firewall.internal.name}"
 }
 
-output "director_address" {
-	value = "https://${google_compute_address.bosh-external-ip.address}:25555"
-}
-
-resource "google_compute_network" "bbl-network" {
-  name		 = "${var.env_id}-network"
-}
-
-resource "google_compute_subnetwork" "bbl-subnet" {
-  name			= "${var.env_id}-subnet"
-  ip_cidr_range = "10.0.0.0/16"
-  network		= "${google_compute_network.bbl-network.self_link}"
-}
-
-resource "google_compute_address" "bosh-external-ip" {
-  name = "${var.env_id}-bosh-external-ip"
-}
-
 resource "google_compute_firewall" "external" {
   name    = "${var.env_id}-external"
-  network = "${google_compute_network.bbl-network.name}"
+  {{template "network" .}}
 
   source_ranges = ["0.0.0.0/0"]
 
@@ -56,7 +30,7 @@ resource "google_compute_firewall" "external" {
 
 resource "google_compute_firewall" "bosh-open" {
   name    = "${var.env_id}-bosh-open"
-  network = "${google_compute_network.bbl-network.name}"
+  {{template "network" .}}
 
   source_tags = ["${var.env_id}-bosh-open"]
 
@@ -70,7 +44,7 @@ resource "google_compute_firewall" "bosh-open" {
 
 resource "google_compute_firewall" "bosh-director" {
   name    = "${var.env_id}-bosh-director"
-  network = "${google_compute_network.bbl-network.name}"
+  {{template "network" .}}
 
   source_tags = ["${var.env_id}-bosh-director"]
 
@@ -83,7 +57,7 @@ resource "google_compute_firewall" "bosh-director" {
 
 resource "google_compute_firewall" "internal-to-director" {
   name    = "${var.env_id}-internal-to-director"
-  network = "${google_compute_network.bbl-network.name}"
+  {{template "network" .}}
 
   source_tags = ["${var.env_id}-internal"]
 
@@ -97,7 +71,7 @@ resource "google_compute_firewall" "internal-to-director" {
 
 resource "google_compute_firewall" "jumpbox-to-all" {
   name    = "${var.env_id}-jumpbox-to-all"
-  network = "${google_compute_network.bbl-network.name}"
+  {{template "network" .}}
 
   source_tags = ["${var.env_id}-jumpbox"]
 
@@ -111,7 +85,7 @@ resource "google_compute_firewall" "jumpbox-to-all" {
 
 resource "google_compute_firewall" "internal" {
   name    = "${var.env_id}-internal"
-  network = "${google_compute_network.bbl-network.name}"
+  {{template "network" .}}
 
   source_tags = ["${var.env_id}-internal"]
 
@@ -6,6 +6,7 @@ resource "google_dns_managed_zone" "env_dns_zone" {
   name        = "${var.env_id}-zone"
   dns_name    = "${var.system_domain}."
   description = "DNS zone for the ${var.env_id} environment"
+{{- template "labels" .}}
 }
 
 output "system_domain_dns_servers" {
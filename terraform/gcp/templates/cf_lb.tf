@@ -28,8 +28,13 @@ output "ws_lb_ip" {
 
 resource "google_compute_firewall" "firewall-cf" {
   name       = "${var.env_id}-cf-open"
+{{- if .ExistingNetwork}}
+  project    = "${var.network_project}"
+  network    = "${var.network}"
+{{- else}}
   depends_on = ["google_compute_network.bbl-network"]
   network    = "${google_compute_network.bbl-network.name}"
+{{- end}}
 
   allow {
     protocol = "tcp"
@@ -43,6 +48,7 @@ resource "google_compute_firewall" "firewall-cf" {
 
 resource "google_compute_global_address" "cf-address" {
   name = "${var.env_id}-cf"
+{{- template "labels" .}}
 }
 
 resource "google_compute_global_forwarding_rule" "cf-http-forwarding-rule" {
@@ -50,6 +56,7 @@ resource "google_compute_global_forwarding_rule" "cf-http-forwarding-rule" {
   ip_address = "${google_compute_global_address.cf-address.address}"
   target     = "${google_compute_target_http_proxy.cf-http-lb-proxy.self_link}"
   port_range = "80"
+{{- template "labels" .}}
 }
 
 resource "google_compute_global_forwarding_rule" "cf-https-forwarding-rule" {
@@ -57,6 +64,7 @@ resource "google_compute_global_forwarding_rule" "cf-https-forwarding-rule" {
   ip_address = "${google_compute_global_address.cf-address.address}"
   target     = "${google_compute_target_https_proxy.cf-https-lb-proxy.self_link}"
   port_range = "443"
+{{- template "labels" .}}
 }
 
 resource "google_compute_target_http_proxy" "cf-http-lb-proxy" {
@@ -105,8 +113,13 @@ resource "google_compute_http_health_check" "cf-public-health-check" {
 
 resource "google_compute_firewall" "cf-health-check" {
   name       = "${var.env_id}-cf-health-check"
+{{- if .ExistingNetwork}}
+  project    = "${var.network_project}"
+  network    = "${var.network}"
+{{- else}}
   depends_on = ["google_compute_network.bbl-network"]
   network    = "${google_compute_network.bbl-network.name}"
+{{- end}}
 
   allow {
     protocol = "tcp"
@@ -123,12 +136,18 @@ output "ssh_proxy_target_pool" {
 
 resource "google_compute_address" "cf-ssh-proxy" {
   name = "${var.env_id}-cf-ssh-proxy"
+{{- template "labels" .}}
 }
 
 resource "google_compute_firewall" "cf-ssh-proxy" {
   name       = "${var.env_id}-cf-ssh-proxy-open"
+{{- if .ExistingNetwork}}
+  project    = "${var.network_project}"
+  network    = "${var.network}"
+{{- else}}
   depends_on = ["google_compute_network.bbl-network"]
   network    = "${google_compute_network.bbl-network.name}"
+{{- end}}
 
   allow {
     protocol = "tcp"
@@ -150,6 +169,7 @@ resource "google_compute_forwarding_rule" "cf-ssh-proxy" {
   port_range  = "2222"
   ip_protocol = "TCP"
   ip_address  = "${google_compute_address.cf-ssh-proxy.address}"
+{{- template "labels" .}}
 }
 
 output "tcp_router_target_pool" {
@@ -158,8 +178,13 @@ output "tcp_router_target_pool" {
 
 resource "google_compute_firewall" "cf-tcp-router" {
   name       = "${var.env_id}-cf-tcp-router"
+{{- if .ExistingNetwork}}
+  project    = "${var.network_project}"
+  network    = "${var.network}"
+{{- else}}
   depends_on = ["google_compute_network.bbl-network"]
   network    = "${google_compute_network.bbl-network.name}"
+{{- end}}
 
   allow {
     protocol = "tcp"
@@ -171,6 +196,7 @@ resource "google_compute_firewall" "cf-tcp-router" {
 
 resource "google_compute_address" "cf-tcp-router" {
   name = "${var.env_id}-cf-tcp-router"
+{{- template "labels" .}}
 }
 
 resource "google_compute_http_health_check" "cf-tcp-router" {
@@ -195,6 +221,7 @@ resource "google_compute_forwarding_rule" "cf-tcp-router" {
   port_range  = "1024-32768"
   ip_protocol = "TCP"
   ip_address  = "${google_compute_address.cf-tcp-router.address}"
+{{- template "labels" .}}
 }
 
 output "ws_target_pool" {
@@ -203,6 +230,7 @@ output "ws_target_pool" {
 
 resource "google_compute_address" "cf-ws" {
   name = "${var.env_id}-cf-ws"
+{{- template "labels" .}}
 }
 
 resource "google_compute_target_pool" "cf-ws" {
@@ -219,6 +247,7 @@ resource "google_compute_forwarding_rule" "cf-ws-https" {
   port_range  = "443"
   ip_protocol = "TCP"
   ip_address  = "${google_compute_address.cf-ws.address}"
+{{- template "labels" .}}
 }
 
 resource "google_compute_forwarding_rule" "cf-ws-http" {
@@ -227,4 +256,5 @@ resource "google_compute_forwarding_rule" "cf-ws-http" {
   port_range  = "80"
   ip_protocol = "TCP"
   ip_address  = "${google_compute_address.cf-ws.address}"
+{{- template "labels" .}}
 }
@@ -8,7 +8,7 @@ output "concourse_lb_ip" {
 
 resource "google_compute_firewall" "firewall-concourse" {
   name    = "${var.env_id}-concourse-open"
-  network = "${google_compute_network.bbl-network.name}"
+  {{template "network" .}}
 
   allow {
     protocol = "tcp"
@@ -20,6 +20,7 @@ resource "google_compute_firewall" "firewall-concourse" {
 
 resource "google_compute_address" "concourse-address" {
   name = "${var.env_id}-concourse"
+{{- template "labels" .}}
 }
 
 resource "google_compute_target_pool" "target-pool" {
@@ -34,6 +35,7 @@ resource "google_compute_forwarding_rule" "ssh-forwarding-rule" {
   port_range  = "2222"
   ip_protocol = "TCP"
   ip_address  = "${google_compute_address.concourse-address.address}"
+{{- template "labels" .}}
 }
 
 resource "google_compute_forwarding_rule" "https-forwarding-rule" {
@@ -42,4 +44,5 @@ resource "google_compute_forwarding_rule" "https-forwarding-rule" {
   port_range  = "443"
   ip_protocol = "TCP"
   ip_address  = "${google_compute_address.concourse-address.address}"
+{{- template "labels" .}}
 }
@@ -0,0 +1,19 @@
+variable "network" {
+	type = "string"
+}
+
+variable "subnetwork" {
+	type = "string"
+}
+
+variable "network_project" {
+	type = "string"
+}
+
+output "network_name" {
+    value = "${var.network}"
+}
+
+output "subnetwork_name" {
+    value = "${var.subnetwork}"
+}
@@ -1,5 +1,6 @@
 resource "google_compute_address" "jumpbox-ip" {
   name = "${var.env_id}-jumpbox-ip"
+{{- template "labels" .}}
 }
 
 output "jumpbox_url" {
@@ -9,3 +10,12 @@ output "jumpbox_url" {
 output "external_ip" {
     value = "${google_compute_address.jumpbox-ip.address}"
 }
+
+output "director_address" {
+	value = "https://${google_compute_address.bosh-external-ip.address}:25555"
+}
+
+resource "google_compute_address" "bosh-external-ip" {
+  name = "${var.env_id}-bosh-external-ip"
+{{- template "labels" .}}
+}
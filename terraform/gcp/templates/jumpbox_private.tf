@@ -0,0 +1,19 @@
+variable "jumpbox_internal_ip" {
+	type = "string"
+}
+
+variable "director_internal_ip" {
+	type = "string"
+}
+
+output "jumpbox_url" {
+    value = "${var.jumpbox_internal_ip}:22"
+}
+
+output "external_ip" {
+    value = "${var.jumpbox_internal_ip}"
+}
+
+output "director_address" {
+	value = "https://${var.director_internal_ip}:25555"
+}
@@ -0,0 +1,90 @@
+{{range $i, $network := .Networks}}{{if $i}}
+{{end}}variable "network_{{.Name}}_cidr" {
+	type = "string"
+}
+
+output "network_{{.Name}}_subnetwork_name" {
+    value = "${google_compute_subnetwork.network-{{.Name}}.name}"
+}
+
+output "network_{{.Name}}_tag_name" {
+    value = "${google_compute_firewall.network-{{.Name}}.name}"
+}
+
+resource "google_compute_subnetwork" "network-{{.Name}}" {
+  name          = "${var.env_id}-{{.Name}}-subnet"
+  ip_cidr_range = "${var.network_{{.Name}}_cidr}"
+{{- if $.ExistingNetwork}}
+  project       = "${var.network_project}"
+  network       = "${var.network}"
+{{- else}}
+  network       = "${google_compute_network.bbl-network.name}"
+{{- end}}
+{{- if $.Private}}
+
+  private_ip_google_access = true
+{{- end}}
+}
+
+resource "google_compute_firewall" "network-{{.Name}}" {
+  name    = "${var.env_id}-{{.Name}}"
+  {{template "network" $}}
+
+  source_tags = ["${var.env_id}-{{.Name}}"]
+
+  allow {
+    protocol = "icmp"
+  }
+
+  allow {
+    protocol = "tcp"
+  }
+
+  allow {
+    protocol = "udp"
+  }
+
+  target_tags = ["${var.env_id}-{{.Name}}"]
+}
+
+resource "google_compute_firewall" "network-{{.Name}}-to-director" {
+  name    = "${var.env_id}-{{.Name}}-to-director"
+  {{template "network" $}}
+
+  source_tags = ["${var.env_id}-{{.Name}}"]
+
+  allow {
+    ports = ["4222", "25250", "25777"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-bosh-director"]
+}
+
+resource "google_compute_firewall" "director-to-network-{{.Name}}" {
+  name    = "${var.env_id}-director-to-{{.Name}}"
+  {{template "network" $}}
+
+  source_tags = ["${var.env_id}-bosh-director"]
+
+  allow {
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-{{.Name}}"]
+}
+
+resource "google_compute_firewall" "jumpbox-to-network-{{.Name}}" {
+  name    = "${var.env_id}-jumpbox-to-{{.Name}}"
+  {{template "network" $}}
+
+  source_tags = ["${var.env_id}-jumpbox"]
+
+  allow {
+    ports = ["22"]
+    protocol = "tcp"
+  }
+
+  target_tags = ["${var.env_id}-{{.Name}}"]
+}
+{{end -}}
@@ -2,12 +2,32 @@ package terraform
 
 import (
 	"bytes"
-	"errors"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"sort"
 
 	"github.com/cloudfoundry/bosh-bootloader/storage"
 	"github.com/coreos/go-semver/semver"
 )
 
+// MinimumVersion is the oldest terraform that bbl works with.
+const MinimumVersion = "0.10.0"
+
+// unfingerprintedInputs are left out of the fingerprint so rotating
+// credentials does not force an apply. On gcp the certificate inputs are
+// temporary file paths, so the fingerprint uses the certificate in the state.
+var unfingerprintedInputs = map[string]bool{
+	"access_key":                  true,
+	"secret_key":                  true,
+	"client_id":                   true,
+	"client_secret":               true,
+	"credentials":                 true,
+	"ssl_certificate":             true,
+	"ssl_certificate_private_key": true,
+	"ssl_certificate_chain":       true,
+}
+
 type Manager struct {
 	executor              executor
 	templateGenerator     TemplateGenerator
@@ -74,13 +94,13 @@ func (m Manager) ValidateVersion() error {
 		return err
 	}
 
-	minimumVersion, err := semver.NewVersion("0.10.0")
+	minimumVersion, err := semver.NewVersion(MinimumVersion)
 	if err != nil {
 		return err
 	}
 
 	if currentVersion.LessThan(*minimumVersion) {
-		return errors.New("Terraform version must be at least v0.10.0")
+		return fmt.Errorf("Terraform version must be at least v%s", MinimumVersion)
 	}
 
 	return nil
@@ -96,6 +116,12 @@ func (m Manager) Apply(bblState storage.State) (storage.State, error) {
 		return storage.State{}, err
 	}
 
+	fingerprint := fingerprint(template, input, bblState.LB)
+	if bblState.TFState != "" && fingerprint == bblState.TFFingerprint {
+		m.logger.Step("terraform template and variables are unchanged, skipping terraform apply")
+		return bblState, nil
+	}
+
 	m.logger.Step("applying terraform template")
 	tfState, err := m.executor.Apply(
 		input,
@@ -107,12 +133,14 @@ func (m Manager) Apply(bblState storage.State) (storage.State, error) {
 
 	switch err.(type) {
 	case executorError:
+		bblState.TFFingerprint = ""
 		return storage.State{}, NewManagerError(bblState, err.(executorError))
 	case error:
 		return storage.State{}, err
 	}
 
 	bblState.TFState = tfState
+	bblState.TFFingerprint = fingerprint
 	return bblState, nil
 }
 
@@ -158,3 +186,22 @@ func readAndReset(buf *bytes.Buffer) string {
 
 	return string(contents)
 }
+
+func fingerprint(template string, inputs map[string]string, lb storage.LB) string {
+	var keys []string
+	for key := range inputs {
+		if !unfingerprintedInputs[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	io.WriteString(hash, template)
+	for _, key := range keys {
+		fmt.Fprintf(hash, "\x00%s=%s", key, inputs[key])
+	}
+	fmt.Fprintf(hash, "\x00%s\x00%s\x00%s", lb.Cert, lb.Key, lb.Chain)
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
@@ -131,6 +131,7 @@ var _ = Describe("Manager", func() {
 				expectedAWSState := awsState
 				expectedAWSState.TFState = "some-updated-tf-state"
 				expectedAWSState.LatestTFOutput = "some-updated-tf-state"
+				expectedAWSState.TFFingerprint = terraform.Fingerprint("some-terraform-template", map[string]string{"env_id": "some-env-id"}, storage.LB{})
 				state, err := manager.Apply(awsState)
 				Expect(err).NotTo(HaveOccurred())
 
@@ -146,6 +147,7 @@ var _ = Describe("Manager", func() {
 
 		It("returns a state with new tfState and output from executor apply", func() {
 			terraformOutputBuffer.Write([]byte(expectedTFOutput))
+			expectedState.TFFingerprint = terraform.Fingerprint("some-gcp-terraform-template", inputGenerator.GenerateCall.Returns.Inputs, incomingState.LB)
 
 			state, err := manager.Apply(incomingState)
 			Expect(err).NotTo(HaveOccurred())
@@ -166,6 +168,57 @@ var _ = Describe("Manager", func() {
 			Expect(state).To(Equal(expectedState))
 		})
 
+		Context("when the template and inputs are unchanged since the last apply", func() {
+			BeforeEach(func() {
+				incomingState.TFFingerprint = terraform.Fingerprint("some-gcp-terraform-template", inputGenerator.GenerateCall.Returns.Inputs, incomingState.LB)
+			})
+
+			It("skips terraform apply", func() {
+				state, err := manager.Apply(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executor.ApplyCall.CallCount).To(Equal(0))
+				Expect(logger.StepCall.Messages).To(ContainElement("terraform template and variables are unchanged, skipping terraform apply"))
+				Expect(state).To(Equal(incomingState))
+			})
+
+			It("skips terraform apply when only credentials have changed", func() {
+				inputGenerator.GenerateCall.Returns.Inputs["credentials"] = "some-other-path"
+
+				_, err := manager.Apply(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executor.ApplyCall.CallCount).To(Equal(0))
+			})
+
+			It("applies when an input has changed", func() {
+				inputGenerator.GenerateCall.Returns.Inputs["zone"] = "some-other-zone"
+
+				_, err := manager.Apply(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executor.ApplyCall.CallCount).To(Equal(1))
+			})
+
+			It("applies when the lb certificate has changed", func() {
+				incomingState.LB.Cert = "some-new-cert"
+
+				_, err := manager.Apply(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executor.ApplyCall.CallCount).To(Equal(1))
+			})
+
+			It("applies when there is no terraform state", func() {
+				incomingState.TFState = ""
+
+				_, err := manager.Apply(incomingState)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executor.ApplyCall.CallCount).To(Equal(1))
+			})
+		})
+
 		Context("when an error occurs", func() {
 			Context("when InputGenerator.Generate returns an error", func() {
 				BeforeEach(func() {
@@ -194,6 +247,18 @@ var _ = Describe("Manager", func() {
 
 					Expect(err).To(BeAssignableToTypeOf(terraform.ManagerError{}))
 				})
+
+				It("clears the fingerprint so the next apply is not skipped", func() {
+					incomingState.TFFingerprint = "some-fingerprint"
+
+					_, err := manager.Apply(incomingState)
+
+					Expect(err).To(BeAssignableToTypeOf(terraform.ManagerError{}))
+
+					bblState, err := err.(terraform.ManagerError).BBLState()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(bblState.TFFingerprint).To(BeEmpty())
+				})
 			})
 
 			Context("when Executor.Apply returns a non-ExecutorError error", func() {
@@ -0,0 +1,32 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MapVariable formats a map so that it can be passed to terraform with -var.
+func MapVariable(values map[string]string) string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", quote(key), quote(values[key])))
+	}
+
+	return fmt.Sprintf("{%s}", strings.Join(pairs, ","))
+}
+
+func quote(s string) string {
+	quoted, err := json.Marshal(s)
+	if err != nil {
+		panic(err) // a string can always be marshaled
+	}
+	return string(quoted)
+}
@@ -0,0 +1,27 @@
+package terraform_test
+
+import (
+	"github.com/cloudfoundry/bosh-bootloader/terraform"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MapVariable", func() {
+	It("formats the map as a sorted hcl map", func() {
+		Expect(terraform.MapVariable(map[string]string{
+			"team":        "core",
+			"cost-center": "1234",
+		})).To(Equal(`{"cost-center"="1234","team"="core"}`))
+	})
+
+	It("escapes quotes in keys and values", func() {
+		Expect(terraform.MapVariable(map[string]string{
+			`some "key"`: `some "value"`,
+		})).To(Equal(`{"some \"key\""="some \"value\""}`))
+	})
+
+	It("formats an empty map", func() {
+		Expect(terraform.MapVariable(map[string]string{})).To(Equal("{}"))
+	})
+})